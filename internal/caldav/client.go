@@ -67,6 +67,17 @@ func (c *Client) AddressBookHomeURL() string {
 	return fmt.Sprintf("%s/dav/addressbooks/user/%s/", baseURL, url.QueryEscape(c.Username))
 }
 
+// basicAuth returns the base64-encoded username:token credentials.
+func (c *Client) basicAuth() string {
+	return base64.StdEncoding.EncodeToString([]byte(c.Username + ":" + c.token))
+}
+
+// redact scrubs the token and the Basic credentials from a response body
+// before it is put in an error.
+func (c *Client) redact(body []byte) string {
+	return transport.RedactSecrets(string(body), c.basicAuth(), c.token)
+}
+
 // doRequest performs an authenticated HTTP request using basic auth
 // The caller is responsible for closing the response body on success.
 func (c *Client) doRequest(ctx context.Context, method, url string, body io.Reader, contentType string) (*http.Response, error) {
@@ -90,9 +101,7 @@ func (c *Client) doRequest(ctx context.Context, method, url string, body io.Read
 		}
 
 		// Set Basic Auth header (username:token)
-		auth := c.Username + ":" + c.token
-		encodedAuth := base64.StdEncoding.EncodeToString([]byte(auth))
-		req.Header.Set("Authorization", "Basic "+encodedAuth)
+		req.Header.Set("Authorization", "Basic "+c.basicAuth())
 
 		// Set content type if provided
 		if contentType != "" {
@@ -117,12 +126,49 @@ func (c *Client) doRequest(ctx context.Context, method, url string, body io.Read
 	if resp.StatusCode >= 400 {
 		bodyBytes, _ := io.ReadAll(resp.Body) //nolint:errcheck // best-effort read for error message
 		_ = resp.Body.Close()
-		return nil, transport.NewHTTPError("CalDAV "+method, resp, bodyBytes)
+		return nil, transport.NewHTTPError("CalDAV "+method, resp, []byte(c.redact(bodyBytes)))
 	}
 
 	return resp, nil
 }
 
+// SetAttendeeStatus replies to an invitation: it fetches the event
+// {calendarName}/{uid}.ics, sets the PARTSTAT of the attendee email to
+// partstat (ACCEPTED, DECLINED or TENTATIVE) and writes it back.
+// It returns ErrAttendeeNotFound when email isn't an attendee.
+func (c *Client) SetAttendeeStatus(ctx context.Context, calendarName, uid, email, partstat string) error {
+	eventURL := fmt.Sprintf("%s%s/%s.ics", c.CalendarHomeURL(), calendarName, url.PathEscape(uid))
+
+	resp, err := c.doRequest(ctx, "GET", eventURL, nil, "")
+	if err != nil {
+		return fmt.Errorf("CalDAV request failed: %w", err)
+	}
+	body, err := io.ReadAll(resp.Body)
+	_ = resp.Body.Close()
+	if err != nil {
+		return fmt.Errorf("reading event: %w", err)
+	}
+
+	ics, err := setAttendeeStatus(string(body), email, partstat)
+	if err != nil {
+		return err
+	}
+
+	resp, err = c.doRequest(ctx, "PUT", eventURL, strings.NewReader(ics), "text/calendar; charset=utf-8")
+	if err != nil {
+		return fmt.Errorf("CalDAV request failed: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	switch resp.StatusCode {
+	case http.StatusOK, http.StatusCreated, http.StatusNoContent:
+		return nil
+	default:
+		body, _ := io.ReadAll(resp.Body) //nolint:errcheck // best-effort read for error message
+		return fmt.Errorf("CalDAV PUT failed: %s - %s", resp.Status, c.redact(body))
+	}
+}
+
 // CreateEvent creates a new calendar event via CalDAV PUT.
 func (c *Client) CreateEvent(ctx context.Context, calendarName string, event *Event) error {
 	if event.UID == "" {
@@ -140,7 +186,7 @@ func (c *Client) CreateEvent(ctx context.Context, calendarName string, event *Ev
 
 	if resp.StatusCode != http.StatusCreated && resp.StatusCode != http.StatusNoContent {
 		body, _ := io.ReadAll(resp.Body) //nolint:errcheck // best-effort read for error message
-		return fmt.Errorf("CalDAV PUT failed: %s - %s", resp.Status, string(body))
+		return fmt.Errorf("CalDAV PUT failed: %s - %s", resp.Status, c.redact(body))
 	}
 
 	return nil
@@ -418,3 +418,54 @@ func TestClient_CreateEvent_ServerError(t *testing.T) {
 		})
 	}
 }
+
+func TestClient_CreateEvent_ErrorRedactsCredentials(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte("echo: " + r.Header.Get("Authorization") + " testtoken"))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "testuser", "testtoken")
+	event := &Event{
+		UID:   "test-event-123",
+		Start: time.Date(2025, 1, 15, 10, 0, 0, 0, time.UTC),
+		End:   time.Date(2025, 1, 15, 11, 0, 0, 0, time.UTC),
+	}
+
+	err := client.CreateEvent(context.Background(), "Default", event)
+	if err == nil {
+		t.Fatal("CreateEvent() error = nil, want error")
+	}
+	if strings.Contains(err.Error(), "testtoken") || strings.Contains(err.Error(), client.basicAuth()) {
+		t.Errorf("error leaks credentials: %v", err)
+	}
+}
+
+func TestClient_SetAttendeeStatus(t *testing.T) {
+	var put string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !strings.HasSuffix(r.URL.EscapedPath(), "/Default/ev%2F1%20x.ics") {
+			t.Errorf("URL path = %q, want to end with /Default/ev%%2F1%%20x.ics", r.URL.EscapedPath())
+		}
+		switch r.Method {
+		case "GET":
+			_, _ = io.WriteString(w, "BEGIN:VCALENDAR\r\nBEGIN:VEVENT\r\nUID:ev-1\r\nATTENDEE;PARTSTAT=NEEDS-ACTION:mailto:me@example.com\r\nEND:VEVENT\r\nEND:VCALENDAR\r\n")
+		case "PUT":
+			body, _ := io.ReadAll(r.Body)
+			put = string(body)
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			t.Errorf("unexpected method %s", r.Method)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "me@example.com", "testtoken")
+	if err := client.SetAttendeeStatus(context.Background(), "Default", "ev/1 x", "me@example.com", "TENTATIVE"); err != nil {
+		t.Fatalf("SetAttendeeStatus() error = %v", err)
+	}
+	if !strings.Contains(put, "ATTENDEE;PARTSTAT=TENTATIVE:mailto:me@example.com") {
+		t.Errorf("PUT body missing updated attendee:\n%s", put)
+	}
+}
@@ -1,11 +1,15 @@
 package caldav
 
 import (
+	"errors"
 	"fmt"
 	"strings"
 	"time"
 )
 
+// ErrAttendeeNotFound indicates the address isn't an attendee of the event.
+var ErrAttendeeNotFound = errors.New("not an attendee of this event")
+
 // Attendee represents a calendar event attendee
 type Attendee struct {
 	Email  string // Email address of the attendee
@@ -26,6 +30,14 @@ type Event struct {
 	Organizer   string     // Organizer email address
 	Attendees   []Attendee // List of attendees
 	Status      string     // CONFIRMED, TENTATIVE, CANCELLED
+	RRule       string     // Recurrence rule value, e.g. FREQ=WEEKLY;COUNT=10
+	Alarms      []Alarm    // Reminders
+}
+
+// Alarm is a VALARM reminder relative to the event start
+type Alarm struct {
+	Trigger string // ISO 8601 duration, e.g. -PT15M (15 min before)
+	Action  string // DISPLAY or EMAIL
 }
 
 // foldLine folds a line at 75 octets per RFC 5545 section 3.1.
@@ -77,18 +89,41 @@ func findSafeByteEnd(data []byte, start, maxBytes int) int {
 	return end
 }
 
-// ToICS generates an iCalendar format string from the event
+// ToICS generates an iCalendar invitation (METHOD:REQUEST) for the event
 func (e *Event) ToICS() string {
 	var sb strings.Builder
+	writeCalendarStart(&sb, "REQUEST")
+	e.writeVEvent(&sb)
+	sb.WriteString("END:VCALENDAR\r\n")
+	return sb.String()
+}
+
+// EventsToICS generates an iCalendar file holding events, for exporting
+// them to other calendar apps.
+func EventsToICS(events []Event) string {
+	var sb strings.Builder
+	writeCalendarStart(&sb, "")
+	for i := range events {
+		events[i].writeVEvent(&sb)
+	}
+	sb.WriteString("END:VCALENDAR\r\n")
+	return sb.String()
+}
 
-	// VCALENDAR wrapper
+// writeCalendarStart writes the VCALENDAR header, with a METHOD property
+// unless method is empty.
+func writeCalendarStart(sb *strings.Builder, method string) {
 	sb.WriteString("BEGIN:VCALENDAR\r\n")
 	sb.WriteString("VERSION:2.0\r\n")
 	sb.WriteString("PRODID:-//Fastmail CLI//NONSGML Event//EN\r\n")
 	sb.WriteString("CALSCALE:GREGORIAN\r\n")
-	sb.WriteString("METHOD:REQUEST\r\n")
+	if method != "" {
+		sb.WriteString(fmt.Sprintf("METHOD:%s\r\n", method))
+	}
+}
 
-	// VEVENT
+// writeVEvent writes the event as a VEVENT component.
+func (e *Event) writeVEvent(sb *strings.Builder) {
 	sb.WriteString("BEGIN:VEVENT\r\n")
 	sb.WriteString(foldLine(fmt.Sprintf("UID:%s", e.UID)))
 	sb.WriteString(fmt.Sprintf("DTSTAMP:%s\r\n", formatICSTime(time.Now().UTC())))
@@ -102,6 +137,10 @@ func (e *Event) ToICS() string {
 		sb.WriteString(fmt.Sprintf("DTEND:%s\r\n", formatICSTime(e.End.UTC())))
 	}
 
+	if e.RRule != "" {
+		sb.WriteString(foldLine("RRULE:" + e.RRule))
+	}
+
 	sb.WriteString(foldLine(fmt.Sprintf("SUMMARY:%s", escapeICS(e.Summary))))
 
 	// Optional fields
@@ -146,10 +185,23 @@ func (e *Event) ToICS() string {
 			status, rsvpStr, escapeICS(name), attendee.Email)))
 	}
 
-	sb.WriteString("END:VEVENT\r\n")
-	sb.WriteString("END:VCALENDAR\r\n")
+	for _, alarm := range e.Alarms {
+		action := alarm.Action
+		if action == "" {
+			action = "DISPLAY"
+		}
+		sb.WriteString("BEGIN:VALARM\r\n")
+		sb.WriteString(fmt.Sprintf("ACTION:%s\r\n", action))
+		sb.WriteString(fmt.Sprintf("TRIGGER:%s\r\n", alarm.Trigger))
+		// DISPLAY and EMAIL alarms require a description, EMAIL a summary too
+		sb.WriteString(foldLine(fmt.Sprintf("DESCRIPTION:%s", escapeICS(e.Summary))))
+		if action == "EMAIL" {
+			sb.WriteString(foldLine(fmt.Sprintf("SUMMARY:%s", escapeICS(e.Summary))))
+		}
+		sb.WriteString("END:VALARM\r\n")
+	}
 
-	return sb.String()
+	sb.WriteString("END:VEVENT\r\n")
 }
 
 // formatICSTime formats a time.Time as an iCalendar datetime string (UTC)
@@ -168,3 +220,84 @@ func escapeICS(s string) string {
 	s = strings.ReplaceAll(s, "\r", "")
 	return s
 }
+
+// setAttendeeStatus returns ics with the PARTSTAT of the attendee whose
+// address is email (case-insensitively) set to partstat, e.g. ACCEPTED.
+// Lines are unfolded to edit and folded again on output.
+func setAttendeeStatus(ics, email, partstat string) (string, error) {
+	unfolded := unfoldICS(ics)
+
+	var sb strings.Builder
+	found := false
+	for _, line := range strings.Split(strings.TrimRight(unfolded, "\r\n"), "\n") {
+		line = strings.TrimSuffix(line, "\r")
+		name, params, value := splitContentLine(line)
+		if strings.EqualFold(name, "ATTENDEE") &&
+			strings.EqualFold(mailtoAddress(value), email) {
+			line = name + setParam(params, "PARTSTAT", partstat) + ":" + value
+			found = true
+		}
+		sb.WriteString(foldLine(line))
+	}
+	if !found {
+		return "", ErrAttendeeNotFound
+	}
+	return sb.String(), nil
+}
+
+// splitContentLine splits an unfolded content line into its name, its
+// parameters (including the leading ';') and its value. Colons and
+// semicolons inside quoted parameter values don't count.
+func splitContentLine(line string) (name, params, value string) {
+	inQuotes := false
+	nameEnd := -1
+	for i, r := range line {
+		switch {
+		case r == '"':
+			inQuotes = !inQuotes
+		case inQuotes:
+		case r == ';' && nameEnd < 0:
+			nameEnd = i
+		case r == ':':
+			if nameEnd < 0 {
+				nameEnd = i
+			}
+			return line[:nameEnd], line[nameEnd:i], line[i+1:]
+		}
+	}
+	return line, "", ""
+}
+
+// setParam returns params (";A=1;B=2") with key set to value, replacing an
+// existing key case-insensitively or appending it.
+func setParam(params, key, value string) string {
+	var parts []string
+	inQuotes := false
+	start := 0
+	for i, r := range params {
+		switch {
+		case r == '"':
+			inQuotes = !inQuotes
+		case r == ';' && !inQuotes:
+			if i > start {
+				parts = append(parts, params[start:i])
+			}
+			start = i + 1
+		}
+	}
+	if start < len(params) {
+		parts = append(parts, params[start:])
+	}
+
+	replaced := false
+	for i, part := range parts {
+		if k, _, ok := strings.Cut(part, "="); ok && strings.EqualFold(k, key) {
+			parts[i] = key + "=" + value
+			replaced = true
+		}
+	}
+	if !replaced {
+		parts = append(parts, key+"="+value)
+	}
+	return ";" + strings.Join(parts, ";")
+}
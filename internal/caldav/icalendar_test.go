@@ -1,6 +1,7 @@
 package caldav
 
 import (
+	"errors"
 	"strings"
 	"testing"
 	"time"
@@ -450,3 +451,34 @@ func TestFoldLineDataIntegrity(t *testing.T) {
 		})
 	}
 }
+
+func TestSetAttendeeStatus(t *testing.T) {
+	ics := "BEGIN:VCALENDAR\r\nBEGIN:VEVENT\r\nUID:abc\r\n" +
+		foldLine(`ATTENDEE;CN="Smith: Alice";ROLE=REQ-PARTICIPANT;PARTSTAT=NEEDS-ACTION;RSVP=TRUE:mailto:Alice@Example.com`) +
+		"ATTENDEE;CN=Bob:mailto:bob@example.com\r\n" +
+		"END:VEVENT\r\nEND:VCALENDAR\r\n"
+
+	got, err := setAttendeeStatus(ics, "alice@example.com", "ACCEPTED")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	unfolded := strings.ReplaceAll(got, "\r\n ", "")
+	if !strings.Contains(unfolded, `ATTENDEE;CN="Smith: Alice";ROLE=REQ-PARTICIPANT;PARTSTAT=ACCEPTED;RSVP=TRUE:mailto:Alice@Example.com`) {
+		t.Errorf("Alice's PARTSTAT not updated:\n%s", got)
+	}
+	if !strings.Contains(unfolded, "ATTENDEE;CN=Bob:mailto:bob@example.com\r\n") {
+		t.Errorf("Bob's attendee line changed:\n%s", got)
+	}
+
+	got, err = setAttendeeStatus(ics, "bob@example.com", "DECLINED")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.Contains(got, "ATTENDEE;CN=Bob;PARTSTAT=DECLINED:mailto:bob@example.com\r\n") {
+		t.Errorf("PARTSTAT not appended for Bob:\n%s", got)
+	}
+
+	if _, err := setAttendeeStatus(ics, "carol@example.com", "ACCEPTED"); !errors.Is(err, ErrAttendeeNotFound) {
+		t.Errorf("expected ErrAttendeeNotFound, got %v", err)
+	}
+}
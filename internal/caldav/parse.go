@@ -0,0 +1,350 @@
+package caldav
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// icsProperty is one content line: NAME;PARAM=value:value
+type icsProperty struct {
+	name   string
+	params map[string]string
+	value  string
+}
+
+// icsComponent collects the properties of a VEVENT and its VALARMs.
+type icsComponent struct {
+	props  []icsProperty
+	alarms [][]icsProperty
+}
+
+func (c *icsComponent) get(name string) (icsProperty, bool) {
+	for _, p := range c.props {
+		if p.name == name {
+			return p, true
+		}
+	}
+	return icsProperty{}, false
+}
+
+// EventError is a VEVENT of an iCalendar file that could not be read.
+type EventError struct {
+	Index   int    // position of the VEVENT in the file, from 1
+	Summary string // its SUMMARY, if any
+	Err     error
+}
+
+func (e *EventError) Error() string {
+	if e.Summary != "" {
+		return fmt.Sprintf("event %d (%s): %v", e.Index, e.Summary, e.Err)
+	}
+	return fmt.Sprintf("event %d: %v", e.Index, e.Err)
+}
+
+func (e *EventError) Unwrap() error {
+	return e.Err
+}
+
+// ParseICS parses the VEVENTs of an iCalendar file. Times may be UTC (Z),
+// carry a TZID (an IANA name, or a VTIMEZONE in the file, read as its
+// standard offset), be floating (read as local time) or be dates (all-day
+// events). A missing DTEND comes from DURATION, or is one day after an
+// all-day start. Only VALARMs triggered relative to the start are kept,
+// and changed occurrences of recurring events (RECURRENCE-ID) are skipped.
+//
+// A VEVENT that can't be read is returned as an EventError and the rest
+// are still parsed; the error is only for a file that isn't iCalendar.
+func ParseICS(data string) ([]Event, []*EventError, error) {
+	var (
+		stack     []string
+		events    []*icsComponent
+		current   *icsComponent
+		alarm     []icsProperty
+		zones     = map[string]*time.Location{}
+		zoneID    string
+		zoneFound bool
+	)
+
+	for _, line := range strings.Split(unfoldICS(data), "\n") {
+		line = strings.TrimSuffix(line, "\r")
+		if line == "" {
+			continue
+		}
+		name, rawParams, value := splitContentLine(line)
+		name = strings.ToUpper(name)
+
+		switch name {
+		case "BEGIN":
+			component := strings.ToUpper(value)
+			stack = append(stack, component)
+			switch component {
+			case "VEVENT":
+				current = &icsComponent{}
+			case "VALARM":
+				alarm = []icsProperty{}
+			case "VTIMEZONE":
+				zoneID, zoneFound = "", false
+			}
+			continue
+		case "END":
+			component := strings.ToUpper(value)
+			if len(stack) == 0 || stack[len(stack)-1] != component {
+				return nil, nil, fmt.Errorf("unexpected END:%s", value)
+			}
+			stack = stack[:len(stack)-1]
+			switch component {
+			case "VEVENT":
+				events = append(events, current)
+				current = nil
+			case "VALARM":
+				if current != nil {
+					current.alarms = append(current.alarms, alarm)
+				}
+				alarm = nil
+			}
+			continue
+		}
+
+		if len(stack) == 0 {
+			continue
+		}
+		prop := icsProperty{name: name, params: parseICSParams(rawParams), value: value}
+
+		switch stack[len(stack)-1] {
+		case "VEVENT":
+			current.props = append(current.props, prop)
+		case "VALARM":
+			alarm = append(alarm, prop)
+		case "VTIMEZONE":
+			if name == "TZID" {
+				zoneID = value
+			}
+		case "STANDARD":
+			if name == "TZOFFSETTO" && zoneID != "" && !zoneFound {
+				if offset, err := parseUTCOffset(value); err == nil {
+					zones[zoneID] = time.FixedZone(zoneID, offset)
+					zoneFound = true
+				}
+			}
+		}
+	}
+	if len(stack) > 0 {
+		return nil, nil, fmt.Errorf("missing END:%s", stack[len(stack)-1])
+	}
+
+	result := make([]Event, 0, len(events))
+	var failed []*EventError
+	for i, c := range events {
+		if _, ok := c.get("RECURRENCE-ID"); ok {
+			continue
+		}
+		event, err := c.toEvent(zones)
+		if err != nil {
+			eventErr := &EventError{Index: i + 1, Err: err}
+			if summary, ok := c.get("SUMMARY"); ok {
+				eventErr.Summary = unescapeICS(summary.value)
+			}
+			failed = append(failed, eventErr)
+			continue
+		}
+		result = append(result, event)
+	}
+	return result, failed, nil
+}
+
+func (c *icsComponent) toEvent(zones map[string]*time.Location) (Event, error) {
+	var event Event
+
+	start, ok := c.get("DTSTART")
+	if !ok {
+		return Event{}, fmt.Errorf("missing DTSTART")
+	}
+	var err error
+	if event.Start, event.AllDay, err = parseICSDateTime(start, zones); err != nil {
+		return Event{}, fmt.Errorf("DTSTART: %w", err)
+	}
+
+	if end, ok := c.get("DTEND"); ok {
+		if event.End, _, err = parseICSDateTime(end, zones); err != nil {
+			return Event{}, fmt.Errorf("DTEND: %w", err)
+		}
+	} else if duration, ok := c.get("DURATION"); ok {
+		d, err := parseICSDuration(duration.value)
+		if err != nil {
+			return Event{}, fmt.Errorf("DURATION: %w", err)
+		}
+		event.End = event.Start.Add(d)
+	} else if event.AllDay {
+		event.End = event.Start.AddDate(0, 0, 1)
+	} else {
+		event.End = event.Start
+	}
+
+	for _, p := range c.props {
+		switch p.name {
+		case "UID":
+			event.UID = p.value
+		case "SUMMARY":
+			event.Summary = unescapeICS(p.value)
+		case "DESCRIPTION":
+			event.Description = unescapeICS(p.value)
+		case "LOCATION":
+			event.Location = unescapeICS(p.value)
+		case "STATUS":
+			event.Status = strings.ToUpper(p.value)
+		case "RRULE":
+			event.RRule = p.value
+		case "ORGANIZER":
+			event.Organizer = mailtoAddress(p.value)
+		case "ATTENDEE":
+			event.Attendees = append(event.Attendees, Attendee{
+				Email:  mailtoAddress(p.value),
+				Name:   p.params["CN"],
+				RSVP:   strings.EqualFold(p.params["RSVP"], "TRUE"),
+				Status: strings.ToUpper(p.params["PARTSTAT"]),
+			})
+		}
+	}
+
+	for _, props := range c.alarms {
+		var alarm Alarm
+		for _, p := range props {
+			switch p.name {
+			case "ACTION":
+				alarm.Action = strings.ToUpper(p.value)
+			case "TRIGGER":
+				if strings.EqualFold(p.params["VALUE"], "DATE-TIME") || strings.EqualFold(p.params["RELATED"], "END") {
+					continue
+				}
+				alarm.Trigger = p.value
+			}
+		}
+		if alarm.Trigger != "" {
+			event.Alarms = append(event.Alarms, alarm)
+		}
+	}
+
+	return event, nil
+}
+
+// parseICSDateTime parses a DATE or DATE-TIME property value. allDay
+// reports a DATE, which is returned as midnight UTC.
+func parseICSDateTime(p icsProperty, zones map[string]*time.Location) (t time.Time, allDay bool, err error) {
+	if strings.EqualFold(p.params["VALUE"], "DATE") || len(p.value) == len("20060102") {
+		t, err = time.ParseInLocation("20060102", p.value, time.UTC)
+		return t, true, err
+	}
+	if strings.HasSuffix(p.value, "Z") {
+		t, err = time.Parse("20060102T150405Z", p.value)
+		return t, false, err
+	}
+
+	loc := time.Local
+	if tzid := p.params["TZID"]; tzid != "" {
+		// Prefer the IANA zone, which knows about daylight saving time
+		if iana, err := time.LoadLocation(tzid); err == nil {
+			loc = iana
+		} else if zone, ok := zones[tzid]; ok {
+			loc = zone
+		} else {
+			return time.Time{}, false, fmt.Errorf("unknown time zone %q", tzid)
+		}
+	}
+	t, err = time.ParseInLocation("20060102T150405", p.value, loc)
+	return t, false, err
+}
+
+var icsDurationRE = regexp.MustCompile(`^([+-])?P(?:(\d+)W)?(?:(\d+)D)?(?:T(?:(\d+)H)?(?:(\d+)M)?(?:(\d+)S)?)?$`)
+
+// parseICSDuration parses an RFC 5545 duration such as PT1H30M or -P1D.
+func parseICSDuration(s string) (time.Duration, error) {
+	m := icsDurationRE.FindStringSubmatch(strings.ToUpper(s))
+	if m == nil || strings.Join(m[2:], "") == "" {
+		return 0, fmt.Errorf("invalid duration %q", s)
+	}
+
+	units := []time.Duration{7 * 24 * time.Hour, 24 * time.Hour, time.Hour, time.Minute, time.Second}
+	var d time.Duration
+	for i, unit := range units {
+		if m[i+2] == "" {
+			continue
+		}
+		n, err := strconv.Atoi(m[i+2])
+		if err != nil {
+			return 0, fmt.Errorf("invalid duration %q", s)
+		}
+		d += time.Duration(n) * unit
+	}
+	if m[1] == "-" {
+		d = -d
+	}
+	return d, nil
+}
+
+// parseUTCOffset parses a TZOFFSETTO value such as +0100 or -053000 into
+// seconds east of UTC.
+func parseUTCOffset(s string) (int, error) {
+	if len(s) != 5 && len(s) != 7 || (s[0] != '+' && s[0] != '-') {
+		return 0, fmt.Errorf("invalid UTC offset %q", s)
+	}
+	seconds := 0
+	for i, unit := range []int{3600, 60, 1} {
+		pos := 1 + 2*i
+		if pos >= len(s) {
+			break
+		}
+		n, err := strconv.Atoi(s[pos : pos+2])
+		if err != nil {
+			return 0, fmt.Errorf("invalid UTC offset %q", s)
+		}
+		seconds += n * unit
+	}
+	if s[0] == '-' {
+		seconds = -seconds
+	}
+	return seconds, nil
+}
+
+// parseICSParams parses ";KEY=value;KEY2=\"quoted\"" into upper-cased keys
+// and unquoted values.
+func parseICSParams(params string) map[string]string {
+	result := map[string]string{}
+	inQuotes := false
+	start := 0
+	add := func(part string) {
+		if k, v, ok := strings.Cut(part, "="); ok {
+			result[strings.ToUpper(k)] = strings.Trim(v, `"`)
+		}
+	}
+	for i, r := range params {
+		switch {
+		case r == '"':
+			inQuotes = !inQuotes
+		case r == ';' && !inQuotes:
+			add(params[start:i])
+			start = i + 1
+		}
+	}
+	add(params[start:])
+	return result
+}
+
+// unfoldICS joins folded content lines (RFC 5545 section 3.1).
+func unfoldICS(s string) string {
+	return strings.NewReplacer("\r\n ", "", "\r\n\t", "", "\n ", "", "\n\t", "").Replace(s)
+}
+
+// unescapeICS reverses escapeICS.
+func unescapeICS(s string) string {
+	return strings.NewReplacer(`\n`, "\n", `\N`, "\n", `\,`, ",", `\;`, ";", `\\`, `\`).Replace(s)
+}
+
+func mailtoAddress(value string) string {
+	if len(value) >= len("mailto:") && strings.EqualFold(value[:len("mailto:")], "mailto:") {
+		return value[len("mailto:"):]
+	}
+	return value
+}
@@ -0,0 +1,201 @@
+package caldav
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseICS(t *testing.T) {
+	ics := strings.Join([]string{
+		"BEGIN:VCALENDAR",
+		"VERSION:2.0",
+		"BEGIN:VTIMEZONE",
+		"TZID:Custom Standard Time",
+		"BEGIN:STANDARD",
+		"TZOFFSETFROM:+0200",
+		"TZOFFSETTO:+0100",
+		"END:STANDARD",
+		"END:VTIMEZONE",
+		"BEGIN:VEVENT",
+		"UID:ev-1",
+		"DTSTART:20260105T090000Z",
+		"DTEND:20260105T093000Z",
+		"SUMMARY:Standup\\, daily",
+		"DESCRIPTION:Line one\\nLine two",
+		"RRULE:FREQ=WEEKLY;COUNT=10",
+		`ATTENDEE;CN="Smith: Alice";PARTSTAT=ACCEPTED:mailto:alice@example.com`,
+		"BEGIN:VALARM",
+		"ACTION:DISPLAY",
+		"TRIGGER:-PT15M",
+		"END:VALARM",
+		"BEGIN:VALARM",
+		"ACTION:DISPLAY",
+		"TRIGGER;VALUE=DATE-TIME:20260105T080000Z",
+		"END:VALARM",
+		"END:VEVENT",
+		"BEGIN:VEVENT",
+		"UID:ev-2",
+		"DTSTART;VALUE=DATE:20261225",
+		"SUMMARY:Holiday",
+		"END:VEVENT",
+		"BEGIN:VEVENT",
+		"UID:ev-3",
+		"DTSTART;TZID=Europe/Berlin:20260701T100000",
+		"DURATION:PT1H30M",
+		"SUMMARY:Berlin",
+		"END:VEVENT",
+		"BEGIN:VEVENT",
+		"UID:ev-4",
+		"DTSTART;TZID=Custom Standard Time:20260105T100000",
+		"DTEND;TZID=Custom Standard Time:20260105T110000",
+		"SUMMARY:Custom zone, folded over a",
+		" second line",
+		"END:VEVENT",
+		"BEGIN:VEVENT",
+		"UID:ev-1",
+		"RECURRENCE-ID:20260112T090000Z",
+		"DTSTART:20260112T100000Z",
+		"SUMMARY:Moved standup",
+		"END:VEVENT",
+		"END:VCALENDAR",
+	}, "\r\n")
+
+	events, failed, err := ParseICS(ics)
+	if err != nil || len(failed) > 0 {
+		t.Fatalf("ParseICS() error = %v, failed = %v", err, failed)
+	}
+	if len(events) != 4 {
+		t.Fatalf("got %d events, want 4", len(events))
+	}
+
+	standup := events[0]
+	if standup.Summary != "Standup, daily" || standup.Description != "Line one\nLine two" {
+		t.Errorf("text not unescaped: %q / %q", standup.Summary, standup.Description)
+	}
+	if !standup.Start.Equal(time.Date(2026, 1, 5, 9, 0, 0, 0, time.UTC)) || standup.End.Sub(standup.Start) != 30*time.Minute {
+		t.Errorf("standup times = %v - %v", standup.Start, standup.End)
+	}
+	if standup.RRule != "FREQ=WEEKLY;COUNT=10" {
+		t.Errorf("RRule = %q", standup.RRule)
+	}
+	if len(standup.Alarms) != 1 || standup.Alarms[0] != (Alarm{Trigger: "-PT15M", Action: "DISPLAY"}) {
+		t.Errorf("Alarms = %+v, want only the relative one", standup.Alarms)
+	}
+	if len(standup.Attendees) != 1 || standup.Attendees[0].Name != "Smith: Alice" || standup.Attendees[0].Status != "ACCEPTED" {
+		t.Errorf("Attendees = %+v", standup.Attendees)
+	}
+
+	holiday := events[1]
+	if !holiday.AllDay || holiday.Start.Format("2006-01-02") != "2026-12-25" || holiday.End.Format("2006-01-02") != "2026-12-26" {
+		t.Errorf("holiday = %+v, want all day on 2026-12-25", holiday)
+	}
+
+	berlin := events[2]
+	if !berlin.Start.Equal(time.Date(2026, 7, 1, 8, 0, 0, 0, time.UTC)) || berlin.End.Sub(berlin.Start) != 90*time.Minute {
+		t.Errorf("berlin times = %v - %v, want 08:00Z for 90m", berlin.Start, berlin.End)
+	}
+
+	custom := events[3]
+	if !custom.Start.Equal(time.Date(2026, 1, 5, 9, 0, 0, 0, time.UTC)) {
+		t.Errorf("custom zone start = %v, want 09:00Z", custom.Start)
+	}
+	if custom.Summary != "Custom zone, folded over asecond line" {
+		t.Errorf("folded summary = %q", custom.Summary)
+	}
+}
+
+func TestParseICS_Errors(t *testing.T) {
+	for name, ics := range map[string]string{
+		"unclosed":       "BEGIN:VCALENDAR\r\nBEGIN:VEVENT\r\nDTSTART:20260105T090000Z\r\n",
+		"unexpected END": "BEGIN:VCALENDAR\r\nEND:VEVENT\r\n",
+	} {
+		if _, _, err := ParseICS(ics); err == nil {
+			t.Errorf("%s: expected error", name)
+		}
+	}
+}
+
+func TestParseICS_EventErrors(t *testing.T) {
+	ics := strings.Join([]string{
+		"BEGIN:VCALENDAR",
+		"BEGIN:VEVENT",
+		"SUMMARY:No start",
+		"END:VEVENT",
+		"BEGIN:VEVENT",
+		"SUMMARY:Fine",
+		"DTSTART:20260105T090000Z",
+		"END:VEVENT",
+		"BEGIN:VEVENT",
+		"DTSTART;TZID=Nowhere/Special:20260105T090000",
+		"END:VEVENT",
+		"END:VCALENDAR",
+	}, "\r\n")
+
+	events, failed, err := ParseICS(ics)
+	if err != nil {
+		t.Fatalf("ParseICS() error = %v", err)
+	}
+	if len(events) != 1 || events[0].Summary != "Fine" {
+		t.Errorf("events = %+v, want only the valid one", events)
+	}
+	if len(failed) != 2 || failed[0].Index != 1 || failed[0].Summary != "No start" || failed[1].Index != 3 {
+		t.Fatalf("failed = %v", failed)
+	}
+	if got := failed[0].Error(); got != "event 1 (No start): missing DTSTART" {
+		t.Errorf("Error() = %q", got)
+	}
+}
+
+func TestParseICSDuration(t *testing.T) {
+	tests := map[string]time.Duration{
+		"PT15M":    15 * time.Minute,
+		"-PT15M":   -15 * time.Minute,
+		"P1D":      24 * time.Hour,
+		"P1W":      7 * 24 * time.Hour,
+		"P1DT2H3S": 26*time.Hour + 3*time.Second,
+	}
+	for in, want := range tests {
+		if got, err := parseICSDuration(in); err != nil || got != want {
+			t.Errorf("parseICSDuration(%q) = %v, %v; want %v", in, got, err, want)
+		}
+	}
+	for _, in := range []string{"P", "PT", "15M", "P1H"} {
+		if _, err := parseICSDuration(in); err == nil {
+			t.Errorf("parseICSDuration(%q) expected error", in)
+		}
+	}
+}
+
+func TestEventsToICS_RoundTrip(t *testing.T) {
+	events := []Event{
+		{
+			UID:     "a",
+			Summary: "Review; weekly",
+			Start:   time.Date(2026, 1, 5, 14, 0, 0, 0, time.UTC),
+			End:     time.Date(2026, 1, 5, 15, 0, 0, 0, time.UTC),
+			RRule:   "FREQ=WEEKLY;INTERVAL=2",
+			Alarms:  []Alarm{{Trigger: "-PT10M", Action: "EMAIL"}},
+		},
+		{UID: "b", Summary: "Holiday", Start: time.Date(2026, 12, 25, 0, 0, 0, 0, time.UTC), End: time.Date(2026, 12, 26, 0, 0, 0, 0, time.UTC), AllDay: true},
+	}
+
+	ics := EventsToICS(events)
+	if strings.Contains(ics, "METHOD:") {
+		t.Error("export should not carry an iTIP METHOD")
+	}
+	for _, want := range []string{"RRULE:FREQ=WEEKLY;INTERVAL=2\r\n", "BEGIN:VALARM\r\nACTION:EMAIL\r\nTRIGGER:-PT10M\r\n", "DTSTART;VALUE=DATE:20261225\r\n"} {
+		if !strings.Contains(ics, want) {
+			t.Errorf("ICS missing %q:\n%s", want, ics)
+		}
+	}
+
+	parsed, failed, err := ParseICS(ics)
+	if err != nil || len(failed) > 0 {
+		t.Fatalf("ParseICS() error = %v, failed = %v", err, failed)
+	}
+	if len(parsed) != 2 || parsed[0].Summary != "Review; weekly" || parsed[0].RRule != events[0].RRule ||
+		len(parsed[0].Alarms) != 1 || !parsed[1].AllDay || !parsed[0].Start.Equal(events[0].Start) {
+		t.Errorf("round trip = %+v", parsed)
+	}
+}
@@ -2,11 +2,15 @@ package cmd
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"os"
+	"reflect"
+	"time"
 
 	"github.com/salmonumbrella/fastmail-cli/internal/config"
 	cerrors "github.com/salmonumbrella/fastmail-cli/internal/errors"
+	"github.com/salmonumbrella/fastmail-cli/internal/format"
 	"github.com/salmonumbrella/fastmail-cli/internal/jmap"
 	"github.com/salmonumbrella/fastmail-cli/internal/outfmt"
 	"github.com/salmonumbrella/fastmail-cli/internal/ui"
@@ -20,6 +24,21 @@ type App struct {
 	Flags  *rootFlags
 	UI     *ui.UI
 	Logger Logger
+
+	// Settings are the config file defaults, loaded before each command.
+	Settings config.Settings
+
+	// account is the account last resolved by RequireAccount, used to name
+	// it in reauth suggestions.
+	account string
+
+	// listTokens lists the stored credentials that --account is resolved
+	// against; nil means config.ListTokens.
+	listTokens func() ([]config.Token, error)
+
+	// deadline is the --deadline context; cancelDeadline releases it.
+	deadline       context.Context
+	cancelDeadline context.CancelFunc
 }
 
 // Logger is the minimal interface we need from slog.Logger.
@@ -56,13 +75,53 @@ func runE(app *App, fn func(cmd *cobra.Command, args []string, app *App) error)
 		if app == nil {
 			app = &App{Flags: &rootFlags{}}
 		}
-		return mapCommandError(fn(cmd, args, app))
+		return mapCommandError(fn(cmd, args, app), app.account)
 	}
 }
 
+// IsJSON reports whether output is machine-readable, either as one JSON
+// document (--output json) or as NDJSON lines (--output ndjson).
 func (a *App) IsJSON(ctx context.Context) bool {
 	mode, ok := ctx.Value(outputModeKey).(outfmt.Mode)
-	return ok && mode == outfmt.JSON
+	return ok && (mode == outfmt.JSON || mode == outfmt.NDJSON)
+}
+
+// IsNDJSON reports whether --output ndjson was selected.
+func (a *App) IsNDJSON(ctx context.Context) bool {
+	mode, ok := ctx.Value(outputModeKey).(outfmt.Mode)
+	return ok && mode == outfmt.NDJSON
+}
+
+// TableStyle returns the colors for text tables, honoring --color and
+// NO_COLOR. Machine-readable output is never colored.
+func (a *App) TableStyle(ctx context.Context) outfmt.TableStyle {
+	if a.IsJSON(ctx) || a.IsCSV(ctx) || a.Flags == nil {
+		return outfmt.TableStyle{}
+	}
+	return outfmt.NewTableStyle(a.Flags.Color)
+}
+
+// DateStyle returns how dates should be shown in text and CSV output,
+// from --date-format, --relative and --utc.
+func (a *App) DateStyle() format.DateStyle {
+	if a.Flags == nil {
+		return format.DateStyle{}
+	}
+	style := format.DateStyle{
+		Layout:   a.Flags.DateFormat,
+		Relative: a.Flags.RelativeDates,
+	}
+	if a.Flags.UTC {
+		style.Location = time.UTC
+	}
+	return style
+}
+
+// IsCSV reports whether --output csv was selected. Only tabular commands
+// honor it; the rest print text.
+func (a *App) IsCSV(ctx context.Context) bool {
+	mode, ok := ctx.Value(outputModeKey).(outfmt.Mode)
+	return ok && mode == outfmt.CSV
 }
 
 func (a *App) Query(ctx context.Context) string {
@@ -70,8 +129,29 @@ func (a *App) Query(ctx context.Context) string {
 	return query
 }
 
+// PrintJSON prints v as JSON. With --output ndjson a slice is written one
+// element per line and anything else as a single line.
 func (a *App) PrintJSON(cmd *cobra.Command, v any) error {
-	return outfmt.PrintJSONFiltered(v, a.Query(cmd.Context()))
+	if !a.IsNDJSON(cmd.Context()) {
+		return outfmt.PrintJSONFiltered(v, a.Query(cmd.Context()))
+	}
+
+	w := a.NDJSONWriter(cmd)
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Slice {
+		return w.Write(v)
+	}
+	for i := range rv.Len() {
+		if err := w.Write(rv.Index(i).Interface()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// NDJSONWriter returns a writer for streaming --output ndjson lines to stdout.
+func (a *App) NDJSONWriter(cmd *cobra.Command) *outfmt.NDJSONWriter {
+	return outfmt.NewNDJSONWriter(os.Stdout, a.Query(cmd.Context()))
 }
 
 func (a *App) Confirm(cmd *cobra.Command, skip bool, prompt string, accepted ...string) (bool, error) {
@@ -81,9 +161,37 @@ func (a *App) Confirm(cmd *cobra.Command, skip bool, prompt string, accepted ...
 	return confirmPrompt(os.Stderr, prompt, accepted...)
 }
 
+// ConfirmOperation is like Confirm, but a non-empty token from a previous
+// --dry-run approves the operation without prompting if it matches op and ids.
+// A mismatched token is an error rather than a fallback to prompting.
+func (a *App) ConfirmOperation(cmd *cobra.Command, token, op string, ids []string, prompt string, accepted ...string) (bool, error) {
+	if token != "" {
+		if err := verifyConfirmToken(token, op, ids); err != nil {
+			return false, err
+		}
+		return true, nil
+	}
+	return a.Confirm(cmd, false, prompt, accepted...)
+}
+
+// RequireAccount returns the account to use: --account resolved against
+// the stored credentials (see config.ResolveAccount), else the primary one.
 func (a *App) RequireAccount() (string, error) {
 	if a.Flags != nil && a.Flags.Account != "" {
-		return a.Flags.Account, nil
+		listTokens := a.listTokens
+		if listTokens == nil {
+			listTokens = config.ListTokens
+		}
+		tokens, err := listTokens()
+		if err != nil {
+			return "", fmt.Errorf("failed to get accounts: %w", err)
+		}
+		account, err := config.ResolveAccount(tokens, a.Flags.Account)
+		if err != nil {
+			return "", err
+		}
+		a.account = account
+		return account, nil
 	}
 
 	// Auto-select primary/only account when not explicitly specified
@@ -92,6 +200,7 @@ func (a *App) RequireAccount() (string, error) {
 		return "", fmt.Errorf("failed to get accounts: %w", err)
 	}
 	if primary != "" {
+		a.account = primary
 		return primary, nil
 	}
 
@@ -110,11 +219,87 @@ func (a *App) JMAPClient() (*jmap.Client, error) {
 		return nil, fmt.Errorf("failed to get token for %s: %w", account, err)
 	}
 
-	return jmap.NewClient(token), nil
+	client := jmap.NewClient(token)
+	client.SetUserAgent(a.UserAgent())
+	if a.Flags.AccountID != "" {
+		client.SetAccountID(a.Flags.AccountID)
+	}
+	if a.Flags.Timeout > 0 {
+		client.SetRequestTimeout(a.Flags.Timeout)
+	}
+	if a.Flags.Verbose {
+		client.SetLogger(stderrRequestLogger)
+	}
+	if a.Flags.DryRunRequests {
+		client.SetDryRun(stderrRequestCapture)
+	}
+	if !a.Flags.NoSessionCache {
+		if dir, err := config.SessionCacheDir(); err == nil {
+			client.SetSessionCacheDir(dir)
+		}
+	}
+	return client, nil
+}
+
+// JMAPClientFor returns a JMAP client after checking that the session grants
+// capability (e.g. jmap.CapabilityCalendars), so a feature the account or
+// API token lacks fails up front with a clear message.
+func (a *App) JMAPClientFor(ctx context.Context, capability string) (*jmap.Client, error) {
+	client, err := a.JMAPClient()
+	if err != nil {
+		return nil, err
+	}
+	if err := client.RequireCapability(ctx, capability); err != nil {
+		return nil, capabilityError(err)
+	}
+	return client, nil
+}
+
+// capabilityError adds a suggestion to a feature's not-enabled error.
+func capabilityError(err error) error {
+	for _, sentinel := range []error{jmap.ErrMaskedEmailNotEnabled, jmap.ErrCalendarsNotEnabled, jmap.ErrContactsNotEnabled, jmap.ErrVacationNotEnabled} {
+		if errors.Is(err, sentinel) {
+			return cerrors.WithSuggestion(err, "This feature isn't enabled on your account or API token. Run 'fastmail whoami' to see what is enabled, or create a token with the matching scope")
+		}
+	}
+	return err
+}
+
+// UserAgent returns the --user-agent value, or fastmail-cli/<version>.
+func (a *App) UserAgent() string {
+	if a.Flags != nil && a.Flags.UserAgent != "" {
+		return a.Flags.UserAgent
+	}
+	return jmap.DefaultUserAgent + "/" + currentBuildInfo().Version
+}
+
+// stderrRequestLogger writes one line per JMAP request attempt to stderr.
+func stderrRequestLogger(evt jmap.RequestEvent) {
+	fmt.Fprintln(os.Stderr, "jmap:", evt)
+}
+
+// stderrRequestCapture prints a JMAP request that --dry-run-requests kept
+// from being sent.
+func stderrRequestCapture(req *jmap.Request) {
+	_ = outfmt.WriteJSON(os.Stderr, req)
+}
+
+// requireJMAPOnly fails under --dry-run-requests, which only keeps JMAP
+// requests from being sent, before service (Sieve, CalDAV or WebDAV) is
+// used.
+func (a *App) requireJMAPOnly(service string) error {
+	if a.Flags != nil && a.Flags.DryRunRequests {
+		return fmt.Errorf("--dry-run-requests only covers JMAP requests; %s requests would still be sent", service)
+	}
+	return nil
 }
 
 // WebDAVClient creates a WebDAV client for the configured account.
 func (a *App) WebDAVClient() (*webdav.Client, error) {
+	if err := a.requireJMAPOnly("WebDAV"); err != nil {
+		return nil, err
+	}
+
 	account, err := a.RequireAccount()
 	if err != nil {
 		return nil, err
@@ -1,6 +1,7 @@
 package cmd
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"os"
@@ -15,6 +16,8 @@ import (
 
 	"github.com/salmonumbrella/fastmail-cli/internal/auth"
 	"github.com/salmonumbrella/fastmail-cli/internal/config"
+	cerrors "github.com/salmonumbrella/fastmail-cli/internal/errors"
+	"github.com/salmonumbrella/fastmail-cli/internal/jmap"
 	"github.com/salmonumbrella/fastmail-cli/internal/logging"
 	"github.com/salmonumbrella/fastmail-cli/internal/ui"
 )
@@ -51,6 +54,7 @@ func newAuthCmd(app *App) *cobra.Command {
 	cmd.AddCommand(newAuthListCmd(app))
 	cmd.AddCommand(newAuthRemoveCmd(app))
 	cmd.AddCommand(newAuthStatusCmd(app))
+	cmd.AddCommand(newAuthTestCmd(app))
 
 	return cmd
 }
@@ -334,3 +338,75 @@ func newAuthStatusCmd(app *App) *cobra.Command {
 		}),
 	}
 }
+
+// authTestResult is what auth test reports for a working token.
+type authTestResult struct {
+	Account   string `json:"account"`
+	AccountID string `json:"accountId"`
+	Mailboxes int    `json:"mailboxes"`
+	LatencyMs int64  `json:"latencyMs"`
+}
+
+// testCredentials fetches the session and the mailbox list, the smallest
+// round trip that proves the token can read mail.
+func testCredentials(ctx context.Context, client *jmap.Client, account string) (*authTestResult, error) {
+	start := time.Now()
+
+	session, err := client.GetSession(ctx)
+	if err != nil {
+		return nil, cerrors.WithContext(err, "fetching session")
+	}
+	mailboxes, err := client.GetMailboxes(ctx)
+	if err != nil {
+		return nil, cerrors.WithContext(err, "listing mailboxes")
+	}
+
+	return &authTestResult{
+		Account:   account,
+		AccountID: session.AccountID,
+		Mailboxes: len(mailboxes),
+		LatencyMs: time.Since(start).Milliseconds(),
+	}, nil
+}
+
+func newAuthTestCmd(app *App) *cobra.Command {
+	return &cobra.Command{
+		Use:   "test",
+		Short: "Check that the stored API token works",
+		Long: `Check the stored API token of the account (--account, or the default) by
+fetching a fresh session and the mailbox list.
+
+The exit code tells failures apart: 4 for a rejected token, 7 when the
+circuit breaker is open, 8 for network errors and 1 for anything else.`,
+		Example: `  fastmail auth test
+  fastmail auth test --account work@example.com
+  fastmail auth test --output json`,
+		Args: cobra.NoArgs,
+		RunE: runE(app, func(cmd *cobra.Command, _ []string, app *App) error {
+			account, err := app.RequireAccount()
+			if err != nil {
+				return err
+			}
+
+			// A cached session would skip the round trip this is meant to test
+			app.Flags.NoSessionCache = true
+			client, err := app.JMAPClient()
+			if err != nil {
+				return err
+			}
+
+			result, err := testCredentials(cmd.Context(), client, account)
+			if err != nil {
+				return err
+			}
+
+			if app.IsJSON(cmd.Context()) {
+				return app.PrintJSON(cmd, result)
+			}
+
+			fmt.Printf("OK: token for %s works (account %s, %d mailboxes, %dms)\n",
+				result.Account, result.AccountID, result.Mailboxes, result.LatencyMs)
+			return nil
+		}),
+	}
+}
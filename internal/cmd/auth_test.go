@@ -1,8 +1,13 @@
 package cmd
 
 import (
+	"context"
+	"net/http"
 	"testing"
 	"time"
+
+	"github.com/salmonumbrella/fastmail-cli/internal/jmap"
+	"github.com/salmonumbrella/fastmail-cli/internal/testutil"
 )
 
 func TestCheckCredentialAge(t *testing.T) {
@@ -57,3 +62,50 @@ func TestCheckCredentialAge(t *testing.T) {
 		})
 	}
 }
+
+func TestTestCredentials(t *testing.T) {
+	jm := testutil.NewJMAPMock()
+	defer jm.Close()
+	jm.QueueMethodResponses(testutil.MethodResponse{Name: "Mailbox/get", Args: map[string]any{"list": []any{
+		map[string]any{"id": "mb1", "name": "Inbox", "role": "inbox"},
+		map[string]any{"id": "mb2", "name": "Sent", "role": "sent"},
+	}}})
+
+	client := jmap.NewClientWithBaseURL("test-token", jm.URL())
+	result, err := testCredentials(context.Background(), client, "me@example.com")
+	if err != nil {
+		t.Fatalf("testCredentials() error = %v", err)
+	}
+	if result.Account != "me@example.com" || result.AccountID != testutil.JMAPAccountID || result.Mailboxes != 2 {
+		t.Errorf("result = %+v", result)
+	}
+}
+
+func TestTestCredentials_ExitCodes(t *testing.T) {
+	unauthorized := testutil.NewMockServer()
+	defer unauthorized.Close()
+	unauthorized.HandleJSON(http.MethodGet, testutil.JMAPSessionPath, http.StatusUnauthorized, map[string]any{"error": "unauthorized"})
+
+	unreachable := testutil.NewMockServer()
+	unreachable.Close()
+
+	tests := []struct {
+		name string
+		url  string
+		want int
+	}{
+		{"rejected token", unauthorized.URL(), ExitAuth},
+		{"network", unreachable.URL(), ExitNetwork},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := jmap.NewClientWithBaseURL("test-token", tt.url)
+			client.SetRetryConfig(jmap.RetryConfig{MaxRetries: 0})
+			_, err := testCredentials(context.Background(), client, "me@example.com")
+			if got := ExitCode(err); got != tt.want {
+				t.Errorf("ExitCode(%v) = %d, want %d", err, got, tt.want)
+			}
+		})
+	}
+}
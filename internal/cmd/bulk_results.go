@@ -41,3 +41,13 @@ func printBulkResults(action, target string, succeededCount, failedCount int, fa
 		fmt.Printf("  %s: %s\n", id, errMsg)
 	}
 }
+
+// errNoneSucceeded returns an error when all total items of a bulk run
+// failed, so the command exits non-zero after printing its results. A run
+// with nothing to do, or with at least one success, returns nil.
+func errNoneSucceeded(what string, succeeded, total int) error {
+	if total == 0 || succeeded > 0 {
+		return nil
+	}
+	return fmt.Errorf("all %d %s failed", total, what)
+}
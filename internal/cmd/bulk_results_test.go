@@ -46,3 +46,15 @@ func TestPrintBulkResults_WithFailures_NoTarget(t *testing.T) {
 		t.Fatalf("missing failure line: %q", out)
 	}
 }
+
+func TestErrNoneSucceeded(t *testing.T) {
+	if err := errNoneSucceeded("imports", 0, 0); err != nil {
+		t.Errorf("empty run: %v", err)
+	}
+	if err := errNoneSucceeded("imports", 1, 3); err != nil {
+		t.Errorf("partial success: %v", err)
+	}
+	if err := errNoneSucceeded("imports", 0, 3); err == nil || err.Error() != "all 3 imports failed" {
+		t.Errorf("all failed: %v", err)
+	}
+}
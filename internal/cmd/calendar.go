@@ -1,16 +1,23 @@
 package cmd
 
 import (
+	"context"
 	"crypto/rand"
 	"encoding/hex"
+	"errors"
 	"fmt"
+	"io"
+	"os"
 	"sort"
+	"strconv"
 	"strings"
 	"time"
 
 	"github.com/salmonumbrella/fastmail-cli/internal/caldav"
 	"github.com/salmonumbrella/fastmail-cli/internal/config"
 	"github.com/salmonumbrella/fastmail-cli/internal/dateparse"
+	cerrors "github.com/salmonumbrella/fastmail-cli/internal/errors"
+	"github.com/salmonumbrella/fastmail-cli/internal/format"
 	"github.com/salmonumbrella/fastmail-cli/internal/jmap"
 	"github.com/salmonumbrella/fastmail-cli/internal/outfmt"
 	"github.com/salmonumbrella/fastmail-cli/internal/validation"
@@ -34,7 +41,10 @@ If calendars are not available via JMAP, you'll receive an error.`,
 	cmd.AddCommand(newCalendarEventCreateCmd(app))
 	cmd.AddCommand(newCalendarEventUpdateCmd(app))
 	cmd.AddCommand(newCalendarEventDeleteCmd(app))
+	cmd.AddCommand(newCalendarEventExportCmd(app))
+	cmd.AddCommand(newCalendarImportCmd(app))
 	cmd.AddCommand(newCalendarInviteCmd(app))
+	cmd.AddCommand(newCalendarRSVPCmd(app))
 
 	return cmd
 }
@@ -47,7 +57,7 @@ func newCalendarListCmd(app *App) *cobra.Command {
 		Example: `  fastmail calendar list
   fastmail calendar list --output json`,
 		RunE: runE(app, func(cmd *cobra.Command, args []string, app *App) error {
-			client, err := app.JMAPClient()
+			client, err := app.JMAPClientFor(cmd.Context(), jmap.CapabilityCalendars)
 			if err != nil {
 				return err
 			}
@@ -108,6 +118,8 @@ func newCalendarEventsCmd(app *App) *cobra.Command {
 	var fromDate string
 	var toDate string
 	var limit int
+	var withAttendees bool
+	var withLocation bool
 
 	cmd := &cobra.Command{
 		Use:   "events",
@@ -119,9 +131,10 @@ or relative expressions like yesterday, 2h ago, or monday.`,
 		Example: `  fastmail calendar events
   fastmail calendar events --calendar <id>
   fastmail calendar events --from 2025-12-01 --to 2025-12-31
-  fastmail calendar events --limit 50`,
+  fastmail calendar events --limit 50
+  fastmail calendar events --with-attendees --with-location`,
 		RunE: runE(app, func(cmd *cobra.Command, args []string, app *App) error {
-			client, err := app.JMAPClient()
+			client, err := app.JMAPClientFor(cmd.Context(), jmap.CapabilityCalendars)
 			if err != nil {
 				return err
 			}
@@ -153,6 +166,9 @@ or relative expressions like yesterday, 2h ago, or monday.`,
 			if app.IsJSON(cmd.Context()) {
 				return app.PrintJSON(cmd, events)
 			}
+			if app.IsCSV(cmd.Context()) {
+				return writeEventsCSV(os.Stdout, events, app.DateStyle(), withAttendees, withLocation)
+			}
 
 			if len(events) == 0 {
 				printNoResults("No events found")
@@ -160,18 +176,7 @@ or relative expressions like yesterday, 2h ago, or monday.`,
 			}
 
 			tw := outfmt.NewTabWriter()
-			_, _ = fmt.Fprintln(tw, "ID\tTITLE\tSTART\tEND\tSTATUS") //nolint:errcheck
-			for _, event := range events {
-				startStr := formatEventTime(event.Start, event.IsAllDay)
-				endStr := formatEventTime(event.End, event.IsAllDay)
-				_, _ = fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\n", //nolint:errcheck
-					event.ID,
-					outfmt.SanitizeTab(event.Title),
-					startStr,
-					endStr,
-					event.Status,
-				)
-			}
+			writeEventsTable(tw, events, app.DateStyle(), withAttendees, withLocation)
 			_ = tw.Flush() //nolint:errcheck
 
 			return nil
@@ -182,10 +187,184 @@ or relative expressions like yesterday, 2h ago, or monday.`,
 	cmd.Flags().StringVar(&fromDate, "from", "", "Start date (RFC3339, YYYY-MM-DD, or relative like yesterday, 2h ago, monday)")
 	cmd.Flags().StringVar(&toDate, "to", "", "End date (RFC3339, YYYY-MM-DD, or relative like yesterday, 2h ago, monday)")
 	cmd.Flags().IntVar(&limit, "limit", 100, "Maximum number of events to retrieve")
+	cmd.Flags().BoolVar(&withAttendees, "with-attendees", false, "Add a column with the number of participants")
+	cmd.Flags().BoolVar(&withLocation, "with-location", false, "Add a column with the (truncated) location")
 
 	return cmd
 }
 
+// eventLocationWidth is the maximum LOCATION column width in calendar events.
+const eventLocationWidth = 30
+
+// writeEventsTable writes the calendar events table. The optional columns are
+// appended after the default ID/TITLE/START/END/STATUS columns.
+func writeEventsTable(w io.Writer, events []jmap.CalendarEvent, dates format.DateStyle, withAttendees, withLocation bool) {
+	header := "ID\tTITLE\tSTART\tEND\tSTATUS"
+	if withAttendees {
+		header += "\tATTENDEES"
+	}
+	if withLocation {
+		header += "\tLOCATION"
+	}
+	_, _ = fmt.Fprintln(w, header) //nolint:errcheck
+
+	for _, event := range events {
+		_, _ = fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s", //nolint:errcheck
+			event.ID,
+			outfmt.SanitizeTab(event.Title),
+			formatEventTime(dates, event.Start, event.IsAllDay),
+			formatEventTime(dates, event.End, event.IsAllDay),
+			event.Status,
+		)
+		if withAttendees {
+			_, _ = fmt.Fprintf(w, "\t%d", len(event.Participants)) //nolint:errcheck
+		}
+		if withLocation {
+			_, _ = fmt.Fprintf(w, "\t%s", outfmt.SanitizeTab(format.Truncate(event.Location, eventLocationWidth))) //nolint:errcheck
+		}
+		_, _ = fmt.Fprintln(w) //nolint:errcheck
+	}
+}
+
+// writeEventsCSV writes the calendar events table as CSV, with the same
+// columns and the full location.
+func writeEventsCSV(w io.Writer, events []jmap.CalendarEvent, dates format.DateStyle, withAttendees, withLocation bool) error {
+	columns := []string{"ID", "TITLE", "START", "END", "STATUS"}
+	if withAttendees {
+		columns = append(columns, "ATTENDEES")
+	}
+	if withLocation {
+		columns = append(columns, "LOCATION")
+	}
+	return outfmt.WriteCSV(w, columns, func(yield func([]string) bool) {
+		for _, event := range events {
+			row := []string{
+				event.ID,
+				event.Title,
+				formatEventTime(dates, event.Start, event.IsAllDay),
+				formatEventTime(dates, event.End, event.IsAllDay),
+				event.Status,
+			}
+			if withAttendees {
+				row = append(row, strconv.Itoa(len(event.Participants)))
+			}
+			if withLocation {
+				row = append(row, event.Location)
+			}
+			if !yield(row) {
+				return
+			}
+		}
+	})
+}
+
+var recurrenceUnits = map[string]string{
+	"secondly": "second",
+	"minutely": "minute",
+	"hourly":   "hour",
+	"daily":    "day",
+	"weekly":   "week",
+	"monthly":  "month",
+	"yearly":   "year",
+}
+
+var recurrenceWeekdays = map[string]string{
+	"mo": "Monday",
+	"tu": "Tuesday",
+	"we": "Wednesday",
+	"th": "Thursday",
+	"fr": "Friday",
+	"sa": "Saturday",
+	"su": "Sunday",
+}
+
+// humanizeRecurrence renders a recurrence rule as text such as
+// "Every 2 weeks on Monday until 2025-12-31".
+func humanizeRecurrence(r *jmap.RecurrenceRule) string {
+	if r == nil {
+		return ""
+	}
+
+	var b strings.Builder
+	freq := strings.ToLower(r.Frequency)
+	unit, ok := recurrenceUnits[freq]
+	switch {
+	case !ok:
+		b.WriteString("Repeats " + r.Frequency)
+	case r.Interval > 1:
+		fmt.Fprintf(&b, "Every %d %ss", r.Interval, unit)
+	default:
+		b.WriteString("Every " + unit)
+	}
+
+	if len(r.ByDay) > 0 {
+		days := make([]string, 0, len(r.ByDay))
+		for _, d := range r.ByDay {
+			days = append(days, humanizeNDay(d))
+		}
+		b.WriteString(" on " + joinWithAnd(days))
+	}
+
+	if r.Count > 0 {
+		if r.Count == 1 {
+			b.WriteString(", once")
+		} else {
+			fmt.Fprintf(&b, ", %d times", r.Count)
+		}
+	}
+	if r.Until != "" {
+		until := r.Until
+		if i := strings.IndexByte(until, 'T'); i > 0 {
+			until = until[:i]
+		}
+		b.WriteString(" until " + until)
+	}
+
+	return b.String()
+}
+
+// humanizeNDay renders a weekday with its optional position, e.g. "the 2nd Tuesday".
+func humanizeNDay(d jmap.NDay) string {
+	day, ok := recurrenceWeekdays[strings.ToLower(d.Day)]
+	if !ok {
+		day = d.Day
+	}
+	switch {
+	case d.NthOfPeriod == -1:
+		return "the last " + day
+	case d.NthOfPeriod < -1:
+		return fmt.Sprintf("the %s to last %s", ordinal(-d.NthOfPeriod), day)
+	case d.NthOfPeriod > 0:
+		return fmt.Sprintf("the %s %s", ordinal(d.NthOfPeriod), day)
+	default:
+		return day
+	}
+}
+
+// ordinal returns n with its English suffix (1st, 2nd, 3rd, 4th, 11th, ...).
+func ordinal(n int) string {
+	suffix := "th"
+	if n%100 < 11 || n%100 > 13 {
+		switch n % 10 {
+		case 1:
+			suffix = "st"
+		case 2:
+			suffix = "nd"
+		case 3:
+			suffix = "rd"
+		}
+	}
+	return fmt.Sprintf("%d%s", n, suffix)
+}
+
+// joinWithAnd joins items as "a", "a and b" or "a, b and c".
+func joinWithAnd(items []string) string {
+	if len(items) <= 1 {
+		return strings.Join(items, "")
+	}
+	return strings.Join(items[:len(items)-1], ", ") + " and " + items[len(items)-1]
+}
+
 func newCalendarEventGetCmd(app *App) *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "event-get <eventId>",
@@ -195,7 +374,7 @@ func newCalendarEventGetCmd(app *App) *cobra.Command {
   fastmail calendar event-get <id> --output json`,
 		Args: cobra.ExactArgs(1),
 		RunE: runE(app, func(cmd *cobra.Command, args []string, app *App) error {
-			client, err := app.JMAPClient()
+			client, err := app.JMAPClientFor(cmd.Context(), jmap.CapabilityCalendars)
 			if err != nil {
 				return err
 			}
@@ -218,8 +397,8 @@ func newCalendarEventGetCmd(app *App) *cobra.Command {
 			if event.Location != "" {
 				fmt.Printf("Location:   %s\n", event.Location)
 			}
-			fmt.Printf("Start:      %s\n", formatEventTime(event.Start, event.IsAllDay))
-			fmt.Printf("End:        %s\n", formatEventTime(event.End, event.IsAllDay))
+			fmt.Printf("Start:      %s\n", formatEventTime(app.DateStyle(), event.Start, event.IsAllDay))
+			fmt.Printf("End:        %s\n", formatEventTime(app.DateStyle(), event.End, event.IsAllDay))
 			if event.TimeZone != "" {
 				fmt.Printf("Timezone:   %s\n", event.TimeZone)
 			}
@@ -227,17 +406,7 @@ func newCalendarEventGetCmd(app *App) *cobra.Command {
 			fmt.Printf("Status:     %s\n", event.Status)
 
 			if event.Recurrence != nil {
-				fmt.Printf("\nRecurrence:\n")
-				fmt.Printf("  Frequency: %s\n", event.Recurrence.Frequency)
-				if event.Recurrence.Interval > 0 {
-					fmt.Printf("  Interval:  %d\n", event.Recurrence.Interval)
-				}
-				if event.Recurrence.Until != "" {
-					fmt.Printf("  Until:     %s\n", event.Recurrence.Until)
-				}
-				if event.Recurrence.Count > 0 {
-					fmt.Printf("  Count:     %d\n", event.Recurrence.Count)
-				}
+				fmt.Printf("Repeats:    %s\n", humanizeRecurrence(event.Recurrence))
 			}
 
 			if len(event.Alerts) > 0 {
@@ -276,6 +445,10 @@ func newCalendarEventCreateCmd(app *App) *cobra.Command {
 	var endStr string
 	var allDay bool
 	var status string
+	var repeat string
+	var interval, count int
+	var untilStr string
+	var alertOffsets, emailAlertOffsets []string
 
 	cmd := &cobra.Command{
 		Use:   "event-create",
@@ -284,10 +457,21 @@ func newCalendarEventCreateCmd(app *App) *cobra.Command {
 
 Required fields: --calendar, --title, --start, --end
 Dates should be in RFC3339 format (e.g., 2025-12-19T15:00:00Z), YYYY-MM-DD for all-day events,
-or relative expressions like yesterday, 2h ago, or monday.`,
+or relative expressions like yesterday, 2h ago, or monday.
+
+--repeat makes the event recurring (daily, weekly, monthly or yearly);
+--interval repeats every N periods, and --count or --until (not both) ends it.
+A --until date without a time includes that whole day.
+
+--alert adds a reminder notification and --alert-email a reminder email; both
+are repeatable. Offsets are durations like 15m, 1h30m or 2d, before the start
+unless written as "10m after" or +10m.`,
 		Example: `  fastmail calendar event-create --calendar <id> --title "Meeting" --start "2025-12-19T15:00:00Z" --end "2025-12-19T16:00:00Z"
   fastmail calendar event-create --calendar <id> --title "Birthday" --start "2025-12-25" --end "2025-12-26" --all-day
-  fastmail calendar event-create --calendar <id> --title "Lunch" --start "2025-12-20T12:00:00Z" --end "2025-12-20T13:00:00Z" --location "Restaurant"`,
+  fastmail calendar event-create --calendar <id> --title "Lunch" --start "2025-12-20T12:00:00Z" --end "2025-12-20T13:00:00Z" --location "Restaurant"
+  fastmail calendar event-create --calendar <id> --title "Standup" --start "2026-01-05T09:00:00Z" --end "2026-01-05T09:15:00Z" --repeat weekly --count 10
+  fastmail calendar event-create --calendar <id> --title "Review" --start "2026-01-05T14:00:00Z" --end "2026-01-05T15:00:00Z" --repeat weekly --interval 2 --until 2026-06-30
+  fastmail calendar event-create --calendar <id> --title "Dentist" --start "2026-02-10T10:00:00Z" --end "2026-02-10T11:00:00Z" --alert 15m --alert 1d --alert-email 1h`,
 		RunE: runE(app, func(cmd *cobra.Command, args []string, app *App) error {
 			if calendarID == "" {
 				return fmt.Errorf("--calendar is required")
@@ -316,7 +500,17 @@ or relative expressions like yesterday, 2h ago, or monday.`,
 				status = "confirmed"
 			}
 
-			client, err := app.JMAPClient()
+			recurrence, err := eventRecurrence(repeat, interval, count, untilStr)
+			if err != nil {
+				return err
+			}
+
+			alerts, err := eventAlerts(alertOffsets, emailAlertOffsets)
+			if err != nil {
+				return err
+			}
+
+			client, err := app.JMAPClientFor(cmd.Context(), jmap.CapabilityCalendars)
 			if err != nil {
 				return err
 			}
@@ -330,6 +524,8 @@ or relative expressions like yesterday, 2h ago, or monday.`,
 				End:         end,
 				IsAllDay:    allDay,
 				Status:      status,
+				Recurrence:  recurrence,
+				Alerts:      alerts,
 			}
 
 			created, err := client.CreateEvent(cmd.Context(), event)
@@ -354,6 +550,12 @@ or relative expressions like yesterday, 2h ago, or monday.`,
 	cmd.Flags().StringVar(&endStr, "end", "", "End date/time (required)")
 	cmd.Flags().BoolVar(&allDay, "all-day", false, "All-day event")
 	cmd.Flags().StringVar(&status, "status", "confirmed", "Event status (confirmed, tentative, cancelled)")
+	cmd.Flags().StringVar(&repeat, "repeat", "", "Repeat the event: "+strings.Join(jmap.RecurrenceFrequencies, "|"))
+	cmd.Flags().IntVar(&interval, "interval", 0, "With --repeat, repeat every N days/weeks/months/years (default 1)")
+	cmd.Flags().IntVar(&count, "count", 0, "With --repeat, stop after N occurrences")
+	cmd.Flags().StringVar(&untilStr, "until", "", "With --repeat, stop after this date/time")
+	cmd.Flags().StringArrayVar(&alertOffsets, "alert", nil, "Show a reminder this long before the start, e.g. 15m (repeatable)")
+	cmd.Flags().StringArrayVar(&emailAlertOffsets, "alert-email", nil, "Email a reminder this long before the start (repeatable)")
 
 	_ = cmd.MarkFlagRequired("calendar") //nolint:errcheck
 	_ = cmd.MarkFlagRequired("title")    //nolint:errcheck
@@ -363,6 +565,100 @@ or relative expressions like yesterday, 2h ago, or monday.`,
 	return cmd
 }
 
+// eventRecurrence builds the recurrence rule for event-create's --repeat
+// flags, or returns nil when --repeat isn't set.
+func eventRecurrence(repeat string, interval, count int, untilStr string) (*jmap.RecurrenceRule, error) {
+	if repeat == "" {
+		if interval != 0 || count != 0 || untilStr != "" {
+			return nil, fmt.Errorf("--interval, --count and --until require --repeat")
+		}
+		return nil, nil
+	}
+	if count != 0 && untilStr != "" {
+		return nil, fmt.Errorf("--count and --until cannot be used together")
+	}
+
+	var until time.Time
+	if untilStr != "" {
+		var err error
+		until, err = parseDateTime(untilStr)
+		if err != nil {
+			return nil, fmt.Errorf("invalid --until date: %w", err)
+		}
+		if len(untilStr) == len("2006-01-02") {
+			until = until.Add(24*time.Hour - time.Second)
+		}
+	}
+
+	return jmap.NewRecurrenceRule(repeat, interval, count, until)
+}
+
+// eventAlerts builds display alerts for the --alert offsets followed by
+// email alerts for the --alert-email ones.
+func eventAlerts(display, email []string) ([]jmap.Alert, error) {
+	var alerts []jmap.Alert
+	for _, group := range []struct {
+		flag    string
+		action  string
+		offsets []string
+	}{
+		{"--alert", jmap.AlertDisplay, display},
+		{"--alert-email", jmap.AlertEmail, email},
+	} {
+		for _, s := range group.offsets {
+			offset, err := parseAlertOffset(s)
+			if err != nil {
+				return nil, fmt.Errorf("invalid %s: %w", group.flag, err)
+			}
+			alerts = append(alerts, jmap.NewAlert(offset, group.action))
+		}
+	}
+	return alerts, nil
+}
+
+// parseAlertOffset parses an alert offset relative to the event start:
+// a duration as accepted by time.ParseDuration, or whole days like 2d.
+// Offsets are before the start ("15m", "-15m", "15m before") unless
+// written as "+15m" or "15m after".
+func parseAlertOffset(s string) (time.Duration, error) {
+	value := strings.ToLower(strings.TrimSpace(s))
+	after := false
+	switch {
+	case strings.HasSuffix(value, " after"):
+		value, after = strings.TrimSpace(strings.TrimSuffix(value, " after")), true
+	case strings.HasSuffix(value, " before"):
+		value = strings.TrimSpace(strings.TrimSuffix(value, " before"))
+	}
+	switch {
+	case strings.HasPrefix(value, "+"):
+		value, after = value[1:], true
+	case strings.HasPrefix(value, "-"):
+		value = value[1:]
+	}
+
+	var d time.Duration
+	if days, ok := strings.CutSuffix(value, "d"); ok {
+		n, err := strconv.Atoi(days)
+		if err != nil {
+			return 0, fmt.Errorf("%q is not a duration (e.g. 15m, 1h, 2d)", s)
+		}
+		d = time.Duration(n) * 24 * time.Hour
+	} else {
+		var err error
+		if d, err = time.ParseDuration(value); err != nil {
+			return 0, fmt.Errorf("%q is not a duration (e.g. 15m, 1h, 2d)", s)
+		}
+	}
+	if d < 0 {
+		return 0, fmt.Errorf("%q is not a duration (e.g. 15m, 1h, 2d)", s)
+	}
+
+	if after {
+		return d, nil
+	}
+	return -d, nil
+}
+
 func newCalendarEventUpdateCmd(app *App) *cobra.Command {
 	var title string
 	var description string
@@ -370,19 +666,29 @@ func newCalendarEventUpdateCmd(app *App) *cobra.Command {
 	var startStr string
 	var endStr string
 	var status string
+	var addAttendees []string
+	var removeAttendees []string
+	var alertOffsets, emailAlertOffsets []string
 
 	cmd := &cobra.Command{
 		Use:   "event-update <eventId>",
 		Short: "Update a calendar event",
 		Long: `Update an existing calendar event.
 
-Only the fields you specify will be updated.`,
+Only the fields you specify will be updated.
+
+--add-attendee and --remove-attendee change the participant list; Fastmail
+sends invitations to added attendees and cancellations to removed ones.
+
+--alert and --alert-email replace the event's reminders, as in event-create.`,
 		Example: `  fastmail calendar event-update <id> --title "Updated Meeting"
   fastmail calendar event-update <id> --start "2025-12-19T16:00:00Z" --end "2025-12-19T17:00:00Z"
-  fastmail calendar event-update <id> --location "Conference Room A" --description "Updated description"`,
+  fastmail calendar event-update <id> --location "Conference Room A" --description "Updated description"
+  fastmail calendar event-update <id> --add-attendee alice@example.com --remove-attendee bob@example.com
+  fastmail calendar event-update <id> --alert 30m --alert-email 1d`,
 		Args: cobra.ExactArgs(1),
 		RunE: runE(app, func(cmd *cobra.Command, args []string, app *App) error {
-			client, err := app.JMAPClient()
+			client, err := app.JMAPClientFor(cmd.Context(), jmap.CapabilityCalendars)
 			if err != nil {
 				return err
 			}
@@ -420,6 +726,35 @@ Only the fields you specify will be updated.`,
 				updates["end"] = end
 			}
 
+			if len(alertOffsets) > 0 || len(emailAlertOffsets) > 0 {
+				alerts, err := eventAlerts(alertOffsets, emailAlertOffsets)
+				if err != nil {
+					return err
+				}
+				updates["alerts"] = alerts
+			}
+
+			if len(addAttendees) > 0 || len(removeAttendees) > 0 {
+				for _, email := range append(append([]string{}, addAttendees...), removeAttendees...) {
+					if !validation.IsValidEmail(email) {
+						return fmt.Errorf("invalid attendee email address: %s", email)
+					}
+				}
+
+				event, err := client.GetEventByID(cmd.Context(), args[0])
+				if err != nil {
+					return fmt.Errorf("failed to get event: %w", err)
+				}
+
+				participants, changed := jmap.MergeParticipants(event.Participants, addAttendees, removeAttendees)
+				if changed {
+					updates["participants"] = participants
+				} else if len(updates) == 0 {
+					printAlready("Attendees already up to date")
+					return nil
+				}
+			}
+
 			if len(updates) == 0 {
 				return fmt.Errorf("no updates specified")
 			}
@@ -444,6 +779,10 @@ Only the fields you specify will be updated.`,
 	cmd.Flags().StringVar(&startStr, "start", "", "Start date/time")
 	cmd.Flags().StringVar(&endStr, "end", "", "End date/time")
 	cmd.Flags().StringVar(&status, "status", "", "Event status")
+	cmd.Flags().StringSliceVar(&addAttendees, "add-attendee", nil, "Add an attendee by email (repeatable)")
+	cmd.Flags().StringSliceVar(&removeAttendees, "remove-attendee", nil, "Remove an attendee by email (repeatable)")
+	cmd.Flags().StringArrayVar(&alertOffsets, "alert", nil, "Replace reminders: show one this long before the start (repeatable)")
+	cmd.Flags().StringArrayVar(&emailAlertOffsets, "alert-email", nil, "Replace reminders: email one this long before the start (repeatable)")
 
 	return cmd
 }
@@ -466,7 +805,7 @@ func newCalendarEventDeleteCmd(app *App) *cobra.Command {
 				return nil
 			}
 
-			client, err := app.JMAPClient()
+			client, err := app.JMAPClientFor(cmd.Context(), jmap.CapabilityCalendars)
 			if err != nil {
 				return err
 			}
@@ -502,12 +841,13 @@ func parseDateTime(s string) (time.Time, error) {
 	return t, nil
 }
 
-// formatEventTime formats an event time for display
-func formatEventTime(t time.Time, isAllDay bool) string {
+// formatEventTime formats an event time for display. All-day events are
+// shown as a plain date, without time zone conversion.
+func formatEventTime(dates format.DateStyle, t time.Time, isAllDay bool) string {
 	if isAllDay {
 		return t.Format("2006-01-02")
 	}
-	return t.Format("2006-01-02 15:04")
+	return dates.Format(t)
 }
 
 func newCalendarInviteCmd(app *App) *cobra.Command {
@@ -518,6 +858,7 @@ func newCalendarInviteCmd(app *App) *cobra.Command {
 	var endStr string
 	var attendees []string
 	var calendarName string
+	var force bool
 
 	cmd := &cobra.Command{
 		Use:   "invite",
@@ -525,10 +866,15 @@ func newCalendarInviteCmd(app *App) *cobra.Command {
 		Long: `Create a calendar event with attendees. Fastmail will automatically send email invitations to all attendees.
 
 Required fields: --title, --start, --end, --attendee (at least one)
-Times can be in RFC3339 format (2025-12-19T15:00:00Z) or simplified format (2025-12-19T15:00).`,
+Times can be in RFC3339 format (2025-12-19T15:00:00Z) or simplified format (2025-12-19T15:00).
+
+Before sending, the invite is checked against events in your visible calendars
+(all-day events block their whole day). If it overlaps any, they are listed and
+nothing is sent; use --force to send anyway.`,
 		Example: `  fastmail calendar invite --title "Team Meeting" --start "2025-12-19T15:00:00Z" --end "2025-12-19T16:00:00Z" --attendee "colleague@example.com"
   fastmail calendar invite --title "Project Review" --start "2025-12-20T14:00" --end "2025-12-20T15:00" --attendee "alice@example.com" --attendee "bob@example.com"
-  fastmail calendar invite --title "Lunch" --start "2025-12-21T12:00:00Z" --end "2025-12-21T13:00:00Z" --attendee "friend@example.com" --location "Restaurant" --description "Quarterly catch-up"`,
+  fastmail calendar invite --title "Lunch" --start "2025-12-21T12:00:00Z" --end "2025-12-21T13:00:00Z" --attendee "friend@example.com" --location "Restaurant" --description "Quarterly catch-up"
+  fastmail calendar invite --title "Sync" --start "2025-12-22T10:00" --end "2025-12-22T10:30" --attendee "alice@example.com" --force`,
 		RunE: runE(app, func(cmd *cobra.Command, args []string, app *App) error {
 			// Validate required flags
 			if title == "" {
@@ -573,6 +919,13 @@ Times can be in RFC3339 format (2025-12-19T15:00:00Z) or simplified format (2025
 				return err
 			}
 
+			if err := checkInviteConflicts(cmd.Context(), app, start, end, force); err != nil {
+				return err
+			}
+
+			if err := app.requireJMAPOnly("CalDAV"); err != nil {
+				return err
+			}
 			token, err := config.GetToken(account)
 			if err != nil {
 				return fmt.Errorf("failed to get token for %s: %w", account, err)
@@ -635,10 +988,194 @@ Times can be in RFC3339 format (2025-12-19T15:00:00Z) or simplified format (2025
 	cmd.Flags().StringVar(&endStr, "end", "", "End time (required, RFC3339 or 2006-01-02T15:04)")
 	cmd.Flags().StringArrayVar(&attendees, "attendee", []string{}, "Attendee email address (required, repeatable)")
 	cmd.Flags().StringVar(&calendarName, "calendar", "Default", "Calendar name")
+	cmd.Flags().BoolVar(&force, "force", false, "Send the invite even if it overlaps existing events")
+
+	return cmd
+}
+
+// checkInviteConflicts looks for events overlapping [start, end) and
+// refuses the invite if there are any, unless force is set. Accounts
+// without JMAP calendars skip the check.
+func checkInviteConflicts(ctx context.Context, app *App, start, end time.Time, force bool) error {
+	client, err := app.JMAPClientFor(ctx, jmap.CapabilityCalendars)
+	if errors.Is(err, jmap.ErrCalendarsNotEnabled) {
+		fmt.Fprintln(os.Stderr, "Warning: calendars aren't enabled for this token; not checking for conflicts")
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	busy, err := client.GetBusyPeriods(ctx, start, end)
+	if err != nil {
+		if force {
+			fmt.Fprintf(os.Stderr, "Warning: could not check for conflicts: %v\n", err)
+			return nil
+		}
+		return cerrors.WithSuggestion(cerrors.WithContext(err, "checking for conflicts"), "Use --force to send the invite without checking")
+	}
+	return reportConflicts(os.Stderr, busy, app.DateStyle(), force)
+}
+
+// reportConflicts lists the events an invite overlaps on w. It returns an
+// error when there are any, unless force is set.
+func reportConflicts(w io.Writer, busy []jmap.BusyPeriod, dates format.DateStyle, force bool) error {
+	if len(busy) == 0 {
+		return nil
+	}
+
+	fmt.Fprintln(w, "The invite overlaps:")
+	for _, p := range busy {
+		if p.IsAllDay {
+			fmt.Fprintf(w, "  %s (all day %s)\n", p.Title, p.Start.Format("2006-01-02"))
+			continue
+		}
+		fmt.Fprintf(w, "  %s (%s - %s)\n", p.Title, dates.Format(p.Start), dates.Format(p.End))
+	}
+
+	if force {
+		fmt.Fprintln(w, "Sending anyway (--force)")
+		return nil
+	}
+	return fmt.Errorf("invite overlaps %d existing event(s); use --force to send it anyway", len(busy))
+}
+
+func newCalendarRSVPCmd(app *App) *cobra.Command {
+	var accept, decline, tentative bool
+	var calendarName string
+
+	cmd := &cobra.Command{
+		Use:   "rsvp <eventId>",
+		Short: "Accept, decline or tentatively accept a calendar invitation",
+		Long: `Reply to a calendar invitation by setting your participant status on the
+event. Fastmail sends the reply to the organizer.
+
+Your status is set on every participant entry matching your account address
+or one of your sending identities. Accounts without JMAP calendars are updated
+over CalDAV, where <eventId> is the event UID and --calendar names its calendar.`,
+		Example: `  fastmail calendar rsvp <id> --accept
+  fastmail calendar rsvp <id> --decline
+  fastmail calendar rsvp <uid> --tentative --calendar Work`,
+		Args: cobra.ExactArgs(1),
+		RunE: runE(app, func(cmd *cobra.Command, args []string, app *App) error {
+			status, err := rsvpStatus(accept, decline, tentative)
+			if err != nil {
+				return err
+			}
+
+			account, err := app.RequireAccount()
+			if err != nil {
+				return err
+			}
+			client, err := app.JMAPClient()
+			if err != nil {
+				return err
+			}
+
+			hasCalendars, err := client.HasCapability(cmd.Context(), jmap.CapabilityCalendars)
+			if err != nil {
+				return err
+			}
+
+			title := args[0]
+			if hasCalendars {
+				event, err := client.GetEventByID(cmd.Context(), args[0])
+				if err != nil {
+					return fmt.Errorf("failed to get event: %w", err)
+				}
+
+				addresses := myAddresses(cmd.Context(), client, account)
+				participants, err := jmap.SetParticipantStatus(event.Participants, addresses, status)
+				if err != nil {
+					return notParticipantError(err, addresses, args[0])
+				}
+
+				if _, err := client.UpdateEvent(cmd.Context(), args[0], map[string]interface{}{"participants": participants}); err != nil {
+					return fmt.Errorf("failed to update event: %w", err)
+				}
+				title = event.Title
+			} else {
+				if err := app.requireJMAPOnly("CalDAV"); err != nil {
+					return err
+				}
+				token, err := config.GetToken(account)
+				if err != nil {
+					return fmt.Errorf("failed to get token for %s: %w", account, err)
+				}
+				caldavClient := caldav.NewClient(caldav.DefaultBaseURL, account, token)
+				err = caldavClient.SetAttendeeStatus(cmd.Context(), calendarName, args[0], account, strings.ToUpper(status))
+				if err != nil {
+					return notParticipantError(err, []string{account}, args[0])
+				}
+			}
+
+			if app.IsJSON(cmd.Context()) {
+				return app.PrintJSON(cmd, map[string]interface{}{
+					"eventId": args[0],
+					"status":  status,
+				})
+			}
+
+			fmt.Printf("RSVP %s: %s\n", status, title)
+			return nil
+		}),
+	}
+
+	cmd.Flags().BoolVar(&accept, "accept", false, "Accept the invitation")
+	cmd.Flags().BoolVar(&decline, "decline", false, "Decline the invitation")
+	cmd.Flags().BoolVar(&tentative, "tentative", false, "Tentatively accept the invitation")
+	cmd.Flags().StringVar(&calendarName, "calendar", "Default", "Calendar name (CalDAV only)")
 
 	return cmd
 }
 
+// rsvpStatus returns the participant status for exactly one of the rsvp
+// flags.
+func rsvpStatus(accept, decline, tentative bool) (string, error) {
+	var statuses []string
+	if accept {
+		statuses = append(statuses, "accepted")
+	}
+	if decline {
+		statuses = append(statuses, "declined")
+	}
+	if tentative {
+		statuses = append(statuses, "tentative")
+	}
+	if len(statuses) != 1 {
+		return "", fmt.Errorf("specify exactly one of --accept, --decline or --tentative")
+	}
+	return statuses[0], nil
+}
+
+// myAddresses returns the account address and the sending identities'
+// addresses, which may all appear as participants.
+func myAddresses(ctx context.Context, client *jmap.Client, account string) []string {
+	addresses := []string{account}
+	identities, err := client.GetIdentities(ctx)
+	if err != nil {
+		return addresses
+	}
+	for _, id := range identities {
+		if !strings.EqualFold(id.Email, account) {
+			addresses = append(addresses, id.Email)
+		}
+	}
+	return addresses
+}
+
+// notParticipantError explains an rsvp for an event the user isn't
+// invited to; other errors pass through.
+func notParticipantError(err error, addresses []string, eventID string) error {
+	if !errors.Is(err, jmap.ErrNotParticipant) && !errors.Is(err, caldav.ErrAttendeeNotFound) {
+		return fmt.Errorf("failed to update event: %w", err)
+	}
+	return cerrors.WithSuggestion(
+		fmt.Errorf("none of your addresses (%s) is a participant of this event", strings.Join(addresses, ", ")),
+		fmt.Sprintf("Check the participants with 'fastmail calendar event-get %s'", eventID),
+	)
+}
+
 // parseFlexibleTime tries multiple time formats
 func parseFlexibleTime(s string) (time.Time, error) {
 	formats := []string{
@@ -0,0 +1,324 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/salmonumbrella/fastmail-cli/internal/caldav"
+	"github.com/salmonumbrella/fastmail-cli/internal/jmap"
+	"github.com/spf13/cobra"
+)
+
+func newCalendarEventExportCmd(app *App) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "event-export <eventId> [file.ics]",
+		Short: "Export a calendar event as iCalendar",
+		Long: `Export a calendar event as an iCalendar (RFC 5545) file, including its
+recurrence rule (RRULE), reminders (VALARM) and attendees. Times are written
+in UTC; all-day events as dates.
+
+If no file is given, the iCalendar data is written to stdout.`,
+		Example: `  fastmail calendar event-export <id>
+  fastmail calendar event-export <id> meeting.ics`,
+		Args: cobra.RangeArgs(1, 2),
+		RunE: runE(app, func(cmd *cobra.Command, args []string, app *App) error {
+			client, err := app.JMAPClientFor(cmd.Context(), jmap.CapabilityCalendars)
+			if err != nil {
+				return err
+			}
+
+			event, err := client.GetEventByID(cmd.Context(), args[0])
+			if err != nil {
+				return fmt.Errorf("failed to get event: %w", err)
+			}
+
+			ics := caldav.EventsToICS([]caldav.Event{eventToICS(event)})
+
+			if len(args) < 2 {
+				if app.IsJSON(cmd.Context()) {
+					return app.PrintJSON(cmd, map[string]any{
+						"eventId": args[0],
+						"ics":     ics,
+					})
+				}
+				fmt.Print(ics)
+				return nil
+			}
+
+			if _, statErr := os.Stat(args[1]); statErr == nil {
+				return fmt.Errorf("file '%s' already exists. Specify a different output file", args[1])
+			}
+			if err := os.WriteFile(args[1], []byte(ics), 0o600); err != nil {
+				return fmt.Errorf("failed to write iCalendar file: %w", err)
+			}
+
+			if app.IsJSON(cmd.Context()) {
+				return app.PrintJSON(cmd, map[string]any{
+					"eventId":    args[0],
+					"outputFile": args[1],
+				})
+			}
+
+			fmt.Printf("Exported event to %s\n", args[1])
+			return nil
+		}),
+	}
+
+	return cmd
+}
+
+func newCalendarImportCmd(app *App) *cobra.Command {
+	var calendarID string
+
+	cmd := &cobra.Command{
+		Use:   "import <file.ics>",
+		Short: "Import events from an iCalendar file",
+		Long: `Import the events (VEVENTs) of an iCalendar (RFC 5545) file into a calendar.
+
+Times may be in UTC, carry a time zone (TZID) or be dates for all-day events.
+Recurrence rules and reminders are kept. Attendees are not imported, so no
+invitations are sent. An event that can't be read or imported does not stop
+the rest; failures are reported at the end, and the command fails if no
+event was imported.`,
+		Example: `  fastmail calendar import --calendar <id> events.ics`,
+		Args:    cobra.ExactArgs(1),
+		RunE: runE(app, func(cmd *cobra.Command, args []string, app *App) error {
+			data, err := os.ReadFile(args[0])
+			if err != nil {
+				return fmt.Errorf("failed to read iCalendar file: %w", err)
+			}
+
+			events, parseErrs, err := caldav.ParseICS(string(data))
+			if err != nil {
+				return fmt.Errorf("failed to parse iCalendar file: %w", err)
+			}
+			if len(events) == 0 && len(parseErrs) == 0 {
+				return fmt.Errorf("no events found in %s", args[0])
+			}
+
+			created := []string{}
+			failed := map[string]string{}
+			for _, e := range parseErrs {
+				failed[fmt.Sprintf("event %d (%s)", e.Index, e.Summary)] = e.Err.Error()
+			}
+
+			if len(events) > 0 {
+				client, err := app.JMAPClientFor(cmd.Context(), jmap.CapabilityCalendars)
+				if err != nil {
+					return err
+				}
+
+				for _, ics := range events {
+					event, err := eventFromICS(ics, calendarID)
+					if err == nil {
+						var result *jmap.CalendarEvent
+						if result, err = client.CreateEvent(cmd.Context(), event); err == nil {
+							created = append(created, result.ID)
+							continue
+						}
+					}
+					failed[fmt.Sprintf("%s (%s)", ics.UID, ics.Summary)] = err.Error()
+				}
+			}
+			failedErr := errNoneSucceeded("event imports", len(created), len(created)+len(failed))
+
+			if app.IsJSON(cmd.Context()) {
+				if err := app.PrintJSON(cmd, map[string]any{
+					"created": created,
+					"failed":  failed,
+				}); err != nil {
+					return err
+				}
+				return failedErr
+			}
+
+			printBulkResults("Imported", "events", len(created), len(failed), failed)
+			return failedErr
+		}),
+	}
+
+	cmd.Flags().StringVar(&calendarID, "calendar", "", "Calendar ID to import into (required)")
+	_ = cmd.MarkFlagRequired("calendar") //nolint:errcheck
+
+	return cmd
+}
+
+// eventToICS converts a JMAP event for iCalendar export.
+func eventToICS(event *jmap.CalendarEvent) caldav.Event {
+	uid := event.UID
+	if uid == "" {
+		uid = event.ID
+	}
+
+	ics := caldav.Event{
+		UID:         uid,
+		Summary:     event.Title,
+		Description: event.Description,
+		Location:    event.Location,
+		Start:       event.Start,
+		End:         event.End,
+		AllDay:      event.IsAllDay,
+		Status:      strings.ToUpper(event.Status),
+		RRule:       recurrenceToRRULE(event.Recurrence, event.IsAllDay, eventLocation(event)),
+	}
+	for _, p := range event.Participants {
+		ics.Attendees = append(ics.Attendees, caldav.Attendee{
+			Email:  p.Email,
+			Name:   p.Name,
+			Status: strings.ToUpper(p.Status),
+		})
+	}
+	for _, alert := range event.Alerts {
+		ics.Alarms = append(ics.Alarms, caldav.Alarm{
+			Trigger: alert.Trigger,
+			Action:  strings.ToUpper(alert.Action),
+		})
+	}
+	return ics
+}
+
+// eventFromICS converts an imported iCalendar event into a JMAP event in
+// calendarID. Attendees are dropped so importing sends no invitations.
+func eventFromICS(ics caldav.Event, calendarID string) (*jmap.CalendarEvent, error) {
+	recurrence, err := recurrenceFromRRULE(ics.RRule, ics.Start.Location())
+	if err != nil {
+		return nil, err
+	}
+
+	status := strings.ToLower(ics.Status)
+	if status == "" {
+		status = "confirmed"
+	}
+
+	event := &jmap.CalendarEvent{
+		UID:         ics.UID,
+		CalendarID:  calendarID,
+		Title:       ics.Summary,
+		Description: ics.Description,
+		Location:    ics.Location,
+		Start:       ics.Start,
+		End:         ics.End,
+		IsAllDay:    ics.AllDay,
+		Status:      status,
+		Recurrence:  recurrence,
+	}
+	for _, alarm := range ics.Alarms {
+		action := jmap.AlertDisplay
+		if alarm.Action == "EMAIL" {
+			action = jmap.AlertEmail
+		}
+		event.Alerts = append(event.Alerts, jmap.Alert{Trigger: alarm.Trigger, Action: action})
+	}
+	return event, nil
+}
+
+// eventLocation returns the time zone of event: its timeZone when the
+// server names one, else the zone its start was given in.
+func eventLocation(event *jmap.CalendarEvent) *time.Location {
+	if event.TimeZone != "" {
+		if loc, err := time.LoadLocation(event.TimeZone); err == nil {
+			return loc
+		}
+	}
+	return event.Start.Location()
+}
+
+// recurrenceToRRULE formats a recurrence rule as an RRULE value. The rule's
+// until is a local date-time in loc, the event's time zone. UNTIL is a date
+// for all-day events and UTC otherwise, matching how event-export writes
+// DTSTART.
+func recurrenceToRRULE(rule *jmap.RecurrenceRule, allDay bool, loc *time.Location) string {
+	if rule == nil {
+		return ""
+	}
+
+	parts := []string{"FREQ=" + strings.ToUpper(rule.Frequency)}
+	if rule.Interval > 1 {
+		parts = append(parts, fmt.Sprintf("INTERVAL=%d", rule.Interval))
+	}
+	if rule.Count > 0 {
+		parts = append(parts, fmt.Sprintf("COUNT=%d", rule.Count))
+	}
+	if until, err := time.ParseInLocation("2006-01-02T15:04:05", rule.Until, loc); err == nil {
+		if allDay {
+			parts = append(parts, "UNTIL="+until.Format("20060102"))
+		} else {
+			parts = append(parts, "UNTIL="+until.UTC().Format("20060102T150405Z"))
+		}
+	}
+	if len(rule.ByDay) > 0 {
+		days := make([]string, len(rule.ByDay))
+		for i, d := range rule.ByDay {
+			days[i] = strings.ToUpper(d.Day)
+			if d.NthOfPeriod != 0 {
+				days[i] = strconv.Itoa(d.NthOfPeriod) + days[i]
+			}
+		}
+		parts = append(parts, "BYDAY="+strings.Join(days, ","))
+	}
+	return strings.Join(parts, ";")
+}
+
+// recurrenceFromRRULE parses the FREQ, INTERVAL, COUNT, UNTIL and BYDAY
+// parts of an RRULE value; other parts are ignored. A UTC UNTIL becomes
+// local time in loc, the event's time zone. An empty rrule yields nil.
+func recurrenceFromRRULE(rrule string, loc *time.Location) (*jmap.RecurrenceRule, error) {
+	if rrule == "" {
+		return nil, nil
+	}
+
+	var (
+		frequency       string
+		interval, count int
+		until           time.Time
+		byDay           []jmap.NDay
+	)
+	for _, part := range strings.Split(rrule, ";") {
+		key, value, _ := strings.Cut(part, "=")
+		var err error
+		switch strings.ToUpper(key) {
+		case "FREQ":
+			frequency = value
+		case "INTERVAL":
+			interval, err = strconv.Atoi(value)
+		case "COUNT":
+			count, err = strconv.Atoi(value)
+		case "UNTIL":
+			switch {
+			case len(value) == len("20060102"):
+				until, err = time.Parse("20060102", value)
+			case strings.HasSuffix(value, "Z"):
+				until, err = time.Parse("20060102T150405Z", value)
+				until = until.In(loc)
+			default:
+				until, err = time.Parse("20060102T150405", value)
+			}
+		case "BYDAY":
+			for _, day := range strings.Split(value, ",") {
+				if len(day) < 2 {
+					return nil, fmt.Errorf("invalid RRULE BYDAY %q", value)
+				}
+				nday := jmap.NDay{Day: strings.ToLower(day[len(day)-2:])}
+				if nth := day[:len(day)-2]; nth != "" {
+					if nday.NthOfPeriod, err = strconv.Atoi(nth); err != nil {
+						return nil, fmt.Errorf("invalid RRULE BYDAY %q", value)
+					}
+				}
+				byDay = append(byDay, nday)
+			}
+		}
+		if err != nil {
+			return nil, fmt.Errorf("invalid RRULE %s %q", key, value)
+		}
+	}
+
+	rule, err := jmap.NewRecurrenceRule(frequency, interval, count, until)
+	if err != nil {
+		return nil, fmt.Errorf("unsupported RRULE %q: %w", rrule, err)
+	}
+	rule.ByDay = byDay
+	return rule, nil
+}
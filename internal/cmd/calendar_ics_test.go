@@ -0,0 +1,141 @@
+package cmd
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/salmonumbrella/fastmail-cli/internal/caldav"
+	"github.com/salmonumbrella/fastmail-cli/internal/jmap"
+)
+
+func TestRecurrenceToRRULE(t *testing.T) {
+	tests := []struct {
+		name   string
+		rule   *jmap.RecurrenceRule
+		allDay bool
+		loc    *time.Location
+		want   string
+	}{
+		{name: "none", rule: nil, want: ""},
+		{name: "weekly count", rule: &jmap.RecurrenceRule{Frequency: "weekly", Interval: 2, Count: 10}, want: "FREQ=WEEKLY;INTERVAL=2;COUNT=10"},
+		{name: "until", rule: &jmap.RecurrenceRule{Frequency: "daily", Until: "2026-06-30T23:59:59"}, want: "FREQ=DAILY;UNTIL=20260630T235959Z"},
+		{name: "all-day until", rule: &jmap.RecurrenceRule{Frequency: "yearly", Until: "2030-12-25T00:00:00"}, allDay: true, want: "FREQ=YEARLY;UNTIL=20301225"},
+		{name: "until in event zone", rule: &jmap.RecurrenceRule{Frequency: "daily", Until: "2026-06-30T18:00:00"}, loc: time.FixedZone("EDT", -4*3600), want: "FREQ=DAILY;UNTIL=20260630T220000Z"},
+		{name: "by day", rule: &jmap.RecurrenceRule{Frequency: "monthly", ByDay: []jmap.NDay{{Day: "fr", NthOfPeriod: -1}, {Day: "mo"}}}, want: "FREQ=MONTHLY;BYDAY=-1FR,MO"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			loc := tt.loc
+			if loc == nil {
+				loc = time.UTC
+			}
+			if got := recurrenceToRRULE(tt.rule, tt.allDay, loc); got != tt.want {
+				t.Errorf("recurrenceToRRULE() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRecurrenceFromRRULE(t *testing.T) {
+	rule, err := recurrenceFromRRULE("FREQ=MONTHLY;INTERVAL=2;UNTIL=20261231T000000Z;BYDAY=+1MO,-1FR;WKST=MO", time.UTC)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := &jmap.RecurrenceRule{
+		Frequency: "monthly",
+		Interval:  2,
+		Until:     "2026-12-31T00:00:00",
+		ByDay:     []jmap.NDay{{Day: "mo", NthOfPeriod: 1}, {Day: "fr", NthOfPeriod: -1}},
+	}
+	if !reflect.DeepEqual(rule, want) {
+		t.Errorf("recurrenceFromRRULE() = %+v, want %+v", rule, want)
+	}
+
+	if rule, err := recurrenceFromRRULE("FREQ=DAILY;UNTIL=20261231T050000Z", time.FixedZone("EST", -5*3600)); err != nil || rule.Until != "2026-12-31T00:00:00" {
+		t.Errorf("UTC UNTIL in EST = %+v, %v; want local until 2026-12-31T00:00:00", rule, err)
+	}
+	if rule, err := recurrenceFromRRULE("", time.UTC); rule != nil || err != nil {
+		t.Errorf("empty RRULE = %+v, %v; want nil, nil", rule, err)
+	}
+	for _, bad := range []string{"FREQ=HOURLY", "FREQ=DAILY;COUNT=x", "FREQ=DAILY;COUNT=2;UNTIL=20260101"} {
+		if _, err := recurrenceFromRRULE(bad, time.UTC); err == nil {
+			t.Errorf("recurrenceFromRRULE(%q) expected error", bad)
+		}
+	}
+}
+
+func TestEventICSRoundTrip(t *testing.T) {
+	event := &jmap.CalendarEvent{
+		ID:         "ev1",
+		UID:        "uid-1@example.com",
+		CalendarID: "cal1",
+		Title:      "Review",
+		Location:   "Room 2",
+		Start:      time.Date(2026, 1, 5, 14, 0, 0, 0, time.UTC),
+		End:        time.Date(2026, 1, 5, 15, 0, 0, 0, time.UTC),
+		Status:     "tentative",
+		Recurrence: &jmap.RecurrenceRule{Frequency: "weekly", Count: 4},
+		Alerts:     []jmap.Alert{{Trigger: "-PT15M", Action: "display"}, {Trigger: "-P1D", Action: "email"}},
+		Participants: []jmap.Participant{
+			{Name: "Alice", Email: "alice@example.com", Status: "accepted"},
+		},
+	}
+
+	parsed, failed, err := caldav.ParseICS(caldav.EventsToICS([]caldav.Event{eventToICS(event)}))
+	if err != nil || len(failed) > 0 {
+		t.Fatalf("ParseICS() error = %v, failed = %v", err, failed)
+	}
+	if len(parsed) != 1 {
+		t.Fatalf("got %d events, want 1", len(parsed))
+	}
+	if parsed[0].UID != "uid-1@example.com" || len(parsed[0].Attendees) != 1 {
+		t.Errorf("exported event = %+v", parsed[0])
+	}
+
+	imported, err := eventFromICS(parsed[0], "cal2")
+	if err != nil {
+		t.Fatalf("eventFromICS() error = %v", err)
+	}
+	if imported.CalendarID != "cal2" || imported.Title != event.Title || imported.Location != event.Location ||
+		!imported.Start.Equal(event.Start) || !imported.End.Equal(event.End) || imported.Status != "tentative" {
+		t.Errorf("imported event = %+v", imported)
+	}
+	if !reflect.DeepEqual(imported.Recurrence, event.Recurrence) {
+		t.Errorf("Recurrence = %+v, want %+v", imported.Recurrence, event.Recurrence)
+	}
+	if !reflect.DeepEqual(imported.Alerts, event.Alerts) {
+		t.Errorf("Alerts = %+v, want %+v", imported.Alerts, event.Alerts)
+	}
+	if len(imported.Participants) != 0 {
+		t.Error("import should not carry participants")
+	}
+}
+
+func TestCalendarImportCmd_FailsWhenNoEventImports(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "bad.ics")
+	ics := "BEGIN:VCALENDAR\r\nBEGIN:VEVENT\r\nSUMMARY:No start\r\nEND:VEVENT\r\nEND:VCALENDAR\r\n"
+	if err := os.WriteFile(path, []byte(ics), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	cmd := newCalendarImportCmd(newTestApp())
+	cmd.SetContext(context.Background())
+	cmd.SetArgs([]string{"--calendar", "cal1", path})
+	cmd.SilenceUsage = true
+	cmd.SilenceErrors = true
+
+	var runErr error
+	out := captureStdout(t, func() { runErr = cmd.Execute() })
+	if runErr == nil || !strings.Contains(runErr.Error(), "all 1 event imports failed") {
+		t.Errorf("error = %v, want all imports failed", runErr)
+	}
+	if !strings.Contains(out, "event 1 (No start): missing DTSTART") {
+		t.Errorf("output = %q, want the parse failure", out)
+	}
+}
@@ -1,7 +1,13 @@
 package cmd
 
 import (
+	"bytes"
+	"strings"
 	"testing"
+	"time"
+
+	"github.com/salmonumbrella/fastmail-cli/internal/format"
+	"github.com/salmonumbrella/fastmail-cli/internal/jmap"
 )
 
 func TestCalendarInviteCmd_RequiresTitle(t *testing.T) {
@@ -170,3 +176,35 @@ func TestGenerateShortID(t *testing.T) {
 		ids[id] = true
 	}
 }
+
+func TestReportConflicts(t *testing.T) {
+	start := time.Date(2025, 12, 19, 15, 0, 0, 0, time.UTC)
+	busy := []jmap.BusyPeriod{
+		{Title: "Holiday", Start: start.Truncate(24 * time.Hour), End: start.Truncate(24 * time.Hour).Add(24 * time.Hour), IsAllDay: true},
+		{Title: "1:1", Start: start, End: start.Add(30 * time.Minute)},
+	}
+
+	var buf bytes.Buffer
+	if err := reportConflicts(&buf, nil, format.DateStyle{}, false); err != nil || buf.Len() != 0 {
+		t.Fatalf("no conflicts: err = %v, output %q", err, buf.String())
+	}
+
+	err := reportConflicts(&buf, busy, format.DateStyle{}, false)
+	if err == nil || !strings.Contains(err.Error(), "--force") {
+		t.Errorf("expected refusal mentioning --force, got %v", err)
+	}
+	out := buf.String()
+	for _, want := range []string{"Holiday (all day 2025-12-19)", "1:1 ("} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output %q missing %q", out, want)
+		}
+	}
+
+	buf.Reset()
+	if err := reportConflicts(&buf, busy, format.DateStyle{}, true); err != nil {
+		t.Errorf("--force should not fail, got %v", err)
+	}
+	if !strings.Contains(buf.String(), "Sending anyway") {
+		t.Errorf("--force output %q should say it sends anyway", buf.String())
+	}
+}
@@ -0,0 +1,227 @@
+package cmd
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/salmonumbrella/fastmail-cli/internal/format"
+	"github.com/salmonumbrella/fastmail-cli/internal/jmap"
+)
+
+func TestWriteEventsTable_Columns(t *testing.T) {
+	start := time.Date(2025, 12, 19, 15, 0, 0, 0, time.UTC)
+	events := []jmap.CalendarEvent{{
+		ID:           "ev1",
+		Title:        "Planning",
+		Start:        start,
+		End:          start.Add(time.Hour),
+		Status:       "confirmed",
+		Location:     strings.Repeat("x", 50),
+		Participants: []jmap.Participant{{Email: "a@example.com"}, {Email: "b@example.com"}},
+	}}
+
+	var buf bytes.Buffer
+	writeEventsTable(&buf, events, format.DateStyle{}, false, false)
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if lines[0] != "ID\tTITLE\tSTART\tEND\tSTATUS" {
+		t.Fatalf("default header changed: %q", lines[0])
+	}
+	if got := len(strings.Split(lines[1], "\t")); got != 5 {
+		t.Fatalf("default row has %d columns, want 5", got)
+	}
+
+	buf.Reset()
+	writeEventsTable(&buf, events, format.DateStyle{}, true, true)
+	lines = strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if lines[0] != "ID\tTITLE\tSTART\tEND\tSTATUS\tATTENDEES\tLOCATION" {
+		t.Fatalf("unexpected header: %q", lines[0])
+	}
+	cols := strings.Split(lines[1], "\t")
+	if cols[5] != "2" {
+		t.Errorf("ATTENDEES = %q, want 2", cols[5])
+	}
+	if len(cols[6]) > eventLocationWidth {
+		t.Errorf("LOCATION not truncated: %q", cols[6])
+	}
+}
+
+func TestWriteEventsCSV(t *testing.T) {
+	start := time.Date(2025, 12, 19, 15, 0, 0, 0, time.UTC)
+	events := []jmap.CalendarEvent{{
+		ID:       "ev1",
+		Title:    `Review "Q4", part 2`,
+		Start:    start,
+		End:      start.Add(time.Hour),
+		Status:   "confirmed",
+		Location: "Room 1\nBuilding A",
+	}}
+
+	var buf bytes.Buffer
+	if err := writeEventsCSV(&buf, events, format.DateStyle{}, false, true); err != nil {
+		t.Fatalf("writeEventsCSV: %v", err)
+	}
+	want := "ID,TITLE,START,END,STATUS,LOCATION\r\n" +
+		`ev1,"Review ""Q4"", part 2",` +
+		formatEventTime(format.DateStyle{}, start, false) + "," + formatEventTime(format.DateStyle{}, start.Add(time.Hour), false) +
+		",confirmed,\"Room 1\r\nBuilding A\"\r\n"
+	if buf.String() != want {
+		t.Errorf("CSV =\n%q\nwant\n%q", buf.String(), want)
+	}
+}
+
+func TestHumanizeRecurrence(t *testing.T) {
+	tests := []struct {
+		name string
+		rule *jmap.RecurrenceRule
+		want string
+	}{
+		{"nil", nil, ""},
+		{"daily", &jmap.RecurrenceRule{Frequency: "daily"}, "Every day"},
+		{"interval one", &jmap.RecurrenceRule{Frequency: "weekly", Interval: 1}, "Every week"},
+		{
+			"interval byday until",
+			&jmap.RecurrenceRule{Frequency: "weekly", Interval: 2, ByDay: []jmap.NDay{{Day: "mo"}}, Until: "2025-12-31T00:00:00"},
+			"Every 2 weeks on Monday until 2025-12-31",
+		},
+		{
+			"several days with count",
+			&jmap.RecurrenceRule{Frequency: "weekly", ByDay: []jmap.NDay{{Day: "mo"}, {Day: "we"}, {Day: "fr"}}, Count: 10},
+			"Every week on Monday, Wednesday and Friday, 10 times",
+		},
+		{"count once", &jmap.RecurrenceRule{Frequency: "yearly", Count: 1}, "Every year, once"},
+		{
+			"nth weekday",
+			&jmap.RecurrenceRule{Frequency: "monthly", ByDay: []jmap.NDay{{Day: "tu", NthOfPeriod: 2}}},
+			"Every month on the 2nd Tuesday",
+		},
+		{
+			"last weekday",
+			&jmap.RecurrenceRule{Frequency: "monthly", Interval: 3, ByDay: []jmap.NDay{{Day: "fr", NthOfPeriod: -1}}},
+			"Every 3 months on the last Friday",
+		},
+		{"unknown frequency", &jmap.RecurrenceRule{Frequency: "fortnightly"}, "Repeats fortnightly"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := humanizeRecurrence(tt.rule); got != tt.want {
+				t.Errorf("humanizeRecurrence() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestOrdinal(t *testing.T) {
+	for n, want := range map[int]string{1: "1st", 2: "2nd", 3: "3rd", 4: "4th", 11: "11th", 12: "12th", 13: "13th", 21: "21st", 22: "22nd"} {
+		if got := ordinal(n); got != want {
+			t.Errorf("ordinal(%d) = %q, want %q", n, got, want)
+		}
+	}
+}
+
+func TestEventRecurrence(t *testing.T) {
+	rule, err := eventRecurrence("", 0, 0, "")
+	if err != nil || rule != nil {
+		t.Fatalf("no --repeat: got %+v, %v; want nil, nil", rule, err)
+	}
+
+	rule, err = eventRecurrence("weekly", 2, 0, "2026-06-30")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if rule.Frequency != "weekly" || rule.Interval != 2 || rule.Until != "2026-06-30T23:59:59" {
+		t.Errorf("rule = %+v, want weekly every 2 until end of 2026-06-30", rule)
+	}
+
+	if _, err := eventRecurrence("", 0, 5, ""); err == nil {
+		t.Error("expected error for --count without --repeat")
+	}
+	if _, err := eventRecurrence("daily", 0, 5, "2026-06-30"); err == nil {
+		t.Error("expected error for --count with --until")
+	}
+	if _, err := eventRecurrence("hourly", 0, 0, ""); err == nil {
+		t.Error("expected error for unknown --repeat")
+	}
+}
+
+func TestParseAlertOffset(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    time.Duration
+		wantErr bool
+	}{
+		{in: "15m", want: -15 * time.Minute},
+		{in: "-15m", want: -15 * time.Minute},
+		{in: "1h30m before", want: -90 * time.Minute},
+		{in: "2d", want: -48 * time.Hour},
+		{in: "+10m", want: 10 * time.Minute},
+		{in: "10m after", want: 10 * time.Minute},
+		{in: "0m", want: 0},
+		{in: "soon", wantErr: true},
+		{in: "xd", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		got, err := parseAlertOffset(tt.in)
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("parseAlertOffset(%q) expected error", tt.in)
+			}
+			continue
+		}
+		if err != nil || got != tt.want {
+			t.Errorf("parseAlertOffset(%q) = %v, %v; want %v", tt.in, got, err, tt.want)
+		}
+	}
+}
+
+func TestEventAlerts(t *testing.T) {
+	alerts, err := eventAlerts([]string{"15m", "1h"}, []string{"1d"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := []jmap.Alert{
+		{Trigger: "-PT15M", Action: "display"},
+		{Trigger: "-PT1H", Action: "display"},
+		{Trigger: "-P1D", Action: "email"},
+	}
+	if len(alerts) != len(want) {
+		t.Fatalf("got %d alerts, want %d", len(alerts), len(want))
+	}
+	for i := range want {
+		if alerts[i] != want[i] {
+			t.Errorf("alerts[%d] = %+v, want %+v", i, alerts[i], want[i])
+		}
+	}
+
+	if _, err := eventAlerts(nil, []string{"tomorrow"}); err == nil || !strings.Contains(err.Error(), "--alert-email") {
+		t.Errorf("expected --alert-email error, got %v", err)
+	}
+}
+
+func TestRSVPStatus(t *testing.T) {
+	if got, err := rsvpStatus(false, true, false); err != nil || got != "declined" {
+		t.Errorf("rsvpStatus(--decline) = %q, %v; want declined", got, err)
+	}
+	if _, err := rsvpStatus(false, false, false); err == nil {
+		t.Error("expected error with no flag")
+	}
+	if _, err := rsvpStatus(true, true, false); err == nil {
+		t.Error("expected error with --accept and --decline")
+	}
+}
+
+func TestNotParticipantError(t *testing.T) {
+	err := notParticipantError(jmap.ErrNotParticipant, []string{"me@example.com", "alias@example.com"}, "ev1")
+	if !strings.Contains(err.Error(), "me@example.com, alias@example.com") {
+		t.Errorf("error %q should list the addresses", err)
+	}
+
+	err = notParticipantError(jmap.ErrEventNotFound, nil, "ev1")
+	if !errors.Is(err, jmap.ErrEventNotFound) {
+		t.Errorf("other errors should pass through, got %v", err)
+	}
+}
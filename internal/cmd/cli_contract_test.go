@@ -1,9 +1,16 @@
 package cmd
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
+	"fmt"
 	"strings"
 	"testing"
+	"time"
+
+	cerrors "github.com/salmonumbrella/fastmail-cli/internal/errors"
+	"github.com/spf13/cobra"
 )
 
 func TestExecute_JSONErrorsAreStructuredAndStdoutIsClean(t *testing.T) {
@@ -267,3 +274,51 @@ func TestRootShortcutsExist(t *testing.T) {
 		}
 	}
 }
+
+func TestDeadlineFlag_BoundsWholeCommand(t *testing.T) {
+	app := NewApp()
+	root := NewRootCmd(app)
+
+	var calls int
+	root.AddCommand(&cobra.Command{
+		Use: "slow",
+		RunE: runE(app, func(cmd *cobra.Command, args []string, app *App) error {
+			// Simulate a multi-call command: each "call" waits 20ms.
+			for {
+				select {
+				case <-cmd.Context().Done():
+					return fmt.Errorf("after %d calls: %w", calls, cmd.Context().Err())
+				case <-time.After(20 * time.Millisecond):
+					calls++
+				}
+			}
+		}),
+	})
+	root.SetArgs([]string{"--deadline", "100ms", "slow"})
+
+	start := time.Now()
+	err := app.finishDeadline(root.Execute())
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Fatalf("command ran %v, want it stopped near the 100ms deadline", elapsed)
+	}
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("error = %v, want deadline exceeded", err)
+	}
+	if !strings.Contains(err.Error(), "--deadline of 100ms") {
+		t.Errorf("error = %q, want it to name --deadline", err)
+	}
+	if cerrors.GetSuggestion(err) == "" {
+		t.Error("expected a suggestion for an exceeded deadline")
+	}
+	if calls == 0 {
+		t.Error("expected some work before the deadline")
+	}
+}
+
+func TestDeadlineFlag_UnsetLeavesErrorsAlone(t *testing.T) {
+	app := NewApp()
+	want := errors.New("boom")
+	if got := app.finishDeadline(want); got != want {
+		t.Errorf("finishDeadline() = %v, want error unchanged", got)
+	}
+}
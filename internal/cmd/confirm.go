@@ -2,11 +2,15 @@ package cmd
 
 import (
 	"bufio"
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
 	"io"
 	"os"
+	"sort"
 	"strings"
 
+	"github.com/spf13/cobra"
 	"golang.org/x/term"
 )
 
@@ -30,3 +34,36 @@ func confirmPrompt(w io.Writer, prompt string, accepted ...string) (bool, error)
 	}
 	return false, nil
 }
+
+// confirmTokenLength is the number of hex characters in a confirm token.
+const confirmTokenLength = 12
+
+// confirmToken summarizes an operation (action plus target IDs) as a short
+// token. A dry-run prints it; passing it back via --confirm-token approves
+// exactly that operation without prompting. ID order does not matter.
+func confirmToken(op string, ids []string) string {
+	sorted := append([]string(nil), ids...)
+	sort.Strings(sorted)
+
+	h := sha256.New()
+	h.Write([]byte(op))
+	for _, id := range sorted {
+		h.Write([]byte{0})
+		h.Write([]byte(id))
+	}
+	return hex.EncodeToString(h.Sum(nil))[:confirmTokenLength]
+}
+
+// verifyConfirmToken checks that token was issued for this exact operation.
+func verifyConfirmToken(token, op string, ids []string) error {
+	want := confirmToken(op, ids)
+	if !strings.EqualFold(strings.TrimSpace(token), want) {
+		return Suggest(fmt.Errorf("confirm token %q does not match this operation", token), "Re-run with --dry-run to get a token for the current operation")
+	}
+	return nil
+}
+
+// addConfirmTokenFlag registers --confirm-token on a command that supports --dry-run.
+func addConfirmTokenFlag(cmd *cobra.Command, token *string) {
+	cmd.Flags().StringVar(token, "confirm-token", "", "Skip the prompt if this token from --dry-run matches the operation")
+}
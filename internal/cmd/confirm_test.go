@@ -65,3 +65,59 @@ func TestConfirmPrompt_EOF(t *testing.T) {
 		t.Fatalf("confirmPrompt error = nil, want error")
 	}
 }
+
+func TestConfirmToken_OrderIndependent(t *testing.T) {
+	a := confirmToken("delete", []string{"id1", "id2"})
+	b := confirmToken("delete", []string{"id2", "id1"})
+	if a != b {
+		t.Fatalf("tokens differ by ID order: %q vs %q", a, b)
+	}
+	if len(a) != confirmTokenLength {
+		t.Fatalf("token length = %d, want %d", len(a), confirmTokenLength)
+	}
+}
+
+func TestVerifyConfirmToken(t *testing.T) {
+	ids := []string{"id1", "id2"}
+	token := confirmToken("delete", ids)
+
+	if err := verifyConfirmToken(token, "delete", ids); err != nil {
+		t.Fatalf("matching token rejected: %v", err)
+	}
+
+	tests := []struct {
+		name string
+		op   string
+		ids  []string
+	}{
+		{"different action", "move:Archive", ids},
+		{"extra id", "delete", []string{"id1", "id2", "id3"}},
+		{"missing id", "delete", []string{"id1"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := verifyConfirmToken(token, tt.op, tt.ids); err == nil {
+				t.Fatal("expected mismatch error")
+			}
+		})
+	}
+}
+
+func TestConfirmOperation_TokenMismatchRejected(t *testing.T) {
+	app := newTestApp()
+	cmd := newEmailBulkDeleteCmd(app)
+
+	confirmed, err := app.ConfirmOperation(cmd, "deadbeef0000", "delete", []string{"id1"}, "Delete? ", "y")
+	if err == nil {
+		t.Fatal("expected error for mismatched token")
+	}
+	if confirmed {
+		t.Fatal("mismatched token must not confirm")
+	}
+
+	token := confirmToken("delete", []string{"id1"})
+	confirmed, err = app.ConfirmOperation(cmd, token, "delete", []string{"id1"}, "Delete? ", "y")
+	if err != nil || !confirmed {
+		t.Fatalf("ConfirmOperation = %v, %v; want true, nil", confirmed, err)
+	}
+}
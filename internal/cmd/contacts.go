@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"sort"
 	"strings"
+	"time"
 
 	"github.com/salmonumbrella/fastmail-cli/internal/jmap"
 	"github.com/salmonumbrella/fastmail-cli/internal/outfmt"
@@ -27,6 +28,10 @@ If contacts are not available via JMAP, you'll receive an error.`,
 	cmd.AddCommand(newContactsUpdateCmd(app))
 	cmd.AddCommand(newContactsDeleteCmd(app))
 	cmd.AddCommand(newContactsSearchCmd(app))
+	cmd.AddCommand(newContactsExportCmd(app))
+	cmd.AddCommand(newContactsExportAllCmd(app))
+	cmd.AddCommand(newContactsImportCmd(app))
+	cmd.AddCommand(newContactsGroupCmd(app))
 	cmd.AddCommand(newContactsAddressBooksCmd(app))
 
 	return cmd
@@ -35,27 +40,45 @@ If contacts are not available via JMAP, you'll receive an error.`,
 func newContactsListCmd(app *App) *cobra.Command {
 	var limit int
 	var addressbook string
+	var company string
 
 	cmd := &cobra.Command{
 		Use:   "list",
 		Short: "List contacts",
 		Long: `List contacts from your address book.
 
-Optionally filter by address book ID and limit the number of results.`,
+Optionally filter by address book ID or company and limit the number of
+results. The company filter is a case-insensitive substring match applied
+after fetching, so up to 5000 contacts are scanned to fill the limit.`,
 		Example: `  fastmail contacts list
   fastmail contacts list --limit 50
-  fastmail contacts list --addressbook <id>`,
+  fastmail contacts list --addressbook <id>
+  fastmail contacts list --company acme`,
 		RunE: runE(app, func(cmd *cobra.Command, args []string, app *App) error {
 			client, err := app.JMAPClient()
 			if err != nil {
 				return err
 			}
 
-			contacts, err := client.GetContacts(cmd.Context(), addressbook, limit)
+			// ContactCard/query has no company filter, so fetch more and
+			// filter locally before applying the limit
+			fetchLimit := limit
+			if company != "" {
+				fetchLimit = contactCompanyScanLimit
+			}
+
+			contacts, err := client.GetContacts(cmd.Context(), addressbook, fetchLimit)
 			if err != nil {
 				return fmt.Errorf("failed to list contacts: %w", err)
 			}
 
+			if company != "" {
+				contacts = filterContactsByCompany(contacts, company)
+				if limit > 0 && len(contacts) > limit {
+					contacts = contacts[:limit]
+				}
+			}
+
 			// Sort by name
 			sort.Slice(contacts, func(i, j int) bool {
 				return contacts[i].Name < contacts[j].Name
@@ -64,6 +87,22 @@ Optionally filter by address book ID and limit the number of results.`,
 			if app.IsJSON(cmd.Context()) {
 				return app.PrintJSON(cmd, contacts)
 			}
+			if app.IsCSV(cmd.Context()) {
+				return outfmt.PrintCSV([]string{"NAME", "EMAIL", "PHONE", "COMPANY"}, func(yield func([]string) bool) {
+					for _, contact := range contacts {
+						var email, phone string
+						if len(contact.Emails) > 0 {
+							email = contact.Emails[0].Value
+						}
+						if len(contact.Phones) > 0 {
+							phone = contact.Phones[0].Value
+						}
+						if !yield([]string{contact.Name, email, phone, contact.Company}) {
+							return
+						}
+					}
+				})
+			}
 
 			if len(contacts) == 0 {
 				printNoResults("No contacts found")
@@ -100,10 +139,28 @@ Optionally filter by address book ID and limit the number of results.`,
 
 	cmd.Flags().IntVar(&limit, "limit", 100, "Maximum number of contacts to retrieve")
 	cmd.Flags().StringVar(&addressbook, "addressbook", "", "Filter by address book ID")
+	cmd.Flags().StringVar(&company, "company", "", "Only contacts whose company contains this text (case-insensitive)")
 
 	return cmd
 }
 
+// contactCompanyScanLimit bounds how many contacts are fetched for
+// client-side --company filtering.
+const contactCompanyScanLimit = 5000
+
+// filterContactsByCompany keeps contacts whose company contains company,
+// ignoring case.
+func filterContactsByCompany(contacts []jmap.Contact, company string) []jmap.Contact {
+	needle := strings.ToLower(strings.TrimSpace(company))
+	filtered := make([]jmap.Contact, 0, len(contacts))
+	for _, contact := range contacts {
+		if strings.Contains(strings.ToLower(contact.Company), needle) {
+			filtered = append(filtered, contact)
+		}
+	}
+	return filtered
+}
+
 func newContactsGetCmd(app *App) *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "get <contactId>",
@@ -176,6 +233,13 @@ func newContactsGetCmd(app *App) *cobra.Command {
 				}
 			}
 
+			if len(contact.Online) > 0 {
+				fmt.Println("\nOnline:")
+				for _, online := range contact.Online {
+					fmt.Printf("  [%s] %s\n", online.Type, online.Value)
+				}
+			}
+
 			if contact.Birthday != "" {
 				fmt.Printf("\nBirthday: %s\n", contact.Birthday)
 			}
@@ -203,6 +267,9 @@ func newContactsCreateCmd(app *App) *cobra.Command {
 	var company string
 	var jobTitle string
 	var notes string
+	var birthday string
+	var urls []string
+	var address contactAddressFlags
 
 	cmd := &cobra.Command{
 		Use:   "create --name <name>",
@@ -211,11 +278,15 @@ func newContactsCreateCmd(app *App) *cobra.Command {
 
 At minimum, you must provide a name. Other fields are optional.`,
 		Example: `  fastmail contacts create --name "John Doe" --email "john@example.com"
-  fastmail contacts create --name "Jane Smith" --email "jane@example.com" --phone "+1-555-1234" --company "Acme Corp"`,
+  fastmail contacts create --name "Jane Smith" --email "jane@example.com" --phone "+1-555-1234" --company "Acme Corp"
+  fastmail contacts create --name "Jane Smith" --street "1 Main St" --city "Springfield" --country "US" --url "https://example.com" --birthday 1990-04-01`,
 		RunE: runE(app, func(cmd *cobra.Command, args []string, app *App) error {
 			if name == "" {
 				return fmt.Errorf("name is required")
 			}
+			if err := validateContactBirthday(birthday); err != nil {
+				return err
+			}
 
 			client, err := app.JMAPClient()
 			if err != nil {
@@ -223,10 +294,13 @@ At minimum, you must provide a name. Other fields are optional.`,
 			}
 
 			contact := &jmap.Contact{
-				Name:     name,
-				Company:  company,
-				JobTitle: jobTitle,
-				Notes:    notes,
+				Name:      name,
+				Company:   company,
+				JobTitle:  jobTitle,
+				Notes:     notes,
+				Birthday:  birthday,
+				Addresses: address.toAddresses(),
+				Online:    contactOnlineFromURLs(urls),
 			}
 
 			if email != "" {
@@ -261,6 +335,9 @@ At minimum, you must provide a name. Other fields are optional.`,
 	cmd.Flags().StringVar(&company, "company", "", "Company name")
 	cmd.Flags().StringVar(&jobTitle, "job-title", "", "Job title")
 	cmd.Flags().StringVar(&notes, "notes", "", "Notes")
+	cmd.Flags().StringVar(&birthday, "birthday", "", "Birthday (YYYY-MM-DD)")
+	cmd.Flags().StringSliceVar(&urls, "url", nil, "Website or profile URL (repeatable)")
+	address.register(cmd)
 
 	_ = cmd.MarkFlagRequired("name") //nolint:errcheck
 
@@ -274,6 +351,9 @@ func newContactsUpdateCmd(app *App) *cobra.Command {
 	var company string
 	var jobTitle string
 	var notes string
+	var birthday string
+	var urls []string
+	var address contactAddressFlags
 
 	cmd := &cobra.Command{
 		Use:   "update <contactId>",
@@ -283,9 +363,14 @@ func newContactsUpdateCmd(app *App) *cobra.Command {
 Only the fields you specify will be updated.`,
 		Example: `  fastmail contacts update <id> --name "John Doe Jr."
   fastmail contacts update <id> --email "newemail@example.com"
-  fastmail contacts update <id> --company "New Corp" --job-title "CEO"`,
+  fastmail contacts update <id> --company "New Corp" --job-title "CEO"
+  fastmail contacts update <id> --city "Berlin" --country "DE"`,
 		Args: cobra.ExactArgs(1),
 		RunE: runE(app, func(cmd *cobra.Command, args []string, app *App) error {
+			if err := validateContactBirthday(birthday); err != nil {
+				return err
+			}
+
 			client, err := app.JMAPClient()
 			if err != nil {
 				return err
@@ -315,6 +400,15 @@ Only the fields you specify will be updated.`,
 					{Type: "work", Value: phone},
 				}
 			}
+			if birthday != "" {
+				updates["birthday"] = birthday
+			}
+			if addresses := address.toAddresses(); len(addresses) > 0 {
+				updates["addresses"] = addresses
+			}
+			if len(urls) > 0 {
+				updates["online"] = contactOnlineFromURLs(urls)
+			}
 
 			if len(updates) == 0 {
 				return fmt.Errorf("no updates specified")
@@ -340,6 +434,9 @@ Only the fields you specify will be updated.`,
 	cmd.Flags().StringVar(&company, "company", "", "Company name")
 	cmd.Flags().StringVar(&jobTitle, "job-title", "", "Job title")
 	cmd.Flags().StringVar(&notes, "notes", "", "Notes")
+	cmd.Flags().StringVar(&birthday, "birthday", "", "Birthday (YYYY-MM-DD)")
+	cmd.Flags().StringSliceVar(&urls, "url", nil, "Website or profile URL (repeatable)")
+	address.register(cmd)
 
 	return cmd
 }
@@ -510,3 +607,56 @@ func newContactsAddressBooksCmd(app *App) *cobra.Command {
 
 	return cmd
 }
+
+// contactAddressFlags holds the address flags shared by contacts create and update.
+type contactAddressFlags struct {
+	street     string
+	city       string
+	state      string
+	postalCode string
+	country    string
+}
+
+func (f *contactAddressFlags) register(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&f.street, "street", "", "Street address")
+	cmd.Flags().StringVar(&f.city, "city", "", "City")
+	cmd.Flags().StringVar(&f.state, "state", "", "State or region")
+	cmd.Flags().StringVar(&f.postalCode, "postal-code", "", "Postal code")
+	cmd.Flags().StringVar(&f.country, "country", "", "Country")
+}
+
+// toAddresses returns a single home address, or nil if no address flag was set.
+func (f *contactAddressFlags) toAddresses() []jmap.ContactAddress {
+	if f.street == "" && f.city == "" && f.state == "" && f.postalCode == "" && f.country == "" {
+		return nil
+	}
+	return []jmap.ContactAddress{{
+		Type:       "home",
+		Street:     f.street,
+		City:       f.city,
+		State:      f.state,
+		PostalCode: f.postalCode,
+		Country:    f.country,
+	}}
+}
+
+func contactOnlineFromURLs(urls []string) []jmap.ContactOnline {
+	if len(urls) == 0 {
+		return nil
+	}
+	online := make([]jmap.ContactOnline, len(urls))
+	for i, u := range urls {
+		online[i] = jmap.ContactOnline{Type: "uri", Value: u}
+	}
+	return online
+}
+
+func validateContactBirthday(birthday string) error {
+	if birthday == "" {
+		return nil
+	}
+	if _, err := time.Parse("2006-01-02", birthday); err != nil {
+		return fmt.Errorf("invalid --birthday %q (expected YYYY-MM-DD)", birthday)
+	}
+	return nil
+}
@@ -0,0 +1,185 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/salmonumbrella/fastmail-cli/internal/jmap"
+	"github.com/spf13/cobra"
+)
+
+func newContactsExportCmd(app *App) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "export <contactId> [file.vcf]",
+		Short: "Export a contact as vCard 4.0",
+		Long: `Export a contact as a vCard 4.0 (RFC 6350) file.
+
+If no file is given, the vCard is written to stdout.`,
+		Example: `  fastmail contacts export <id>
+  fastmail contacts export <id> jane.vcf`,
+		Args: cobra.RangeArgs(1, 2),
+		RunE: runE(app, func(cmd *cobra.Command, args []string, app *App) error {
+			client, err := app.JMAPClient()
+			if err != nil {
+				return err
+			}
+
+			vcard, err := client.ExportContactVCard(cmd.Context(), args[0])
+			if err != nil {
+				return fmt.Errorf("failed to export contact: %w", err)
+			}
+
+			if len(args) < 2 {
+				if app.IsJSON(cmd.Context()) {
+					return app.PrintJSON(cmd, map[string]any{
+						"contactId": args[0],
+						"vcard":     vcard,
+					})
+				}
+				fmt.Print(vcard)
+				return nil
+			}
+
+			if err := writeVCardFile(args[1], vcard); err != nil {
+				return err
+			}
+
+			if app.IsJSON(cmd.Context()) {
+				return app.PrintJSON(cmd, map[string]any{
+					"contactId":  args[0],
+					"outputFile": args[1],
+				})
+			}
+
+			fmt.Printf("Exported contact to %s\n", args[1])
+			return nil
+		}),
+	}
+
+	return cmd
+}
+
+func newContactsExportAllCmd(app *App) *cobra.Command {
+	var limit int
+	var addressbook string
+
+	cmd := &cobra.Command{
+		Use:   "export-all [file.vcf]",
+		Short: "Export all contacts to a single vCard file",
+		Long: `Export all contacts as vCard 4.0 (RFC 6350) into one multi-vCard file.
+
+If no file is given, the vCards are written to stdout.`,
+		Example: `  fastmail contacts export-all contacts.vcf
+  fastmail contacts export-all --addressbook <id> work.vcf`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: runE(app, func(cmd *cobra.Command, args []string, app *App) error {
+			client, err := app.JMAPClient()
+			if err != nil {
+				return err
+			}
+
+			contacts, err := client.GetContacts(cmd.Context(), addressbook, limit)
+			if err != nil {
+				return fmt.Errorf("failed to list contacts: %w", err)
+			}
+
+			sort.Slice(contacts, func(i, j int) bool {
+				return contacts[i].Name < contacts[j].Name
+			})
+
+			var b strings.Builder
+			for i := range contacts {
+				b.WriteString(jmap.ContactToVCard(&contacts[i]))
+			}
+
+			if len(args) == 0 {
+				if app.IsJSON(cmd.Context()) {
+					return app.PrintJSON(cmd, map[string]any{
+						"count": len(contacts),
+						"vcard": b.String(),
+					})
+				}
+				fmt.Print(b.String())
+				return nil
+			}
+
+			if err := writeVCardFile(args[0], b.String()); err != nil {
+				return err
+			}
+
+			if app.IsJSON(cmd.Context()) {
+				return app.PrintJSON(cmd, map[string]any{
+					"count":      len(contacts),
+					"outputFile": args[0],
+				})
+			}
+
+			fmt.Printf("Exported %d contacts to %s\n", len(contacts), args[0])
+			return nil
+		}),
+	}
+
+	cmd.Flags().IntVar(&limit, "limit", 1000, "Maximum number of contacts to export")
+	cmd.Flags().StringVar(&addressbook, "addressbook", "", "Export only this address book ID")
+
+	return cmd
+}
+
+// writeVCardFile writes vCard text to path, refusing to overwrite an existing file.
+func writeVCardFile(path, vcard string) error {
+	if _, statErr := os.Stat(path); statErr == nil {
+		return fmt.Errorf("file '%s' already exists. Specify a different output file", path)
+	}
+	if err := os.WriteFile(path, []byte(vcard), 0o600); err != nil {
+		return fmt.Errorf("failed to write vCard file: %w", err)
+	}
+	return nil
+}
+
+func newContactsImportCmd(app *App) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "import <file.vcf>",
+		Short: "Import contacts from a vCard file",
+		Long: `Import contacts from a vCard file (4.0, and tolerantly 3.0/2.1).
+
+Files may contain multiple cards. A card that fails to import does not stop
+the rest; failures are reported at the end.`,
+		Example: `  fastmail contacts import contacts.vcf`,
+		Args:    cobra.ExactArgs(1),
+		RunE: runE(app, func(cmd *cobra.Command, args []string, app *App) error {
+			data, err := os.ReadFile(args[0])
+			if err != nil {
+				return fmt.Errorf("failed to read vCard file: %w", err)
+			}
+
+			client, err := app.JMAPClient()
+			if err != nil {
+				return err
+			}
+
+			created, err := client.ImportContactVCard(cmd.Context(), string(data))
+			failed := map[string]string{}
+			var importErr *jmap.ContactImportError
+			if errors.As(err, &importErr) {
+				failed = importErr.Failed
+			} else if err != nil {
+				return fmt.Errorf("failed to import contacts: %w", err)
+			}
+
+			if app.IsJSON(cmd.Context()) {
+				return app.PrintJSON(cmd, map[string]any{
+					"created": created,
+					"failed":  failed,
+				})
+			}
+
+			printBulkResults("Imported", "contacts", len(created), len(failed), failed)
+			return nil
+		}),
+	}
+
+	return cmd
+}
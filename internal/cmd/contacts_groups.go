@@ -0,0 +1,165 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/salmonumbrella/fastmail-cli/internal/outfmt"
+	"github.com/spf13/cobra"
+)
+
+func newContactsGroupCmd(app *App) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "group",
+		Aliases: []string{"groups"},
+		Short:   "Manage contact groups",
+		Long: `Manage contact groups.
+
+Groups are contact cards of kind "group"; members are referenced by the
+uid of each contact card.`,
+	}
+
+	cmd.AddCommand(newContactsGroupListCmd(app))
+	cmd.AddCommand(newContactsGroupCreateCmd(app))
+	cmd.AddCommand(newContactsGroupAddCmd(app))
+	cmd.AddCommand(newContactsGroupRemoveCmd(app))
+
+	return cmd
+}
+
+func newContactsGroupListCmd(app *App) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List contact groups",
+		Example: `  fastmail contacts group list
+  fastmail contacts group list --output json`,
+		Args: cobra.NoArgs,
+		RunE: runE(app, func(cmd *cobra.Command, args []string, app *App) error {
+			client, err := app.JMAPClient()
+			if err != nil {
+				return err
+			}
+
+			groups, err := client.GetContactGroups(cmd.Context())
+			if err != nil {
+				return fmt.Errorf("failed to list contact groups: %w", err)
+			}
+
+			if app.IsJSON(cmd.Context()) {
+				return app.PrintJSON(cmd, groups)
+			}
+
+			if len(groups) == 0 {
+				printNoResults("No contact groups found")
+				return nil
+			}
+
+			tw := outfmt.NewTabWriter()
+			fmt.Fprintln(tw, "ID\tNAME\tMEMBERS")
+			for i := range groups {
+				fmt.Fprintf(tw, "%s\t%s\t%d\n",
+					groups[i].ID,
+					outfmt.SanitizeTab(groups[i].Name),
+					len(groups[i].MemberUIDs()),
+				)
+			}
+			tw.Flush()
+
+			return nil
+		}),
+	}
+
+	return cmd
+}
+
+func newContactsGroupCreateCmd(app *App) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "create <name>",
+		Short:   "Create a contact group",
+		Example: `  fastmail contacts group create "Book club"`,
+		Args:    cobra.ExactArgs(1),
+		RunE: runE(app, func(cmd *cobra.Command, args []string, app *App) error {
+			client, err := app.JMAPClient()
+			if err != nil {
+				return err
+			}
+
+			group, err := client.CreateContactGroup(cmd.Context(), args[0])
+			if err != nil {
+				return fmt.Errorf("failed to create contact group: %w", err)
+			}
+
+			if app.IsJSON(cmd.Context()) {
+				return app.PrintJSON(cmd, group)
+			}
+
+			fmt.Printf("Created contact group %s (%s)\n", group.Name, group.ID)
+			return nil
+		}),
+	}
+
+	return cmd
+}
+
+func newContactsGroupAddCmd(app *App) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "add <groupId> <contactId>",
+		Short:   "Add a contact to a group",
+		Example: `  fastmail contacts group add <groupId> <contactId>`,
+		Args:    cobra.ExactArgs(2),
+		RunE: runE(app, func(cmd *cobra.Command, args []string, app *App) error {
+			client, err := app.JMAPClient()
+			if err != nil {
+				return err
+			}
+
+			if err := client.AddContactToGroup(cmd.Context(), args[0], args[1]); err != nil {
+				return fmt.Errorf("failed to add contact to group: %w", err)
+			}
+
+			if app.IsJSON(cmd.Context()) {
+				return app.PrintJSON(cmd, map[string]any{
+					"status":    "added",
+					"groupId":   args[0],
+					"contactId": args[1],
+				})
+			}
+
+			fmt.Printf("Added contact %s to group %s\n", args[1], args[0])
+			return nil
+		}),
+	}
+
+	return cmd
+}
+
+func newContactsGroupRemoveCmd(app *App) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "remove <groupId> <contactId>",
+		Short:   "Remove a contact from a group",
+		Example: `  fastmail contacts group remove <groupId> <contactId>`,
+		Args:    cobra.ExactArgs(2),
+		RunE: runE(app, func(cmd *cobra.Command, args []string, app *App) error {
+			client, err := app.JMAPClient()
+			if err != nil {
+				return err
+			}
+
+			if err := client.RemoveContactFromGroup(cmd.Context(), args[0], args[1]); err != nil {
+				return fmt.Errorf("failed to remove contact from group: %w", err)
+			}
+
+			if app.IsJSON(cmd.Context()) {
+				return app.PrintJSON(cmd, map[string]any{
+					"status":    "removed",
+					"groupId":   args[0],
+					"contactId": args[1],
+				})
+			}
+
+			fmt.Printf("Removed contact %s from group %s\n", args[1], args[0])
+			return nil
+		}),
+	}
+
+	return cmd
+}
@@ -0,0 +1,25 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/salmonumbrella/fastmail-cli/internal/jmap"
+)
+
+func TestFilterContactsByCompany(t *testing.T) {
+	contacts := []jmap.Contact{
+		{ID: "1", Name: "Ann", Company: "Acme Corp"},
+		{ID: "2", Name: "Bob", Company: "Globex"},
+		{ID: "3", Name: "Cid"},
+		{ID: "4", Name: "Dee", Company: "ACME Labs"},
+	}
+
+	got := filterContactsByCompany(contacts, " acme ")
+	if len(got) != 2 || got[0].ID != "1" || got[1].ID != "4" {
+		t.Errorf("filterContactsByCompany() = %+v, want Ann and Dee", got)
+	}
+
+	if got := filterContactsByCompany(contacts, "initech"); len(got) != 0 {
+		t.Errorf("no match returned %+v", got)
+	}
+}
@@ -0,0 +1,63 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"golang.org/x/term"
+)
+
+const progressBarWidth = 30
+
+// downloadProgress is an io.Writer that renders a percentage bar for a
+// download of known total size. Bytes already on disk (when resuming) count
+// toward the starting position.
+type downloadProgress struct {
+	w       io.Writer
+	label   string
+	done    int64
+	total   int64
+	lastPct int
+}
+
+func newDownloadProgress(w io.Writer, label string, start, total int64) *downloadProgress {
+	return &downloadProgress{w: w, label: label, done: start, total: total, lastPct: -1}
+}
+
+// stderrIsTerminal reports whether progress output would reach a terminal.
+func stderrIsTerminal() bool {
+	return term.IsTerminal(int(os.Stderr.Fd()))
+}
+
+func (p *downloadProgress) Write(b []byte) (int, error) {
+	p.done += int64(len(b))
+	p.render()
+	return len(b), nil
+}
+
+func (p *downloadProgress) render() {
+	if p.total <= 0 {
+		return
+	}
+	pct := int(p.done * 100 / p.total)
+	if pct > 100 {
+		pct = 100
+	}
+	if pct == p.lastPct {
+		return
+	}
+	p.lastPct = pct
+
+	filled := pct * progressBarWidth / 100
+	bar := strings.Repeat("=", filled) + strings.Repeat(" ", progressBarWidth-filled)
+	fmt.Fprintf(p.w, "\r%s [%s] %3d%%", p.label, bar, pct)
+}
+
+// finish terminates the progress line.
+func (p *downloadProgress) finish() {
+	if p.lastPct >= 0 {
+		fmt.Fprintln(p.w)
+	}
+}
@@ -3,7 +3,6 @@ package cmd
 import (
 	"fmt"
 
-	"github.com/salmonumbrella/fastmail-cli/internal/config"
 	"github.com/salmonumbrella/fastmail-cli/internal/jmap"
 	"github.com/salmonumbrella/fastmail-cli/internal/validation"
 	"github.com/spf13/cobra"
@@ -61,7 +60,7 @@ func newDraftListCmd(app *App) *cobra.Command {
 				return nil
 			}
 
-			printEmailList(drafts, threadCounts)
+			printEmailList(drafts, threadCounts, app.DateStyle(), app.TableStyle(cmd.Context()))
 			return nil
 		}),
 	}
@@ -96,7 +95,7 @@ func newDraftGetCmd(app *App) *cobra.Command {
 				return app.PrintJSON(cmd, emailToOutput(*draft))
 			}
 
-			printEmailDetails(draft)
+			printEmailDetails(draft, false)
 			return nil
 		}),
 	}
@@ -143,12 +142,8 @@ Examples:
 			// Apply default identity if --from not specified
 			effectiveFrom := fromIdentity
 			if effectiveFrom == "" {
-				accountEmail, accountErr := app.RequireAccount()
-				if accountErr == nil {
-					if defaultIdentity, _ := config.GetDefaultIdentity(accountEmail); defaultIdentity != "" {
-						effectiveFrom = defaultIdentity
-					}
-				}
+				accountEmail, _ := app.RequireAccount()
+				effectiveFrom = app.DefaultFrom(accountEmail)
 			}
 
 			opts := jmap.SendEmailOpts{
@@ -1,8 +1,19 @@
 package cmd
 
-import "github.com/spf13/cobra"
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+)
+
+// printDryRunList prints what a bulk operation would do. When op is non-empty
+// it also prints the confirm token for op and items (see confirmToken).
+func printDryRunList(app *App, cmd *cobra.Command, op, header, key string, items []string, extra map[string]any) error {
+	var token string
+	if op != "" {
+		token = confirmToken(op, items)
+	}
 
-func printDryRunList(app *App, cmd *cobra.Command, header, key string, items []string, extra map[string]any) error {
 	if app.IsJSON(cmd.Context()) {
 		payload := map[string]any{
 			"dryRun": true,
@@ -11,9 +22,15 @@ func printDryRunList(app *App, cmd *cobra.Command, header, key string, items []s
 		for k, v := range extra {
 			payload[k] = v
 		}
+		if token != "" {
+			payload["confirmToken"] = token
+		}
 		return app.PrintJSON(cmd, payload)
 	}
 
 	printList(header, items)
+	if token != "" {
+		fmt.Printf("\nConfirm token: %s (re-run with --confirm-token %s to proceed without prompting)\n", token, token)
+	}
 	return nil
 }
@@ -16,7 +16,7 @@ func TestPrintDryRunList_Text(t *testing.T) {
 	app := newTestApp()
 
 	out := captureStdout(t, func() {
-		err := printDryRunList(app, cmd, "Would delete 2 emails:", "wouldDelete", []string{"a", "b"}, nil)
+		err := printDryRunList(app, cmd, "", "Would delete 2 emails:", "wouldDelete", []string{"a", "b"}, nil)
 		if err != nil {
 			t.Fatalf("printDryRunList error: %v", err)
 		}
@@ -38,7 +38,7 @@ func TestPrintDryRunList_JSON(t *testing.T) {
 	app := newTestApp()
 
 	out := captureStdout(t, func() {
-		err := printDryRunList(app, cmd, "ignored", "wouldMove", []string{"id1"}, map[string]any{"mailbox": "Inbox"})
+		err := printDryRunList(app, cmd, "", "ignored", "wouldMove", []string{"id1"}, map[string]any{"mailbox": "Inbox"})
 		if err != nil {
 			t.Fatalf("printDryRunList error: %v", err)
 		}
@@ -60,3 +60,24 @@ func TestPrintDryRunList_JSON(t *testing.T) {
 		t.Fatalf("unexpected wouldMove payload: %#v", payload["wouldMove"])
 	}
 }
+
+func TestPrintDryRunList_ConfirmToken(t *testing.T) {
+	cmd := &cobra.Command{}
+	ctx := context.WithValue(context.Background(), outputModeKey, outfmt.JSON)
+	cmd.SetContext(ctx)
+	app := newTestApp()
+
+	out := captureStdout(t, func() {
+		if err := printDryRunList(app, cmd, "delete", "ignored", "wouldDelete", []string{"a", "b"}, nil); err != nil {
+			t.Fatalf("printDryRunList error: %v", err)
+		}
+	})
+
+	var payload map[string]any
+	if err := json.Unmarshal([]byte(out), &payload); err != nil {
+		t.Fatalf("json unmarshal: %v", err)
+	}
+	if got, want := payload["confirmToken"], confirmToken("delete", []string{"a", "b"}); got != want {
+		t.Fatalf("confirmToken = %v, want %q", got, want)
+	}
+}
@@ -11,25 +11,48 @@ func newEmailCmd(app *App) *cobra.Command {
 
 	cmd.AddCommand(newEmailListCmd(app))
 	cmd.AddCommand(newEmailSearchCmd(app))
+	cmd.AddCommand(newEmailFocusedCmd(app))
+	cmd.AddCommand(newEmailKeywordsCmd(app))
 	cmd.AddCommand(newEmailGetCmd(app))
 	cmd.AddCommand(newEmailSendCmd(app))
+	cmd.AddCommand(newEmailWriteCmd(app))
+	cmd.AddCommand(newEmailReplyCmd(app))
 	cmd.AddCommand(newEmailForwardCmd(app))
+	cmd.AddCommand(newEmailResendCmd(app))
 	cmd.AddCommand(newEmailDeleteCmd(app))
 	cmd.AddCommand(newEmailBulkDeleteCmd(app))
+	cmd.AddCommand(newEmailEmptyTrashCmd(app))
+	cmd.AddCommand(newEmailEmptySpamCmd(app))
 	cmd.AddCommand(newEmailMoveCmd(app))
 	cmd.AddCommand(newEmailBulkMoveCmd(app))
+	cmd.AddCommand(newEmailApplyRulesCmd(app))
 	cmd.AddCommand(newEmailMarkReadCmd(app))
 	cmd.AddCommand(newEmailBulkMarkReadCmd(app))
+	cmd.AddCommand(newEmailUpdateCmd(app))
+	cmd.AddCommand(newEmailTriageCmd(app))
+	cmd.AddCommand(newEmailSnoozeCmd(app))
+	cmd.AddCommand(newEmailSnoozeWakeCmd(app))
 	cmd.AddCommand(newEmailThreadCmd(app))
+	cmd.AddCommand(newEmailThreadSearchCmd(app))
+	cmd.AddCommand(newEmailThreadReadCmd(app))
+	cmd.AddCommand(newEmailThreadFlagCmd(app))
+	cmd.AddCommand(newEmailThreadMoveCmd(app))
 	cmd.AddCommand(newEmailAttachmentsCmd(app))
 	cmd.AddCommand(newEmailDownloadCmd(app))
+	cmd.AddCommand(newEmailDownloadAllCmd(app))
+	cmd.AddCommand(newEmailAttachmentsZipCmd(app))
 	cmd.AddCommand(newEmailMailboxesCmd(app))
+	cmd.AddCommand(newEmailInboxStatusCmd(app))
 	cmd.AddCommand(newMailboxCreateCmd(app))
 	cmd.AddCommand(newMailboxDeleteCmd(app))
 	cmd.AddCommand(newMailboxRenameCmd(app))
+	cmd.AddCommand(newMailboxMoveCmd(app))
 	cmd.AddCommand(newEmailImportCmd(app))
+	cmd.AddCommand(newEmailImportMboxCmd(app))
+	cmd.AddCommand(newEmailImportDirCmd(app))
 	cmd.AddCommand(newEmailIdentitiesCmd(app))
 	cmd.AddCommand(newIdentitySetDefaultCmd(app))
+	cmd.AddCommand(newEmailIdentityCmd(app))
 	cmd.AddCommand(newEmailTrackCmd(app))
 
 	return cmd
@@ -4,6 +4,7 @@ import (
 	"fmt"
 
 	cerrors "github.com/salmonumbrella/fastmail-cli/internal/errors"
+	"github.com/salmonumbrella/fastmail-cli/internal/jmap"
 	"github.com/spf13/cobra"
 )
 
@@ -41,16 +42,22 @@ func newEmailDeleteCmd(app *App) *cobra.Command {
 
 func newEmailBulkDeleteCmd(app *App) *cobra.Command {
 	var dryRun bool
+	var token string
 
 	cmd := &cobra.Command{
 		Use:     "bulk-delete <emailId>...",
 		Aliases: []string{"bulk-rm", "rm-many"},
 		Short:   "Delete multiple emails (move to trash)",
-		Args:    cobra.MinimumNArgs(1),
+		Args:    cobra.ArbitraryArgs,
 		RunE: runE(app, func(cmd *cobra.Command, args []string, app *App) error {
+			args, err := readIDArgs(cmd, args)
+			if err != nil {
+				return err
+			}
+
 			// Handle dry-run mode
 			if dryRun {
-				return printDryRunList(app, cmd, fmt.Sprintf("Would delete %d emails:", len(args)), "wouldDelete", args, nil)
+				return printDryRunList(app, cmd, "delete", fmt.Sprintf("Would delete %d emails:", len(args)), "wouldDelete", args, nil)
 			}
 
 			client, err := app.JMAPClient()
@@ -59,7 +66,7 @@ func newEmailBulkDeleteCmd(app *App) *cobra.Command {
 			}
 
 			// Prompt for confirmation unless --yes flag is set (global) or JSON output mode.
-			confirmed, err := app.Confirm(cmd, false, fmt.Sprintf("Delete %d emails? [y/N] ", len(args)), "y", "yes")
+			confirmed, err := app.ConfirmOperation(cmd, token, "delete", args, fmt.Sprintf("Delete %d emails? [y/N] ", len(args)), "y", "yes")
 			if err != nil {
 				return err
 			}
@@ -96,6 +103,7 @@ func newEmailBulkDeleteCmd(app *App) *cobra.Command {
 	}
 
 	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "Show what would be deleted without making changes")
+	addConfirmTokenFlag(cmd, &token)
 
 	return cmd
 }
@@ -143,7 +151,7 @@ func newEmailMoveCmd(app *App) *cobra.Command {
 		}),
 	}
 
-	cmd.Flags().StringVar(&targetMailbox, "to", "", "Target mailbox ID or name")
+	cmd.Flags().StringVar(&targetMailbox, "to", "", "Target mailbox ID, name or role (role:archive matches only by role)")
 
 	return cmd
 }
@@ -151,13 +159,19 @@ func newEmailMoveCmd(app *App) *cobra.Command {
 func newEmailBulkMoveCmd(app *App) *cobra.Command {
 	var targetMailbox string
 	var dryRun bool
+	var token string
 
 	cmd := &cobra.Command{
 		Use:     "bulk-move <emailId>...",
 		Aliases: []string{"bulk-mv"},
 		Short:   "Move multiple emails to a mailbox",
-		Args:    cobra.MinimumNArgs(1),
+		Args:    cobra.ArbitraryArgs,
 		RunE: runE(app, func(cmd *cobra.Command, args []string, app *App) error {
+			args, err := readIDArgs(cmd, args)
+			if err != nil {
+				return err
+			}
+
 			// Validate required flags before accessing keyring
 			if targetMailbox == "" {
 				return fmt.Errorf("--to is required")
@@ -165,7 +179,7 @@ func newEmailBulkMoveCmd(app *App) *cobra.Command {
 
 			// Handle dry-run mode without requiring keyring / network.
 			if dryRun {
-				return printDryRunList(app, cmd, fmt.Sprintf("Would move %d emails to %s:", len(args), targetMailbox), "wouldMove", args, map[string]any{
+				return printDryRunList(app, cmd, "move:"+targetMailbox, fmt.Sprintf("Would move %d emails to %s:", len(args), targetMailbox), "wouldMove", args, map[string]any{
 					"mailbox": targetMailbox,
 				})
 			}
@@ -199,7 +213,7 @@ func newEmailBulkMoveCmd(app *App) *cobra.Command {
 			}
 
 			// Prompt for confirmation unless --yes flag is set (global) or JSON output mode.
-			confirmed, err := app.Confirm(cmd, false, fmt.Sprintf("Move %d emails to %s? [y/N] ", len(args), mailboxName), "y", "yes")
+			confirmed, err := app.ConfirmOperation(cmd, token, "move:"+targetMailbox, args, fmt.Sprintf("Move %d emails to %s? [y/N] ", len(args), mailboxName), "y", "yes")
 			if err != nil {
 				return err
 			}
@@ -236,10 +250,11 @@ func newEmailBulkMoveCmd(app *App) *cobra.Command {
 		}),
 	}
 
-	cmd.Flags().StringVar(&targetMailbox, "to", "", "Target mailbox ID or name")
-	cmd.Flags().StringVar(&targetMailbox, "mailbox", "", "Target mailbox ID or name (alias for --to)")
+	cmd.Flags().StringVar(&targetMailbox, "to", "", "Target mailbox ID, name or role (role:archive matches only by role)")
+	cmd.Flags().StringVar(&targetMailbox, "mailbox", "", "Target mailbox ID, name or role (alias for --to)")
 	_ = cmd.Flags().MarkHidden("mailbox") // Hidden alias for agent compatibility
 	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "Show what would be moved without making changes")
+	addConfirmTokenFlag(cmd, &token)
 
 	return cmd
 }
@@ -293,8 +308,13 @@ func newEmailBulkMarkReadCmd(app *App) *cobra.Command {
 		Use:     "bulk-mark-read <emailId>...",
 		Aliases: []string{"bulk-read", "bulk-seen"},
 		Short:   "Mark multiple emails as read/unread",
-		Args:    cobra.MinimumNArgs(1),
+		Args:    cobra.ArbitraryArgs,
 		RunE: runE(app, func(cmd *cobra.Command, args []string, app *App) error {
+			args, err := readIDArgs(cmd, args)
+			if err != nil {
+				return err
+			}
+
 			status := "read"
 			if unread {
 				status = "unread"
@@ -302,7 +322,7 @@ func newEmailBulkMarkReadCmd(app *App) *cobra.Command {
 
 			// Handle dry-run mode
 			if dryRun {
-				return printDryRunList(app, cmd, fmt.Sprintf("Would mark %d emails as %s:", len(args), status), "wouldMark", args, map[string]any{
+				return printDryRunList(app, cmd, "", fmt.Sprintf("Would mark %d emails as %s:", len(args), status), "wouldMark", args, map[string]any{
 					"status": status,
 				})
 			}
@@ -344,3 +364,127 @@ func newEmailBulkMarkReadCmd(app *App) *cobra.Command {
 
 	return cmd
 }
+
+// emailUpdateFlags holds the flags that compose an email update patch.
+type emailUpdateFlags struct {
+	moveTo         string
+	flag           bool
+	unflag         bool
+	read           bool
+	unread         bool
+	keywords       []string
+	removeKeywords []string
+}
+
+// validate checks for contradictory flags and that at least one change was requested.
+func (f *emailUpdateFlags) validate() error {
+	if f.flag && f.unflag {
+		return fmt.Errorf("--flag and --unflag cannot be used together")
+	}
+	if f.read && f.unread {
+		return fmt.Errorf("--read and --unread cannot be used together")
+	}
+	if f.moveTo == "" && !f.flag && !f.unflag && !f.read && !f.unread &&
+		len(f.keywords) == 0 && len(f.removeKeywords) == 0 {
+		return fmt.Errorf("nothing to update: use --move-to, --flag, --unflag, --read, --unread, --keyword, or --remove-keyword")
+	}
+	return nil
+}
+
+// patch builds the update patch; mailboxID is the resolved --move-to target.
+func (f *emailUpdateFlags) patch(mailboxID string) jmap.EmailPatch {
+	patch := jmap.NewEmailPatch()
+	if mailboxID != "" {
+		patch.MoveTo(mailboxID)
+	}
+	if f.flag || f.unflag {
+		patch.Flag(f.flag)
+	}
+	if f.read || f.unread {
+		patch.Read(f.read)
+	}
+	for _, kw := range f.keywords {
+		patch.SetKeyword(kw, true)
+	}
+	for _, kw := range f.removeKeywords {
+		patch.SetKeyword(kw, false)
+	}
+	return patch
+}
+
+func newEmailUpdateCmd(app *App) *cobra.Command {
+	var flags emailUpdateFlags
+
+	cmd := &cobra.Command{
+		Use:   "update <emailId>...",
+		Short: "Move, flag, and mark emails in a single request",
+		Long: `Apply several changes to one or more emails in a single request.
+
+Changes are combined into one JMAP Email/set update, so moving an email and
+marking it flagged and read costs a single round-trip.`,
+		Example: `  fastmail email update <id> --move-to Archive --flag --read
+  fastmail email update <id1> <id2> --unread --keyword '$important'`,
+		Args: cobra.MinimumNArgs(1),
+		RunE: runE(app, func(cmd *cobra.Command, args []string, app *App) error {
+			if err := flags.validate(); err != nil {
+				return err
+			}
+
+			client, err := app.JMAPClient()
+			if err != nil {
+				return err
+			}
+
+			var mailboxID string
+			if flags.moveTo != "" {
+				mailboxID, err = client.ResolveMailboxID(cmd.Context(), flags.moveTo)
+				if err != nil {
+					return fmt.Errorf("invalid target mailbox: %w", err)
+				}
+			}
+
+			patch := flags.patch(mailboxID)
+			if err := jmap.ValidateEmailPatch(patch); err != nil {
+				return err
+			}
+
+			results, err := client.UpdateEmails(cmd.Context(), args, patch)
+			if err != nil {
+				return cerrors.WithContext(err, "updating emails")
+			}
+
+			if app.IsJSON(cmd.Context()) {
+				output := map[string]any{
+					"status":    "updated",
+					"succeeded": results.Succeeded,
+					"patch":     patch,
+				}
+				if len(results.Failed) > 0 {
+					output["failed"] = results.Failed
+				}
+				return app.PrintJSON(cmd, output)
+			}
+
+			if len(args) == 1 {
+				if msg, failed := results.Failed[args[0]]; failed {
+					return fmt.Errorf("failed to update email %s: %s", args[0], msg)
+				}
+				fmt.Printf("Email %s updated\n", args[0])
+				return nil
+			}
+
+			printBulkResults("Updated", "emails", len(results.Succeeded), len(results.Failed), results.Failed)
+			return nil
+		}),
+	}
+
+	cmd.Flags().StringVar(&flags.moveTo, "move-to", "", "Move to mailbox ID or name")
+	cmd.Flags().BoolVar(&flags.flag, "flag", false, "Mark as flagged")
+	cmd.Flags().BoolVar(&flags.unflag, "unflag", false, "Remove the flag")
+	cmd.Flags().BoolVar(&flags.read, "read", false, "Mark as read")
+	cmd.Flags().BoolVar(&flags.unread, "unread", false, "Mark as unread")
+	cmd.Flags().StringArrayVar(&flags.keywords, "keyword", nil, "Set a keyword (repeatable)")
+	cmd.Flags().StringArrayVar(&flags.removeKeywords, "remove-keyword", nil, "Remove a keyword (repeatable)")
+
+	return cmd
+}
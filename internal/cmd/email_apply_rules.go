@@ -0,0 +1,250 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/salmonumbrella/fastmail-cli/internal/config"
+	cerrors "github.com/salmonumbrella/fastmail-cli/internal/errors"
+	"github.com/salmonumbrella/fastmail-cli/internal/jmap"
+	"github.com/salmonumbrella/fastmail-cli/internal/outfmt"
+	"github.com/spf13/cobra"
+)
+
+func newEmailApplyRulesCmd(app *App) *cobra.Command {
+	var mailbox string
+	var limit int
+	var dryRun bool
+
+	cmd := &cobra.Command{
+		Use:   "apply-rules",
+		Short: "Move emails into folders using client-side sender/subject rules",
+		Long: `Apply client-side foldering rules to recent emails in a mailbox.
+
+Rules are read from rules.json in the fastmail-cli config directory
+(e.g. ~/.config/fastmail-cli/rules.json on Linux):
+
+  {
+    "rules": [
+      {"name": "receipts", "from": "@shop.example.com", "mailbox": "Receipts"},
+      {"subject": "^\\[ci\\]", "regex": true, "mailbox": "CI"},
+      {"from": "boss@example.com", "subject": "urgent", "mailbox": "Priority"}
+    ]
+  }
+
+"from" and "subject" are case-insensitive substrings, or regular expressions
+when "regex" is true. A rule with both requires both to match. Each email is
+moved by the first rule that matches it.
+
+Examples:
+  fastmail email apply-rules --dry-run
+  fastmail email apply-rules --mailbox inbox --limit 500`,
+		Args: cobra.NoArgs,
+		RunE: runE(app, func(cmd *cobra.Command, args []string, app *App) error {
+			rules, err := config.LoadRules()
+			if err != nil {
+				return err
+			}
+			if len(rules) == 0 {
+				path, _ := config.RulesPath() //nolint:errcheck // path is only for the hint
+				return cerrors.WithSuggestion(fmt.Errorf("no rules configured"), fmt.Sprintf("Create %s (see 'fastmail email apply-rules --help')", path))
+			}
+			matchers, err := compileRules(rules)
+			if err != nil {
+				return err
+			}
+
+			client, err := app.JMAPClient()
+			if err != nil {
+				return err
+			}
+
+			sourceID, err := client.ResolveMailboxID(cmd.Context(), mailbox)
+			if err != nil {
+				return fmt.Errorf("invalid mailbox: %w", err)
+			}
+
+			emails, err := client.GetEmails(cmd.Context(), sourceID, limit)
+			if err != nil {
+				return cerrors.WithContext(err, "listing emails")
+			}
+
+			results, err := applyRules(cmd.Context(), client, matchers, emails, sourceID, dryRun)
+			if err != nil {
+				return err
+			}
+
+			if app.IsJSON(cmd.Context()) {
+				return app.PrintJSON(cmd, map[string]any{
+					"mailbox": mailbox,
+					"dryRun":  dryRun,
+					"scanned": len(emails),
+					"rules":   results,
+				})
+			}
+
+			printRuleResults(results, len(emails), dryRun)
+			return nil
+		}),
+	}
+
+	cmd.Flags().StringVar(&mailbox, "mailbox", "inbox", "Mailbox to apply rules to (ID or name)")
+	cmd.Flags().IntVar(&limit, "limit", 100, "Maximum number of recent emails to check")
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "Show what would be moved without making changes")
+
+	return cmd
+}
+
+// ruleMatcher is a config.Rule with its patterns compiled.
+type ruleMatcher struct {
+	rule    config.Rule
+	from    func(string) bool
+	subject func(string) bool
+}
+
+// ruleResult reports what one rule did during apply-rules.
+type ruleResult struct {
+	Rule    string            `json:"rule"`
+	Mailbox string            `json:"mailbox"`
+	Matched []string          `json:"matched"`
+	Moved   int               `json:"moved"`
+	Failed  map[string]string `json:"failed,omitempty"`
+	Skipped int               `json:"skipped,omitempty"` // already in the target mailbox
+}
+
+func compileRules(rules []config.Rule) ([]ruleMatcher, error) {
+	matchers := make([]ruleMatcher, 0, len(rules))
+	for _, rule := range rules {
+		m := ruleMatcher{rule: rule}
+		var err error
+		if m.from, err = compilePattern(rule.From, rule.Regex); err != nil {
+			return nil, fmt.Errorf("rule %s: %w", rule.Label(), err)
+		}
+		if m.subject, err = compilePattern(rule.Subject, rule.Regex); err != nil {
+			return nil, fmt.Errorf("rule %s: %w", rule.Label(), err)
+		}
+		matchers = append(matchers, m)
+	}
+	return matchers, nil
+}
+
+// compilePattern returns a case-insensitive matcher, or nil for an empty pattern.
+func compilePattern(pattern string, isRegex bool) (func(string) bool, error) {
+	if pattern == "" {
+		return nil, nil
+	}
+	if isRegex {
+		re, err := regexp.Compile("(?i)" + pattern)
+		if err != nil {
+			return nil, fmt.Errorf("invalid regex %q: %w", pattern, err)
+		}
+		return re.MatchString, nil
+	}
+	needle := strings.ToLower(pattern)
+	return func(s string) bool {
+		return strings.Contains(strings.ToLower(s), needle)
+	}, nil
+}
+
+// matches reports whether the email satisfies every pattern the rule sets.
+// The sender pattern is tried against each From address as "Name <email>".
+func (m ruleMatcher) matches(email jmap.Email) bool {
+	if m.from != nil {
+		found := false
+		for _, addr := range email.From {
+			if m.from(fmt.Sprintf("%s <%s>", addr.Name, addr.Email)) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	if m.subject != nil && !m.subject(email.Subject) {
+		return false
+	}
+	return true
+}
+
+// applyRules assigns each email to the first matching rule and, unless
+// dryRun, moves each rule's matches to its mailbox.
+func applyRules(ctx context.Context, client jmap.EmailService, matchers []ruleMatcher, emails []jmap.Email, sourceID string, dryRun bool) ([]ruleResult, error) {
+	results := make([]ruleResult, len(matchers))
+	for i, m := range matchers {
+		results[i] = ruleResult{Rule: m.rule.Label(), Mailbox: m.rule.Mailbox, Matched: []string{}}
+	}
+
+	for _, email := range emails {
+		for i, m := range matchers {
+			if m.matches(email) {
+				results[i].Matched = append(results[i].Matched, email.ID)
+				break
+			}
+		}
+	}
+
+	for i := range results {
+		r := &results[i]
+		if len(r.Matched) == 0 {
+			continue
+		}
+
+		targetID, err := client.ResolveMailboxID(ctx, r.Mailbox)
+		if err != nil {
+			return nil, fmt.Errorf("rule %s: invalid mailbox: %w", r.Rule, err)
+		}
+		if targetID == sourceID {
+			r.Skipped = len(r.Matched)
+			continue
+		}
+		if dryRun {
+			continue
+		}
+
+		moved, err := client.MoveEmails(ctx, r.Matched, targetID)
+		if err != nil {
+			return nil, cerrors.WithContext(err, fmt.Sprintf("moving emails for rule %s", r.Rule))
+		}
+		r.Moved = len(moved.Succeeded)
+		if len(moved.Failed) > 0 {
+			r.Failed = moved.Failed
+		}
+	}
+
+	return results, nil
+}
+
+func printRuleResults(results []ruleResult, scanned int, dryRun bool) {
+	countHeader := "MOVED"
+	if dryRun {
+		countHeader = "WOULD MOVE"
+	}
+
+	tw := outfmt.NewTabWriter()
+	fmt.Fprintf(tw, "RULE\tMAILBOX\tMATCHED\t%s\tFAILED\n", countHeader)
+	total := 0
+	for _, r := range results {
+		count := r.Moved
+		if dryRun {
+			count = len(r.Matched) - r.Skipped
+		}
+		total += count
+		fmt.Fprintf(tw, "%s\t%s\t%d\t%d\t%d\n",
+			outfmt.SanitizeTab(r.Rule),
+			outfmt.SanitizeTab(r.Mailbox),
+			len(r.Matched),
+			count,
+			len(r.Failed),
+		)
+	}
+	tw.Flush()
+
+	if dryRun {
+		fmt.Printf("\nChecked %d emails; %d would be moved (dry run, no changes made)\n", scanned, total)
+		return
+	}
+	fmt.Printf("\nChecked %d emails; moved %d\n", scanned, total)
+}
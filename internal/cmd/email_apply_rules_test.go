@@ -0,0 +1,100 @@
+package cmd
+
+import (
+	"context"
+	"testing"
+
+	"github.com/salmonumbrella/fastmail-cli/internal/config"
+	"github.com/salmonumbrella/fastmail-cli/internal/jmap"
+)
+
+func TestRuleMatcher(t *testing.T) {
+	matchers, err := compileRules([]config.Rule{
+		{From: "@Shop.example.com", Mailbox: "Receipts"},
+		{Subject: `^\[ci\]`, Regex: true, Mailbox: "CI"},
+		{From: "boss", Subject: "urgent", Mailbox: "Priority"},
+	})
+	if err != nil {
+		t.Fatalf("compileRules: %v", err)
+	}
+
+	from := func(name, email string) []jmap.EmailAddress {
+		return []jmap.EmailAddress{{Name: name, Email: email}}
+	}
+
+	tests := []struct {
+		name  string
+		rule  int
+		email jmap.Email
+		want  bool
+	}{
+		{"substring case-insensitive", 0, jmap.Email{From: from("", "orders@shop.example.com")}, true},
+		{"substring no match", 0, jmap.Email{From: from("", "orders@other.example.com")}, false},
+		{"regex subject", 1, jmap.Email{Subject: "[CI] build failed"}, true},
+		{"regex anchored", 1, jmap.Email{Subject: "Re: [ci] build failed"}, false},
+		{"both required", 2, jmap.Email{From: from("The Boss", "b@example.com"), Subject: "Urgent: call me"}, true},
+		{"both required, one missing", 2, jmap.Email{From: from("The Boss", "b@example.com"), Subject: "lunch"}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := matchers[tt.rule].matches(tt.email); got != tt.want {
+				t.Errorf("matches() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestApplyRules(t *testing.T) {
+	matchers, err := compileRules([]config.Rule{
+		{Name: "receipts", From: "shop.example.com", Mailbox: "Receipts"},
+		{Name: "all-shop", From: "example.com", Mailbox: "Misc"},
+		{Name: "noop", Subject: "keep", Mailbox: "Inbox"},
+	})
+	if err != nil {
+		t.Fatalf("compileRules: %v", err)
+	}
+
+	emails := []jmap.Email{
+		{ID: "e1", From: []jmap.EmailAddress{{Email: "a@shop.example.com"}}},
+		{ID: "e2", From: []jmap.EmailAddress{{Email: "b@example.com"}}},
+		{ID: "e3", From: []jmap.EmailAddress{{Email: "c@elsewhere.org"}}, Subject: "keep me"},
+		{ID: "e4", From: []jmap.EmailAddress{{Email: "d@elsewhere.org"}}},
+	}
+
+	moves := map[string][]string{}
+	mock := &jmap.MockEmailService{
+		ResolveMailboxIDFunc: func(ctx context.Context, idOrName string) (string, error) {
+			return "mb-" + idOrName, nil
+		},
+		MoveEmailsFunc: func(ctx context.Context, ids []string, target string) (*jmap.BulkResult, error) {
+			moves[target] = ids
+			return &jmap.BulkResult{Succeeded: ids, Failed: map[string]string{}}, nil
+		},
+	}
+
+	results, err := applyRules(context.Background(), mock, matchers, emails, "mb-Inbox", false)
+	if err != nil {
+		t.Fatalf("applyRules: %v", err)
+	}
+
+	if got := moves["mb-Receipts"]; len(got) != 1 || got[0] != "e1" {
+		t.Errorf("Receipts moves = %v, want [e1] (first matching rule wins)", got)
+	}
+	if got := moves["mb-Misc"]; len(got) != 1 || got[0] != "e2" {
+		t.Errorf("Misc moves = %v, want [e2]", got)
+	}
+	if _, moved := moves["mb-Inbox"]; moved {
+		t.Error("rule targeting the source mailbox should not move")
+	}
+	if results[0].Moved != 1 || results[1].Moved != 1 || results[2].Skipped != 1 {
+		t.Errorf("unexpected results: %+v", results)
+	}
+
+	moves = map[string][]string{}
+	if _, err := applyRules(context.Background(), mock, matchers, emails, "mb-Inbox", true); err != nil {
+		t.Fatalf("applyRules dry run: %v", err)
+	}
+	if len(moves) != 0 {
+		t.Errorf("dry run moved emails: %v", moves)
+	}
+}
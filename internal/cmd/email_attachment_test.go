@@ -12,6 +12,7 @@ func TestParseAttachmentFlag(t *testing.T) {
 		input    string
 		wantPath string
 		wantName string
+		wantDisp string
 		wantErr  bool
 	}{
 		{
@@ -66,6 +67,59 @@ func TestParseAttachmentFlag(t *testing.T) {
 			wantName: "file.pdf",
 			wantErr:  false,
 		},
+		{
+			name:     "inline disposition",
+			input:    "logo.png:logo.png:inline",
+			wantPath: "logo.png",
+			wantName: "logo.png",
+			wantDisp: "inline",
+		},
+		{
+			name:     "disposition with default name",
+			input:    "/path/to/logo.png::INLINE",
+			wantPath: "/path/to/logo.png",
+			wantName: "logo.png",
+			wantDisp: "inline",
+		},
+		{
+			name:     "explicit attachment disposition",
+			input:    "file.pdf:Report.pdf:attachment",
+			wantPath: "file.pdf",
+			wantName: "Report.pdf",
+			wantDisp: "attachment",
+		},
+		{
+			name:     "colons in path before name and disposition",
+			input:    "/tmp/a:b.png:logo.png:inline",
+			wantPath: "/tmp/a:b.png",
+			wantName: "logo.png",
+			wantDisp: "inline",
+		},
+		{
+			name:     "windows path with disposition",
+			input:    "C:\\Users\\test\\logo.png:logo.png:inline",
+			wantPath: "C:\\Users\\test\\logo.png",
+			wantName: "logo.png",
+			wantDisp: "inline",
+		},
+		{
+			name:     "windows path without name",
+			input:    "C:/Users/test/file.pdf",
+			wantPath: "C:/Users/test/file.pdf",
+			wantName: "file.pdf",
+		},
+		{
+			name:     "colons in path before name",
+			input:    "/tmp/a:b:name.pdf",
+			wantPath: "/tmp/a:b",
+			wantName: "name.pdf",
+		},
+		{
+			name:     "unknown last field is the name",
+			input:    "file.pdf:Report.pdf:embedded",
+			wantPath: "file.pdf:Report.pdf",
+			wantName: "embedded",
+		},
 		{
 			name:     "empty input",
 			input:    "",
@@ -77,7 +131,7 @@ func TestParseAttachmentFlag(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			path, name, err := format.ParseAttachmentFlag(tt.input)
+			path, name, disposition, err := format.ParseAttachmentFlag(tt.input)
 			if tt.wantErr {
 				if err == nil {
 					t.Errorf("expected error but got none")
@@ -94,6 +148,13 @@ func TestParseAttachmentFlag(t *testing.T) {
 			if name != tt.wantName {
 				t.Errorf("name = %s, want %s", name, tt.wantName)
 			}
+			wantDisp := tt.wantDisp
+			if wantDisp == "" {
+				wantDisp = format.DispositionAttachment
+			}
+			if disposition != wantDisp {
+				t.Errorf("disposition = %s, want %s", disposition, wantDisp)
+			}
 		})
 	}
 }
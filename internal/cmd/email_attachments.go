@@ -1,14 +1,17 @@
 package cmd
 
 import (
+	"bytes"
 	"fmt"
 	"io"
 	"os"
+	"time"
 
 	cerrors "github.com/salmonumbrella/fastmail-cli/internal/errors"
 	"github.com/salmonumbrella/fastmail-cli/internal/format"
 	"github.com/salmonumbrella/fastmail-cli/internal/jmap"
 	"github.com/salmonumbrella/fastmail-cli/internal/outfmt"
+	"github.com/salmonumbrella/fastmail-cli/internal/ui"
 	"github.com/spf13/cobra"
 )
 
@@ -63,6 +66,8 @@ func newEmailAttachmentsCmd(app *App) *cobra.Command {
 func newEmailDownloadCmd(app *App) *cobra.Command {
 	var downloadAll bool
 	var outputDir string
+	var preserveDate bool
+	var resume bool
 
 	cmd := &cobra.Command{
 		Use:     "download <emailId> [blobId] [output-file]",
@@ -76,6 +81,16 @@ Without --all: Download a specific attachment by blob ID.
 If output-file is not specified, attachments are saved with their original names.
 Use --dir to specify the output directory (created if it doesn't exist).
 
+A download is written to <output-file>.part and renamed when complete, and
+an existing output file is never overwritten. If a download is interrupted,
+run the same command with --resume to continue from the .part file; the end
+of the partial file is checked against the attachment first, and a mismatch
+is an error. Without --resume a leftover .part file is started over. A
+progress bar is shown on stderr when attached to a terminal.
+
+With --preserve-date, downloaded files get the email's received date as their
+modification time, so archives sort chronologically.
+
 Examples:
   # Download all attachments from an email to a directory
   fastmail email download ABC123 --all --dir ~/Downloads/attachments/
@@ -94,9 +109,14 @@ Examples:
 
 			emailID := args[0]
 
+			var mtime time.Time
+			if preserveDate {
+				mtime = emailReceivedTime(cmd, client, emailID)
+			}
+
 			// Handle --all flag: download all attachments
 			if downloadAll {
-				return downloadAllAttachments(cmd, client, app, emailID, outputDir)
+				return downloadAllAttachments(cmd, client, app, emailID, outputDir, mtime)
 			}
 
 			// Single attachment download requires blobId
@@ -105,30 +125,34 @@ Examples:
 			}
 
 			blobID := args[1]
-			var outputFile string
 
-			// Determine output file path
-			if len(args) < 3 {
-				var attachments []jmap.Attachment
-				attachments, err = client.GetEmailAttachments(cmd.Context(), emailID)
-				if err != nil {
-					return fmt.Errorf("failed to get attachments: %w", err)
-				}
+			attachments, err := client.GetEmailAttachments(cmd.Context(), emailID)
+			if err != nil {
+				return fmt.Errorf("failed to get attachments: %w", err)
+			}
 
-				// Find the attachment with matching blob ID
-				var found bool
-				for _, att := range attachments {
-					if att.BlobID == blobID {
-						outputFile = att.Name
-						found = true
-						break
-					}
+			// Find the attachment with matching blob ID; its size enables resume and progress
+			var att *jmap.Attachment
+			for i := range attachments {
+				if attachments[i].BlobID == blobID {
+					att = &attachments[i]
+					break
 				}
+			}
 
-				if !found {
+			var outputFile string
+			var size int64
+			if att != nil {
+				size = att.Size
+			}
+
+			// Determine output file path
+			if len(args) < 3 {
+				if att == nil {
 					return fmt.Errorf("blob ID '%s' not found in email '%s'", blobID, emailID)
 				}
 
+				outputFile = att.Name
 				if outputFile == "" {
 					outputFile = "attachment"
 				}
@@ -147,18 +171,21 @@ Examples:
 				outputFile = args[2]
 			}
 
-			return downloadSingleAttachment(cmd, client, app, emailID, blobID, outputFile)
+			return downloadSingleAttachment(cmd, client, app, emailID, blobID, outputFile, size, mtime, resume)
 		}),
 	}
 
 	cmd.Flags().BoolVarP(&downloadAll, "all", "a", false, "Download all attachments from the email")
 	cmd.Flags().StringVarP(&outputDir, "dir", "d", "", "Output directory for downloaded files (created if it doesn't exist)")
+	cmd.Flags().BoolVar(&preserveDate, "preserve-date", false, "Set each file's modification time to the email's received date")
+	cmd.Flags().BoolVar(&resume, "resume", false, "Continue an interrupted download from its .part file")
 
 	return cmd
 }
 
-// downloadAllAttachments downloads all attachments from an email
-func downloadAllAttachments(cmd *cobra.Command, client jmap.EmailService, app *App, emailID, outputDir string) error {
+// downloadAllAttachments downloads all attachments from an email. A non-zero
+// mtime is applied to each downloaded file.
+func downloadAllAttachments(cmd *cobra.Command, client jmap.EmailService, app *App, emailID, outputDir string, mtime time.Time) error {
 	attachments, err := client.GetEmailAttachments(cmd.Context(), emailID)
 	if err != nil {
 		return fmt.Errorf("failed to get attachments: %w", err)
@@ -266,6 +293,7 @@ func downloadAllAttachments(cmd *cobra.Command, client jmap.EmailService, app *A
 			}
 			continue
 		}
+		setFileDate(cmd, outputFile, mtime)
 
 		results = append(results, map[string]any{
 			"blobId":     att.BlobID,
@@ -293,42 +321,155 @@ func downloadAllAttachments(cmd *cobra.Command, client jmap.EmailService, app *A
 	return nil
 }
 
-// downloadSingleAttachment downloads a single attachment by blob ID
-func downloadSingleAttachment(cmd *cobra.Command, client jmap.EmailService, app *App, emailID, blobID, outputFile string) error {
-	// Check if file already exists
+// resumeOverlap is how many bytes before the end of a partial download are
+// fetched again on resume and compared with the file, to make sure it really
+// holds the start of the attachment.
+const resumeOverlap = 4096
+
+// downloadSingleAttachment downloads a single attachment by blob ID. The
+// data goes to outputFile + ".part", which is renamed to outputFile once
+// complete; an existing outputFile is refused. With resume, a .part file
+// shorter than the attachment (when size is known) is continued, provided
+// its last bytes match the attachment; otherwise the download starts over.
+// A non-zero mtime is applied once the file is complete.
+func downloadSingleAttachment(cmd *cobra.Command, client jmap.EmailService, app *App, emailID, blobID, outputFile string, size int64, mtime time.Time, resume bool) error {
 	if _, statErr := os.Stat(outputFile); statErr == nil {
 		return fmt.Errorf("file '%s' already exists. Specify a different output file", outputFile)
 	}
 
-	// Download the blob
-	reader, err := client.DownloadBlob(cmd.Context(), blobID)
+	writePath := outputFile + ".part"
+	var offset int64
+	if info, statErr := os.Stat(writePath); resume && statErr == nil && size > 0 && info.Size() < size {
+		offset = info.Size()
+	}
+
+	// Download the blob; a resume starts resumeOverlap bytes early so the
+	// partial file can be checked
+	var reader io.ReadCloser
+	var err error
+	if offset > 0 {
+		reader, err = client.DownloadBlobRange(cmd.Context(), blobID, offset-min(offset, resumeOverlap))
+	} else {
+		reader, err = client.DownloadBlob(cmd.Context(), blobID)
+	}
 	if err != nil {
 		return cerrors.WithContext(err, "downloading attachment")
 	}
 	defer reader.Close()
 
-	// Create output file
-	outFile, err := os.Create(outputFile)
+	if offset > 0 {
+		if err := checkPartialDownload(writePath, offset, reader); err != nil {
+			return err
+		}
+	}
+
+	// Create the file, or append to the partial one
+	var outFile *os.File
+	if offset > 0 {
+		outFile, err = os.OpenFile(writePath, os.O_WRONLY|os.O_APPEND, 0o600)
+	} else {
+		outFile, err = os.Create(writePath)
+	}
 	if err != nil {
 		return fmt.Errorf("failed to create output file: %w", err)
 	}
 	defer outFile.Close()
 
+	var dst io.Writer = outFile
+	var progress *downloadProgress
+	if size > 0 && !app.IsJSON(cmd.Context()) && stderrIsTerminal() {
+		progress = newDownloadProgress(os.Stderr, "Downloading", offset, size)
+		dst = io.MultiWriter(outFile, progress)
+	}
+
 	// Copy content
-	written, err := io.Copy(outFile, reader)
+	written, err := io.Copy(dst, reader)
+	if progress != nil {
+		progress.finish()
+	}
 	if err != nil {
 		return fmt.Errorf("failed to write attachment: %w", err)
 	}
+	if err := outFile.Close(); err != nil {
+		return fmt.Errorf("failed to write attachment: %w", err)
+	}
+	if err := os.Rename(writePath, outputFile); err != nil {
+		return fmt.Errorf("failed to move download into place: %w", err)
+	}
+	setFileDate(cmd, outputFile, mtime)
+	total := offset + written
 
 	if app.IsJSON(cmd.Context()) {
-		return app.PrintJSON(cmd, map[string]any{
+		out := map[string]any{
 			"emailId":    emailID,
 			"blobId":     blobID,
 			"outputFile": outputFile,
-			"size":       written,
-		})
+			"size":       total,
+		}
+		if offset > 0 {
+			out["resumedFrom"] = offset
+		}
+		return app.PrintJSON(cmd, out)
 	}
 
-	fmt.Printf("Downloaded attachment to %s (%s)\n", outputFile, format.FormatBytes(written))
+	if offset > 0 {
+		fmt.Printf("Resumed download of %s from %s (%s total)\n", outputFile, format.FormatBytes(offset), format.FormatBytes(total))
+		return nil
+	}
+	fmt.Printf("Downloaded attachment to %s (%s)\n", outputFile, format.FormatBytes(total))
 	return nil
 }
+
+// checkPartialDownload reads the overlap that precedes offset from r, the
+// attachment stream, and compares it with the end of the partial file at
+// path. It leaves r positioned at offset.
+func checkPartialDownload(path string, offset int64, r io.Reader) error {
+	overlap := min(offset, resumeOverlap)
+	fromServer := make([]byte, overlap)
+	if _, err := io.ReadFull(r, fromServer); err != nil {
+		return fmt.Errorf("failed to read attachment: %w", err)
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("failed to read partial download: %w", err)
+	}
+	defer f.Close()
+	onDisk := make([]byte, overlap)
+	if _, err := f.ReadAt(onDisk, offset-overlap); err != nil {
+		return fmt.Errorf("failed to read partial download: %w", err)
+	}
+
+	if !bytes.Equal(fromServer, onDisk) {
+		return fmt.Errorf("'%s' does not match the attachment; delete it or run without --resume to download again", path)
+	}
+	return nil
+}
+
+// emailReceivedTime returns when an email was received, for --preserve-date.
+// On failure it warns and returns the zero time, leaving files with their
+// default modification time.
+func emailReceivedTime(cmd *cobra.Command, client jmap.EmailService, emailID string) time.Time {
+	email, err := client.GetEmailByID(cmd.Context(), emailID)
+	if err != nil {
+		ui.FromContext(cmd.Context()).Warning(fmt.Sprintf("Warning: --preserve-date ignored: %v", err))
+		return time.Time{}
+	}
+	received, err := time.Parse(time.RFC3339, email.ReceivedAt)
+	if err != nil {
+		ui.FromContext(cmd.Context()).Warning(fmt.Sprintf("Warning: --preserve-date ignored: unrecognized received date %q", email.ReceivedAt))
+		return time.Time{}
+	}
+	return received
+}
+
+// setFileDate sets path's access and modification times to mtime, unless
+// mtime is zero. Failures only warn: the download itself succeeded.
+func setFileDate(cmd *cobra.Command, path string, mtime time.Time) {
+	if mtime.IsZero() {
+		return
+	}
+	if err := os.Chtimes(path, mtime, mtime); err != nil {
+		ui.FromContext(cmd.Context()).Warning(fmt.Sprintf("Warning: could not set date on %s: %v", path, err))
+	}
+}
@@ -8,6 +8,7 @@ import (
 	"os"
 	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/salmonumbrella/fastmail-cli/internal/jmap"
 	"github.com/salmonumbrella/fastmail-cli/internal/outfmt"
@@ -46,7 +47,7 @@ func TestDownloadAllAttachments_JSONMode_IsSingleJSONDocument(t *testing.T) {
 	cmd.SetContext(ctx)
 
 	stdout := captureStdout(t, func() {
-		if err := downloadAllAttachments(cmd, mock, app, emailID, tmp); err != nil {
+		if err := downloadAllAttachments(cmd, mock, app, emailID, tmp, time.Time{}); err != nil {
 			t.Fatalf("downloadAllAttachments returned error: %v", err)
 		}
 	})
@@ -66,3 +67,131 @@ func TestDownloadAllAttachments_JSONMode_IsSingleJSONDocument(t *testing.T) {
 		t.Fatalf("expected attachment file to exist at %s: %v", wantPath, err)
 	}
 }
+
+func TestDownloadSingleAttachment_ResumesPartialFile(t *testing.T) {
+	outputFile := filepath.Join(t.TempDir(), "report.txt")
+	if err := os.WriteFile(outputFile+".part", []byte("hel"), 0o600); err != nil {
+		t.Fatalf("write partial file: %v", err)
+	}
+
+	gotOffset := int64(-1)
+	mock := &jmap.MockEmailService{
+		DownloadBlobFunc: func(ctx context.Context, blobID string) (io.ReadCloser, error) {
+			t.Fatal("expected ranged download for partial file")
+			return nil, nil
+		},
+		DownloadBlobRangeFunc: func(ctx context.Context, blobID string, offset int64) (io.ReadCloser, error) {
+			gotOffset = offset
+			return io.NopCloser(bytes.NewBufferString("hello"[offset:])), nil
+		},
+	}
+
+	app := &App{Flags: &rootFlags{}}
+	cmd := &cobra.Command{}
+	ctx := context.WithValue(context.Background(), outputModeKey, outfmt.JSON)
+	cmd.SetContext(ctx)
+
+	stdout := captureStdout(t, func() {
+		if err := downloadSingleAttachment(cmd, mock, app, "E1", "B1", outputFile, 5, time.Time{}, true); err != nil {
+			t.Fatalf("downloadSingleAttachment returned error: %v", err)
+		}
+	})
+
+	// The whole partial file is short enough to be fetched again and checked
+	if gotOffset != 0 {
+		t.Fatalf("offset = %d, want 0", gotOffset)
+	}
+	data, err := os.ReadFile(outputFile)
+	if err != nil {
+		t.Fatalf("read output: %v", err)
+	}
+	if string(data) != "hello" {
+		t.Fatalf("file content = %q, want %q", data, "hello")
+	}
+	if _, err := os.Stat(outputFile + ".part"); !os.IsNotExist(err) {
+		t.Fatalf(".part file should be renamed away, stat err = %v", err)
+	}
+
+	var payload map[string]any
+	if err := json.Unmarshal([]byte(stdout), &payload); err != nil {
+		t.Fatalf("stdout is not valid JSON: %v; stdout=%q", err, stdout)
+	}
+	if payload["resumedFrom"] != float64(3) || payload["size"] != float64(5) {
+		t.Fatalf("unexpected payload: %v", payload)
+	}
+}
+
+func TestDownloadSingleAttachment_ResumeRejectsMismatchedPartialFile(t *testing.T) {
+	outputFile := filepath.Join(t.TempDir(), "report.txt")
+	if err := os.WriteFile(outputFile+".part", []byte("abc"), 0o600); err != nil {
+		t.Fatalf("write partial file: %v", err)
+	}
+
+	mock := &jmap.MockEmailService{
+		DownloadBlobRangeFunc: func(ctx context.Context, blobID string, offset int64) (io.ReadCloser, error) {
+			return io.NopCloser(bytes.NewBufferString("hello"[offset:])), nil
+		},
+	}
+
+	app := &App{Flags: &rootFlags{}}
+	cmd := &cobra.Command{}
+	cmd.SetContext(context.Background())
+
+	if err := downloadSingleAttachment(cmd, mock, app, "E1", "B1", outputFile, 5, time.Time{}, true); err == nil {
+		t.Fatal("expected error for a partial file that is not a prefix of the attachment")
+	}
+	if data, _ := os.ReadFile(outputFile + ".part"); string(data) != "abc" {
+		t.Fatalf("partial file changed to %q", data)
+	}
+	if _, err := os.Stat(outputFile); !os.IsNotExist(err) {
+		t.Fatalf("output file should not exist, stat err = %v", err)
+	}
+}
+
+func TestDownloadSingleAttachment_WithoutResumeStartsOver(t *testing.T) {
+	outputFile := filepath.Join(t.TempDir(), "report.txt")
+	if err := os.WriteFile(outputFile+".part", []byte("abc"), 0o600); err != nil {
+		t.Fatalf("write partial file: %v", err)
+	}
+
+	mock := &jmap.MockEmailService{
+		DownloadBlobFunc: func(ctx context.Context, blobID string) (io.ReadCloser, error) {
+			return io.NopCloser(bytes.NewBufferString("hello")), nil
+		},
+		DownloadBlobRangeFunc: func(ctx context.Context, blobID string, offset int64) (io.ReadCloser, error) {
+			t.Fatal("expected a full download without --resume")
+			return nil, nil
+		},
+	}
+
+	app := &App{Flags: &rootFlags{}}
+	cmd := &cobra.Command{}
+	cmd.SetContext(context.Background())
+
+	captureStdout(t, func() {
+		if err := downloadSingleAttachment(cmd, mock, app, "E1", "B1", outputFile, 5, time.Time{}, false); err != nil {
+			t.Fatalf("downloadSingleAttachment returned error: %v", err)
+		}
+	})
+	if data, _ := os.ReadFile(outputFile); string(data) != "hello" {
+		t.Fatalf("file content = %q, want %q", data, "hello")
+	}
+}
+
+func TestDownloadSingleAttachment_ExistingFileRejected(t *testing.T) {
+	outputFile := filepath.Join(t.TempDir(), "report.txt")
+	if err := os.WriteFile(outputFile, []byte("hel"), 0o600); err != nil {
+		t.Fatalf("write file: %v", err)
+	}
+
+	app := &App{Flags: &rootFlags{}}
+	cmd := &cobra.Command{}
+	cmd.SetContext(context.Background())
+
+	if err := downloadSingleAttachment(cmd, &jmap.MockEmailService{}, app, "E1", "B1", outputFile, 5, time.Time{}, true); err == nil {
+		t.Fatal("expected error for an existing output file")
+	}
+	if data, _ := os.ReadFile(outputFile); string(data) != "hel" {
+		t.Fatalf("existing file changed to %q", data)
+	}
+}
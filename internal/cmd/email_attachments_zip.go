@@ -0,0 +1,172 @@
+package cmd
+
+import (
+	"archive/zip"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/salmonumbrella/fastmail-cli/internal/format"
+	"github.com/salmonumbrella/fastmail-cli/internal/jmap"
+	"github.com/spf13/cobra"
+)
+
+func newEmailAttachmentsZipCmd(app *App) *cobra.Command {
+	var includeInline bool
+
+	cmd := &cobra.Command{
+		Use:   "attachments-zip <emailId> [output.zip]",
+		Short: "Download every attachment of an email into a zip archive",
+		Long: `Download every attachment of an email into a single zip archive.
+
+Each attachment is streamed into the archive under its sanitized original
+name; duplicate names get " (1)", " (2)", ... before the extension. The
+archive defaults to <emailId>-attachments.zip and is never overwritten.
+
+Inline images (embedded in the HTML body via Content-ID) are skipped unless
+--include-inline is given.
+
+Examples:
+  fastmail email attachments-zip ABC123
+  fastmail email attachments-zip ABC123 invoices.zip --include-inline`,
+		Args: cobra.RangeArgs(1, 2),
+		RunE: runE(app, func(cmd *cobra.Command, args []string, app *App) error {
+			emailID := args[0]
+			outputFile := format.SanitizeFilename(emailID) + "-attachments.zip"
+			if len(args) > 1 {
+				outputFile = args[1]
+			}
+
+			client, err := app.JMAPClient()
+			if err != nil {
+				return err
+			}
+			return zipEmailAttachments(cmd, client, app, emailID, outputFile, includeInline)
+		}),
+	}
+
+	cmd.Flags().BoolVar(&includeInline, "include-inline", false, "Also include inline images")
+
+	return cmd
+}
+
+// zipEmailAttachments implements email attachments-zip.
+func zipEmailAttachments(cmd *cobra.Command, client jmap.EmailService, app *App, emailID, outputFile string, includeInline bool) error {
+	attachments, err := client.GetEmailAttachments(cmd.Context(), emailID)
+	if err != nil {
+		return fmt.Errorf("failed to get attachments: %w", err)
+	}
+
+	var selected []jmap.Attachment
+	skipped := 0
+	for _, att := range attachments {
+		if att.IsInline() && !includeInline {
+			skipped++
+			continue
+		}
+		selected = append(selected, att)
+	}
+
+	if len(selected) == 0 {
+		if app.IsJSON(cmd.Context()) {
+			return app.PrintJSON(cmd, map[string]any{
+				"emailId": emailID,
+				"files":   []map[string]any{},
+				"skipped": skipped,
+			})
+		}
+		if skipped > 0 {
+			printNoResults(fmt.Sprintf("No attachments to archive (%d inline skipped, use --include-inline to include)", skipped))
+		} else {
+			printNoResults("No attachments to archive")
+		}
+		return nil
+	}
+
+	files, err := writeAttachmentsZip(cmd, client, selected, outputFile)
+	if err != nil {
+		return err
+	}
+
+	info, err := os.Stat(outputFile)
+	if err != nil {
+		return fmt.Errorf("failed to stat archive: %w", err)
+	}
+
+	if app.IsJSON(cmd.Context()) {
+		return app.PrintJSON(cmd, map[string]any{
+			"emailId":    emailID,
+			"outputFile": outputFile,
+			"files":      files,
+			"skipped":    skipped,
+			"size":       info.Size(),
+		})
+	}
+
+	fmt.Printf("Wrote %s (%d file(s), %s)", outputFile, len(files), format.FormatBytes(info.Size()))
+	if skipped > 0 {
+		fmt.Printf("; skipped %d inline", skipped)
+	}
+	fmt.Println()
+	return nil
+}
+
+// writeAttachmentsZip streams each attachment into a new zip archive at
+// outputFile. Any failure removes the partial archive.
+func writeAttachmentsZip(cmd *cobra.Command, client jmap.EmailService, attachments []jmap.Attachment, outputFile string) (files []map[string]any, err error) {
+	outFile, err := os.OpenFile(outputFile, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o600)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create archive: %w", err)
+	}
+	defer func() {
+		if closeErr := outFile.Close(); err == nil && closeErr != nil {
+			err = fmt.Errorf("failed to write archive: %w", closeErr)
+		}
+		if err != nil {
+			_ = os.Remove(outputFile)
+		}
+	}()
+
+	zw := zip.NewWriter(outFile)
+	taken := make(map[string]bool)
+	files = []map[string]any{}
+
+	for _, att := range attachments {
+		name := format.SanitizeFilename(att.Name)
+		if name == "" {
+			name = "attachment"
+		}
+		name = uniqueName(name, func(n string) bool { return taken[n] })
+		taken[name] = true
+
+		written, err := addBlobToZip(cmd, client, zw, att.BlobID, name)
+		if err != nil {
+			return nil, fmt.Errorf("failed to add %s to archive: %w", att.Name, err)
+		}
+		files = append(files, map[string]any{
+			"blobId": att.BlobID,
+			"name":   name,
+			"size":   written,
+		})
+	}
+
+	if err := zw.Close(); err != nil {
+		return nil, fmt.Errorf("failed to write archive: %w", err)
+	}
+	return files, nil
+}
+
+// addBlobToZip downloads a blob straight into a new archive entry.
+func addBlobToZip(cmd *cobra.Command, client jmap.EmailService, zw *zip.Writer, blobID, name string) (int64, error) {
+	reader, err := client.DownloadBlob(cmd.Context(), blobID)
+	if err != nil {
+		return 0, err
+	}
+	defer reader.Close()
+
+	entry, err := zw.Create(name)
+	if err != nil {
+		return 0, err
+	}
+	return io.Copy(entry, reader)
+}
@@ -0,0 +1,135 @@
+package cmd
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/salmonumbrella/fastmail-cli/internal/jmap"
+	"github.com/salmonumbrella/fastmail-cli/internal/outfmt"
+	"github.com/spf13/cobra"
+)
+
+func TestZipEmailAttachments(t *testing.T) {
+	mock := &jmap.MockEmailService{
+		GetEmailAttachmentsFunc: func(ctx context.Context, id string) ([]jmap.Attachment, error) {
+			return []jmap.Attachment{
+				{BlobID: "B1", Name: "scan.pdf", Type: "application/pdf"},
+				{BlobID: "B2", Name: "../scan.pdf", Type: "application/pdf"},
+				{BlobID: "B3", Name: "logo.png", Type: "image/png", ContentID: "logo@x"},
+			}, nil
+		},
+		DownloadBlobFunc: func(ctx context.Context, blobID string) (io.ReadCloser, error) {
+			return io.NopCloser(bytes.NewBufferString("data-" + blobID)), nil
+		},
+	}
+
+	run := func(outputFile string, inline bool) map[string]any {
+		app := newTestApp()
+		cmd := &cobra.Command{}
+		cmd.SetContext(context.WithValue(context.Background(), outputModeKey, outfmt.JSON))
+		out := captureStdout(t, func() {
+			if err := zipEmailAttachments(cmd, mock, app, "E1", outputFile, inline); err != nil {
+				t.Fatalf("zipEmailAttachments: %v", err)
+			}
+		})
+		var payload map[string]any
+		if err := json.Unmarshal([]byte(out), &payload); err != nil {
+			t.Fatalf("invalid JSON: %v; %q", err, out)
+		}
+		return payload
+	}
+
+	dir := t.TempDir()
+	outputFile := filepath.Join(dir, "out.zip")
+	payload := run(outputFile, false)
+
+	if payload["skipped"] != float64(1) {
+		t.Errorf("skipped = %v, want inline image skipped", payload["skipped"])
+	}
+	info, err := os.Stat(outputFile)
+	if err != nil {
+		t.Fatalf("stat archive: %v", err)
+	}
+	if payload["size"] != float64(info.Size()) {
+		t.Errorf("size = %v, want %d", payload["size"], info.Size())
+	}
+
+	zr, err := zip.OpenReader(outputFile)
+	if err != nil {
+		t.Fatalf("open archive: %v", err)
+	}
+	defer zr.Close()
+
+	want := map[string]string{"scan.pdf": "data-B1", "scan (1).pdf": "data-B2"}
+	if len(zr.File) != len(want) {
+		t.Fatalf("archive has %d entries, want %d", len(zr.File), len(want))
+	}
+	for _, f := range zr.File {
+		rc, err := f.Open()
+		if err != nil {
+			t.Fatalf("open %s: %v", f.Name, err)
+		}
+		data, _ := io.ReadAll(rc)
+		rc.Close()
+		if want[f.Name] != string(data) {
+			t.Errorf("entry %q = %q, want %q", f.Name, data, want[f.Name])
+		}
+	}
+
+	payload = run(filepath.Join(dir, "inline.zip"), true)
+	if files := payload["files"].([]any); len(files) != 3 {
+		t.Errorf("files with --include-inline = %v, want 3", files)
+	}
+}
+
+func TestZipEmailAttachments_FailureRemovesArchive(t *testing.T) {
+	mock := &jmap.MockEmailService{
+		GetEmailAttachmentsFunc: func(ctx context.Context, id string) ([]jmap.Attachment, error) {
+			return []jmap.Attachment{{BlobID: "B1", Name: "a.txt"}, {BlobID: "B2", Name: "b.txt"}}, nil
+		},
+		DownloadBlobFunc: func(ctx context.Context, blobID string) (io.ReadCloser, error) {
+			if blobID == "B2" {
+				return nil, errors.New("boom")
+			}
+			return io.NopCloser(bytes.NewBufferString("ok")), nil
+		},
+	}
+
+	outputFile := filepath.Join(t.TempDir(), "out.zip")
+	cmd := &cobra.Command{}
+	cmd.SetContext(context.Background())
+	if err := zipEmailAttachments(cmd, mock, newTestApp(), "E1", outputFile, false); err == nil {
+		t.Fatal("expected error when a download fails")
+	}
+	if _, err := os.Stat(outputFile); !os.IsNotExist(err) {
+		t.Errorf("partial archive left behind: %v", err)
+	}
+}
+
+func TestZipEmailAttachments_DoesNotOverwrite(t *testing.T) {
+	outputFile := filepath.Join(t.TempDir(), "out.zip")
+	if err := os.WriteFile(outputFile, []byte("keep"), 0o600); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	mock := &jmap.MockEmailService{
+		GetEmailAttachmentsFunc: func(ctx context.Context, id string) ([]jmap.Attachment, error) {
+			return []jmap.Attachment{{BlobID: "B1", Name: "a.txt"}}, nil
+		},
+	}
+
+	cmd := &cobra.Command{}
+	cmd.SetContext(context.Background())
+	if err := zipEmailAttachments(cmd, mock, newTestApp(), "E1", outputFile, false); err == nil {
+		t.Fatal("expected error for existing output file")
+	}
+	if data, _ := os.ReadFile(outputFile); string(data) != "keep" {
+		t.Errorf("existing file was modified: %q", data)
+	}
+}
@@ -1,6 +1,7 @@
 package cmd
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/spf13/cobra"
@@ -11,8 +12,9 @@ func TestEmailBulkDeleteCmd_RequiresArgs(t *testing.T) {
 	app := newTestApp()
 	cmd := newEmailBulkDeleteCmd(app)
 
-	// Set args to empty (no email IDs provided)
+	// Set args to empty (no email IDs provided, nothing on stdin)
 	cmd.SetArgs([]string{})
+	cmd.SetIn(strings.NewReader(""))
 
 	// Execute should fail because bulk-delete requires at least 1 email ID
 	err := cmd.Execute()
@@ -21,7 +23,7 @@ func TestEmailBulkDeleteCmd_RequiresArgs(t *testing.T) {
 	}
 
 	// Verify the error is related to args validation
-	// Cobra's MinimumNArgs returns an error like "requires at least 1 arg(s), only received 0"
+	// readIDArgs returns an error like "requires at least 1 arg(s): pass IDs ..."
 	expectedErrPattern := "requires at least 1 arg"
 	if err != nil && !contains(err.Error(), expectedErrPattern) {
 		t.Errorf("expected error containing %q, got: %v", expectedErrPattern, err)
@@ -64,10 +66,10 @@ func TestEmailBulkDeleteCmd_AcceptsMultipleArgs(t *testing.T) {
 		t.Errorf("expected Args validator to accept multiple args, got error: %v", err)
 	}
 
-	// Test with 0 args - should fail validation
+	// Test with 0 args - should pass validation (IDs may come from stdin)
 	err = argsValidator(cmd, []string{})
-	if err == nil {
-		t.Error("expected Args validator to reject 0 args, got nil error")
+	if err != nil {
+		t.Errorf("expected Args validator to accept 0 args, got error: %v", err)
 	}
 }
 
@@ -130,7 +132,7 @@ func TestEmailBulkDeleteCmd_CommandMetadata(t *testing.T) {
 		t.Error("expected RunE function to be set")
 	}
 
-	// Verify it's using MinimumNArgs(1)
+	// Verify it's using ArbitraryArgs (IDs may come from stdin)
 	if cmd.Args == nil {
 		t.Error("expected Args validator to be set")
 	}
@@ -139,8 +141,8 @@ func TestEmailBulkDeleteCmd_CommandMetadata(t *testing.T) {
 	if err := cmd.Args(cmd, []string{"id1"}); err != nil {
 		t.Errorf("Args validator should accept 1 arg: %v", err)
 	}
-	if err := cmd.Args(cmd, []string{}); err == nil {
-		t.Error("Args validator should reject 0 args")
+	if err := cmd.Args(cmd, []string{}); err != nil {
+		t.Errorf("Args validator should accept 0 args (IDs from stdin): %v", err)
 	}
 }
 
@@ -188,8 +190,9 @@ func TestEmailBulkMoveCmd_RequiresArgs(t *testing.T) {
 	app := newTestApp()
 	cmd := newEmailBulkMoveCmd(app)
 
-	// Set args to empty (no email IDs provided)
+	// Set args to empty (no email IDs provided, nothing on stdin)
 	cmd.SetArgs([]string{})
+	cmd.SetIn(strings.NewReader(""))
 
 	// Execute should fail because bulk-move requires at least 1 email ID
 	err := cmd.Execute()
@@ -226,10 +229,10 @@ func TestEmailBulkMoveCmd_AcceptsMultipleArgs(t *testing.T) {
 		t.Errorf("expected Args validator to accept multiple args, got error: %v", err)
 	}
 
-	// Test with 0 args - should fail validation
+	// Test with 0 args - should pass validation (IDs may come from stdin)
 	err = argsValidator(cmd, []string{})
-	if err == nil {
-		t.Error("expected Args validator to reject 0 args, got nil error")
+	if err != nil {
+		t.Errorf("expected Args validator to accept 0 args, got error: %v", err)
 	}
 }
 
@@ -299,7 +302,7 @@ func TestEmailBulkMoveCmd_CommandMetadata(t *testing.T) {
 		t.Error("expected RunE function to be set")
 	}
 
-	// Verify it's using MinimumNArgs(1)
+	// Verify it's using ArbitraryArgs (IDs may come from stdin)
 	if cmd.Args == nil {
 		t.Error("expected Args validator to be set")
 	}
@@ -308,8 +311,8 @@ func TestEmailBulkMoveCmd_CommandMetadata(t *testing.T) {
 	if err := cmd.Args(cmd, []string{"id1"}); err != nil {
 		t.Errorf("Args validator should accept 1 arg: %v", err)
 	}
-	if err := cmd.Args(cmd, []string{}); err == nil {
-		t.Error("Args validator should reject 0 args")
+	if err := cmd.Args(cmd, []string{}); err != nil {
+		t.Errorf("Args validator should accept 0 args (IDs from stdin): %v", err)
 	}
 }
 
@@ -335,8 +338,9 @@ func TestEmailBulkMarkReadCmd_RequiresArgs(t *testing.T) {
 	app := newTestApp()
 	cmd := newEmailBulkMarkReadCmd(app)
 
-	// Set args to empty (no email IDs provided)
+	// Set args to empty (no email IDs provided, nothing on stdin)
 	cmd.SetArgs([]string{})
+	cmd.SetIn(strings.NewReader(""))
 
 	// Execute should fail because bulk-mark-read requires at least 1 email ID
 	err := cmd.Execute()
@@ -373,10 +377,10 @@ func TestEmailBulkMarkReadCmd_AcceptsMultipleArgs(t *testing.T) {
 		t.Errorf("expected Args validator to accept multiple args, got error: %v", err)
 	}
 
-	// Test with 0 args - should fail validation
+	// Test with 0 args - should pass validation (IDs may come from stdin)
 	err = argsValidator(cmd, []string{})
-	if err == nil {
-		t.Error("expected Args validator to reject 0 args, got nil error")
+	if err != nil {
+		t.Errorf("expected Args validator to accept 0 args, got error: %v", err)
 	}
 }
 
@@ -413,7 +417,7 @@ func TestEmailBulkMarkReadCmd_CommandMetadata(t *testing.T) {
 		t.Error("expected RunE function to be set")
 	}
 
-	// Verify it's using MinimumNArgs(1)
+	// Verify it's using ArbitraryArgs (IDs may come from stdin)
 	if cmd.Args == nil {
 		t.Error("expected Args validator to be set")
 	}
@@ -422,8 +426,8 @@ func TestEmailBulkMarkReadCmd_CommandMetadata(t *testing.T) {
 	if err := cmd.Args(cmd, []string{"id1"}); err != nil {
 		t.Errorf("Args validator should accept 1 arg: %v", err)
 	}
-	if err := cmd.Args(cmd, []string{}); err == nil {
-		t.Error("Args validator should reject 0 args")
+	if err := cmd.Args(cmd, []string{}); err != nil {
+		t.Errorf("Args validator should accept 0 args (IDs from stdin): %v", err)
 	}
 }
 
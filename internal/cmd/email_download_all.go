@@ -0,0 +1,206 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/salmonumbrella/fastmail-cli/internal/format"
+	"github.com/salmonumbrella/fastmail-cli/internal/jmap"
+	"github.com/spf13/cobra"
+)
+
+func newEmailDownloadAllCmd(app *App) *cobra.Command {
+	var outputDir string
+	var includeInline bool
+	var preserveDate bool
+
+	cmd := &cobra.Command{
+		Use:   "download-all <emailId>",
+		Short: "Download every attachment of an email",
+		Long: `Download every attachment of an email into a directory.
+
+Files are saved under their sanitized original names. When two attachments
+share a name, or a file with that name already exists, " (1)", " (2)", ...
+is appended before the extension. Existing files are never overwritten.
+
+Inline images (embedded in the HTML body via Content-ID) are skipped unless
+--inline is given. With --preserve-date, files get the email's received date
+as their modification time.
+
+Examples:
+  fastmail email download-all ABC123
+  fastmail email download-all ABC123 --dir ./out
+  fastmail email download-all ABC123 --dir ./out --inline
+  fastmail email download-all ABC123 --dir ./archive --preserve-date`,
+		Args: cobra.ExactArgs(1),
+		RunE: runE(app, func(cmd *cobra.Command, args []string, app *App) error {
+			client, err := app.JMAPClient()
+			if err != nil {
+				return err
+			}
+			var mtime time.Time
+			if preserveDate {
+				mtime = emailReceivedTime(cmd, client, args[0])
+			}
+			return downloadEmailAttachments(cmd, client, app, args[0], outputDir, includeInline, mtime)
+		}),
+	}
+
+	cmd.Flags().StringVarP(&outputDir, "dir", "d", ".", "Output directory (created if it doesn't exist)")
+	cmd.Flags().BoolVar(&includeInline, "inline", false, "Also download inline images")
+	cmd.Flags().BoolVar(&preserveDate, "preserve-date", false, "Set each file's modification time to the email's received date")
+
+	return cmd
+}
+
+// downloadEmailAttachments implements email download-all. A non-zero mtime is
+// applied to each downloaded file.
+func downloadEmailAttachments(cmd *cobra.Command, client jmap.EmailService, app *App, emailID, outputDir string, includeInline bool, mtime time.Time) error {
+	attachments, err := client.GetEmailAttachments(cmd.Context(), emailID)
+	if err != nil {
+		return fmt.Errorf("failed to get attachments: %w", err)
+	}
+
+	if err := os.MkdirAll(outputDir, 0o750); err != nil {
+		return fmt.Errorf("failed to create directory '%s': %w", outputDir, err)
+	}
+
+	files := []map[string]any{}
+	skipped := []map[string]any{}
+	failures := []map[string]any{}
+	var totalBytes int64
+	taken := make(map[string]bool)
+
+	for _, att := range attachments {
+		if att.IsInline() && !includeInline {
+			skipped = append(skipped, map[string]any{
+				"blobId": att.BlobID,
+				"name":   att.Name,
+				"reason": "inline",
+			})
+			continue
+		}
+
+		name := format.SanitizeFilename(att.Name)
+		if name == "" {
+			name = "attachment"
+		}
+		outputFile := uniqueFilePath(outputDir, name, taken)
+
+		written, err := writeBlobToNewFile(cmd, client, att.BlobID, outputFile)
+		if err != nil {
+			failures = append(failures, map[string]any{
+				"blobId":     att.BlobID,
+				"name":       att.Name,
+				"outputFile": outputFile,
+				"error":      err.Error(),
+			})
+			if !app.IsJSON(cmd.Context()) {
+				fmt.Fprintf(os.Stderr, "Error downloading %s: %v\n", att.Name, err)
+			}
+			continue
+		}
+		setFileDate(cmd, outputFile, mtime)
+
+		totalBytes += written
+		files = append(files, map[string]any{
+			"blobId":     att.BlobID,
+			"name":       att.Name,
+			"outputFile": outputFile,
+			"size":       written,
+		})
+		if !app.IsJSON(cmd.Context()) {
+			fmt.Printf("Downloaded %s (%s)\n", outputFile, format.FormatBytes(written))
+		}
+	}
+
+	if app.IsJSON(cmd.Context()) {
+		return app.PrintJSON(cmd, map[string]any{
+			"emailId":    emailID,
+			"dir":        outputDir,
+			"files":      files,
+			"skipped":    skipped,
+			"errors":     failures,
+			"totalBytes": totalBytes,
+		})
+	}
+
+	if len(files) == 0 && len(failures) == 0 {
+		if len(skipped) > 0 {
+			printNoResults(fmt.Sprintf("No attachments to download (%d inline skipped, use --inline to include)", len(skipped)))
+		} else {
+			printNoResults("No attachments to download")
+		}
+		return nil
+	}
+
+	fmt.Printf("\nWrote %d file(s), %s total", len(files), format.FormatBytes(totalBytes))
+	if len(skipped) > 0 {
+		fmt.Printf("; skipped %d inline", len(skipped))
+	}
+	if len(failures) > 0 {
+		fmt.Printf("; %d failed", len(failures))
+	}
+	fmt.Println()
+	return nil
+}
+
+// writeBlobToNewFile downloads a blob into outputFile, which must not exist.
+func writeBlobToNewFile(cmd *cobra.Command, client jmap.EmailService, blobID, outputFile string) (int64, error) {
+	reader, err := client.DownloadBlob(cmd.Context(), blobID)
+	if err != nil {
+		return 0, err
+	}
+	defer reader.Close()
+
+	outFile, err := os.OpenFile(outputFile, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o600)
+	if err != nil {
+		return 0, fmt.Errorf("creating file: %w", err)
+	}
+
+	written, err := io.Copy(outFile, reader)
+	if closeErr := outFile.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		_ = os.Remove(outputFile)
+		return 0, fmt.Errorf("writing file: %w", err)
+	}
+	return written, nil
+}
+
+// uniqueFilePath returns dir/name, or dir/"base (N).ext" for the smallest N
+// that neither exists on disk nor was already returned (tracked in taken).
+func uniqueFilePath(dir, name string, taken map[string]bool) string {
+	candidate := uniqueName(name, func(n string) bool {
+		path := filepath.Join(dir, n)
+		return taken[path] || fileExists(path)
+	})
+	path := filepath.Join(dir, candidate)
+	taken[path] = true
+	return path
+}
+
+// uniqueName returns name, or "base (N).ext" for the smallest N for which
+// inUse reports false.
+func uniqueName(name string, inUse func(string) bool) string {
+	ext := filepath.Ext(name)
+	base := strings.TrimSuffix(name, ext)
+
+	candidate := name
+	for n := 1; inUse(candidate); n++ {
+		candidate = fmt.Sprintf("%s (%d)%s", base, n, ext)
+	}
+	return candidate
+}
+
+func fileExists(path string) bool {
+	_, err := os.Stat(path)
+	return !errors.Is(err, fs.ErrNotExist)
+}
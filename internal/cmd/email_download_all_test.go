@@ -0,0 +1,136 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/salmonumbrella/fastmail-cli/internal/jmap"
+	"github.com/salmonumbrella/fastmail-cli/internal/outfmt"
+	"github.com/spf13/cobra"
+)
+
+func TestUniqueFilePath(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "notes.txt"), nil, 0o600); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+
+	taken := make(map[string]bool)
+	got := []string{
+		uniqueFilePath(dir, "report.pdf", taken),
+		uniqueFilePath(dir, "report.pdf", taken),
+		uniqueFilePath(dir, "report.pdf", taken),
+		uniqueFilePath(dir, "notes.txt", taken),
+	}
+	want := []string{"report.pdf", "report (1).pdf", "report (2).pdf", "notes (1).txt"}
+	for i := range want {
+		if got[i] != filepath.Join(dir, want[i]) {
+			t.Errorf("path %d = %q, want %q", i, got[i], filepath.Join(dir, want[i]))
+		}
+	}
+}
+
+func TestDownloadEmailAttachments(t *testing.T) {
+	dir := t.TempDir()
+	mock := &jmap.MockEmailService{
+		GetEmailAttachmentsFunc: func(ctx context.Context, id string) ([]jmap.Attachment, error) {
+			return []jmap.Attachment{
+				{BlobID: "B1", Name: "scan.pdf", Type: "application/pdf"},
+				{BlobID: "B2", Name: "scan.pdf", Type: "application/pdf"},
+				{BlobID: "B3", Name: "logo.png", Type: "image/png", ContentID: "logo@x"},
+			}, nil
+		},
+		DownloadBlobFunc: func(ctx context.Context, blobID string) (io.ReadCloser, error) {
+			return io.NopCloser(bytes.NewBufferString("data-" + blobID)), nil
+		},
+	}
+
+	run := func(inline bool) map[string]any {
+		app := newTestApp()
+		cmd := &cobra.Command{}
+		cmd.SetContext(context.WithValue(context.Background(), outputModeKey, outfmt.JSON))
+		out := captureStdout(t, func() {
+			if err := downloadEmailAttachments(cmd, mock, app, "E1", dir, inline, time.Time{}); err != nil {
+				t.Fatalf("downloadEmailAttachments: %v", err)
+			}
+		})
+		var payload map[string]any
+		if err := json.Unmarshal([]byte(out), &payload); err != nil {
+			t.Fatalf("invalid JSON: %v; %q", err, out)
+		}
+		return payload
+	}
+
+	payload := run(false)
+	if files := payload["files"].([]any); len(files) != 2 {
+		t.Fatalf("files = %v, want 2", files)
+	}
+	if skipped := payload["skipped"].([]any); len(skipped) != 1 {
+		t.Fatalf("skipped = %v, want inline image skipped", skipped)
+	}
+	if payload["totalBytes"] != float64(14) {
+		t.Fatalf("totalBytes = %v, want 14", payload["totalBytes"])
+	}
+	data, err := os.ReadFile(filepath.Join(dir, "scan (1).pdf"))
+	if err != nil || string(data) != "data-B2" {
+		t.Fatalf("collision file = %q, %v", data, err)
+	}
+
+	// A second run with --inline must not overwrite anything.
+	payload = run(true)
+	if files := payload["files"].([]any); len(files) != 3 {
+		t.Fatalf("files = %v, want 3", files)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "scan (3).pdf")); err != nil {
+		t.Fatalf("expected de-duplicated file: %v", err)
+	}
+}
+
+func TestDownloadEmailAttachments_PreserveDate(t *testing.T) {
+	dir := t.TempDir()
+	mock := &jmap.MockEmailService{
+		GetEmailByIDFunc: func(ctx context.Context, id string) (*jmap.Email, error) {
+			return &jmap.Email{ID: id, ReceivedAt: "2024-03-05T10:30:00Z"}, nil
+		},
+		GetEmailAttachmentsFunc: func(ctx context.Context, id string) ([]jmap.Attachment, error) {
+			return []jmap.Attachment{{BlobID: "B1", Name: "scan.pdf", Type: "application/pdf"}}, nil
+		},
+		DownloadBlobFunc: func(ctx context.Context, blobID string) (io.ReadCloser, error) {
+			return io.NopCloser(bytes.NewBufferString("data")), nil
+		},
+	}
+
+	cmd := &cobra.Command{}
+	cmd.SetContext(context.WithValue(context.Background(), outputModeKey, outfmt.JSON))
+	mtime := emailReceivedTime(cmd, mock, "E1")
+	if want := time.Date(2024, 3, 5, 10, 30, 0, 0, time.UTC); !mtime.Equal(want) {
+		t.Fatalf("emailReceivedTime() = %v, want %v", mtime, want)
+	}
+
+	captureStdout(t, func() {
+		if err := downloadEmailAttachments(cmd, mock, newTestApp(), "E1", dir, false, mtime); err != nil {
+			t.Fatalf("downloadEmailAttachments: %v", err)
+		}
+	})
+	info, err := os.Stat(filepath.Join(dir, "scan.pdf"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !info.ModTime().Equal(mtime) {
+		t.Errorf("ModTime = %v, want %v", info.ModTime(), mtime)
+	}
+
+	// An unparseable date leaves files with their default modification time
+	mock.GetEmailByIDFunc = func(ctx context.Context, id string) (*jmap.Email, error) {
+		return &jmap.Email{ID: id, ReceivedAt: "yesterday"}, nil
+	}
+	if got := emailReceivedTime(cmd, mock, "E1"); !got.IsZero() {
+		t.Errorf("emailReceivedTime() = %v, want zero time", got)
+	}
+}
@@ -0,0 +1,88 @@
+package cmd
+
+import (
+	"fmt"
+
+	cerrors "github.com/salmonumbrella/fastmail-cli/internal/errors"
+	"github.com/spf13/cobra"
+)
+
+func newEmailEmptyTrashCmd(app *App) *cobra.Command {
+	return newEmailEmptyMailboxCmd(app, "empty-trash", "trash", "Trash")
+}
+
+func newEmailEmptySpamCmd(app *App) *cobra.Command {
+	cmd := newEmailEmptyMailboxCmd(app, "empty-spam", "junk", "Spam")
+	cmd.Aliases = []string{"empty-junk"}
+	return cmd
+}
+
+// newEmailEmptyMailboxCmd builds a command that permanently destroys every email
+// in the mailbox with the given role.
+func newEmailEmptyMailboxCmd(app *App, use, role, label string) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   use,
+		Short: fmt.Sprintf("Permanently delete all emails in %s", label),
+		Long: fmt.Sprintf(`Permanently delete all emails in the %s mailbox.
+
+This cannot be undone: emails are destroyed, not moved to trash.
+Requires confirmation unless --yes is set.`, label),
+		Args: cobra.NoArgs,
+		RunE: runE(app, func(cmd *cobra.Command, args []string, app *App) error {
+			client, err := app.JMAPClient()
+			if err != nil {
+				return err
+			}
+
+			mailbox, err := client.GetMailboxByRole(cmd.Context(), role)
+			if err != nil {
+				return cerrors.WithContext(err, fmt.Sprintf("finding %s mailbox", role))
+			}
+
+			if mailbox.TotalEmails == 0 {
+				if app.IsJSON(cmd.Context()) {
+					return app.PrintJSON(cmd, map[string]any{
+						"status":    "empty",
+						"mailbox":   mailbox.Name,
+						"destroyed": 0,
+					})
+				}
+				printAlready(fmt.Sprintf("%s is already empty", mailbox.Name))
+				return nil
+			}
+
+			// Prompt for confirmation unless --yes flag is set (global) or JSON output mode.
+			prompt := fmt.Sprintf("Permanently delete %d emails in %s? This cannot be undone. [y/N] ", mailbox.TotalEmails, mailbox.Name)
+			confirmed, err := app.Confirm(cmd, false, prompt, "y", "yes")
+			if err != nil {
+				return err
+			}
+			if !confirmed {
+				printCancelled()
+				return nil
+			}
+
+			results, err := client.EmptyMailbox(cmd.Context(), mailbox.ID)
+			if err != nil {
+				return cerrors.WithContext(err, fmt.Sprintf("emptying %s", mailbox.Name))
+			}
+
+			if app.IsJSON(cmd.Context()) {
+				output := map[string]any{
+					"status":    "destroyed",
+					"mailbox":   mailbox.Name,
+					"destroyed": len(results.Succeeded),
+				}
+				if len(results.Failed) > 0 {
+					output["failed"] = results.Failed
+				}
+				return app.PrintJSON(cmd, output)
+			}
+
+			printBulkResults("Permanently deleted", fmt.Sprintf("emails from %s", mailbox.Name), len(results.Succeeded), len(results.Failed), results.Failed)
+			return nil
+		}),
+	}
+
+	return cmd
+}
@@ -0,0 +1,144 @@
+package cmd
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/salmonumbrella/fastmail-cli/internal/format"
+	"github.com/salmonumbrella/fastmail-cli/internal/jmap"
+	"github.com/salmonumbrella/fastmail-cli/internal/outfmt"
+)
+
+// emailColumns holds what the email table columns need besides the email
+// itself.
+type emailColumns struct {
+	threadCounts map[string]int
+	dates        format.DateStyle
+	// widths truncates SUBJECT and FROM; zero for CSV
+	widths columnWidths
+	// subjects overrides the subject per email ID (highlighted search snippets)
+	subjects map[string]string
+}
+
+// emailField is a column of the email list/search tables.
+type emailField struct {
+	header string
+	value  func(email jmap.Email, c *emailColumns) string
+}
+
+// emailFields maps --fields names to columns.
+var emailFields = map[string]emailField{
+	"id": {"ID", func(e jmap.Email, _ *emailColumns) string { return e.ID }},
+	"subject": {"SUBJECT", func(e jmap.Email, c *emailColumns) string {
+		subject := e.Subject
+		if s, ok := c.subjects[e.ID]; ok {
+			subject = s
+		}
+		return format.Truncate(subject, c.widths.subject)
+	}},
+	"from": {"FROM", func(e jmap.Email, c *emailColumns) string {
+		return format.Truncate(format.FormatEmailAddressList(e.From), c.widths.from)
+	}},
+	"to": {"TO", func(e jmap.Email, c *emailColumns) string {
+		return format.Truncate(format.FormatEmailAddressList(e.To), c.widths.from)
+	}},
+	"date": {"DATE", func(e jmap.Email, c *emailColumns) string { return c.dates.FormatString(e.ReceivedAt) }},
+	"size": {"SIZE", func(e jmap.Email, _ *emailColumns) string { return format.FormatBytes(e.Size) }},
+	"unread": {"UNREAD", func(e jmap.Email, _ *emailColumns) string {
+		unread, _ := emailMarkers(e)
+		return unread
+	}},
+	"flagged": {"FLAGGED", func(e jmap.Email, _ *emailColumns) string {
+		_, flagged := emailMarkers(e)
+		return flagged
+	}},
+	"thread": {"THREAD", func(e jmap.Email, c *emailColumns) string {
+		return formatThreadCount(c.threadCounts[e.ThreadID])
+	}},
+	"attach": {"ATTACH", func(e jmap.Email, _ *emailColumns) string { return formatAttachmentCount(e) }},
+	"preview": {"PREVIEW", func(e jmap.Email, _ *emailColumns) string {
+		if e.PreviewTruncated {
+			return e.Preview + "..."
+		}
+		return e.Preview
+	}},
+}
+
+// defaultEmailFields are the email table columns shown without --fields.
+var defaultEmailFields = []string{"id", "subject", "from", "date", "unread", "flagged", "thread"}
+
+// emailFieldOpts are the column options that add to the default fields.
+type emailFieldOpts struct {
+	attachmentCount bool // --attachment-count: ATTACH column
+	preview         bool // --preview-bytes: PREVIEW column
+	size            bool // --show-size: SIZE column, also added to --fields
+}
+
+// parseEmailFields validates and normalizes --fields names. Nil means the
+// defaults, with size after the date and attach and preview at the end when
+// those options are on. --show-size appends size to explicit fields too.
+func parseEmailFields(names []string, opts emailFieldOpts) ([]string, error) {
+	if len(names) == 0 {
+		fields := slices.Clone(defaultEmailFields)
+		if opts.size {
+			fields = slices.Insert(fields, slices.Index(fields, "date")+1, "size")
+		}
+		if opts.attachmentCount {
+			fields = append(fields, "attach")
+		}
+		if opts.preview {
+			fields = append(fields, "preview")
+		}
+		return fields, nil
+	}
+
+	fields := make([]string, 0, len(names))
+	for _, name := range names {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if _, ok := emailFields[name]; !ok {
+			return nil, fmt.Errorf("unknown field %q in --fields (valid fields: %s)", name, strings.Join(validEmailFields(), ", "))
+		}
+		fields = append(fields, name)
+	}
+	if opts.size && !slices.Contains(fields, "size") {
+		fields = append(fields, "size")
+	}
+	return fields, nil
+}
+
+// validEmailFields returns the --fields names, sorted.
+func validEmailFields() []string {
+	names := make([]string, 0, len(emailFields))
+	for name := range emailFields {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names
+}
+
+// emailFieldHeader returns the tab-separated table header for fields.
+func emailFieldHeader(fields []string) string {
+	headers := make([]string, len(fields))
+	for i, name := range fields {
+		headers[i] = emailFields[name].header
+	}
+	return strings.Join(headers, "\t")
+}
+
+// emailFieldRow returns the table cells of email for fields. Cells are
+// tab-sanitized and the subject and flagged cells styled.
+func emailFieldRow(email jmap.Email, fields []string, c *emailColumns, style outfmt.TableStyle) []string {
+	cells := make([]string, len(fields))
+	for i, name := range fields {
+		cell := outfmt.SanitizeTab(emailFields[name].value(email, c))
+		switch name {
+		case "subject":
+			cell = styleSubject(style, email, cell)
+		case "flagged":
+			cell = style.Flagged(cell)
+		}
+		cells[i] = cell
+	}
+	return cells
+}
@@ -0,0 +1,79 @@
+package cmd
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/salmonumbrella/fastmail-cli/internal/format"
+	"github.com/salmonumbrella/fastmail-cli/internal/jmap"
+	"github.com/salmonumbrella/fastmail-cli/internal/outfmt"
+)
+
+func TestParseEmailFields(t *testing.T) {
+	tests := []struct {
+		name  string
+		names []string
+		opts  emailFieldOpts
+		want  []string
+	}{
+		{"defaults", nil, emailFieldOpts{}, defaultEmailFields},
+		{"defaults with options", nil, emailFieldOpts{attachmentCount: true, preview: true}, append(append([]string{}, defaultEmailFields...), "attach", "preview")},
+		{"show size", nil, emailFieldOpts{size: true}, []string{"id", "subject", "from", "date", "size", "unread", "flagged", "thread"}},
+		{"selected and ordered", []string{"date", " Subject ", "size"}, emailFieldOpts{}, []string{"date", "subject", "size"}},
+		{"explicit ignores options", []string{"id"}, emailFieldOpts{attachmentCount: true, preview: true}, []string{"id"}},
+		{"show size with fields", []string{"id"}, emailFieldOpts{size: true}, []string{"id", "size"}},
+		{"show size already selected", []string{"size", "id"}, emailFieldOpts{size: true}, []string{"size", "id"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseEmailFields(tt.names, tt.opts)
+			if err != nil {
+				t.Fatalf("parseEmailFields() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parseEmailFields() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	_, err := parseEmailFields([]string{"id", "bogus"}, emailFieldOpts{})
+	if err == nil || !strings.Contains(err.Error(), `"bogus"`) || !strings.Contains(err.Error(), "subject") {
+		t.Errorf("unknown field error = %v, want the name and the valid fields", err)
+	}
+}
+
+func TestEmailFieldRow(t *testing.T) {
+	email := jmap.Email{
+		ID:         "e1",
+		Subject:    "Quarterly\treport",
+		From:       []jmap.EmailAddress{{Email: "alice@example.com"}},
+		ReceivedAt: "2025-06-15T10:00:00Z",
+		Size:       2048,
+		ThreadID:   "t1",
+		Keywords:   map[string]bool{"$flagged": true},
+	}
+	c := &emailColumns{
+		threadCounts: map[string]int{"t1": 3},
+		dates:        format.DateStyle{Location: time.UTC},
+		widths:       columnWidths{subject: 10},
+	}
+	fields := []string{"size", "flagged", "subject", "date", "thread", "id"}
+
+	if got := emailFieldHeader(fields); got != "SIZE\tFLAGGED\tSUBJECT\tDATE\tTHREAD\tID" {
+		t.Errorf("header = %q", got)
+	}
+
+	got := emailFieldRow(email, fields, c, outfmt.TableStyle{})
+	want := []string{format.FormatBytes(2048), "*", "Quarter...", "2025-06-15 10:00", "[3 msgs]", "e1"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("row = %q, want %q", got, want)
+	}
+
+	c.subjects = map[string]string{"e1": "<mark>Q</mark>"}
+	c.widths = columnWidths{}
+	if got := emailFieldRow(email, []string{"subject"}, c, outfmt.TableStyle{}); got[0] != "<mark>Q</mark>" {
+		t.Errorf("highlighted subject = %q", got[0])
+	}
+}
@@ -0,0 +1,171 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	cerrors "github.com/salmonumbrella/fastmail-cli/internal/errors"
+	"github.com/salmonumbrella/fastmail-cli/internal/jmap"
+	"github.com/salmonumbrella/fastmail-cli/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+func newEmailFocusedCmd(app *App) *cobra.Command {
+	var mailbox string
+	var limit int
+
+	cmd := &cobra.Command{
+		Use:   "focused",
+		Short: "Split a mailbox into focused and other emails",
+		Long: `Split recent emails into "focused" and "other" sections.
+
+An email is focused when it is flagged, carries the $important keyword, or
+its sender is one of your contacts. Everything else is "other". Each sender
+is looked up in contacts once per run; when contacts are not available only
+the keywords are used.
+
+Examples:
+  fastmail email focused
+  fastmail email focused --mailbox Archive --limit 100
+  fastmail email focused --output json --query '.focused[].subject'`,
+		RunE: runE(app, func(cmd *cobra.Command, args []string, app *App) error {
+			client, err := app.JMAPClient()
+			if err != nil {
+				return err
+			}
+
+			mailboxID, err := client.ResolveMailboxID(cmd.Context(), mailbox)
+			if err != nil {
+				return fmt.Errorf("invalid mailbox: %w", err)
+			}
+
+			emails, err := client.ListEmails(cmd.Context(), mailboxID, limit, jmap.EmailListOpts{})
+			if err != nil {
+				return cerrors.WithContext(err, "listing emails")
+			}
+
+			classifier := newFocusClassifier(client)
+			focused, other, err := classifier.split(cmd.Context(), emails)
+			if err != nil {
+				return cerrors.WithContext(err, "looking up contacts")
+			}
+			if classifier.contactsUnavailable {
+				ui.FromContext(cmd.Context()).Warning("Warning: contacts are not available; using flags and keywords only")
+			}
+
+			threadIDs := make([]string, 0, len(emails))
+			for _, email := range emails {
+				threadIDs = append(threadIDs, email.ThreadID)
+			}
+			threadCounts, err := client.GetThreadMessageCounts(cmd.Context(), threadIDs)
+			if err != nil {
+				// Non-fatal: continue without thread counts
+				threadCounts = map[string]int{}
+			}
+
+			if app.IsJSON(cmd.Context()) {
+				return app.PrintJSON(cmd, map[string]any{
+					"focused": emailsToOutputWithCounts(focused, threadCounts),
+					"other":   emailsToOutputWithCounts(other, threadCounts),
+				})
+			}
+
+			if len(emails) == 0 {
+				printNoResults("No emails found")
+				return nil
+			}
+
+			style := app.TableStyle(cmd.Context())
+			for i, section := range []struct {
+				title  string
+				emails []jmap.Email
+			}{{"Focused", focused}, {"Other", other}} {
+				if i > 0 {
+					fmt.Println()
+				}
+				fmt.Printf("%s (%d)\n", section.title, len(section.emails))
+				if len(section.emails) > 0 {
+					printEmailList(section.emails, threadCounts, app.DateStyle(), style)
+				}
+			}
+			return nil
+		}),
+	}
+
+	cmd.Flags().StringVar(&mailbox, "mailbox", "inbox", "Mailbox ID or name to classify")
+	cmd.Flags().IntVar(&limit, "limit", 50, "Maximum number of emails to classify")
+
+	return cmd
+}
+
+// focusClassifier sorts emails into focused and other. Contact lookups are
+// cached per sender address.
+type focusClassifier struct {
+	contacts jmap.ContactsService
+	known    map[string]bool
+	// contactsUnavailable is set once a lookup reports contacts are not enabled
+	contactsUnavailable bool
+}
+
+func newFocusClassifier(contacts jmap.ContactsService) *focusClassifier {
+	return &focusClassifier{contacts: contacts, known: make(map[string]bool)}
+}
+
+// split returns emails partitioned into focused and other, each in the
+// original order.
+func (f *focusClassifier) split(ctx context.Context, emails []jmap.Email) (focused, other []jmap.Email, err error) {
+	focused, other = []jmap.Email{}, []jmap.Email{}
+	for _, email := range emails {
+		ok, err := f.isFocused(ctx, email)
+		if err != nil {
+			return nil, nil, err
+		}
+		if ok {
+			focused = append(focused, email)
+		} else {
+			other = append(other, email)
+		}
+	}
+	return focused, other, nil
+}
+
+// isFocused reports whether email is flagged, marked $important, or from a
+// known contact.
+func (f *focusClassifier) isFocused(ctx context.Context, email jmap.Email) (bool, error) {
+	if email.Keywords["$flagged"] || email.Keywords["$important"] {
+		return true, nil
+	}
+	for _, from := range email.From {
+		known, err := f.isContact(ctx, from.Email)
+		if err != nil || known {
+			return known, err
+		}
+	}
+	return false, nil
+}
+
+func (f *focusClassifier) isContact(ctx context.Context, address string) (bool, error) {
+	address = strings.ToLower(strings.TrimSpace(address))
+	if address == "" || f.contactsUnavailable {
+		return false, nil
+	}
+	if known, ok := f.known[address]; ok {
+		return known, nil
+	}
+
+	contact, err := f.contacts.GetContactByEmail(ctx, address)
+	switch {
+	case errors.Is(err, jmap.ErrContactsNotEnabled):
+		f.contactsUnavailable = true
+		return false, nil
+	case errors.Is(err, jmap.ErrContactNotFound):
+		err = nil
+	case err != nil:
+		return false, err
+	}
+
+	f.known[address] = contact != nil
+	return f.known[address], nil
+}
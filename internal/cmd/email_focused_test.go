@@ -0,0 +1,78 @@
+package cmd
+
+import (
+	"context"
+	"testing"
+
+	"github.com/salmonumbrella/fastmail-cli/internal/jmap"
+)
+
+func TestFocusClassifier(t *testing.T) {
+	var lookups []string
+	contacts := &jmap.MockContactsService{
+		GetContactByEmailFunc: func(ctx context.Context, email string) (*jmap.Contact, error) {
+			lookups = append(lookups, email)
+			if email == "alice@example.com" {
+				return &jmap.Contact{ID: "c1"}, nil
+			}
+			return nil, jmap.ErrContactNotFound
+		},
+	}
+	from := func(addr string) []jmap.EmailAddress { return []jmap.EmailAddress{{Email: addr}} }
+	emails := []jmap.Email{
+		{ID: "e1", From: from("Alice@Example.com")},
+		{ID: "e2", From: from("news@shop.example")},
+		{ID: "e3", From: from("news@shop.example"), Keywords: map[string]bool{"$flagged": true}},
+		{ID: "e4", From: from("boss@corp.example"), Keywords: map[string]bool{"$important": true}},
+		{ID: "e5", From: from("alice@example.com")},
+		{ID: "e6", From: from("news@shop.example")},
+	}
+
+	focused, other, err := newFocusClassifier(contacts).split(context.Background(), emails)
+	if err != nil {
+		t.Fatalf("split() error = %v", err)
+	}
+
+	ids := func(emails []jmap.Email) (out []string) {
+		for _, e := range emails {
+			out = append(out, e.ID)
+		}
+		return out
+	}
+	if got := ids(focused); len(got) != 4 || got[0] != "e1" || got[1] != "e3" || got[2] != "e4" || got[3] != "e5" {
+		t.Errorf("focused = %v, want [e1 e3 e4 e5]", got)
+	}
+	if got := ids(other); len(got) != 2 || got[0] != "e2" || got[1] != "e6" {
+		t.Errorf("other = %v, want [e2 e6]", got)
+	}
+	if len(lookups) != 2 {
+		t.Errorf("contact lookups = %v, want one per sender (keyword matches skip lookup)", lookups)
+	}
+}
+
+func TestFocusClassifier_ContactsUnavailable(t *testing.T) {
+	calls := 0
+	contacts := &jmap.MockContactsService{
+		GetContactByEmailFunc: func(ctx context.Context, email string) (*jmap.Contact, error) {
+			calls++
+			return nil, jmap.ErrContactsNotEnabled
+		},
+	}
+	emails := []jmap.Email{
+		{ID: "e1", From: []jmap.EmailAddress{{Email: "a@example.com"}}},
+		{ID: "e2", From: []jmap.EmailAddress{{Email: "b@example.com"}}, Keywords: map[string]bool{"$flagged": true}},
+		{ID: "e3", From: []jmap.EmailAddress{{Email: "c@example.com"}}},
+	}
+
+	classifier := newFocusClassifier(contacts)
+	focused, other, err := classifier.split(context.Background(), emails)
+	if err != nil {
+		t.Fatalf("split() error = %v", err)
+	}
+	if len(focused) != 1 || len(other) != 2 || !classifier.contactsUnavailable {
+		t.Errorf("focused = %d, other = %d, unavailable = %v", len(focused), len(other), classifier.contactsUnavailable)
+	}
+	if calls != 1 {
+		t.Errorf("lookups = %d, want 1 (stop after contacts are unavailable)", calls)
+	}
+}
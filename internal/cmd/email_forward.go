@@ -5,6 +5,7 @@ import (
 	"strings"
 
 	cerrors "github.com/salmonumbrella/fastmail-cli/internal/errors"
+	"github.com/salmonumbrella/fastmail-cli/internal/format"
 	"github.com/salmonumbrella/fastmail-cli/internal/jmap"
 	"github.com/salmonumbrella/fastmail-cli/internal/validation"
 	"github.com/spf13/cobra"
@@ -14,6 +15,8 @@ func newEmailForwardCmd(app *App) *cobra.Command {
 	var to []string
 	var fromIdentity string
 	var body string
+	var noAttachments bool
+	var maxAttachmentSize string
 
 	cmd := &cobra.Command{
 		Use:     "forward <emailId>",
@@ -25,13 +28,16 @@ By default, if the original email was received on a masked email address, the
 forwarded email will be sent from that same masked email to maintain privacy.
 Use --from to override this behavior.
 
-Attachments from the original email are automatically included.
+Attachments from the original email are automatically included. Use
+--no-attachments to drop them all, or --max-attachment-size to drop only
+those larger than the given size (e.g. 10MB).
 
 Examples:
   fastmail email forward Mf1234abc --to recipient@example.com
   fastmail email forward Mf1234abc --to user1@example.com --to user2@example.com
   fastmail email forward Mf1234abc --to recipient@example.com --body "FYI, see below"
-  fastmail email forward Mf1234abc --to recipient@example.com --from my.identity@fastmail.com`,
+  fastmail email forward Mf1234abc --to recipient@example.com --from my.identity@fastmail.com
+  fastmail email forward Mf1234abc --to recipient@example.com --max-attachment-size 5MB`,
 		Args: cobra.ExactArgs(1),
 		RunE: runE(app, func(cmd *cobra.Command, args []string, app *App) error {
 			emailID := args[0]
@@ -47,6 +53,15 @@ Examples:
 				}
 			}
 
+			var maxSize int64
+			if maxAttachmentSize != "" {
+				var parseErr error
+				maxSize, parseErr = format.ParseBytes(maxAttachmentSize)
+				if parseErr != nil {
+					return fmt.Errorf("invalid --max-attachment-size: %w", parseErr)
+				}
+			}
+
 			client, err := app.JMAPClient()
 			if err != nil {
 				return err
@@ -60,10 +75,13 @@ Examples:
 
 			// Build forward options
 			opts := jmap.ForwardEmailOpts{
-				To:   to,
-				From: fromIdentity,
-				Body: body,
+				To:                to,
+				From:              fromIdentity,
+				Body:              body,
+				NoAttachments:     noAttachments,
+				MaxAttachmentSize: maxSize,
 			}
+			kept, dropped := jmap.FilterForwardAttachments(original.Attachments, opts)
 
 			resolvedFrom, fromSource, err := client.ResolveForwardFrom(cmd.Context(), original, opts)
 			if err != nil {
@@ -86,6 +104,18 @@ Examples:
 				"forwardedTo":     to,
 				"from":            resolvedFrom,
 				"fromSource":      fromSource,
+				"attachments":     len(kept),
+			}
+			if len(dropped) > 0 {
+				droppedInfo := make([]map[string]any, len(dropped))
+				for i, att := range dropped {
+					droppedInfo[i] = map[string]any{
+						"blobId": att.BlobID,
+						"name":   att.Name,
+						"size":   att.Size,
+					}
+				}
+				result["droppedAttachments"] = droppedInfo
 			}
 
 			if app.IsJSON(cmd.Context()) {
@@ -95,8 +125,14 @@ Examples:
 			fmt.Printf("Email forwarded successfully (submission ID: %s)\n", submissionID)
 			fmt.Printf("  From: %s (%s)\n", resolvedFrom, fromSource)
 			fmt.Printf("  To: %s\n", strings.Join(to, ", "))
-			if len(original.Attachments) > 0 {
-				fmt.Printf("  Attachments: %d included\n", len(original.Attachments))
+			if len(kept) > 0 {
+				fmt.Printf("  Attachments: %d included\n", len(kept))
+			}
+			if len(dropped) > 0 {
+				fmt.Printf("  Dropped attachments: %d\n", len(dropped))
+				for _, att := range dropped {
+					fmt.Printf("    %s (%s)\n", att.Name, format.FormatBytes(att.Size))
+				}
 			}
 
 			return nil
@@ -106,6 +142,8 @@ Examples:
 	cmd.Flags().StringSliceVar(&to, "to", nil, "Recipient email addresses (required)")
 	cmd.Flags().StringVar(&fromIdentity, "from", "", "Send from this identity or masked email (default: auto-detect from original)")
 	cmd.Flags().StringVar(&body, "body", "", "Optional message to prepend to the forwarded email")
+	cmd.Flags().BoolVar(&noAttachments, "no-attachments", false, "Do not include the original email's attachments")
+	cmd.Flags().StringVar(&maxAttachmentSize, "max-attachment-size", "", "Drop original attachments larger than this size (e.g. 10MB)")
 
 	return cmd
 }
@@ -1,61 +1,152 @@
 package cmd
 
 import (
+	"context"
 	"fmt"
+	"strings"
 
 	cerrors "github.com/salmonumbrella/fastmail-cli/internal/errors"
-	"github.com/salmonumbrella/fastmail-cli/internal/format"
+	"github.com/salmonumbrella/fastmail-cli/internal/jmap"
 	"github.com/spf13/cobra"
 )
 
+// emailGetOutput is the JSON shape of email get.
+type emailGetOutput struct {
+	EmailOutput
+	MarkedRead bool `json:"markedRead,omitempty"`
+}
+
+// emailGetManyOutput is the JSON shape of email get with several IDs.
+type emailGetManyOutput struct {
+	Emails   []emailGetOutput `json:"emails"`
+	NotFound []string         `json:"notFound"`
+}
+
 func newEmailGetCmd(app *App) *cobra.Command {
+	var markRead bool
+	var stripQuotes bool
+
 	cmd := &cobra.Command{
-		Use:     "get <emailId>",
+		Use:     "get <emailId> [emailId...]",
 		Aliases: []string{"show", "cat"},
-		Short:   "Get email by ID",
-		Args:    cobra.ExactArgs(1),
+		Short:   "Get emails by ID",
+		Long: `Get one or more emails by ID.
+
+Several IDs are fetched in a single request. Emails that exist are printed
+and missing IDs are reported at the end; the command then exits with the
+not-found exit code.
+
+Fetching does not change the email. With --mark-read (or FASTMAIL_MARK_READ=1)
+it is marked as read afterwards, like opening it in a mail client.
+
+--strip-quotes leaves the quoted history ("On ... wrote:" and the ">" lines
+below it) out of the text body. JSON output always has the full body.
+
+Examples:
+  fastmail email get ABC123
+  fastmail email get ABC123 --strip-quotes
+  fastmail email get ABC123 DEF456 GHI789 --output json`,
+		Args: cobra.MinimumNArgs(1),
 		RunE: runE(app, func(cmd *cobra.Command, args []string, app *App) error {
 			client, err := app.JMAPClient()
 			if err != nil {
 				return err
 			}
 
+			if len(args) > 1 {
+				return getEmails(cmd, app, client, args, markRead, stripQuotes)
+			}
+
 			email, err := client.GetEmailByID(cmd.Context(), args[0])
 			if err != nil {
 				return cerrors.WithContext(err, "fetching email")
 			}
 
-			if app.IsJSON(cmd.Context()) {
-				return app.PrintJSON(cmd, emailToOutput(*email))
+			var markedRead bool
+			if markRead {
+				if markedRead, err = markFetchedEmailRead(cmd.Context(), client, email); err != nil {
+					return cerrors.WithContext(err, "marking email read")
+				}
 			}
 
-			// Text output
-			fmt.Printf("ID:        %s\n", email.ID)
-			fmt.Printf("Subject:   %s\n", email.Subject)
-			fmt.Printf("From:      %s\n", format.FormatEmailAddressList(email.From))
-			fmt.Printf("To:        %s\n", format.FormatEmailAddressList(email.To))
-			if len(email.CC) > 0 {
-				fmt.Printf("CC:        %s\n", format.FormatEmailAddressList(email.CC))
-			}
-			fmt.Printf("Date:      %s\n", email.ReceivedAt)
-			fmt.Printf("Thread ID: %s\n", email.ThreadID)
-			fmt.Printf("Attachments: %d\n", len(email.Attachments))
-			fmt.Println()
-
-			// Print body
-			if len(email.TextBody) > 0 && len(email.BodyValues) > 0 {
-				for _, part := range email.TextBody {
-					if body, ok := email.BodyValues[part.PartID]; ok {
-						fmt.Println(body.Value)
-					}
-				}
-			} else if email.Preview != "" {
-				fmt.Println(email.Preview)
+			if app.IsJSON(cmd.Context()) {
+				return app.PrintJSON(cmd, emailGetOutput{
+					EmailOutput: emailToOutput(*email),
+					MarkedRead:  markedRead,
+				})
 			}
 
+			printEmailDetails(email, stripQuotes)
 			return nil
 		}),
 	}
 
+	cmd.Flags().BoolVar(&markRead, "mark-read", envBool("FASTMAIL_MARK_READ", false), "Mark the email as read after fetching it")
+	cmd.Flags().BoolVar(&stripQuotes, "strip-quotes", false, "Leave quoted reply text out of the displayed body (text output only)")
+
 	return cmd
 }
+
+// getEmails implements email get with several IDs. It returns an
+// ErrEmailNotFound error naming the missing IDs after printing the rest.
+func getEmails(cmd *cobra.Command, app *App, client jmap.EmailService, ids []string, markRead, stripQuotes bool) error {
+	emails, notFound, err := client.GetEmailsByIDs(cmd.Context(), ids)
+	if err != nil {
+		return cerrors.WithContext(err, "fetching emails")
+	}
+
+	markedRead := make([]bool, len(emails))
+	if markRead {
+		for i := range emails {
+			if markedRead[i], err = markFetchedEmailRead(cmd.Context(), client, &emails[i]); err != nil {
+				return cerrors.WithContext(err, "marking email read")
+			}
+		}
+	}
+
+	var missingErr error
+	if len(notFound) > 0 {
+		missingErr = fmt.Errorf("%w: %s", jmap.ErrEmailNotFound, strings.Join(notFound, ", "))
+	}
+
+	if app.IsJSON(cmd.Context()) {
+		out := emailGetManyOutput{
+			Emails:   make([]emailGetOutput, 0, len(emails)),
+			NotFound: notFound,
+		}
+		for i, email := range emails {
+			out.Emails = append(out.Emails, emailGetOutput{
+				EmailOutput: emailToOutput(email),
+				MarkedRead:  markedRead[i],
+			})
+		}
+		if err := app.PrintJSON(cmd, out); err != nil {
+			return err
+		}
+		return missingErr
+	}
+
+	for i := range emails {
+		if i > 0 {
+			fmt.Println(strings.Repeat("-", 72))
+		}
+		printEmailDetails(&emails[i], stripQuotes)
+	}
+	return missingErr
+}
+
+// markFetchedEmailRead marks email as read unless it already is, and
+// updates its keywords to match. It reports whether the keyword was written.
+func markFetchedEmailRead(ctx context.Context, client jmap.EmailService, email *jmap.Email) (bool, error) {
+	if email.Keywords["$seen"] {
+		return false, nil
+	}
+	if err := client.MarkEmailRead(ctx, email.ID, true); err != nil {
+		return false, err
+	}
+	if email.Keywords == nil {
+		email.Keywords = make(map[string]bool)
+	}
+	email.Keywords["$seen"] = true
+	return true, nil
+}
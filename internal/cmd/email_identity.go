@@ -0,0 +1,302 @@
+package cmd
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	cerrors "github.com/salmonumbrella/fastmail-cli/internal/errors"
+	"github.com/salmonumbrella/fastmail-cli/internal/jmap"
+	"github.com/salmonumbrella/fastmail-cli/internal/validation"
+	"github.com/spf13/cobra"
+)
+
+func newEmailIdentityCmd(app *App) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "identity",
+		Short: "Create, update, and delete sending identities (aliases)",
+		Long: `Manage sending identities (aliases).
+
+Use 'fastmail email identities' to list them and
+'fastmail email identity-set-default' to choose the default.`,
+	}
+
+	cmd.AddCommand(newIdentityCreateCmd(app))
+	cmd.AddCommand(newIdentityUpdateCmd(app))
+	cmd.AddCommand(newIdentityDeleteCmd(app))
+
+	return cmd
+}
+
+func newIdentityCreateCmd(app *App) *cobra.Command {
+	var opts jmap.IdentityOpts
+
+	cmd := &cobra.Command{
+		Use:   "create <email>",
+		Short: "Add a send-only identity",
+		Long: `Add a sending identity for an address you are allowed to send from.
+
+Fastmail only accepts addresses on your domains or aliases it has verified.`,
+		Example: `  fastmail email identity create sales@example.com --name "Example Sales"
+  fastmail email identity create me@example.com --reply-to team@example.com --text-signature "Me"`,
+		Args: cobra.ExactArgs(1),
+		RunE: runE(app, func(cmd *cobra.Command, args []string, app *App) error {
+			opts.Email = strings.TrimSpace(args[0])
+			for _, addr := range append([]string{opts.Email}, opts.ReplyTo...) {
+				if !validation.IsValidEmail(addr) {
+					return fmt.Errorf("invalid email address: %s", addr)
+				}
+			}
+
+			client, err := app.JMAPClient()
+			if err != nil {
+				return err
+			}
+
+			identity, err := client.CreateIdentity(cmd.Context(), opts)
+			if err != nil {
+				return cerrors.WithContext(err, "creating identity")
+			}
+
+			if app.IsJSON(cmd.Context()) {
+				return app.PrintJSON(cmd, identity)
+			}
+
+			fmt.Printf("Created identity %s (ID: %s)\n", identity.Email, identity.ID)
+			return nil
+		}),
+	}
+
+	cmd.Flags().StringVar(&opts.Name, "name", "", "Display name")
+	cmd.Flags().StringSliceVar(&opts.ReplyTo, "reply-to", nil, "Reply-To addresses")
+	cmd.Flags().StringVar(&opts.TextSignature, "text-signature", "", "Plain-text signature")
+	cmd.Flags().StringVar(&opts.HTMLSignature, "html-signature", "", "HTML signature")
+
+	return cmd
+}
+
+func newIdentityUpdateCmd(app *App) *cobra.Command {
+	var (
+		name          string
+		replyTo       []string
+		noReplyTo     bool
+		textSignature string
+		htmlSignature string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "update <identity-id-or-email>",
+		Short: "Change an identity's name, Reply-To, or signatures",
+		Long: `Change an identity's name, Reply-To addresses, or signatures.
+
+Only the given flags are changed; pass an empty value to clear a name or
+signature. The email address of an identity cannot be changed.`,
+		Example: `  fastmail email identity update sales@example.com --name "Sales Team"
+  fastmail email identity update sales@example.com --no-reply-to --text-signature ""`,
+		Args: cobra.ExactArgs(1),
+		RunE: runE(app, func(cmd *cobra.Command, args []string, app *App) error {
+			if noReplyTo && len(replyTo) > 0 {
+				return fmt.Errorf("--reply-to and --no-reply-to cannot be used together")
+			}
+
+			var opts jmap.UpdateIdentityOpts
+			flags := cmd.Flags()
+			if flags.Changed("name") {
+				opts.Name = &name
+			}
+			if len(replyTo) > 0 {
+				opts.ReplyTo = replyTo
+			} else if noReplyTo {
+				opts.ReplyTo = []string{}
+			}
+			if flags.Changed("text-signature") {
+				opts.TextSignature = &textSignature
+			}
+			if flags.Changed("html-signature") {
+				opts.HTMLSignature = &htmlSignature
+			}
+			if opts.Name == nil && opts.ReplyTo == nil && opts.TextSignature == nil && opts.HTMLSignature == nil {
+				return fmt.Errorf("nothing to update: pass --name, --reply-to, --no-reply-to, --text-signature, or --html-signature")
+			}
+
+			client, err := app.JMAPClient()
+			if err != nil {
+				return err
+			}
+
+			identity, err := resolveIdentity(cmd.Context(), client, args[0])
+			if err != nil {
+				return err
+			}
+
+			if err := client.UpdateIdentity(cmd.Context(), identity.ID, opts); err != nil {
+				return cerrors.WithContext(err, "updating identity")
+			}
+
+			if app.IsJSON(cmd.Context()) {
+				return app.PrintJSON(cmd, map[string]any{
+					"status":  "updated",
+					"updated": identity.ID,
+					"email":   identity.Email,
+				})
+			}
+
+			fmt.Printf("Updated identity %s\n", identity.Email)
+			return nil
+		}),
+	}
+
+	cmd.Flags().StringVar(&name, "name", "", "Display name")
+	cmd.Flags().StringSliceVar(&replyTo, "reply-to", nil, "Replace the Reply-To addresses")
+	cmd.Flags().BoolVar(&noReplyTo, "no-reply-to", false, "Remove all Reply-To addresses")
+	cmd.Flags().StringVar(&textSignature, "text-signature", "", "Plain-text signature")
+	cmd.Flags().StringVar(&htmlSignature, "html-signature", "", "HTML signature")
+
+	return cmd
+}
+
+func newIdentityDeleteCmd(app *App) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "delete <identity-id-or-email>",
+		Short: "Delete a sending identity",
+		Long: `Delete a sending identity (alias).
+
+The account's primary identity cannot be deleted.`,
+		Args: cobra.ExactArgs(1),
+		RunE: runE(app, func(cmd *cobra.Command, args []string, app *App) error {
+			client, err := app.JMAPClient()
+			if err != nil {
+				return err
+			}
+
+			identity, err := resolveIdentity(cmd.Context(), client, args[0])
+			if err != nil {
+				return err
+			}
+			if !identity.MayDelete {
+				return fmt.Errorf("%w: %s", jmap.ErrIdentityNotDeletable, identity.Email)
+			}
+
+			confirmed, err := app.Confirm(cmd, false, fmt.Sprintf("Delete identity '%s' (ID: %s)? [y/N] ", identity.Email, identity.ID), "y", "yes")
+			if err != nil {
+				return err
+			}
+			if !confirmed {
+				printCancelled()
+				return nil
+			}
+
+			if err := client.DeleteIdentity(cmd.Context(), identity); err != nil {
+				return cerrors.WithContext(err, "deleting identity")
+			}
+
+			if app.IsJSON(cmd.Context()) {
+				return app.PrintJSON(cmd, map[string]any{
+					"status":  "deleted",
+					"deleted": identity.ID,
+					"email":   identity.Email,
+				})
+			}
+
+			fmt.Printf("Deleted identity %s\n", identity.Email)
+			return nil
+		}),
+	}
+
+	return cmd
+}
+
+// resolveIdentity finds an identity by ID or, case-insensitively, by email.
+func resolveIdentity(ctx context.Context, client jmap.EmailService, idOrEmail string) (jmap.Identity, error) {
+	identities, err := client.GetIdentities(ctx)
+	if err != nil {
+		return jmap.Identity{}, cerrors.WithContext(err, "fetching identities")
+	}
+
+	target := strings.TrimSpace(idOrEmail)
+	for _, id := range identities {
+		if id.ID == target {
+			return id, nil
+		}
+	}
+
+	var matches []jmap.Identity
+	for _, id := range identities {
+		if strings.EqualFold(id.Email, target) {
+			matches = append(matches, id)
+		}
+	}
+	switch len(matches) {
+	case 0:
+		return jmap.Identity{}, fmt.Errorf("%w: %s", jmap.ErrIdentityNotFound, target)
+	case 1:
+		return matches[0], nil
+	}
+
+	ids := make([]string, len(matches))
+	for i, id := range matches {
+		ids[i] = id.ID
+	}
+	return jmap.Identity{}, fmt.Errorf("several identities use %s; pass one of the IDs: %s", target, strings.Join(ids, ", "))
+}
+
+// pickIdentity shows identities as a numbered list on w and reads the choice
+// from r. An empty answer selects the default: defaultEmail if it matches an
+// identity, otherwise the primary (undeletable) one. Returns the chosen
+// identity's email.
+func pickIdentity(r io.Reader, w io.Writer, identities []jmap.Identity, defaultEmail string) (string, error) {
+	if len(identities) == 0 {
+		return "", jmap.ErrNoIdentities
+	}
+
+	def := -1
+	for i, id := range identities {
+		if defaultEmail != "" && strings.EqualFold(id.Email, defaultEmail) {
+			def = i
+			break
+		}
+	}
+	if def < 0 {
+		def = 0
+		for i, id := range identities {
+			if !id.MayDelete {
+				def = i
+				break
+			}
+		}
+	}
+
+	fmt.Fprintln(w, "Send from:")
+	for i, id := range identities {
+		label := id.Email
+		if id.Name != "" {
+			label = fmt.Sprintf("%s <%s>", id.Name, id.Email)
+		}
+		if i == def {
+			label += " (default)"
+		}
+		fmt.Fprintf(w, "  %d) %s\n", i+1, label)
+	}
+	fmt.Fprintf(w, "Choose [1-%d] (Enter for default): ", len(identities))
+
+	scanner := bufio.NewScanner(r)
+	if !scanner.Scan() {
+		if err := scanner.Err(); err != nil {
+			return "", fmt.Errorf("failed to read selection: %w", err)
+		}
+		return "", fmt.Errorf("cancelled")
+	}
+
+	answer := strings.TrimSpace(scanner.Text())
+	if answer == "" {
+		return identities[def].Email, nil
+	}
+	n, err := strconv.Atoi(answer)
+	if err != nil || n < 1 || n > len(identities) {
+		return "", fmt.Errorf("invalid selection %q: enter a number from 1 to %d", answer, len(identities))
+	}
+	return identities[n-1].Email, nil
+}
@@ -1,7 +1,14 @@
 package cmd
 
 import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"strings"
 	"testing"
+
+	"github.com/salmonumbrella/fastmail-cli/internal/jmap"
 )
 
 func TestIdentitySetDefaultCmd_RequiresArg(t *testing.T) {
@@ -84,3 +91,119 @@ func TestEmailCmd_HasIdentitySetDefaultSubcommand(t *testing.T) {
 		t.Error("email command should have identity-set-default subcommand")
 	}
 }
+
+func TestResolveIdentity(t *testing.T) {
+	client := &jmap.MockEmailService{
+		GetIdentitiesFunc: func(ctx context.Context) ([]jmap.Identity, error) {
+			return []jmap.Identity{
+				{ID: "primary", Email: "me@example.com"},
+				{ID: "alias", Email: "Alias@example.com", MayDelete: true},
+				{ID: "dup1", Email: "dup@example.com", MayDelete: true},
+				{ID: "dup2", Email: "dup@example.com", MayDelete: true},
+			}, nil
+		},
+	}
+	ctx := context.Background()
+
+	if got, err := resolveIdentity(ctx, client, "alias"); err != nil || got.ID != "alias" {
+		t.Errorf("by ID = %+v, %v", got, err)
+	}
+	if got, err := resolveIdentity(ctx, client, "alias@EXAMPLE.com"); err != nil || got.ID != "alias" {
+		t.Errorf("by email = %+v, %v", got, err)
+	}
+	if _, err := resolveIdentity(ctx, client, "nobody@example.com"); !errors.Is(err, jmap.ErrIdentityNotFound) {
+		t.Errorf("unknown error = %v, want ErrIdentityNotFound", err)
+	}
+	if _, err := resolveIdentity(ctx, client, "dup@example.com"); err == nil || !strings.Contains(err.Error(), "dup1, dup2") {
+		t.Errorf("ambiguous error = %v, want the candidate IDs", err)
+	}
+}
+
+func TestIdentityUpdateCmd_RequiresChange(t *testing.T) {
+	app := newTestApp()
+	cmd := newIdentityUpdateCmd(app)
+	cmd.SetArgs([]string{"alias@example.com"})
+	cmd.SetOut(io.Discard)
+	cmd.SetErr(io.Discard)
+
+	err := cmd.Execute()
+	if err == nil || !strings.Contains(err.Error(), "nothing to update") {
+		t.Errorf("error = %v, want nothing to update", err)
+	}
+}
+
+func TestEmailCmd_HasIdentitySubcommands(t *testing.T) {
+	app := newTestApp()
+	cmd, _, err := newEmailCmd(app).Find([]string{"identity"})
+	if err != nil || cmd.Name() != "identity" {
+		t.Fatalf("identity subcommand not found: %v", err)
+	}
+	for _, name := range []string{"create", "update", "delete"} {
+		if sub, _, err := cmd.Find([]string{name}); err != nil || sub.Name() != name {
+			t.Errorf("identity %s not registered", name)
+		}
+	}
+}
+
+func TestPickIdentity(t *testing.T) {
+	identities := []jmap.Identity{
+		{ID: "I1", Email: "alias@example.com", MayDelete: true},
+		{ID: "I2", Name: "Me", Email: "me@example.com"},
+		{ID: "I3", Email: "work@example.com", MayDelete: true},
+	}
+
+	tests := []struct {
+		name          string
+		input         string
+		defaultEmail  string
+		want, wantErr string
+	}{
+		{name: "number", input: "3\n", want: "work@example.com"},
+		{name: "enter picks primary", input: "\n", want: "me@example.com"},
+		{name: "enter picks configured default", input: "\n", defaultEmail: "ALIAS@example.com", want: "alias@example.com"},
+		{name: "out of range", input: "4\n", wantErr: "enter a number from 1 to 3"},
+		{name: "not a number", input: "me\n", wantErr: "invalid selection"},
+		{name: "no input", input: "", wantErr: "cancelled"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var prompt bytes.Buffer
+			got, err := pickIdentity(strings.NewReader(tt.input), &prompt, identities, tt.defaultEmail)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("err = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("pickIdentity: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("picked %q, want %q", got, tt.want)
+			}
+			if !strings.Contains(prompt.String(), "2) Me <me@example.com>") {
+				t.Errorf("prompt missing numbered identity: %q", prompt.String())
+			}
+		})
+	}
+
+	if _, err := pickIdentity(strings.NewReader("1\n"), io.Discard, nil, ""); !errors.Is(err, jmap.ErrNoIdentities) {
+		t.Errorf("no identities: err = %v", err)
+	}
+}
+
+func TestIdentityCreateCmd_RejectsInvalidEmail(t *testing.T) {
+	for _, args := range [][]string{
+		{"not-an-email"},
+		{"sales@example.com", "--reply-to", "team"},
+	} {
+		cmd := newIdentityCreateCmd(newTestApp())
+		cmd.SetArgs(args)
+		cmd.SilenceUsage = true
+		cmd.SilenceErrors = true
+		if err := cmd.Execute(); err == nil || !strings.Contains(err.Error(), "invalid email address") {
+			t.Errorf("%v: error = %v, want invalid email address", args, err)
+		}
+	}
+}
@@ -1,8 +1,12 @@
 package cmd
 
 import (
+	"context"
 	"fmt"
+	"io"
+	"net/mail"
 	"os"
+	"time"
 
 	cerrors "github.com/salmonumbrella/fastmail-cli/internal/errors"
 	"github.com/salmonumbrella/fastmail-cli/internal/jmap"
@@ -12,13 +16,17 @@ import (
 func newEmailImportCmd(app *App) *cobra.Command {
 	var mailbox string
 	var markRead bool
+	var receivedAtStr string
 
 	cmd := &cobra.Command{
 		Use:   "import <file.eml>",
 		Short: "Import an email from a .eml file",
 		Long: `Import a raw RFC 5322 email message (.eml file) into your mailbox.
 
-The email will be imported with its original headers and content.
+The email will be imported with its original headers and content. Its received
+date is taken from the message's Date header, so it sorts where it belongs;
+use --received-at to set it explicitly. If the header is missing or cannot be
+parsed, the server uses the current time.
 By default, emails are imported to the Inbox and marked as unread.`,
 		Args: cobra.ExactArgs(1),
 		RunE: runE(app, func(cmd *cobra.Command, args []string, app *App) error {
@@ -38,34 +46,27 @@ By default, emails are imported to the Inbox and marked as unread.`,
 				return fmt.Errorf("cannot import directory: %s", emlPath)
 			}
 
-			// Determine target mailbox
-			targetMailboxID := mailbox
-			if targetMailboxID == "" {
-				// Default to inbox
-				var inbox *jmap.Mailbox
-				inbox, err = client.GetMailboxByName(cmd.Context(), "inbox")
+			var receivedAt string
+			if receivedAtStr != "" {
+				t, err := parseDateTime(receivedAtStr)
 				if err != nil {
-					return fmt.Errorf("failed to find inbox: %w", err)
+					return fmt.Errorf("invalid --received-at: %w", err)
 				}
-				targetMailboxID = inbox.ID
+				receivedAt = t.UTC().Format(time.RFC3339)
 			} else {
-				// Resolve mailbox name/ID
-				var resolvedID string
-				resolvedID, err = client.ResolveMailboxID(cmd.Context(), targetMailboxID)
+				receivedAt, err = fileMessageDate(emlPath)
 				if err != nil {
-					return fmt.Errorf("invalid mailbox: %w", err)
+					return fmt.Errorf("cannot read file '%s': %w", emlPath, err)
 				}
-				targetMailboxID = resolvedID
 			}
 
-			// Open and upload the .eml file
-			file, err := os.Open(emlPath)
+			targetMailboxID, err := resolveImportMailbox(cmd.Context(), client, mailbox)
 			if err != nil {
-				return fmt.Errorf("failed to open file '%s': %w", emlPath, err)
+				return err
 			}
-			defer file.Close()
 
-			uploadResult, err := client.UploadBlob(cmd.Context(), file, "message/rfc822")
+			// Upload the .eml file
+			uploadResult, err := client.UploadBlobFromFile(cmd.Context(), emlPath, "message/rfc822")
 			if err != nil {
 				return fmt.Errorf("failed to upload email: %w", err)
 			}
@@ -74,6 +75,7 @@ By default, emails are imported to the Inbox and marked as unread.`,
 			opts := jmap.ImportEmailOpts{
 				BlobID:     uploadResult.BlobID,
 				MailboxIDs: map[string]bool{targetMailboxID: true},
+				ReceivedAt: receivedAt,
 			}
 
 			if markRead {
@@ -87,10 +89,11 @@ By default, emails are imported to the Inbox and marked as unread.`,
 
 			if app.IsJSON(cmd.Context()) {
 				return app.PrintJSON(cmd, map[string]any{
-					"emailId":   emailID,
-					"blobId":    uploadResult.BlobID,
-					"mailboxId": targetMailboxID,
-					"file":      emlPath,
+					"emailId":    emailID,
+					"blobId":     uploadResult.BlobID,
+					"mailboxId":  targetMailboxID,
+					"file":       emlPath,
+					"receivedAt": receivedAt,
 				})
 			}
 
@@ -101,6 +104,51 @@ By default, emails are imported to the Inbox and marked as unread.`,
 
 	cmd.Flags().StringVar(&mailbox, "mailbox", "", "Target mailbox ID or name (default: Inbox)")
 	cmd.Flags().BoolVar(&markRead, "read", false, "Mark imported email as read")
+	cmd.Flags().StringVar(&receivedAtStr, "received-at", "", "Received date to set instead of the Date header (RFC3339, YYYY-MM-DD, or relative)")
 
 	return cmd
 }
+
+// resolveImportMailbox returns the ID of the named mailbox, or of the Inbox
+// when mailbox is empty.
+func resolveImportMailbox(ctx context.Context, client jmap.EmailService, mailbox string) (string, error) {
+	if mailbox == "" {
+		inbox, err := client.GetMailboxByName(ctx, "inbox")
+		if err != nil {
+			return "", fmt.Errorf("failed to find inbox: %w", err)
+		}
+		return inbox.ID, nil
+	}
+
+	mailboxID, err := client.ResolveMailboxID(ctx, mailbox)
+	if err != nil {
+		return "", fmt.Errorf("invalid mailbox: %w", err)
+	}
+	return mailboxID, nil
+}
+
+// fileMessageDate reads the Date header of the message in path; see
+// messageDate.
+func fileMessageDate(path string) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+	return messageDate(f), nil
+}
+
+// messageDate returns the Date header of an RFC 5322 message as an RFC 3339
+// UTC time, or "" if it is missing or cannot be parsed. Only the header is
+// read.
+func messageDate(r io.Reader) string {
+	m, err := mail.ReadMessage(r)
+	if err != nil {
+		return ""
+	}
+	date, err := m.Header.Date()
+	if err != nil {
+		return ""
+	}
+	return date.UTC().Format(time.RFC3339)
+}
@@ -0,0 +1,216 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/salmonumbrella/fastmail-cli/internal/format"
+	"github.com/salmonumbrella/fastmail-cli/internal/jmap"
+	"github.com/spf13/cobra"
+)
+
+// emlImportResult records the outcome for one .eml file, named relative to
+// the imported directory.
+type emlImportResult struct {
+	File    string `json:"file"`
+	EmailID string `json:"emailId,omitempty"`
+	Error   string `json:"error,omitempty"`
+}
+
+func newEmailImportDirCmd(app *App) *cobra.Command {
+	var mailbox string
+	var markRead bool
+	var concurrency int
+
+	cmd := &cobra.Command{
+		Use:   "import-dir <dir>",
+		Short: "Import every .eml file in a directory",
+		Long: `Import all .eml files found in a directory and its subdirectories.
+
+Each message is uploaded and imported on its own, with up to --concurrency
+files in flight. The message's Date header, when it can be parsed, is kept as
+the received date. A failure is reported at the end and does not stop the
+other files; the command fails only if no file could be imported. By
+default, messages are imported to the Inbox and left unread.`,
+		Example: `  fastmail email import-dir ./export
+  fastmail email import-dir ./old-mail --mailbox Archive --mark-read`,
+		Args: cobra.ExactArgs(1),
+		RunE: runE(app, func(cmd *cobra.Command, args []string, app *App) error {
+			dir := args[0]
+			if concurrency < 1 {
+				return fmt.Errorf("--concurrency must be at least 1")
+			}
+
+			files, err := findEMLFiles(dir)
+			if err != nil {
+				return err
+			}
+			if len(files) == 0 {
+				if app.IsJSON(cmd.Context()) {
+					return app.PrintJSON(cmd, map[string]any{
+						"dir":      dir,
+						"imported": []emlImportResult{},
+						"failed":   map[string]string{},
+					})
+				}
+				printNoResults("No .eml files found in %s", dir)
+				return nil
+			}
+
+			client, err := app.JMAPClient()
+			if err != nil {
+				return err
+			}
+
+			mailboxID, err := resolveImportMailbox(cmd.Context(), client, mailbox)
+			if err != nil {
+				return err
+			}
+
+			results := importEMLFiles(cmd.Context(), client, dir, files, mailboxID, markRead, concurrency)
+
+			imported := []emlImportResult{}
+			failed := map[string]string{}
+			for _, r := range results {
+				if r.Error != "" {
+					failed[r.File] = r.Error
+					continue
+				}
+				imported = append(imported, r)
+			}
+
+			failedErr := errNoneSucceeded("file imports", len(imported), len(results))
+
+			if app.IsJSON(cmd.Context()) {
+				if err := app.PrintJSON(cmd, map[string]any{
+					"dir":       dir,
+					"mailboxId": mailboxID,
+					"imported":  imported,
+					"failed":    failed,
+				}); err != nil {
+					return err
+				}
+				return failedErr
+			}
+
+			printBulkResults("Imported", "emails", len(imported), len(failed), failed)
+			return failedErr
+		}),
+	}
+
+	cmd.Flags().StringVar(&mailbox, "mailbox", "", "Target mailbox ID or name (default: Inbox)")
+	cmd.Flags().BoolVar(&markRead, "mark-read", false, "Mark imported messages as read")
+	cmd.Flags().IntVar(&concurrency, "concurrency", defaultUploadConcurrency, "Maximum number of files imported in parallel")
+
+	return cmd
+}
+
+// findEMLFiles returns the paths of the .eml files under dir in lexical
+// order.
+func findEMLFiles(dir string) ([]string, error) {
+	info, err := os.Stat(dir)
+	if err != nil {
+		return nil, fmt.Errorf("cannot access directory '%s': %w", dir, err)
+	}
+	if !info.IsDir() {
+		return nil, fmt.Errorf("not a directory: %s", dir)
+	}
+
+	var files []string
+	err = filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !d.IsDir() && strings.EqualFold(filepath.Ext(path), ".eml") {
+			files = append(files, path)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("reading directory '%s': %w", dir, err)
+	}
+	return files, nil
+}
+
+// importEMLFiles imports files with at most concurrency imports in flight.
+// Results keep the order of files; per-file failures are recorded rather
+// than returned.
+func importEMLFiles(ctx context.Context, client jmap.EmailService, dir string, files []string, mailboxID string, markRead bool, concurrency int) []emlImportResult {
+	results := make([]emlImportResult, len(files))
+	sem := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+
+	for i, path := range files {
+		name, err := filepath.Rel(dir, path)
+		if err != nil {
+			name = path
+		}
+		results[i].File = name
+
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+			results[i].Error = ctx.Err().Error()
+			continue
+		}
+
+		wg.Add(1)
+		go func(i int, path string) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			emailID, err := importEMLFile(ctx, client, path, mailboxID, markRead)
+			if err != nil {
+				results[i].Error = err.Error()
+				return
+			}
+			results[i].EmailID = emailID
+		}(i, path)
+	}
+	wg.Wait()
+
+	return results
+}
+
+// importEMLFile uploads and imports one .eml file, using its Date header as
+// the received date when it parses.
+func importEMLFile(ctx context.Context, client jmap.EmailService, path, mailboxID string, markRead bool) (string, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return "", err
+	}
+	if info.Size() > jmap.MaxUploadSize {
+		return "", fmt.Errorf("file too large (%s, max 50 MB)", format.FormatBytes(info.Size()))
+	}
+
+	msg, err := os.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+
+	upload, err := client.UploadBlob(ctx, bytes.NewReader(msg), "message/rfc822")
+	if err != nil {
+		return "", fmt.Errorf("upload failed: %w", err)
+	}
+
+	opts := jmap.ImportEmailOpts{
+		BlobID:     upload.BlobID,
+		MailboxIDs: map[string]bool{mailboxID: true},
+		ReceivedAt: messageDate(bytes.NewReader(msg)),
+	}
+	if markRead {
+		opts.Keywords = map[string]bool{"$seen": true}
+	}
+
+	emailID, err := client.ImportEmail(ctx, opts)
+	if err != nil {
+		return "", fmt.Errorf("import failed: %w", err)
+	}
+	return emailID, nil
+}
@@ -0,0 +1,106 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/salmonumbrella/fastmail-cli/internal/jmap"
+)
+
+func TestFindEMLFiles(t *testing.T) {
+	dir := t.TempDir()
+	for _, name := range []string{"b.eml", "a.EML", "notes.txt", filepath.Join("sub", "c.eml")} {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte("Subject: x\n\nbody\n"), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	files, err := findEMLFiles(dir)
+	if err != nil {
+		t.Fatalf("findEMLFiles() error = %v", err)
+	}
+	want := []string{"a.EML", "b.eml", filepath.Join("sub", "c.eml")}
+	if len(files) != len(want) {
+		t.Fatalf("files = %v, want %v", files, want)
+	}
+	for i, f := range files {
+		if rel, _ := filepath.Rel(dir, f); rel != want[i] {
+			t.Errorf("files[%d] = %q, want %q", i, rel, want[i])
+		}
+	}
+
+	if _, err := findEMLFiles(filepath.Join(dir, "b.eml")); err == nil {
+		t.Error("findEMLFiles() on a file should fail")
+	}
+}
+
+func TestImportEMLFiles(t *testing.T) {
+	dir := t.TempDir()
+	messages := map[string]string{
+		"1.eml": "Date: Wed, 15 Jan 2025 10:30:00 +0100\nSubject: one\n\nbody\n",
+		"2.eml": "Subject: two\n\nbody\n",
+		"3.eml": "Date: not a date\nSubject: three\n\nbody\n",
+	}
+	var files []string
+	for _, name := range []string{"1.eml", "2.eml", "3.eml"} {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte(messages[name]), 0o600); err != nil {
+			t.Fatal(err)
+		}
+		files = append(files, path)
+	}
+
+	var mu sync.Mutex
+	receivedAt := map[string]string{}
+	client := &jmap.MockEmailService{
+		UploadBlobFunc: func(ctx context.Context, reader io.Reader, contentType string) (*jmap.UploadBlobResult, error) {
+			data, _ := io.ReadAll(reader)
+			_, rest, _ := strings.Cut(string(data), "Subject: ")
+			subject, _, _ := strings.Cut(rest, "\n")
+			return &jmap.UploadBlobResult{BlobID: "blob-" + subject}, nil
+		},
+		ImportEmailFunc: func(ctx context.Context, opts jmap.ImportEmailOpts) (string, error) {
+			if !opts.MailboxIDs["mb-inbox"] {
+				t.Errorf("MailboxIDs = %v", opts.MailboxIDs)
+			}
+			mu.Lock()
+			receivedAt[opts.BlobID] = opts.ReceivedAt
+			mu.Unlock()
+			if opts.BlobID == "blob-two" {
+				return "", errors.New("invalidEmail")
+			}
+			return "email-" + opts.BlobID, nil
+		},
+	}
+
+	results := importEMLFiles(context.Background(), client, dir, files, "mb-inbox", false, 2)
+
+	if len(results) != 3 {
+		t.Fatalf("results = %+v", results)
+	}
+	if results[0].File != "1.eml" || results[0].EmailID != "email-blob-one" {
+		t.Errorf("results[0] = %+v", results[0])
+	}
+	if results[1].File != "2.eml" || !strings.Contains(results[1].Error, "invalidEmail") {
+		t.Errorf("results[1] = %+v", results[1])
+	}
+	if results[2].EmailID != "email-blob-three" {
+		t.Errorf("results[2] = %+v", results[2])
+	}
+	if got := receivedAt["blob-one"]; got != "2025-01-15T09:30:00Z" {
+		t.Errorf("receivedAt = %q, want Date header in UTC", got)
+	}
+	if got := receivedAt["blob-three"]; got != "" {
+		t.Errorf("receivedAt = %q, want empty for an unparseable Date", got)
+	}
+}
@@ -0,0 +1,168 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/salmonumbrella/fastmail-cli/internal/jmap"
+	"github.com/spf13/cobra"
+)
+
+// mboxImportResult records the outcome for one message of an mbox file,
+// numbered from 1 in file order.
+type mboxImportResult struct {
+	Index   int    `json:"index"`
+	EmailID string `json:"emailId,omitempty"`
+	Error   string `json:"error,omitempty"`
+}
+
+func newEmailImportMboxCmd(app *App) *cobra.Command {
+	var mailbox string
+	var markRead bool
+
+	cmd := &cobra.Command{
+		Use:   "import-mbox <file.mbox>",
+		Short: "Import every message of an mbox file",
+		Long: `Import all messages of an mbox file into a mailbox.
+
+The file is read one message at a time, so large archives are fine. Each
+message is uploaded and imported on its own; a failure is reported and the
+import continues with the next message; the command fails only if no
+message could be imported. By default, messages are imported to the Inbox
+and left unread.`,
+		Example: `  fastmail email import-mbox archive.mbox
+  fastmail email import-mbox old-work.mbox --mailbox Archive --mark-read`,
+		Args: cobra.ExactArgs(1),
+		RunE: runE(app, func(cmd *cobra.Command, args []string, app *App) error {
+			mboxPath := args[0]
+
+			file, err := os.Open(mboxPath)
+			if err != nil {
+				return fmt.Errorf("cannot access file '%s': %w", mboxPath, err)
+			}
+			defer file.Close()
+
+			client, err := app.JMAPClient()
+			if err != nil {
+				return err
+			}
+
+			mailboxID, err := resolveImportMailbox(cmd.Context(), client, mailbox)
+			if err != nil {
+				return err
+			}
+
+			var progress func(mboxImportResult)
+			if !app.IsJSON(cmd.Context()) {
+				progress = printMboxImportResult
+			}
+
+			results, err := importMbox(cmd.Context(), client, file, mailboxID, markRead, progress)
+			if err != nil {
+				return err
+			}
+
+			imported := 0
+			for _, r := range results {
+				if r.Error == "" {
+					imported++
+				}
+			}
+
+			failedErr := errNoneSucceeded("message imports", imported, len(results))
+
+			if app.IsJSON(cmd.Context()) {
+				if err := app.PrintJSON(cmd, map[string]any{
+					"file":      mboxPath,
+					"mailboxId": mailboxID,
+					"total":     len(results),
+					"imported":  imported,
+					"failed":    len(results) - imported,
+					"messages":  results,
+				}); err != nil {
+					return err
+				}
+				return failedErr
+			}
+
+			if len(results) == 0 {
+				printNoResults("No messages found in %s", mboxPath)
+				return nil
+			}
+			fmt.Printf("Imported %d of %d messages from %s\n", imported, len(results), mboxPath)
+			return failedErr
+		}),
+	}
+
+	cmd.Flags().StringVar(&mailbox, "mailbox", "", "Target mailbox ID or name (default: Inbox)")
+	cmd.Flags().BoolVar(&markRead, "mark-read", false, "Mark imported messages as read")
+
+	return cmd
+}
+
+// importMbox uploads and imports each message read from r. Per-message
+// failures are recorded in the results; only a malformed or unreadable file
+// stops the import. progress, when set, is called after each message.
+func importMbox(ctx context.Context, client jmap.EmailService, r io.Reader, mailboxID string, markRead bool, progress func(mboxImportResult)) ([]mboxImportResult, error) {
+	reader := newMboxReader(r)
+	results := []mboxImportResult{}
+
+	for index := 1; ; index++ {
+		msg, err := reader.Next()
+		if errors.Is(err, io.EOF) {
+			return results, nil
+		}
+		if err != nil {
+			return results, fmt.Errorf("reading message %d: %w", index, err)
+		}
+		if err := ctx.Err(); err != nil {
+			return results, err
+		}
+
+		result := mboxImportResult{Index: index}
+		emailID, err := importRawMessage(ctx, client, msg, mailboxID, markRead)
+		if err != nil {
+			result.Error = err.Error()
+		} else {
+			result.EmailID = emailID
+		}
+		results = append(results, result)
+		if progress != nil {
+			progress(result)
+		}
+	}
+}
+
+// importRawMessage uploads one RFC 5322 message and imports it.
+func importRawMessage(ctx context.Context, client jmap.EmailService, msg []byte, mailboxID string, markRead bool) (string, error) {
+	upload, err := client.UploadBlob(ctx, bytes.NewReader(msg), "message/rfc822")
+	if err != nil {
+		return "", fmt.Errorf("upload failed: %w", err)
+	}
+
+	opts := jmap.ImportEmailOpts{
+		BlobID:     upload.BlobID,
+		MailboxIDs: map[string]bool{mailboxID: true},
+	}
+	if markRead {
+		opts.Keywords = map[string]bool{"$seen": true}
+	}
+
+	emailID, err := client.ImportEmail(ctx, opts)
+	if err != nil {
+		return "", fmt.Errorf("import failed: %w", err)
+	}
+	return emailID, nil
+}
+
+func printMboxImportResult(r mboxImportResult) {
+	if r.Error != "" {
+		fmt.Printf("  #%d failed: %s\n", r.Index, r.Error)
+		return
+	}
+	fmt.Printf("  #%d imported (ID: %s)\n", r.Index, r.EmailID)
+}
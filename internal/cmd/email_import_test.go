@@ -0,0 +1,27 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestMessageDate(t *testing.T) {
+	tests := []struct {
+		name string
+		msg  string
+		want string
+	}{
+		{"rfc 5322 date", "Date: Wed, 15 Jan 2025 10:30:00 +0100\r\nSubject: x\r\n\r\nbody\r\n", "2025-01-15T09:30:00Z"},
+		{"zone comment", "Subject: x\nDate: Tue, 7 Jan 2025 23:05:09 -0500 (EST)\n\nbody\n", "2025-01-08T04:05:09Z"},
+		{"missing", "Subject: x\n\nbody\n", ""},
+		{"malformed", "Date: sometime last week\n\nbody\n", ""},
+		{"not a message", "", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := messageDate(strings.NewReader(tt.msg)); got != tt.want {
+				t.Errorf("messageDate() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,143 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/salmonumbrella/fastmail-cli/internal/jmap"
+	"github.com/salmonumbrella/fastmail-cli/internal/outfmt"
+	"github.com/spf13/cobra"
+)
+
+// statusRoles are the mailbox roles inbox-status reports, in display order.
+// "spam" is shown for the JMAP junk role.
+var statusRoles = []struct{ name, role string }{
+	{"inbox", "inbox"},
+	{"archive", "archive"},
+	{"drafts", "drafts"},
+	{"sent", "sent"},
+	{"spam", "junk"},
+}
+
+// mailboxCounts is the JSON shape of one role in inbox-status.
+type mailboxCounts struct {
+	Name   string `json:"name"`
+	Unread int    `json:"unread"`
+	Total  int    `json:"total"`
+}
+
+// inboxStatusSummary is the JSON shape of inbox-status.
+type inboxStatusSummary struct {
+	Roles map[string]mailboxCounts `json:"roles"`
+	// TotalUnread counts unread emails in every mailbox except spam and trash
+	TotalUnread int `json:"totalUnread"`
+}
+
+// summarizeMailboxes builds the inbox-status summary. Roles without a
+// mailbox are left out.
+func summarizeMailboxes(mailboxes []jmap.Mailbox) inboxStatusSummary {
+	summary := inboxStatusSummary{Roles: make(map[string]mailboxCounts)}
+	for _, mb := range mailboxes {
+		for _, r := range statusRoles {
+			if mb.Role == r.role {
+				summary.Roles[r.name] = mailboxCounts{Name: mb.Name, Unread: mb.UnreadEmails, Total: mb.TotalEmails}
+			}
+		}
+		if mb.Role != "junk" && mb.Role != "trash" {
+			summary.TotalUnread += mb.UnreadEmails
+		}
+	}
+	return summary
+}
+
+func newEmailInboxStatusCmd(app *App) *cobra.Command {
+	var role string
+
+	cmd := &cobra.Command{
+		Use:     "inbox-status",
+		Aliases: []string{"unread"},
+		Short:   "Show unread and total counts for the main mailboxes",
+		Long: `Show unread and total email counts for the inbox, archive, drafts, sent
+and spam mailboxes, plus the unread count across all mailboxes except spam
+and trash.
+
+--role prints just that mailbox's unread count, for status bars and scripts.
+
+Examples:
+  fastmail inbox-status
+  fastmail inbox-status --role inbox
+  fastmail inbox-status --output json`,
+		Args: cobra.NoArgs,
+		RunE: runE(app, func(cmd *cobra.Command, args []string, app *App) error {
+			role = strings.ToLower(strings.TrimSpace(role))
+			if role != "" && !isStatusRole(role) {
+				return fmt.Errorf("unknown role %q (valid: %s)", role, strings.Join(statusRoleNames(), ", "))
+			}
+
+			client, err := app.JMAPClient()
+			if err != nil {
+				return err
+			}
+
+			mailboxes, err := client.GetMailboxes(cmd.Context())
+			if err != nil {
+				return fmt.Errorf("failed to get mailboxes: %w", err)
+			}
+			summary := summarizeMailboxes(mailboxes)
+
+			if role != "" {
+				counts, ok := summary.Roles[role]
+				if !ok {
+					return fmt.Errorf("%w: no mailbox has the %s role", jmap.ErrMailboxNotFound, role)
+				}
+				if app.IsJSON(cmd.Context()) {
+					return app.PrintJSON(cmd, map[string]any{
+						"role":   role,
+						"name":   counts.Name,
+						"unread": counts.Unread,
+						"total":  counts.Total,
+					})
+				}
+				fmt.Println(counts.Unread)
+				return nil
+			}
+
+			if app.IsJSON(cmd.Context()) {
+				return app.PrintJSON(cmd, summary)
+			}
+
+			tw := outfmt.NewTabWriter()
+			fmt.Fprintln(tw, "ROLE\tNAME\tUNREAD\tTOTAL")
+			for _, r := range statusRoles {
+				if counts, ok := summary.Roles[r.name]; ok {
+					fmt.Fprintf(tw, "%s\t%s\t%d\t%d\n", r.name, outfmt.SanitizeTab(counts.Name), counts.Unread, counts.Total)
+				}
+			}
+			tw.Flush()
+			fmt.Printf("\nUnread (all mailboxes except spam and trash): %d\n", summary.TotalUnread)
+
+			return nil
+		}),
+	}
+
+	cmd.Flags().StringVar(&role, "role", "", "Print only this mailbox's unread count: "+strings.Join(statusRoleNames(), ", "))
+
+	return cmd
+}
+
+func statusRoleNames() []string {
+	names := make([]string, len(statusRoles))
+	for i, r := range statusRoles {
+		names[i] = r.name
+	}
+	return names
+}
+
+func isStatusRole(name string) bool {
+	for _, r := range statusRoles {
+		if r.name == name {
+			return true
+		}
+	}
+	return false
+}
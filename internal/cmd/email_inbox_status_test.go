@@ -0,0 +1,41 @@
+package cmd
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/salmonumbrella/fastmail-cli/internal/jmap"
+)
+
+func TestSummarizeMailboxes(t *testing.T) {
+	mailboxes := []jmap.Mailbox{
+		{Name: "Inbox", Role: "inbox", UnreadEmails: 3, TotalEmails: 120},
+		{Name: "Sent", Role: "sent", UnreadEmails: 0, TotalEmails: 40},
+		{Name: "Spam", Role: "junk", UnreadEmails: 7, TotalEmails: 9},
+		{Name: "Trash", Role: "trash", UnreadEmails: 2, TotalEmails: 5},
+		{Name: "Receipts", UnreadEmails: 4, TotalEmails: 30},
+	}
+
+	got := summarizeMailboxes(mailboxes)
+	want := inboxStatusSummary{
+		Roles: map[string]mailboxCounts{
+			"inbox": {Name: "Inbox", Unread: 3, Total: 120},
+			"sent":  {Name: "Sent", Unread: 0, Total: 40},
+			"spam":  {Name: "Spam", Unread: 7, Total: 9},
+		},
+		TotalUnread: 7, // inbox + receipts; spam and trash excluded
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("summarizeMailboxes() = %+v, want %+v", got, want)
+	}
+}
+
+func TestEmailInboxStatusCmd_UnknownRole(t *testing.T) {
+	cmd := newEmailInboxStatusCmd(newTestApp())
+	cmd.SetArgs([]string{"--role", "trash"})
+	cmd.SilenceUsage = true
+
+	if err := cmd.Execute(); err == nil || !contains(err.Error(), `unknown role "trash"`) {
+		t.Errorf("Execute() error = %v, want unknown role", err)
+	}
+}
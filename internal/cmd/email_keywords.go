@@ -0,0 +1,112 @@
+package cmd
+
+import (
+	"fmt"
+	"strconv"
+
+	cerrors "github.com/salmonumbrella/fastmail-cli/internal/errors"
+	"github.com/salmonumbrella/fastmail-cli/internal/jmap"
+	"github.com/salmonumbrella/fastmail-cli/internal/outfmt"
+	"github.com/spf13/cobra"
+)
+
+func newEmailKeywordsCmd(app *App) *cobra.Command {
+	var mailbox string
+	var limit int
+	var all bool
+
+	cmd := &cobra.Command{
+		Use:     "keywords",
+		Aliases: []string{"labels"},
+		Short:   "List the keywords used on emails, most used first",
+		Long: `Tally the keywords (labels) on recent emails.
+
+Scans up to --limit emails, newest first, and counts how many carry each
+keyword. System keywords such as $seen and $flagged are left out unless
+--all is given.
+
+Examples:
+  fastmail email keywords
+  fastmail email keywords --mailbox Archive --limit 5000
+  fastmail email keywords --all --output json`,
+		RunE: runE(app, func(cmd *cobra.Command, args []string, app *App) error {
+			if limit <= 0 {
+				return fmt.Errorf("--limit must be positive")
+			}
+
+			client, err := app.JMAPClient()
+			if err != nil {
+				return err
+			}
+
+			var mailboxID string
+			if mailbox != "" {
+				mailboxID, err = client.ResolveMailboxID(cmd.Context(), mailbox)
+				if err != nil {
+					return fmt.Errorf("invalid mailbox: %w", err)
+				}
+			}
+
+			counts, scanned, err := client.CountKeywords(cmd.Context(), mailboxID, limit)
+			if err != nil {
+				return cerrors.WithContext(err, "counting keywords")
+			}
+			counts = filterKeywordCounts(counts, all)
+
+			if app.IsJSON(cmd.Context()) {
+				keywords := make(map[string]int, len(counts))
+				for _, kc := range counts {
+					keywords[kc.Keyword] = kc.Count
+				}
+				return app.PrintJSON(cmd, map[string]any{
+					"scanned":  scanned,
+					"keywords": keywords,
+				})
+			}
+			if app.IsCSV(cmd.Context()) {
+				return outfmt.PrintCSV([]string{"KEYWORD", "COUNT"}, func(yield func([]string) bool) {
+					for _, kc := range counts {
+						if !yield([]string{kc.Keyword, strconv.Itoa(kc.Count)}) {
+							return
+						}
+					}
+				})
+			}
+
+			if len(counts) == 0 {
+				printNoResults("No keywords found in %d emails", scanned)
+				return nil
+			}
+
+			tw := outfmt.NewTabWriter()
+			fmt.Fprintln(tw, "KEYWORD\tCOUNT")
+			for _, kc := range counts {
+				fmt.Fprintf(tw, "%s\t%d\n", outfmt.SanitizeTab(kc.Keyword), kc.Count)
+			}
+			tw.Flush()
+			fmt.Printf("\nScanned %d emails\n", scanned)
+
+			return nil
+		}),
+	}
+
+	cmd.Flags().StringVar(&mailbox, "mailbox", "", "Mailbox ID or name to scan (default: all mail)")
+	cmd.Flags().IntVar(&limit, "limit", 1000, "Maximum number of emails to scan")
+	cmd.Flags().BoolVar(&all, "all", false, "Include system keywords such as $seen and $flagged")
+
+	return cmd
+}
+
+// filterKeywordCounts drops system keywords unless all is set.
+func filterKeywordCounts(counts []jmap.KeywordCount, all bool) []jmap.KeywordCount {
+	if all {
+		return counts
+	}
+	filtered := make([]jmap.KeywordCount, 0, len(counts))
+	for _, kc := range counts {
+		if !jmap.IsSystemKeyword(kc.Keyword) {
+			filtered = append(filtered, kc)
+		}
+	}
+	return filtered
+}
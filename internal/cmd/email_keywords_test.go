@@ -0,0 +1,27 @@
+package cmd
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/salmonumbrella/fastmail-cli/internal/jmap"
+)
+
+func TestFilterKeywordCounts(t *testing.T) {
+	counts := []jmap.KeywordCount{
+		{Keyword: "$seen", Count: 40},
+		{Keyword: "work", Count: 12},
+		{Keyword: "$flagged", Count: 3},
+		{Keyword: "receipts", Count: 2},
+	}
+
+	got := filterKeywordCounts(counts, false)
+	want := []jmap.KeywordCount{{Keyword: "work", Count: 12}, {Keyword: "receipts", Count: 2}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("filterKeywordCounts(all=false) = %v, want %v", got, want)
+	}
+
+	if got := filterKeywordCounts(counts, true); !reflect.DeepEqual(got, counts) {
+		t.Errorf("filterKeywordCounts(all=true) = %v, want all", got)
+	}
+}
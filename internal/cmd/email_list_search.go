@@ -1,25 +1,50 @@
 package cmd
 
 import (
+	"context"
 	"fmt"
+	"slices"
+	"strconv"
+	"strings"
 	"time"
 
 	cerrors "github.com/salmonumbrella/fastmail-cli/internal/errors"
 	"github.com/salmonumbrella/fastmail-cli/internal/format"
 	"github.com/salmonumbrella/fastmail-cli/internal/jmap"
 	"github.com/salmonumbrella/fastmail-cli/internal/outfmt"
+	"github.com/salmonumbrella/fastmail-cli/internal/ui"
 	"github.com/spf13/cobra"
 )
 
 func newEmailListCmd(app *App) *cobra.Command {
 	var limit int
 	var mailboxID string
+	var previewBytes int
+	var attachmentCount bool
+	var flaggedFirst bool
+	var sortName string
+	var fieldNames []string
+	var showSize bool
+	var widths columnWidths
 
 	cmd := &cobra.Command{
 		Use:     "list",
 		Aliases: []string{"ls"},
 		Short:   "List emails",
 		RunE: runE(app, func(cmd *cobra.Command, args []string, app *App) error {
+			app.applyDefaultLimit(cmd, &limit)
+			if previewBytes < 0 {
+				return fmt.Errorf("--preview-bytes must be positive")
+			}
+			fields, err := parseEmailFields(fieldNames, emailFieldOpts{attachmentCount: attachmentCount, preview: previewBytes > 0, size: showSize})
+			if err != nil {
+				return err
+			}
+			sort, err := jmap.ParseEmailSort(sortName)
+			if err != nil {
+				return err
+			}
+
 			client, err := app.JMAPClient()
 			if err != nil {
 				return err
@@ -35,7 +60,12 @@ func newEmailListCmd(app *App) *cobra.Command {
 				mailboxID = resolvedID
 			}
 
-			emails, err := client.GetEmails(cmd.Context(), mailboxID, limit)
+			emails, err := client.ListEmails(cmd.Context(), mailboxID, limit, jmap.EmailListOpts{
+				PreviewBytes: previewBytes,
+				Attachments:  attachmentCount || slices.Contains(fields, "attach"),
+				FlaggedFirst: flaggedFirst,
+				Sort:         sort,
+			})
 			if err != nil {
 				return cerrors.WithContext(err, "listing emails")
 			}
@@ -51,33 +81,33 @@ func newEmailListCmd(app *App) *cobra.Command {
 				threadCounts = map[string]int{}
 			}
 
+			if app.IsNDJSON(cmd.Context()) {
+				w := app.NDJSONWriter(cmd)
+				for _, email := range emails {
+					if err := w.Write(emailToOutputWithCount(email, threadCounts)); err != nil {
+						return err
+					}
+				}
+				return nil
+			}
 			if app.IsJSON(cmd.Context()) {
 				return app.PrintJSON(cmd, emailsToOutputWithCounts(emails, threadCounts))
 			}
+			columns := emailColumns{threadCounts: threadCounts, dates: app.DateStyle(), widths: widths}
+			if app.IsCSV(cmd.Context()) {
+				return printEmailCSV(emails, fields, columns)
+			}
 
 			if len(emails) == 0 {
 				printNoResults("No emails found")
 				return nil
 			}
 
-			tw := outfmt.NewTabWriter()
-			fmt.Fprintln(tw, "ID\tSUBJECT\tFROM\tDATE\tUNREAD\tTHREAD")
+			style := app.TableStyle(cmd.Context())
+			tw := outfmt.NewStyledTabWriter()
+			fmt.Fprintln(tw, emailFieldHeader(fields))
 			for _, email := range emails {
-				from := format.FormatEmailAddressList(email.From)
-				date := format.FormatEmailDate(email.ReceivedAt)
-				unread := ""
-				if email.Keywords != nil && !email.Keywords["$seen"] {
-					unread = "*"
-				}
-				thread := formatThreadCount(threadCounts[email.ThreadID])
-				fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\t%s\n",
-					email.ID,
-					outfmt.SanitizeTab(format.Truncate(email.Subject, 50)),
-					outfmt.SanitizeTab(format.Truncate(from, 30)),
-					date,
-					unread,
-					thread,
-				)
+				fmt.Fprintln(tw, strings.Join(emailFieldRow(email, fields, &columns, style), "\t"))
 			}
 			tw.Flush()
 
@@ -87,6 +117,12 @@ func newEmailListCmd(app *App) *cobra.Command {
 
 	cmd.Flags().IntVar(&limit, "limit", 25, "Maximum number of emails to list")
 	cmd.Flags().StringVar(&mailboxID, "mailbox", "", "Mailbox ID or name to filter emails")
+	cmd.Flags().IntVar(&previewBytes, "preview-bytes", 0, "Build previews from the first N bytes of each body (adds a PREVIEW column)")
+	cmd.Flags().BoolVar(&attachmentCount, "attachment-count", false, "Fetch attachment lists and show an ATTACH count column")
+	cmd.Flags().BoolVar(&flaggedFirst, "flagged-first", false, "List flagged emails first, then the rest (each in --sort order)")
+	registerEmailSortFlag(cmd, &sortName)
+	registerEmailFieldsFlags(cmd, &fieldNames, &showSize)
+	widths.register(cmd)
 
 	return cmd
 }
@@ -94,6 +130,13 @@ func newEmailListCmd(app *App) *cobra.Command {
 func newEmailSearchCmd(app *App) *cobra.Command {
 	var limit int
 	var snippets bool
+	var includeBody bool
+	var bodyMaxBytes int
+	var attachmentCount bool
+	var sortName string
+	var fieldNames []string
+	var showSize bool
+	var widths columnWidths
 
 	cmd := &cobra.Command{
 		Use:     "search <query>",
@@ -106,9 +149,31 @@ Examples:
   fastmail email search --snippets "invoice"
   fastmail email search "subject:meeting after:2025-01-01"
   fastmail email search "subject:meeting after:yesterday"
-  fastmail email search "subject:meeting after:'2h ago'"`,
+  fastmail email search "subject:meeting after:'2h ago'"
+  fastmail email search "after:2025-01-01" --sort size --show-size
+
+  # Include text/HTML bodies (JSON only), e.g. for building a local index
+  fastmail email search "after:2025-01-01" --include-body --output json`,
 		Args: cobra.ExactArgs(1),
 		RunE: runE(app, func(cmd *cobra.Command, args []string, app *App) error {
+			app.applyDefaultLimit(cmd, &limit)
+			fields, err := parseEmailFields(fieldNames, emailFieldOpts{attachmentCount: attachmentCount, size: showSize})
+			if err != nil {
+				return err
+			}
+			sort, err := jmap.ParseEmailSort(sortName)
+			if err != nil {
+				return err
+			}
+			if includeBody {
+				if !app.IsJSON(cmd.Context()) {
+					return fmt.Errorf("--include-body requires --output json or ndjson")
+				}
+				if bodyMaxBytes <= 0 {
+					return fmt.Errorf("--body-max-bytes must be positive")
+				}
+			}
+
 			client, err := app.JMAPClient()
 			if err != nil {
 				return err
@@ -123,12 +188,11 @@ Examples:
 				return err
 			}
 
-			if snippets {
-				emails, searchSnippets, err = client.SearchEmailsWithSnippets(cmd.Context(), filter, limit)
-			} else {
-				emails, err = client.SearchEmails(cmd.Context(), filter, limit)
-			}
-
+			emails, searchSnippets, err = client.SearchEmailsWithOpts(cmd.Context(), filter, limit, jmap.EmailSearchOpts{
+				Snippets:    snippets,
+				Attachments: attachmentCount || slices.Contains(fields, "attach"),
+				Sort:        sort,
+			})
 			if err != nil {
 				return cerrors.WithContext(err, "searching emails")
 			}
@@ -144,6 +208,30 @@ Examples:
 				threadCounts = map[string]int{}
 			}
 
+			if includeBody && len(emails) > 0 {
+				ui.FromContext(cmd.Context()).Warning(fmt.Sprintf("Warning: --include-body fetches up to %s per body for %d emails; output may be slow and large", format.FormatBytes(int64(bodyMaxBytes)), len(emails)))
+				if err := attachEmailBodies(cmd.Context(), client, emails, bodyMaxBytes); err != nil {
+					return cerrors.WithContext(err, "fetching email bodies")
+				}
+			}
+
+			if app.IsNDJSON(cmd.Context()) {
+				snippetMap := make(map[string]jmap.SearchSnippet, len(searchSnippets))
+				for _, s := range searchSnippets {
+					snippetMap[s.EmailID] = s
+				}
+				w := app.NDJSONWriter(cmd)
+				for _, email := range emails {
+					out := emailToOutputWithCount(email, threadCounts)
+					if s, ok := snippetMap[email.ID]; ok {
+						out.Snippet = &s
+					}
+					if err := w.Write(out); err != nil {
+						return err
+					}
+				}
+				return nil
+			}
 			if app.IsJSON(cmd.Context()) {
 				result := map[string]any{"emails": emailsToOutputWithCounts(emails, threadCounts)}
 				if snippets && len(searchSnippets) > 0 {
@@ -151,6 +239,10 @@ Examples:
 				}
 				return app.PrintJSON(cmd, result)
 			}
+			columns := emailColumns{threadCounts: threadCounts, dates: app.DateStyle(), widths: widths}
+			if app.IsCSV(cmd.Context()) {
+				return printEmailCSV(emails, fields, columns)
+			}
 
 			if len(emails) == 0 {
 				printNoResults("No emails found matching '%s'", args[0])
@@ -162,38 +254,31 @@ Examples:
 			for _, s := range searchSnippets {
 				snippetMap[s.EmailID] = s
 			}
-
-			tw := outfmt.NewTabWriter()
-			fmt.Fprintln(tw, "ID\tSUBJECT\tFROM\tDATE\tUNREAD\tTHREAD")
-			for _, email := range emails {
-				from := format.FormatEmailAddressList(email.From)
-				date := format.FormatEmailDate(email.ReceivedAt)
-				unread := ""
-				if email.Keywords != nil && !email.Keywords["$seen"] {
-					unread = "*"
-				}
-				thread := formatThreadCount(threadCounts[email.ThreadID])
-
-				subject := email.Subject
-				if snippets {
-					if s, ok := snippetMap[email.ID]; ok && s.Subject != "" {
-						subject = s.Subject // Use highlighted subject
+			if snippets {
+				// Use highlighted subjects
+				columns.subjects = make(map[string]string)
+				for id, s := range snippetMap {
+					if s.Subject != "" {
+						columns.subjects[id] = s.Subject
 					}
 				}
+			}
+
+			// Snippet previews go on their own row, under SUBJECT when shown
+			previewColumn := max(slices.Index(fields, "subject"), 0)
 
-				fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\t%s\n",
-					email.ID,
-					outfmt.SanitizeTab(format.Truncate(subject, 50)),
-					outfmt.SanitizeTab(format.Truncate(from, 30)),
-					date,
-					unread,
-					thread,
-				)
+			style := app.TableStyle(cmd.Context())
+			tw := outfmt.NewStyledTabWriter()
+			fmt.Fprintln(tw, emailFieldHeader(fields))
+			for _, email := range emails {
+				fmt.Fprintln(tw, strings.Join(emailFieldRow(email, fields, &columns, style), "\t"))
 
 				// Show snippet preview if available
 				if snippets {
 					if s, ok := snippetMap[email.ID]; ok && s.Preview != "" {
-						fmt.Fprintf(tw, "\t%s\t\t\t\t\n", outfmt.SanitizeTab(format.Truncate(s.Preview, 80)))
+						cells := make([]string, len(fields))
+						cells[previewColumn] = outfmt.SanitizeTab(format.Truncate(s.Preview, 80))
+						fmt.Fprintln(tw, strings.Join(cells, "\t"))
 					}
 				}
 			}
@@ -205,10 +290,58 @@ Examples:
 
 	cmd.Flags().IntVar(&limit, "limit", 25, "Maximum number of results")
 	cmd.Flags().BoolVar(&snippets, "snippets", false, "Show highlighted search snippets")
+	cmd.Flags().BoolVar(&includeBody, "include-body", false, "Include text/HTML body values in JSON output (slower, larger)")
+	cmd.Flags().IntVar(&bodyMaxBytes, "body-max-bytes", defaultBodyMaxBytes, "Maximum bytes fetched per body value with --include-body")
+	cmd.Flags().BoolVar(&attachmentCount, "attachment-count", false, "Fetch attachment lists and show an ATTACH count column")
+	registerEmailSortFlag(cmd, &sortName)
+	registerEmailFieldsFlags(cmd, &fieldNames, &showSize)
+	widths.register(cmd)
 
 	return cmd
 }
 
+// registerEmailSortFlag adds --sort to an email list/search command.
+func registerEmailSortFlag(cmd *cobra.Command, sortName *string) {
+	cmd.Flags().StringVar(sortName, "sort", "date", "Sort order: "+strings.Join(jmap.EmailSortNames(), ", ")+" (date and size are newest/biggest first)")
+}
+
+// registerEmailFieldsFlags adds --fields and --show-size to an email
+// list/search command.
+func registerEmailFieldsFlags(cmd *cobra.Command, fieldNames *[]string, showSize *bool) {
+	cmd.Flags().StringSliceVar(fieldNames, "fields", nil, "Comma-separated table/CSV columns in order: "+strings.Join(validEmailFields(), ","))
+	cmd.Flags().BoolVar(showSize, "show-size", false, "Add a SIZE column (message size)")
+}
+
+// defaultBodyMaxBytes caps each body value fetched by email search --include-body.
+const defaultBodyMaxBytes = 64 * 1024
+
+// attachEmailBodies fetches capped body values for emails and fills in their
+// TextBody, HTMLBody and BodyValues.
+func attachEmailBodies(ctx context.Context, client jmap.EmailService, emails []jmap.Email, maxBytes int) error {
+	ids := make([]string, len(emails))
+	for i, email := range emails {
+		ids[i] = email.ID
+	}
+
+	bodies, err := client.GetEmailBodies(ctx, ids, maxBytes)
+	if err != nil {
+		return err
+	}
+
+	byID := make(map[string]jmap.Email, len(bodies))
+	for _, b := range bodies {
+		byID[b.ID] = b
+	}
+	for i := range emails {
+		if b, ok := byID[emails[i].ID]; ok {
+			emails[i].TextBody = b.TextBody
+			emails[i].HTMLBody = b.HTMLBody
+			emails[i].BodyValues = b.BodyValues
+		}
+	}
+	return nil
+}
+
 // formatThreadCount formats a thread message count for display.
 // Returns "-" for single-message threads, "[N msgs]" for multi-message threads.
 func formatThreadCount(count int) string {
@@ -217,3 +350,31 @@ func formatThreadCount(count int) string {
 	}
 	return fmt.Sprintf("[%d msgs]", count)
 }
+
+// formatAttachmentCount formats the number of attachments for display.
+// Returns "-" for emails without attachments.
+func formatAttachmentCount(email jmap.Email) string {
+	if len(email.Attachments) == 0 {
+		return "-"
+	}
+	return strconv.Itoa(len(email.Attachments))
+}
+
+// Default column widths for email list output.
+const (
+	defaultSubjectWidth = 50
+	defaultFromWidth    = 30
+)
+
+// columnWidths holds the truncation widths for the SUBJECT and FROM columns.
+type columnWidths struct {
+	subject int
+	from    int
+}
+
+// register adds --subject-width and --from-width, defaulting to
+// FASTMAIL_SUBJECT_WIDTH / FASTMAIL_FROM_WIDTH when set.
+func (w *columnWidths) register(cmd *cobra.Command) {
+	cmd.Flags().IntVar(&w.subject, "subject-width", envInt("FASTMAIL_SUBJECT_WIDTH", defaultSubjectWidth), "Truncate subjects to this many characters (0 = no truncation)")
+	cmd.Flags().IntVar(&w.from, "from-width", envInt("FASTMAIL_FROM_WIDTH", defaultFromWidth), "Truncate senders to this many characters (0 = no truncation)")
+}
@@ -2,6 +2,7 @@ package cmd
 
 import (
 	"fmt"
+	"strconv"
 	"strings"
 
 	"github.com/salmonumbrella/fastmail-cli/internal/config"
@@ -30,6 +31,15 @@ func newEmailMailboxesCmd(app *App) *cobra.Command {
 			if app.IsJSON(cmd.Context()) {
 				return app.PrintJSON(cmd, mailboxes)
 			}
+			if app.IsCSV(cmd.Context()) {
+				return outfmt.PrintCSV([]string{"ID", "NAME", "ROLE", "UNREAD", "TOTAL"}, func(yield func([]string) bool) {
+					for _, mb := range mailboxes {
+						if !yield([]string{mb.ID, mb.Name, mb.Role, strconv.Itoa(mb.UnreadEmails), strconv.Itoa(mb.TotalEmails)}) {
+							return
+						}
+					}
+				})
+			}
 
 			tw := outfmt.NewTabWriter()
 			fmt.Fprintln(tw, "ID\tNAME\tROLE\tUNREAD\tTOTAL")
@@ -189,18 +199,94 @@ func newMailboxRenameCmd(app *App) *cobra.Command {
 	return cmd
 }
 
+func newMailboxMoveCmd(app *App) *cobra.Command {
+	var parent string
+
+	cmd := &cobra.Command{
+		Use:   "mailbox-move <mailbox-id-or-name> --parent <id-or-name>",
+		Short: "Move a mailbox (folder) under another one",
+		Long: `Move a mailbox under a new parent folder. Pass an empty --parent to move
+it to the top level. A folder cannot be moved under itself or one of its
+own subfolders.
+
+Examples:
+  fastmail email mailbox-move Receipts --parent Archive
+  fastmail email mailbox-move Receipts --parent ""`,
+		Args: cobra.ExactArgs(1),
+		RunE: runE(app, func(cmd *cobra.Command, args []string, app *App) error {
+			client, err := app.JMAPClient()
+			if err != nil {
+				return err
+			}
+
+			mailboxID, err := client.ResolveMailboxID(cmd.Context(), args[0])
+			if err != nil {
+				return fmt.Errorf("invalid mailbox: %w", err)
+			}
+
+			var parentID string
+			if parent != "" {
+				parentID, err = client.ResolveMailboxID(cmd.Context(), parent)
+				if err != nil {
+					return fmt.Errorf("invalid parent mailbox: %w", err)
+				}
+			}
+
+			if err := client.MoveMailbox(cmd.Context(), mailboxID, parentID); err != nil {
+				return fmt.Errorf("failed to move mailbox: %w", err)
+			}
+
+			if app.IsJSON(cmd.Context()) {
+				return app.PrintJSON(cmd, map[string]any{
+					"status":    "moved",
+					"mailboxId": mailboxID,
+					"parentId":  parentID,
+				})
+			}
+
+			if parentID == "" {
+				fmt.Printf("Moved mailbox %s to the top level\n", mailboxID)
+			} else {
+				fmt.Printf("Moved mailbox %s under %s\n", mailboxID, parentID)
+			}
+			return nil
+		}),
+	}
+
+	cmd.Flags().StringVar(&parent, "parent", "", "New parent mailbox ID or name (empty for the top level)")
+	_ = cmd.MarkFlagRequired("parent") //nolint:errcheck
+
+	return cmd
+}
+
 func newEmailIdentitiesCmd(app *App) *cobra.Command {
+	var since string
+
 	cmd := &cobra.Command{
 		Use:   "identities",
 		Short: "List sending identities (aliases)",
-		Long:  "List all email identities/aliases you can send from.",
+		Long: `List all email identities/aliases you can send from.
+
+The listing ends with the current identity state. Pass it to --since later to
+see only the identities added, changed, or removed in the meantime; if the
+state is too old for the server, every identity is listed as added.`,
+		Example: `  fastmail email identities
+  fastmail email identities --since <state>`,
 		RunE: runE(app, func(cmd *cobra.Command, args []string, app *App) error {
 			client, err := app.JMAPClient()
 			if err != nil {
 				return err
 			}
 
-			identities, err := client.GetIdentities(cmd.Context())
+			if since != "" {
+				changes, changesErr := client.GetIdentityChanges(cmd.Context(), since)
+				if changesErr != nil {
+					return cerrors.WithContext(changesErr, "fetching identity changes")
+				}
+				return printIdentityChanges(cmd, app, changes)
+			}
+
+			identities, state, err := client.GetIdentitiesWithState(cmd.Context())
 			if err != nil {
 				return cerrors.WithContext(err, "fetching identities")
 			}
@@ -226,6 +312,19 @@ func newEmailIdentitiesCmd(app *App) *cobra.Command {
 			if app.IsJSON(cmd.Context()) {
 				return app.PrintJSON(cmd, identities)
 			}
+			if app.IsCSV(cmd.Context()) {
+				return outfmt.PrintCSV([]string{"ID", "EMAIL", "NAME", "DEFAULT"}, func(yield func([]string) bool) {
+					for _, id := range identities {
+						isDefaultStr := ""
+						if id.IsDefault {
+							isDefaultStr = "*"
+						}
+						if !yield([]string{id.ID, id.Email, id.Name, isDefaultStr}) {
+							return
+						}
+					}
+				})
+			}
 
 			if len(identities) == 0 {
 				printNoResults("No identities found")
@@ -248,13 +347,50 @@ func newEmailIdentitiesCmd(app *App) *cobra.Command {
 			}
 			tw.Flush()
 
+			if state != "" {
+				fmt.Printf("\nState: %s\n", state)
+			}
+
 			return nil
 		}),
 	}
 
+	cmd.Flags().StringVar(&since, "since", "", "Only show identity changes since this state")
+
 	return cmd
 }
 
+// printIdentityChanges prints the result of identities --since.
+func printIdentityChanges(cmd *cobra.Command, app *App, changes *jmap.IdentityChanges) error {
+	if app.IsJSON(cmd.Context()) {
+		return app.PrintJSON(cmd, changes)
+	}
+
+	if changes.Full {
+		outfmt.Errorf("State %s is too old to compare against; listing all identities", changes.OldState)
+	}
+
+	if len(changes.Created)+len(changes.Updated)+len(changes.Destroyed) == 0 {
+		printNoResults("No identity changes")
+	} else {
+		tw := outfmt.NewTabWriter()
+		fmt.Fprintln(tw, "CHANGE\tID\tEMAIL\tNAME")
+		for _, id := range changes.Created {
+			fmt.Fprintf(tw, "added\t%s\t%s\t%s\n", id.ID, id.Email, outfmt.SanitizeTab(id.Name))
+		}
+		for _, id := range changes.Updated {
+			fmt.Fprintf(tw, "changed\t%s\t%s\t%s\n", id.ID, id.Email, outfmt.SanitizeTab(id.Name))
+		}
+		for _, id := range changes.Destroyed {
+			fmt.Fprintf(tw, "removed\t%s\t-\t-\n", id)
+		}
+		tw.Flush()
+	}
+
+	fmt.Printf("\nState: %s\n", changes.NewState)
+	return nil
+}
+
 func newIdentitySetDefaultCmd(app *App) *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "identity-set-default <email>",
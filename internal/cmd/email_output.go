@@ -2,6 +2,7 @@ package cmd
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/salmonumbrella/fastmail-cli/internal/format"
 	"github.com/salmonumbrella/fastmail-cli/internal/jmap"
@@ -11,36 +12,53 @@ import (
 // EmailOutput is a flattened representation of Email for agent-friendly JSON output.
 // It includes computed fields like fromEmail and isUnread that are easier to parse.
 type EmailOutput struct {
-	ID            string              `json:"id"`
-	Subject       string              `json:"subject"`
-	From          []jmap.EmailAddress `json:"from,omitempty"`
-	FromEmail     string              `json:"fromEmail,omitempty"`
-	FromName      string              `json:"fromName,omitempty"`
-	To            []jmap.EmailAddress `json:"to,omitempty"`
-	ToEmail       string              `json:"toEmail,omitempty"`
-	CC            []jmap.EmailAddress `json:"cc,omitempty"`
-	ReceivedAt    string              `json:"receivedAt"`
-	Preview       string              `json:"preview,omitempty"`
-	HasAttachment bool                `json:"hasAttachment"`
-	IsUnread      bool                `json:"isUnread"`
-	ThreadID      string              `json:"threadId,omitempty"`
-	Keywords      map[string]bool     `json:"keywords,omitempty"`
-	MessageCount  int                 `json:"messageCount,omitempty"` // Count of messages in thread
+	ID         string              `json:"id"`
+	Subject    string              `json:"subject"`
+	From       []jmap.EmailAddress `json:"from,omitempty"`
+	FromEmail  string              `json:"fromEmail,omitempty"`
+	FromName   string              `json:"fromName,omitempty"`
+	To         []jmap.EmailAddress `json:"to,omitempty"`
+	ToEmail    string              `json:"toEmail,omitempty"`
+	CC         []jmap.EmailAddress `json:"cc,omitempty"`
+	ReceivedAt string              `json:"receivedAt"`
+	Preview    string              `json:"preview,omitempty"`
+	// PreviewTruncated is set when --preview-bytes cut the body short
+	PreviewTruncated bool            `json:"previewTruncated,omitempty"`
+	HasAttachment    bool            `json:"hasAttachment"`
+	Size             int64           `json:"size,omitempty"`
+	IsUnread         bool            `json:"isUnread"`
+	ThreadID         string          `json:"threadId,omitempty"`
+	Keywords         map[string]bool `json:"keywords,omitempty"`
+	MessageCount     int             `json:"messageCount,omitempty"` // Count of messages in thread
+	// Body fields are only populated by email search --include-body
+	TextBody   []jmap.BodyPart           `json:"textBody,omitempty"`
+	HTMLBody   []jmap.BodyPart           `json:"htmlBody,omitempty"`
+	BodyValues map[string]jmap.BodyValue `json:"bodyValues,omitempty"`
+	// Attachments is only populated by --attachment-count
+	Attachments []jmap.Attachment `json:"attachments,omitempty"`
+	// Snippet is only populated by email search --snippets --output ndjson
+	Snippet *jmap.SearchSnippet `json:"snippet,omitempty"`
 }
 
 // emailToOutput converts an Email to a flattened EmailOutput for JSON serialization.
 func emailToOutput(e jmap.Email) EmailOutput {
 	out := EmailOutput{
-		ID:            e.ID,
-		Subject:       e.Subject,
-		From:          e.From,
-		To:            e.To,
-		CC:            e.CC,
-		ReceivedAt:    e.ReceivedAt,
-		Preview:       e.Preview,
-		HasAttachment: e.HasAttachment,
-		ThreadID:      e.ThreadID,
-		Keywords:      e.Keywords,
+		ID:               e.ID,
+		Subject:          e.Subject,
+		From:             e.From,
+		To:               e.To,
+		CC:               e.CC,
+		ReceivedAt:       e.ReceivedAt,
+		Preview:          e.Preview,
+		PreviewTruncated: e.PreviewTruncated,
+		HasAttachment:    e.HasAttachment,
+		Size:             e.Size,
+		ThreadID:         e.ThreadID,
+		Keywords:         e.Keywords,
+		TextBody:         e.TextBody,
+		HTMLBody:         e.HTMLBody,
+		BodyValues:       e.BodyValues,
+		Attachments:      e.Attachments,
 	}
 	// Flatten from address
 	if len(e.From) > 0 {
@@ -69,38 +87,78 @@ func emailsToOutput(emails []jmap.Email) []EmailOutput {
 func emailsToOutputWithCounts(emails []jmap.Email, threadCounts map[string]int) []EmailOutput {
 	out := make([]EmailOutput, len(emails))
 	for i, email := range emails {
-		out[i] = emailToOutput(email)
-		if count, ok := threadCounts[email.ThreadID]; ok {
-			out[i].MessageCount = count
-		}
+		out[i] = emailToOutputWithCount(email, threadCounts)
 	}
 	return out
 }
 
-func printEmailList(emails []jmap.Email, threadCounts map[string]int) {
-	tw := outfmt.NewTabWriter()
-	fmt.Fprintln(tw, "ID\tSUBJECT\tFROM\tDATE\tUNREAD\tTHREAD")
-	for _, email := range emails {
-		from := format.FormatEmailAddressList(email.From)
-		date := format.FormatEmailDate(email.ReceivedAt)
-		unread := ""
-		if email.Keywords != nil && !email.Keywords["$seen"] {
-			unread = "*"
+// emailToOutputWithCount converts one email, adding its thread message count.
+func emailToOutputWithCount(email jmap.Email, threadCounts map[string]int) EmailOutput {
+	out := emailToOutput(email)
+	if count, ok := threadCounts[email.ThreadID]; ok {
+		out.MessageCount = count
+	}
+	return out
+}
+
+// printEmailCSV prints emails as CSV with the given email list/search
+// columns. c.widths is ignored: subjects and senders are not truncated.
+func printEmailCSV(emails []jmap.Email, fields []string, c emailColumns) error {
+	c.widths = columnWidths{}
+	columns := make([]string, len(fields))
+	for i, name := range fields {
+		columns[i] = emailFields[name].header
+	}
+	return outfmt.PrintCSV(columns, func(yield func([]string) bool) {
+		for _, email := range emails {
+			row := make([]string, len(fields))
+			for i, name := range fields {
+				row[i] = emailFields[name].value(email, &c)
+			}
+			if !yield(row) {
+				return
+			}
 		}
-		thread := formatThreadCount(threadCounts[email.ThreadID])
-		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\t%s\n",
-			email.ID,
-			outfmt.SanitizeTab(format.Truncate(email.Subject, 50)),
-			outfmt.SanitizeTab(format.Truncate(from, 30)),
-			date,
-			unread,
-			thread,
-		)
+	})
+}
+
+// printEmailList prints emails as a table with the default columns and widths.
+func printEmailList(emails []jmap.Email, threadCounts map[string]int, dates format.DateStyle, style outfmt.TableStyle) {
+	c := &emailColumns{
+		threadCounts: threadCounts,
+		dates:        dates,
+		widths:       columnWidths{subject: defaultSubjectWidth, from: defaultFromWidth},
+	}
+	tw := outfmt.NewStyledTabWriter()
+	fmt.Fprintln(tw, emailFieldHeader(defaultEmailFields))
+	for _, email := range emails {
+		fmt.Fprintln(tw, strings.Join(emailFieldRow(email, defaultEmailFields, c, style), "\t"))
 	}
 	tw.Flush()
 }
 
-func printEmailDetails(email *jmap.Email) {
+// emailMarkers returns the UNREAD and FLAGGED column markers for an email.
+func emailMarkers(email jmap.Email) (unread, flagged string) {
+	if email.Keywords != nil && !email.Keywords["$seen"] {
+		unread = "*"
+	}
+	if email.Keywords["$flagged"] {
+		flagged = "*"
+	}
+	return unread, flagged
+}
+
+// styleSubject bolds the subject cell of an unread email and dims a read one.
+func styleSubject(style outfmt.TableStyle, email jmap.Email, subject string) string {
+	if unread, _ := emailMarkers(email); unread != "" {
+		return style.Unread(subject)
+	}
+	return style.Read(subject)
+}
+
+// printEmailDetails prints the headers and text body of email. With
+// stripQuotes the quoted reply history is left out of the body.
+func printEmailDetails(email *jmap.Email, stripQuotes bool) {
 	fmt.Printf("ID:        %s\n", email.ID)
 	fmt.Printf("Subject:   %s\n", email.Subject)
 	fmt.Printf("From:      %s\n", format.FormatEmailAddressList(email.From))
@@ -116,7 +174,11 @@ func printEmailDetails(email *jmap.Email) {
 	if len(email.TextBody) > 0 && len(email.BodyValues) > 0 {
 		for _, part := range email.TextBody {
 			if body, ok := email.BodyValues[part.PartID]; ok {
-				fmt.Println(body.Value)
+				if stripQuotes {
+					fmt.Println(format.StripQuotedText(body.Value))
+				} else {
+					fmt.Println(body.Value)
+				}
 			}
 		}
 	} else if email.Preview != "" {
@@ -0,0 +1,128 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	cerrors "github.com/salmonumbrella/fastmail-cli/internal/errors"
+	"github.com/salmonumbrella/fastmail-cli/internal/jmap"
+	"github.com/salmonumbrella/fastmail-cli/internal/validation"
+	"github.com/spf13/cobra"
+)
+
+func newEmailReplyCmd(app *App) *cobra.Command {
+	var body, htmlBody string
+	var all bool
+	var fromIdentity string
+	var cc, bcc []string
+	var attachments []string
+	var sig signatureFlags
+
+	cmd := &cobra.Command{
+		Use:   "reply <emailId>",
+		Short: "Send a threaded reply to an email",
+		Long: `Reply to an email and send it right away.
+
+The reply is threaded (In-Reply-To and References), addressed to the sender
+(or its Reply-To) and titled "Re: <subject>". If the original was sent to
+one of your masked addresses the reply comes from it; otherwise from your
+default identity. --from overrides both.
+
+--all replies to everyone: the original To and CC recipients are added,
+leaving out your own addresses (all identities, including wildcard ones).
+Use 'fastmail email send --reply-to <emailId> --draft' to save a reply
+without sending it.
+
+Examples:
+  fastmail email reply Mf1234abc --body "Thanks, sounds good"
+  fastmail email reply Mf1234abc --all --body "Adding my notes below"
+  fastmail email reply Mf1234abc --body "See attached" --attach notes.pdf`,
+		Args: cobra.ExactArgs(1),
+		RunE: runE(app, func(cmd *cobra.Command, args []string, app *App) error {
+			if body == "" && htmlBody == "" {
+				return fmt.Errorf("--body or --html is required")
+			}
+			for _, addr := range append(append([]string{}, cc...), bcc...) {
+				if !validation.IsValidEmail(addr) {
+					return fmt.Errorf("invalid email address: %s", addr)
+				}
+			}
+
+			// Check all attachments locally before uploading any
+			uploads, err := prepareAttachments(attachments)
+			if err != nil {
+				return err
+			}
+
+			client, err := app.JMAPClient()
+			if err != nil {
+				return err
+			}
+
+			original, err := client.GetEmailByID(cmd.Context(), args[0])
+			if err != nil {
+				return cerrors.WithContext(err, "fetching email")
+			}
+
+			opts, err := client.ReplyOpts(cmd.Context(), original, jmap.SendEmailOpts{
+				CC:        cc,
+				BCC:       bcc,
+				TextBody:  body,
+				HTMLBody:  htmlBody,
+				From:      fromIdentity,
+				Signature: sig.enabled(),
+			}, all)
+			if err != nil {
+				return cerrors.WithContext(err, "building reply")
+			}
+			if len(opts.To) == 0 {
+				return fmt.Errorf("email %s has no sender to reply to", args[0])
+			}
+			if opts.From == "" {
+				account, _ := app.RequireAccount()
+				opts.From = app.DefaultFrom(account)
+			}
+
+			// Upload only once the reply is known to be sendable
+			opts.Attachments, err = uploadAttachments(cmd.Context(), client, uploads, defaultUploadConcurrency)
+			if err != nil {
+				return err
+			}
+
+			sent, err := client.SendEmailResult(cmd.Context(), opts)
+			if err != nil {
+				return cerrors.WithContext(err, "sending reply")
+			}
+
+			if app.IsJSON(cmd.Context()) {
+				return app.PrintJSON(cmd, map[string]any{
+					"emailId":         sent.EmailID,
+					"submissionId":    sent.SubmissionID,
+					"status":          "sent",
+					"originalEmailId": args[0],
+					"to":              opts.To,
+					"cc":              opts.CC,
+					"subject":         opts.Subject,
+				})
+			}
+
+			fmt.Printf("Reply sent (email ID: %s, submission ID: %s)\n", sent.EmailID, sent.SubmissionID)
+			fmt.Printf("  To: %s\n", strings.Join(opts.To, ", "))
+			if len(opts.CC) > 0 {
+				fmt.Printf("  CC: %s\n", strings.Join(opts.CC, ", "))
+			}
+			return nil
+		}),
+	}
+
+	cmd.Flags().StringVar(&body, "body", "", "Reply body (plain text)")
+	cmd.Flags().StringVar(&htmlBody, "html", "", "Reply body (HTML)")
+	cmd.Flags().BoolVar(&all, "all", false, "Reply to all: include the original To and CC, minus your own addresses")
+	cmd.Flags().StringVar(&fromIdentity, "from", "", "Send from this identity or masked email (default: auto-detect from original)")
+	cmd.Flags().StringSliceVar(&cc, "cc", nil, "Additional CC email addresses")
+	cmd.Flags().StringSliceVar(&bcc, "bcc", nil, "BCC email addresses")
+	cmd.Flags().StringSliceVar(&attachments, "attach", nil, "Attach files (path, path:name or path:name:inline; inline parts get cid:<name>)")
+	registerSignatureFlags(cmd, &sig)
+
+	return cmd
+}
@@ -0,0 +1,97 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	cerrors "github.com/salmonumbrella/fastmail-cli/internal/errors"
+	"github.com/salmonumbrella/fastmail-cli/internal/jmap"
+	"github.com/salmonumbrella/fastmail-cli/internal/validation"
+	"github.com/spf13/cobra"
+)
+
+func newEmailResendCmd(app *App) *cobra.Command {
+	var to, cc, bcc []string
+	var from string
+	var subject string
+
+	cmd := &cobra.Command{
+		Use:   "resend <emailId>",
+		Short: "Send a previously sent email again",
+		Long: `Send a previously sent email again as a new message.
+
+The sender, recipients, subject, text/HTML bodies and attachments are copied
+from the original. Attachments reuse the original blobs, so nothing is
+re-uploaded. Passing any of --to, --cc or --bcc replaces all of the
+original recipients.
+
+Examples:
+  fastmail email resend Mf1234abc
+  fastmail email resend Mf1234abc --to colleague@example.com
+  fastmail email resend Mf1234abc --from me@example.com --subject "Resend: invoice"`,
+		Args: cobra.ExactArgs(1),
+		RunE: runE(app, func(cmd *cobra.Command, args []string, app *App) error {
+			emailID := args[0]
+
+			for _, addr := range append(append(append([]string{}, to...), cc...), bcc...) {
+				if !validation.IsValidEmail(addr) {
+					return fmt.Errorf("invalid email address: %s", addr)
+				}
+			}
+
+			client, err := app.JMAPClient()
+			if err != nil {
+				return err
+			}
+
+			submissionID, sent, err := client.ResendEmail(cmd.Context(), emailID, jmap.SendEmailOpts{
+				To:      to,
+				CC:      cc,
+				BCC:     bcc,
+				From:    from,
+				Subject: subject,
+			})
+			if err != nil {
+				return cerrors.WithContext(err, "resending email")
+			}
+
+			if app.IsJSON(cmd.Context()) {
+				return app.PrintJSON(cmd, map[string]any{
+					"submissionId":    submissionID,
+					"status":          "sent",
+					"originalEmailId": emailID,
+					"from":            sent.From,
+					"to":              sent.To,
+					"cc":              sent.CC,
+					"bcc":             sent.BCC,
+					"subject":         sent.Subject,
+					"attachments":     len(sent.Attachments),
+				})
+			}
+
+			fmt.Printf("Email resent successfully (submission ID: %s)\n", submissionID)
+			fmt.Printf("  From: %s\n", sent.From)
+			if len(sent.To) > 0 {
+				fmt.Printf("  To: %s\n", strings.Join(sent.To, ", "))
+			}
+			if len(sent.CC) > 0 {
+				fmt.Printf("  Cc: %s\n", strings.Join(sent.CC, ", "))
+			}
+			if len(sent.BCC) > 0 {
+				fmt.Printf("  Bcc: %s\n", strings.Join(sent.BCC, ", "))
+			}
+			if len(sent.Attachments) > 0 {
+				fmt.Printf("  Attachments: %d included\n", len(sent.Attachments))
+			}
+			return nil
+		}),
+	}
+
+	cmd.Flags().StringSliceVar(&to, "to", nil, "Send to these recipients instead of the original ones")
+	cmd.Flags().StringSliceVar(&cc, "cc", nil, "CC recipients (replaces the original recipients)")
+	cmd.Flags().StringSliceVar(&bcc, "bcc", nil, "BCC recipients (replaces the original recipients)")
+	cmd.Flags().StringVar(&from, "from", "", "Send from this identity (default: the original sender)")
+	cmd.Flags().StringVar(&subject, "subject", "", "Override the subject")
+
+	return cmd
+}
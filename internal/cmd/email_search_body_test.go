@@ -0,0 +1,56 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/salmonumbrella/fastmail-cli/internal/jmap"
+)
+
+func TestAttachEmailBodies_JSONIncludesBodyValues(t *testing.T) {
+	emails := []jmap.Email{{ID: "e1", Subject: "Hello"}, {ID: "e2", Subject: "No body"}}
+
+	var gotCap int
+	mock := &jmap.MockEmailService{
+		GetEmailBodiesFunc: func(ctx context.Context, ids []string, maxBodyValueBytes int) ([]jmap.Email, error) {
+			gotCap = maxBodyValueBytes
+			if strings.Join(ids, ",") != "e1,e2" {
+				t.Fatalf("ids = %v", ids)
+			}
+			return []jmap.Email{{
+				ID:         "e1",
+				TextBody:   []jmap.BodyPart{{PartID: "1", Type: "text/plain"}},
+				BodyValues: map[string]jmap.BodyValue{"1": {Value: "Hi there"}},
+			}}, nil
+		},
+	}
+
+	if err := attachEmailBodies(context.Background(), mock, emails, 2048); err != nil {
+		t.Fatalf("attachEmailBodies: %v", err)
+	}
+	if gotCap != 2048 {
+		t.Errorf("cap = %d, want 2048", gotCap)
+	}
+
+	data, err := json.Marshal(emailsToOutputWithCounts(emails, nil))
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+
+	var out []map[string]any
+	if err := json.Unmarshal(data, &out); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	bodyValues, ok := out[0]["bodyValues"].(map[string]any)
+	if !ok {
+		t.Fatalf("bodyValues missing from JSON: %s", data)
+	}
+	if bodyValues["1"].(map[string]any)["value"] != "Hi there" {
+		t.Errorf("bodyValues = %v", bodyValues)
+	}
+	if _, ok := out[1]["bodyValues"]; ok {
+		t.Errorf("email without fetched body should omit bodyValues: %v", out[1])
+	}
+}
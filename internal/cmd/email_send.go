@@ -1,27 +1,42 @@
 package cmd
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"os"
+	"regexp"
 	"strings"
+	"time"
+	"unicode/utf8"
 
-	"github.com/salmonumbrella/fastmail-cli/internal/config"
 	cerrors "github.com/salmonumbrella/fastmail-cli/internal/errors"
-	"github.com/salmonumbrella/fastmail-cli/internal/format"
 	"github.com/salmonumbrella/fastmail-cli/internal/jmap"
+	"github.com/salmonumbrella/fastmail-cli/internal/maskmap"
 	"github.com/salmonumbrella/fastmail-cli/internal/tracking"
 	"github.com/salmonumbrella/fastmail-cli/internal/validation"
 	"github.com/spf13/cobra"
+	"golang.org/x/term"
 )
 
 func newEmailSendCmd(app *App) *cobra.Command {
 	var to, cc, bcc []string
 	var subject, body, htmlBody string
+	var bodyFile, htmlFile string
 	var draft bool
 	var replyTo string
+	var inReplyTo string
+	var references []string
+	var headers []string
 	var attachments []string
+	var inlineImages []string
 	var fromIdentity string
 	var track bool
+	var mask bool
+	var uploadConcurrency int
+	var sig signatureFlags
+	var manifestPath string
+	var pickFrom bool
 
 	cmd := &cobra.Command{
 		Use:     "send",
@@ -33,14 +48,89 @@ The --from flag accepts both identities and masked email addresses. When replyin
 to emails received on a masked email, use --from with that masked email to maintain
 address privacy and keep the conversation consistent.
 
+A --to value that isn't an email address is looked up in your contacts and
+replaced by the matching contact's address. If several addresses match, the
+command fails and lists them so you can pick one.
+
+With --pick-from and no --from, the identities are listed and you choose one
+by number (Enter keeps the default). Without a terminal, or with --yes, the
+default identity is used as usual.
+
+On a terminal, sending with an empty subject (possible with --reply-to) or an
+(almost) empty body asks for confirmation first; --yes skips the question.
+
+--body-file and --html-file read the body from a file; "-" (also accepted
+as --body) reads it from stdin, so a message can be written in an editor or
+piped in.
+
+--attach takes path[:name[:disposition]]. The disposition is attachment
+(default) or inline; inline parts get their name as Content-ID so the HTML
+body can show them with <img src="cid:name">. --inline path:cid adds an inline
+part with the given Content-ID instead, for HTML that already refers to
+cid:<id>. --inline needs an HTML body; inline parts the HTML never refers to
+are reported as a warning.
+
+--in-reply-to and --references set the threading headers directly, for tools
+that thread messages without an original email to reply to. Message-IDs may be
+given with or without angle brackets. With only --in-reply-to, References is
+set to the same ID.
+
+--header adds a header field such as X-Priority or List-Unsubscribe; repeat it
+for more. Fields set from other flags or by the server (From, To, Date,
+Message-ID and the like) and Content-* fields cannot be set this way.
+
+When sending, the sending identity's signature is appended below a "-- " line
+(unless the body already ends with it); use --no-signature to leave it off.
+
 Examples:
   fastmail email send --to user@example.com --subject "Hello" --body "Hi there"
+  fastmail email send --to "Jane Smith" --subject "Hello" --body "Hi Jane"
   fastmail email send --to user@example.com --subject "Report" --body "See attached" --attach report.pdf
+  fastmail email send --to user@example.com --subject "Notes" --body-file notes.txt
+  generate-report | fastmail email send --to user@example.com --subject "Daily report" --body -
   fastmail email send --to user@example.com --subject "Q4 Results" --attach /docs/q4.pdf:Q4-Report.pdf
+  fastmail email send --to user@example.com --subject "Hi" --html '<img src="cid:logo.png">' --attach logo.png:logo.png:inline
+  fastmail email send --to user@example.com --subject "Receipt" --html-file receipt.html --inline header.png:header@receipt
 
   # Send from a masked email address
-  fastmail email send --from my.alias123@fastmail.com --to vendor@example.com --subject "Re: Order" --body "..."`,
+  fastmail email send --from my.alias123@fastmail.com --to vendor@example.com --subject "Re: Order" --body "..."
+
+  # Send from a masked email created for this correspondent (reused on later sends)
+  fastmail email send --mask --to vendor@example.com --subject "Question" --body "..."
+
+  # Thread a message under an existing one by Message-ID
+  fastmail email send --to desk@example.com --subject "Re: Ticket 42" --body "Updated" --in-reply-to "<ticket-42@example.com>"
+
+  # Send from a YAML manifest (to/cc/bcc/from/subject/body/html/attachments;
+  # ${ENV} references are expanded)
+  fastmail email send --manifest send.yaml`,
 		RunE: runE(app, func(cmd *cobra.Command, args []string, app *App) error {
+			if manifestPath != "" {
+				for _, name := range []string{"to", "cc", "bcc", "subject", "body", "body-file", "html", "html-file", "attach"} {
+					if cmd.Flags().Changed(name) {
+						return fmt.Errorf("--manifest and --%s cannot be used together", name)
+					}
+				}
+				m, err := loadSendManifest(manifestPath)
+				if err != nil {
+					return err
+				}
+				to, cc, bcc = m.To, m.CC, m.BCC
+				subject, body, htmlBody = m.Subject, m.Body, m.HTML
+				attachments = m.attachmentSpecs()
+				if m.From != "" && fromIdentity == "" {
+					fromIdentity = m.From
+				}
+			}
+
+			if manifestPath == "" {
+				var err error
+				body, htmlBody, err = bodySources(cmd.InOrStdin(), body, bodyFile, htmlBody, htmlFile)
+				if err != nil {
+					return err
+				}
+			}
+
 			client, err := app.JMAPClient()
 			if err != nil {
 				return err
@@ -56,6 +146,40 @@ Examples:
 			if body == "" && htmlBody == "" {
 				return fmt.Errorf("--body or --html is required")
 			}
+			if mask && fromIdentity != "" {
+				return fmt.Errorf("--mask and --from cannot be used together")
+			}
+			if mask && len(to) == 0 {
+				return fmt.Errorf("--mask requires --to")
+			}
+			// The masked address is made for one correspondent; other
+			// recipients would see it too
+			if mask && (len(to) > 1 || len(cc) > 0 || len(bcc) > 0) {
+				return fmt.Errorf("--mask sends to a single --to recipient; send separately to each recipient")
+			}
+			if pickFrom && (mask || cmd.Flags().Changed("from")) {
+				return fmt.Errorf("--pick-from cannot be used with --from or --mask")
+			}
+			if uploadConcurrency < 1 {
+				return fmt.Errorf("--upload-concurrency must be at least 1")
+			}
+			if replyTo != "" && (inReplyTo != "" || len(references) > 0) {
+				return fmt.Errorf("--in-reply-to and --references cannot be used with --reply-to")
+			}
+			threadInReplyTo, threadReferences, err := threadingHeaders(inReplyTo, references)
+			if err != nil {
+				return err
+			}
+			customHeaders, err := parseHeaderFlags(headers)
+			if err != nil {
+				return err
+			}
+
+			// Names in --to are looked up in contacts
+			to, err = resolveToRecipients(cmd.Context(), client, to)
+			if err != nil {
+				return err
+			}
 
 			// Validate email addresses (only those provided)
 			allAddrs := make([]string, 0, len(to)+len(cc)+len(bcc))
@@ -68,59 +192,73 @@ Examples:
 				}
 			}
 
-			// Process attachments
-			var attachmentOpts []jmap.AttachmentOpts
-			for _, att := range attachments {
-				var attPath, attName string
-				attPath, attName, err = format.ParseAttachmentFlag(att)
-				if err != nil {
-					return fmt.Errorf("invalid attachment: %w", err)
-				}
-
-				// Verify file exists and get size
-				var fileInfo os.FileInfo
-				fileInfo, err = os.Stat(attPath)
-				if err != nil {
-					return fmt.Errorf("cannot access attachment '%s': %w", attPath, err)
-				}
-				if fileInfo.IsDir() {
-					return fmt.Errorf("cannot attach directory: %s", attPath)
+			// Catch accidental blank sends while someone can still answer
+			if !draft && !app.Flags.Yes && !app.IsJSON(cmd.Context()) &&
+				term.IsTerminal(int(os.Stdin.Fd())) && term.IsTerminal(int(os.Stdout.Fd())) {
+				if prompt := blankSendPrompt(subject, body, htmlBody); prompt != "" {
+					confirmed, confirmErr := confirmPrompt(os.Stderr, prompt, "y", "yes")
+					if confirmErr != nil {
+						return confirmErr
+					}
+					if !confirmed {
+						printCancelled()
+						return nil
+					}
 				}
+			}
 
-				// Check file size before upload
-				if fileInfo.Size() > jmap.MaxUploadSize {
-					return fmt.Errorf("attachment '%s' too large (%s, max 50 MB)", attPath, format.FormatBytes(fileInfo.Size()))
+			// Check all attachments locally before uploading any
+			uploads, err := prepareAttachments(attachments)
+			if err != nil {
+				return err
+			}
+			if len(inlineImages) > 0 && htmlBody == "" {
+				return fmt.Errorf("--inline requires an HTML body (--html or --html-file)")
+			}
+			inlineUploads, err := prepareInlineImages(inlineImages)
+			if err != nil {
+				return err
+			}
+			uploads = append(uploads, inlineUploads...)
+			if htmlBody != "" {
+				for _, cid := range unreferencedInlineParts(uploads, htmlBody) {
+					fmt.Fprintf(os.Stderr, "Warning: inline part %q is not referenced as cid:%s in the HTML body\n", cid, cid)
 				}
+			}
+			attachmentOpts, err := uploadAttachments(cmd.Context(), client, uploads, uploadConcurrency)
+			if err != nil {
+				return err
+			}
 
-				// Open and upload the file
-				var file *os.File
-				file, err = os.Open(attPath)
-				if err != nil {
-					return fmt.Errorf("failed to open attachment '%s': %w", attPath, err)
+			// Apply default identity if --from not specified
+			effectiveFrom := fromIdentity
+			var maskedFrom string
+			var maskCreated bool
+			if mask {
+				account, accountErr := app.RequireAccount()
+				if accountErr != nil {
+					return accountErr
 				}
-
-				mimeType := format.MimeType(attPath)
-				var uploadResult *jmap.UploadBlobResult
-				uploadResult, err = client.UploadBlob(cmd.Context(), file, mimeType)
-				_ = file.Close()
+				maskedFrom, maskCreated, err = resolveSendMask(cmd, client, account, to[0])
 				if err != nil {
-					return fmt.Errorf("failed to upload attachment '%s': %w", attPath, err)
+					return err
 				}
-
-				attachmentOpts = append(attachmentOpts, jmap.AttachmentOpts{
-					BlobID: uploadResult.BlobID,
-					Name:   attName,
-					Type:   mimeType,
-				})
+				effectiveFrom = maskedFrom
 			}
-
-			// Apply default identity if --from not specified
-			effectiveFrom := fromIdentity
 			if effectiveFrom == "" {
-				accountEmail, accountErr := app.RequireAccount()
-				if accountErr == nil {
-					if defaultIdentity, _ := config.GetDefaultIdentity(accountEmail); defaultIdentity != "" {
-						effectiveFrom = defaultIdentity
+				accountEmail, _ := app.RequireAccount()
+				defaultIdentity := app.DefaultFrom(accountEmail)
+				effectiveFrom = defaultIdentity
+
+				// Only prompt when someone can answer; otherwise keep the default
+				if pickFrom && !app.Flags.Yes && !app.IsJSON(cmd.Context()) && term.IsTerminal(int(os.Stdin.Fd())) {
+					identities, idErr := client.GetIdentities(cmd.Context())
+					if idErr != nil {
+						return cerrors.WithContext(idErr, "fetching identities")
+					}
+					effectiveFrom, err = pickIdentity(os.Stdin, os.Stderr, identities, defaultIdentity)
+					if err != nil {
+						return err
 					}
 				}
 			}
@@ -134,6 +272,10 @@ Examples:
 				HTMLBody:    htmlBody,
 				From:        effectiveFrom,
 				Attachments: attachmentOpts,
+				Signature:   sig.enabled(),
+				InReplyTo:   threadInReplyTo,
+				References:  threadReferences,
+				Headers:     customHeaders,
 			}
 
 			// Handle tracking
@@ -199,11 +341,16 @@ Examples:
 				if trackingID != "" {
 					result["trackingId"] = trackingID
 				}
+				if maskedFrom != "" {
+					result["maskedFrom"] = maskedFrom
+					result["maskCreated"] = maskCreated
+				}
 
 				if app.IsJSON(cmd.Context()) {
 					return app.PrintJSON(cmd, result)
 				}
 
+				printMaskedFrom(maskedFrom, maskCreated)
 				fmt.Printf("Draft saved (ID: %s)\n", draftID)
 				if trackingID != "" {
 					fmt.Printf("Tracking ID: %s\n", trackingID)
@@ -212,24 +359,31 @@ Examples:
 			}
 
 			// Send the email
-			submissionID, err := client.SendEmail(cmd.Context(), opts)
+			sent, err := client.SendEmailResult(cmd.Context(), opts)
 			if err != nil {
 				return cerrors.WithContext(err, "sending email")
 			}
 
 			result := map[string]any{
-				"submissionId": submissionID,
+				"draftId":      sent.DraftID,
+				"emailId":      sent.EmailID,
+				"submissionId": sent.SubmissionID,
 				"status":       "sent",
 			}
 			if trackingID != "" {
 				result["trackingId"] = trackingID
 			}
+			if maskedFrom != "" {
+				result["maskedFrom"] = maskedFrom
+				result["maskCreated"] = maskCreated
+			}
 
 			if app.IsJSON(cmd.Context()) {
 				return app.PrintJSON(cmd, result)
 			}
 
-			fmt.Printf("Email sent successfully (submission ID: %s)\n", submissionID)
+			printMaskedFrom(maskedFrom, maskCreated)
+			fmt.Printf("Email sent successfully (email ID: %s, submission ID: %s)\n", sent.EmailID, sent.SubmissionID)
 			if trackingID != "" {
 				fmt.Printf("Tracking ID: %s\n", trackingID)
 			}
@@ -237,21 +391,156 @@ Examples:
 		}),
 	}
 
-	cmd.Flags().StringSliceVar(&to, "to", nil, "Recipient email addresses")
+	cmd.Flags().StringSliceVar(&to, "to", nil, "Recipient email addresses, or contact names to look up")
 	cmd.Flags().StringSliceVar(&cc, "cc", nil, "CC email addresses")
 	cmd.Flags().StringSliceVar(&bcc, "bcc", nil, "BCC email addresses")
 	cmd.Flags().StringVar(&subject, "subject", "", "Email subject")
-	cmd.Flags().StringVar(&body, "body", "", "Email body (plain text)")
+	cmd.Flags().StringVar(&body, "body", "", "Email body (plain text, - for stdin)")
+	cmd.Flags().StringVar(&bodyFile, "body-file", "", "Read the plain text body from a file (- for stdin)")
 	cmd.Flags().StringVar(&htmlBody, "html", "", "Email body (HTML)")
+	cmd.Flags().StringVar(&htmlFile, "html-file", "", "Read the HTML body from a file (- for stdin)")
 	cmd.Flags().StringVar(&fromIdentity, "from", "", "Send from this identity or masked email address")
+	cmd.Flags().BoolVar(&pickFrom, "pick-from", false, "Choose the sending identity from a numbered list (on a terminal)")
 	cmd.Flags().BoolVar(&draft, "draft", false, "Save as draft instead of sending")
 	cmd.Flags().StringVar(&replyTo, "reply-to", "", "Email ID to reply to (threads the draft)")
-	cmd.Flags().StringSliceVar(&attachments, "attach", nil, "Attach files (path or path:name)")
+	cmd.Flags().StringVar(&inReplyTo, "in-reply-to", "", "Message-ID this email replies to (sets the In-Reply-To header)")
+	cmd.Flags().StringSliceVar(&references, "references", nil, "Message-IDs for the References header, oldest first")
+	cmd.Flags().StringArrayVar(&headers, "header", nil, `Extra header field as "Name: value" (repeatable)`)
+	cmd.Flags().StringSliceVar(&attachments, "attach", nil, "Attach files (path, path:name or path:name:inline; inline parts get cid:<name>)")
+	cmd.Flags().StringArrayVar(&inlineImages, "inline", nil, "Add an inline image as path:cid for <img src=\"cid:...\"> in the HTML body (repeatable)")
 	cmd.Flags().BoolVar(&track, "track", false, "Enable open tracking (requires tracking setup)")
+	cmd.Flags().IntVar(&uploadConcurrency, "upload-concurrency", defaultUploadConcurrency, "Maximum number of attachments uploaded in parallel")
+	registerSignatureFlags(cmd, &sig)
+	cmd.Flags().StringVar(&manifestPath, "manifest", "", "Read recipients, subject, body and attachments from a YAML file")
+	cmd.Flags().BoolVar(&mask, "mask", false, "Send to a single recipient from a masked email created for their domain (reused for later sends)")
 
 	return cmd
 }
 
+// signatureFlags hold --signature and --no-signature of the commands that
+// send mail.
+type signatureFlags struct {
+	signature   bool
+	noSignature bool
+}
+
+// registerSignatureFlags adds --signature and --no-signature to cmd.
+func registerSignatureFlags(cmd *cobra.Command, f *signatureFlags) {
+	cmd.Flags().BoolVar(&f.signature, "signature", true, "Append the sending identity's signature when sending")
+	cmd.Flags().BoolVar(&f.noSignature, "no-signature", false, "Don't append the identity's signature")
+}
+
+// enabled reports whether the identity's signature should be appended.
+func (f *signatureFlags) enabled() bool {
+	return f.signature && !f.noSignature
+}
+
+// resolveToRecipients replaces each --to value that isn't an email address
+// with the single contact address it matches. No match, or several, is an
+// error listing the candidates.
+func resolveToRecipients(ctx context.Context, contacts jmap.ContactsService, to []string) ([]string, error) {
+	resolved := make([]string, 0, len(to))
+	for _, recipient := range to {
+		if validation.IsValidEmail(recipient) {
+			resolved = append(resolved, recipient)
+			continue
+		}
+
+		addrs, err := contacts.ResolveRecipient(ctx, recipient)
+		if err != nil {
+			if errors.Is(err, jmap.ErrContactsNotEnabled) {
+				return nil, fmt.Errorf("invalid email address: %s", recipient)
+			}
+			return nil, cerrors.WithContext(err, fmt.Sprintf("looking up %q in contacts", recipient))
+		}
+
+		switch len(addrs) {
+		case 0:
+			return nil, fmt.Errorf("invalid email address: %s (no contact matches it)", recipient)
+		case 1:
+			resolved = append(resolved, addrs[0].Email)
+		default:
+			candidates := make([]string, len(addrs))
+			for i, addr := range addrs {
+				candidates[i] = fmt.Sprintf("%s <%s>", addr.Name, addr.Email)
+			}
+			return nil, fmt.Errorf("%q matches %d contact addresses, use one of: %s", recipient, len(addrs), strings.Join(candidates, ", "))
+		}
+	}
+	return resolved, nil
+}
+
+// resolveSendMask returns the masked address to send to recipient from.
+// The alias recorded for that correspondent is reused while it can still
+// receive mail; otherwise a new one is created and recorded.
+func resolveSendMask(cmd *cobra.Command, client *jmap.Client, account, recipient string) (string, bool, error) {
+	state, err := maskmap.Load()
+	if err != nil {
+		return "", false, err
+	}
+
+	if addr, ok := state.Lookup(account, recipient); ok {
+		alias, lookupErr := client.GetMaskedEmailByEmail(cmd.Context(), addr)
+		if lookupErr == nil && (alias.State == jmap.MaskedEmailEnabled || alias.State == jmap.MaskedEmailPending) {
+			return alias.Email, false, nil
+		}
+	}
+
+	alias, err := client.CreateMaskedEmailForRecipient(cmd.Context(), recipient)
+	if err != nil {
+		if errors.Is(err, jmap.ErrMaskedEmailNotEnabled) {
+			return "", false, cerrors.WithSuggestion(err, "Create an API token with the Masked Email scope, or send without --mask")
+		}
+		return "", false, fmt.Errorf("failed to create masked email: %w", err)
+	}
+
+	state.Set(account, recipient, alias.Email, time.Now())
+	if err := state.Save(); err != nil {
+		return "", false, fmt.Errorf("masked email %s created but not recorded: %w", alias.Email, err)
+	}
+
+	return alias.Email, true, nil
+}
+
+// printMaskedFrom reports which masked address a message is sent from.
+func printMaskedFrom(addr string, created bool) {
+	if addr == "" {
+		return
+	}
+	if created {
+		fmt.Printf("Created masked email %s for this recipient\n", addr)
+		return
+	}
+	fmt.Printf("Using masked email %s\n", addr)
+}
+
+// minSendBodyLength is the body length (after trimming, and ignoring HTML
+// tags) below which email send asks before sending.
+const minSendBodyLength = 3
+
+var htmlTagPattern = regexp.MustCompile(`<[^>]*>`)
+
+// blankSendPrompt returns a confirmation prompt when the subject is empty or
+// the body is nearly empty, or "" when the message looks complete.
+func blankSendPrompt(subject, textBody, htmlBody string) string {
+	content := strings.TrimSpace(textBody)
+	if content == "" {
+		content = strings.TrimSpace(htmlTagPattern.ReplaceAllString(htmlBody, ""))
+	}
+	emptySubject := strings.TrimSpace(subject) == ""
+	shortBody := utf8.RuneCountInString(content) < minSendBodyLength
+
+	switch {
+	case emptySubject && shortBody:
+		return "Send with empty subject and an (almost) empty body? [y/N] "
+	case emptySubject:
+		return "Send with empty subject? [y/N] "
+	case shortBody:
+		return fmt.Sprintf("Send with an (almost) empty body (%q)? [y/N] ", content)
+	}
+	return ""
+}
+
 func injectTrackingPixel(htmlBody, pixelHTML string) string {
 	lower := strings.ToLower(htmlBody)
 	if i := strings.LastIndex(lower, "</body>"); i != -1 {
@@ -262,3 +551,59 @@ func injectTrackingPixel(htmlBody, pixelHTML string) string {
 	}
 	return htmlBody + pixelHTML
 }
+
+// threadingHeaders validates and normalizes the --in-reply-to and
+// --references values. References may also be separated by whitespace, as
+// in a copied header. Without references, the In-Reply-To ID is used.
+func threadingHeaders(inReplyTo string, references []string) ([]string, []string, error) {
+	var parent []string
+	if inReplyTo != "" {
+		id, err := validation.MessageID(inReplyTo)
+		if err != nil {
+			return nil, nil, fmt.Errorf("invalid --in-reply-to: %w", err)
+		}
+		parent = []string{id}
+	}
+
+	var refs []string
+	for _, value := range references {
+		for _, field := range strings.Fields(value) {
+			id, err := validation.MessageID(field)
+			if err != nil {
+				return nil, nil, fmt.Errorf("invalid --references: %w", err)
+			}
+			refs = append(refs, id)
+		}
+	}
+	if len(refs) == 0 {
+		refs = parent
+	}
+	return parent, refs, nil
+}
+
+// parseHeaderFlags parses --header "Name: value" values. Each name may be
+// given once.
+func parseHeaderFlags(specs []string) (map[string]string, error) {
+	if len(specs) == 0 {
+		return nil, nil
+	}
+
+	headers := make(map[string]string, len(specs))
+	seen := make(map[string]bool, len(specs))
+	for _, spec := range specs {
+		name, value, ok := strings.Cut(spec, ":")
+		if !ok {
+			return nil, fmt.Errorf(`invalid --header %q (expected "Name: value")`, spec)
+		}
+		name, value = strings.TrimSpace(name), strings.TrimSpace(value)
+		if err := jmap.ValidateHeader(name, value); err != nil {
+			return nil, fmt.Errorf("invalid --header %q: %w", spec, err)
+		}
+		if seen[strings.ToLower(name)] {
+			return nil, fmt.Errorf("--header %s given more than once", name)
+		}
+		seen[strings.ToLower(name)] = true
+		headers[name] = value
+	}
+	return headers, nil
+}
@@ -0,0 +1,197 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/salmonumbrella/fastmail-cli/internal/format"
+	"github.com/salmonumbrella/fastmail-cli/internal/jmap"
+)
+
+// defaultUploadConcurrency is the default number of attachments uploaded at once.
+const defaultUploadConcurrency = 3
+
+// attachmentUpload is an attachment that passed local checks and is ready to upload.
+type attachmentUpload struct {
+	path        string
+	name        string
+	mimeType    string
+	disposition string
+	cid         string
+}
+
+// contentIDSpecials are the characters a Content-ID may not contain.
+const contentIDSpecials = "<> \t\r\n\""
+
+// prepareAttachments parses --attach values and checks each file exists, is
+// not a directory and is within the upload size limit, before anything is
+// uploaded.
+func prepareAttachments(specs []string) ([]attachmentUpload, error) {
+	uploads := make([]attachmentUpload, 0, len(specs))
+	for _, spec := range specs {
+		attPath, attName, disposition, err := format.ParseAttachmentFlag(spec)
+		if err != nil {
+			return nil, fmt.Errorf("invalid attachment: %w", err)
+		}
+
+		// An inline part's Content-ID defaults to its name
+		if disposition == format.DispositionInline && strings.ContainsAny(attName, contentIDSpecials) {
+			return nil, fmt.Errorf("inline attachment name %q cannot be used as a content ID; give it a name without spaces (path:name:inline) or use --inline path:cid", attName)
+		}
+
+		upload, err := newAttachmentUpload(attPath, attName, disposition)
+		if err != nil {
+			return nil, err
+		}
+		uploads = append(uploads, upload)
+	}
+	return uploads, nil
+}
+
+// newAttachmentUpload checks that attPath exists, is not a directory and is
+// within the upload size limit.
+func newAttachmentUpload(attPath, name, disposition string) (attachmentUpload, error) {
+	// Verify file exists and get size
+	fileInfo, err := os.Stat(attPath)
+	if err != nil {
+		return attachmentUpload{}, fmt.Errorf("cannot access attachment '%s': %w", attPath, err)
+	}
+	if fileInfo.IsDir() {
+		return attachmentUpload{}, fmt.Errorf("cannot attach directory: %s", attPath)
+	}
+
+	// Check file size before upload
+	if fileInfo.Size() > jmap.MaxUploadSize {
+		return attachmentUpload{}, fmt.Errorf("attachment '%s' too large (%s, max 50 MB)", attPath, format.FormatBytes(fileInfo.Size()))
+	}
+
+	return attachmentUpload{
+		path:        attPath,
+		name:        name,
+		mimeType:    format.MimeType(attPath),
+		disposition: disposition,
+	}, nil
+}
+
+// prepareInlineImages parses --inline path:cid values and checks the files
+// like prepareAttachments. The parts are inline, named after the file, with
+// the given Content-ID.
+func prepareInlineImages(specs []string) ([]attachmentUpload, error) {
+	uploads := make([]attachmentUpload, 0, len(specs))
+	for _, spec := range specs {
+		sep := strings.LastIndex(spec, ":")
+		if sep <= 0 || sep == len(spec)-1 || (sep == 1 && len(spec) > 2 && (spec[2] == '\\' || spec[2] == '/')) {
+			return nil, fmt.Errorf("invalid --inline %q (expected path:cid)", spec)
+		}
+		attPath, cid := spec[:sep], spec[sep+1:]
+		if strings.ContainsAny(cid, contentIDSpecials) {
+			return nil, fmt.Errorf("invalid --inline content ID %q", cid)
+		}
+
+		upload, err := newAttachmentUpload(attPath, filepath.Base(attPath), format.DispositionInline)
+		if err != nil {
+			return nil, err
+		}
+		upload.cid = cid
+		uploads = append(uploads, upload)
+	}
+	return uploads, nil
+}
+
+// unreferencedInlineParts returns the Content-IDs of inline uploads that
+// html never refers to as cid:<id>.
+func unreferencedInlineParts(uploads []attachmentUpload, html string) []string {
+	lower := strings.ToLower(html)
+	var unused []string
+	for _, u := range uploads {
+		if u.disposition != format.DispositionInline {
+			continue
+		}
+		cid := u.cid
+		if cid == "" {
+			cid = u.name
+		}
+		if !strings.Contains(lower, "cid:"+strings.ToLower(cid)) {
+			unused = append(unused, cid)
+		}
+	}
+	return unused
+}
+
+// uploadAttachments uploads files with at most concurrency uploads in flight.
+// The result preserves the order of uploads. The first failure cancels the
+// remaining uploads and is returned.
+func uploadAttachments(ctx context.Context, client jmap.EmailService, uploads []attachmentUpload, concurrency int) ([]jmap.AttachmentOpts, error) {
+	if len(uploads) == 0 {
+		return nil, nil
+	}
+	if concurrency < 1 {
+		concurrency = 1
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	results := make([]jmap.AttachmentOpts, len(uploads))
+	sem := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+	var once sync.Once
+	var firstErr error
+
+	fail := func(err error) {
+		once.Do(func() {
+			firstErr = err
+			cancel()
+		})
+	}
+
+	for i, upload := range uploads {
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+		}
+		if ctx.Err() != nil {
+			break
+		}
+
+		wg.Add(1)
+		go func(i int, upload attachmentUpload) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			blobID, err := uploadAttachmentFile(ctx, client, upload)
+			if err != nil {
+				fail(err)
+				return
+			}
+			results[i] = jmap.AttachmentOpts{
+				BlobID:      blobID,
+				Name:        upload.name,
+				Type:        upload.mimeType,
+				Disposition: upload.disposition,
+				CID:         upload.cid,
+			}
+		}(i, upload)
+	}
+	wg.Wait()
+
+	if firstErr != nil {
+		return nil, firstErr
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+func uploadAttachmentFile(ctx context.Context, client jmap.EmailService, upload attachmentUpload) (string, error) {
+	result, err := client.UploadBlobFromFile(ctx, upload.path, upload.mimeType)
+	if err != nil {
+		return "", fmt.Errorf("failed to upload attachment '%s': %w", upload.path, err)
+	}
+	return result.BlobID, nil
+}
@@ -0,0 +1,173 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/salmonumbrella/fastmail-cli/internal/jmap"
+	"github.com/salmonumbrella/fastmail-cli/internal/testutil"
+)
+
+// newUploadTestClient returns a client whose upload endpoint answers with a
+// blob ID equal to the uploaded content, and records the peak number of
+// concurrent uploads. Content "fail" is rejected.
+func newUploadTestClient(t *testing.T, maxInFlight *int32) *jmap.Client {
+	t.Helper()
+
+	var inFlight int32
+	jm := testutil.NewJMAPMock()
+	t.Cleanup(jm.Close)
+	jm.Handle(http.MethodPost, "/jmap/upload/"+testutil.JMAPAccountID+"/", func(w http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt32(&inFlight, 1)
+		defer atomic.AddInt32(&inFlight, -1)
+		for {
+			peak := atomic.LoadInt32(maxInFlight)
+			if n <= peak || atomic.CompareAndSwapInt32(maxInFlight, peak, n) {
+				break
+			}
+		}
+
+		body, _ := io.ReadAll(r.Body)
+		time.Sleep(50 * time.Millisecond)
+		if string(body) == "fail" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		w.WriteHeader(http.StatusCreated)
+		fmt.Fprintf(w, `{"accountId":%q,"blobId":%q,"type":"text/plain","size":%d}`, testutil.JMAPAccountID, body, len(body))
+	})
+
+	return jmap.NewClientWithBaseURL("test-token", jm.URL())
+}
+
+func writeAttachmentFiles(t *testing.T, contents ...string) []string {
+	t.Helper()
+	dir := t.TempDir()
+	specs := make([]string, 0, len(contents))
+	for i, content := range contents {
+		path := filepath.Join(dir, fmt.Sprintf("file%d.txt", i))
+		if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+			t.Fatalf("write: %v", err)
+		}
+		specs = append(specs, path)
+	}
+	return specs
+}
+
+func TestUploadAttachments_BoundedAndOrdered(t *testing.T) {
+	var maxInFlight int32
+	client := newUploadTestClient(t, &maxInFlight)
+
+	contents := []string{"a", "b", "c", "d", "e", "f"}
+	uploads, err := prepareAttachments(writeAttachmentFiles(t, contents...))
+	if err != nil {
+		t.Fatalf("prepareAttachments: %v", err)
+	}
+
+	opts, err := uploadAttachments(context.Background(), client, uploads, 3)
+	if err != nil {
+		t.Fatalf("uploadAttachments: %v", err)
+	}
+
+	if len(opts) != len(contents) {
+		t.Fatalf("got %d attachments, want %d", len(opts), len(contents))
+	}
+	for i, content := range contents {
+		if opts[i].BlobID != content {
+			t.Errorf("attachment %d blobId = %q, want %q (order not preserved)", i, opts[i].BlobID, content)
+		}
+		if opts[i].Name != fmt.Sprintf("file%d.txt", i) {
+			t.Errorf("attachment %d name = %q", i, opts[i].Name)
+		}
+	}
+
+	if peak := atomic.LoadInt32(&maxInFlight); peak > 3 {
+		t.Errorf("peak concurrent uploads = %d, want <= 3", peak)
+	} else if peak < 2 {
+		t.Errorf("peak concurrent uploads = %d, expected uploads to overlap", peak)
+	}
+}
+
+func TestUploadAttachments_FirstErrorReturned(t *testing.T) {
+	var maxInFlight int32
+	client := newUploadTestClient(t, &maxInFlight)
+
+	uploads, err := prepareAttachments(writeAttachmentFiles(t, "a", "fail", "c"))
+	if err != nil {
+		t.Fatalf("prepareAttachments: %v", err)
+	}
+
+	if _, err := uploadAttachments(context.Background(), client, uploads, 2); err == nil {
+		t.Fatal("expected upload error")
+	}
+}
+
+func TestPrepareAttachments_RejectsDirectory(t *testing.T) {
+	if _, err := prepareAttachments([]string{t.TempDir()}); err == nil {
+		t.Fatal("expected error for directory attachment")
+	}
+}
+
+func TestPrepareAttachments_InlineNameMustBeContentID(t *testing.T) {
+	dir := t.TempDir()
+	shot := filepath.Join(dir, "Screen Shot.png")
+	if err := os.WriteFile(shot, []byte("png"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := prepareAttachments([]string{shot + "::inline"}); err == nil || !strings.Contains(err.Error(), "content ID") {
+		t.Errorf("inline part named %q: error = %v, want content ID error", "Screen Shot.png", err)
+	}
+	if _, err := prepareAttachments([]string{shot + ":shot.png:inline"}); err != nil {
+		t.Errorf("inline part with a plain name: %v", err)
+	}
+	if _, err := prepareAttachments([]string{shot}); err != nil {
+		t.Errorf("regular attachment with spaces in its name: %v", err)
+	}
+}
+
+func TestPrepareInlineImages(t *testing.T) {
+	dir := t.TempDir()
+	logo := filepath.Join(dir, "logo.png")
+	if err := os.WriteFile(logo, []byte("png"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	uploads, err := prepareInlineImages([]string{logo + ":logo@example"})
+	if err != nil {
+		t.Fatalf("prepareInlineImages() error = %v", err)
+	}
+	u := uploads[0]
+	if u.path != logo || u.name != "logo.png" || u.cid != "logo@example" || u.disposition != "inline" || u.mimeType != "image/png" {
+		t.Errorf("upload = %+v", u)
+	}
+
+	for _, spec := range []string{logo, logo + ":", ":logo", logo + ":a b", filepath.Join(dir, "missing.png") + ":x"} {
+		if _, err := prepareInlineImages([]string{spec}); err == nil {
+			t.Errorf("prepareInlineImages(%q) should fail", spec)
+		}
+	}
+}
+
+func TestUnreferencedInlineParts(t *testing.T) {
+	uploads := []attachmentUpload{
+		{name: "report.pdf", disposition: "attachment"},
+		{name: "logo.png", disposition: "inline"},
+		{name: "header.png", disposition: "inline", cid: "Header@Receipt"},
+		{name: "footer.png", disposition: "inline", cid: "footer"},
+	}
+	html := `<img src="cid:logo.png"><img src="CID:header@receipt">`
+
+	got := unreferencedInlineParts(uploads, html)
+	if len(got) != 1 || got[0] != "footer" {
+		t.Errorf("unreferencedInlineParts() = %v, want [footer]", got)
+	}
+}
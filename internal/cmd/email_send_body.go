@@ -0,0 +1,72 @@
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"unicode/utf8"
+)
+
+// maxBodyFileSize limits --body-file and --html-file reads, so pointing one
+// at the wrong file does not load it whole.
+const maxBodyFileSize = 25 << 20
+
+// bodySources resolves the --body/--body-file and --html/--html-file pairs
+// into the text and HTML bodies. "-" as --body, --body-file or --html-file
+// reads stdin, which only one of them may do.
+func bodySources(stdin io.Reader, body, bodyFile, html, htmlFile string) (string, string, error) {
+	if body != "" && bodyFile != "" {
+		return "", "", fmt.Errorf("--body and --body-file cannot be used together")
+	}
+	if html != "" && htmlFile != "" {
+		return "", "", fmt.Errorf("--html and --html-file cannot be used together")
+	}
+	if body == "-" {
+		body, bodyFile = "", "-"
+	}
+	if bodyFile == "-" && htmlFile == "-" {
+		return "", "", fmt.Errorf("only one of the text and HTML bodies can be read from stdin")
+	}
+
+	var err error
+	if bodyFile != "" {
+		if body, err = readBodyFile(stdin, bodyFile); err != nil {
+			return "", "", fmt.Errorf("--body-file: %w", err)
+		}
+	}
+	if htmlFile != "" {
+		if html, err = readBodyFile(stdin, htmlFile); err != nil {
+			return "", "", fmt.Errorf("--html-file: %w", err)
+		}
+	}
+	return body, html, nil
+}
+
+// readBodyFile reads a message body from path, or from stdin when path is
+// "-". Files over maxBodyFileSize and ones that are not UTF-8 text are
+// rejected.
+func readBodyFile(stdin io.Reader, path string) (string, error) {
+	r := stdin
+	name := "stdin"
+	if path != "-" {
+		f, err := os.Open(path)
+		if err != nil {
+			return "", err
+		}
+		defer f.Close()
+		r, name = f, path
+	}
+
+	data, err := io.ReadAll(io.LimitReader(r, maxBodyFileSize+1))
+	if err != nil {
+		return "", fmt.Errorf("reading %s: %w", name, err)
+	}
+	if len(data) > maxBodyFileSize {
+		return "", fmt.Errorf("%s is larger than 25 MB", name)
+	}
+	if !utf8.Valid(data) || bytes.IndexByte(data, 0) >= 0 {
+		return "", fmt.Errorf("%s does not look like text (binary or not UTF-8)", name)
+	}
+	return string(data), nil
+}
@@ -0,0 +1,79 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestBodySources(t *testing.T) {
+	dir := t.TempDir()
+	textPath := filepath.Join(dir, "body.txt")
+	htmlPath := filepath.Join(dir, "body.html")
+	if err := os.WriteFile(textPath, []byte("Line one\nLine two\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(htmlPath, []byte("<p>Hi</p>"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	body, html, err := bodySources(strings.NewReader(""), "", textPath, "", htmlPath)
+	if err != nil {
+		t.Fatalf("bodySources() error = %v", err)
+	}
+	if body != "Line one\nLine two\n" || html != "<p>Hi</p>" {
+		t.Errorf("body = %q, html = %q", body, html)
+	}
+
+	// --body - and --html-file - both read stdin
+	body, _, err = bodySources(strings.NewReader("piped text"), "-", "", "", "")
+	if err != nil || body != "piped text" {
+		t.Errorf("--body -: body = %q, err = %v", body, err)
+	}
+	_, html, err = bodySources(strings.NewReader("<b>piped</b>"), "", "", "", "-")
+	if err != nil || html != "<b>piped</b>" {
+		t.Errorf("--html-file -: html = %q, err = %v", html, err)
+	}
+
+	// Plain flags pass through untouched
+	body, html, err = bodySources(strings.NewReader("unused"), "inline", "", "<i>x</i>", "")
+	if err != nil || body != "inline" || html != "<i>x</i>" {
+		t.Errorf("body = %q, html = %q, err = %v", body, html, err)
+	}
+}
+
+func TestBodySources_Errors(t *testing.T) {
+	dir := t.TempDir()
+	binPath := filepath.Join(dir, "image.bin")
+	if err := os.WriteFile(binPath, []byte{0x89, 'P', 'N', 'G', 0, 0, 0xff}, 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name                           string
+		body, bodyFile, html, htmlFile string
+		want                           string
+	}{
+		{name: "body and body-file", body: "x", bodyFile: "y.txt", want: "--body and --body-file"},
+		{name: "html and html-file", html: "x", htmlFile: "y.html", want: "--html and --html-file"},
+		{name: "stdin twice", body: "-", htmlFile: "-", want: "stdin"},
+		{name: "missing file", bodyFile: filepath.Join(dir, "missing.txt"), want: "--body-file"},
+		{name: "binary file", htmlFile: binPath, want: "does not look like text"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, _, err := bodySources(strings.NewReader(""), tt.body, tt.bodyFile, tt.html, tt.htmlFile)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("bodySources() error = %v, want it to contain %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestReadBodyFile_SizeLimit(t *testing.T) {
+	big := strings.NewReader(strings.Repeat("a", maxBodyFileSize+1))
+	if _, err := readBodyFile(big, "-"); err == nil || !strings.Contains(err.Error(), "25 MB") {
+		t.Errorf("readBodyFile() error = %v, want size limit error", err)
+	}
+}
@@ -0,0 +1,143 @@
+package cmd
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/salmonumbrella/fastmail-cli/internal/dateparse"
+	cerrors "github.com/salmonumbrella/fastmail-cli/internal/errors"
+	"github.com/salmonumbrella/fastmail-cli/internal/snooze"
+	"github.com/spf13/cobra"
+)
+
+func newEmailSnoozeCmd(app *App) *cobra.Command {
+	var until string
+
+	cmd := &cobra.Command{
+		Use:   "snooze <emailId>",
+		Short: "Snooze an email until a later time",
+		Long: `Snooze an email until a later time.
+
+The email is moved to the Snoozed mailbox (created if missing) and its wake
+time is recorded locally. Run 'fastmail email snooze-wake' periodically
+(e.g. from cron) to move emails whose time has passed back to the inbox.
+
+Examples:
+  fastmail email snooze ABC123 --until 2025-01-10T09:00
+  fastmail email snooze ABC123 --until tomorrow
+  fastmail email snooze ABC123 --until 3h`,
+		Args: cobra.ExactArgs(1),
+		RunE: runE(app, func(cmd *cobra.Command, args []string, app *App) error {
+			if until == "" {
+				return fmt.Errorf("--until is required")
+			}
+
+			wakeAt, err := dateparse.ParseDateTimeNow(until)
+			if err != nil {
+				return fmt.Errorf("invalid --until (expected RFC3339, YYYY-MM-DDTHH:MM, or relative like tomorrow, 3h, monday): %s", until)
+			}
+
+			account, err := app.RequireAccount()
+			if err != nil {
+				return err
+			}
+
+			client, err := app.JMAPClient()
+			if err != nil {
+				return err
+			}
+
+			state, err := snooze.Load()
+			if err != nil {
+				return err
+			}
+
+			if err := client.SnoozeEmail(cmd.Context(), args[0], wakeAt); err != nil {
+				return cerrors.WithContext(err, "snoozing email")
+			}
+
+			state.Add(account, args[0], wakeAt)
+			if err := state.Save(); err != nil {
+				return fmt.Errorf("email snoozed but wake time not saved: %w", err)
+			}
+
+			if app.IsJSON(cmd.Context()) {
+				return app.PrintJSON(cmd, map[string]any{
+					"status":  "snoozed",
+					"emailId": args[0],
+					"until":   wakeAt.Format(time.RFC3339),
+				})
+			}
+
+			fmt.Printf("Snoozed email %s until %s\n", args[0], wakeAt.Format("2006-01-02 15:04 MST"))
+			return nil
+		}),
+	}
+
+	cmd.Flags().StringVar(&until, "until", "", "When the email should return to the inbox")
+
+	return cmd
+}
+
+func newEmailSnoozeWakeCmd(app *App) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "snooze-wake",
+		Short: "Move snoozed emails whose time has passed back to the inbox",
+		Args:  cobra.NoArgs,
+		RunE: runE(app, func(cmd *cobra.Command, args []string, app *App) error {
+			account, err := app.RequireAccount()
+			if err != nil {
+				return err
+			}
+
+			state, err := snooze.Load()
+			if err != nil {
+				return err
+			}
+
+			due := state.Due(account, time.Now())
+			if len(due) == 0 {
+				if app.IsJSON(cmd.Context()) {
+					return app.PrintJSON(cmd, map[string]any{
+						"woken":  []string{},
+						"failed": map[string]string{},
+					})
+				}
+				printNoResults("No snoozed emails are due")
+				return nil
+			}
+
+			client, err := app.JMAPClient()
+			if err != nil {
+				return err
+			}
+
+			woken := []string{}
+			failed := map[string]string{}
+			for _, entry := range due {
+				if err := client.UnsnoozeEmail(cmd.Context(), entry.EmailID); err != nil {
+					failed[entry.EmailID] = err.Error()
+					continue
+				}
+				state.Remove(entry.EmailID)
+				woken = append(woken, entry.EmailID)
+			}
+
+			if err := state.Save(); err != nil {
+				return err
+			}
+
+			if app.IsJSON(cmd.Context()) {
+				return app.PrintJSON(cmd, map[string]any{
+					"woken":  woken,
+					"failed": failed,
+				})
+			}
+
+			printBulkResults("Woke", "snoozed emails", len(woken), len(failed), failed)
+			return nil
+		}),
+	}
+
+	return cmd
+}
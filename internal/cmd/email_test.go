@@ -1,13 +1,19 @@
 package cmd
 
 import (
+	"context"
+	"encoding/json"
+	"errors"
+	"regexp"
 	"runtime"
 	"strings"
 	"testing"
 
 	"github.com/salmonumbrella/fastmail-cli/internal/format"
 	"github.com/salmonumbrella/fastmail-cli/internal/jmap"
+	"github.com/salmonumbrella/fastmail-cli/internal/outfmt"
 	"github.com/salmonumbrella/fastmail-cli/internal/validation"
+	"github.com/spf13/cobra"
 )
 
 func TestIsValidEmail(t *testing.T) {
@@ -274,3 +280,407 @@ func TestEmailsToOutput(t *testing.T) {
 		t.Errorf("out[1].FromEmail = %q, want %q", out[1].FromEmail, "b@example.com")
 	}
 }
+
+func TestColumnWidthFlags_DefaultsAndEnv(t *testing.T) {
+	app := newTestApp()
+
+	cmd := newEmailListCmd(app)
+	if got := cmd.Flags().Lookup("subject-width").DefValue; got != "50" {
+		t.Errorf("subject-width default = %s, want 50", got)
+	}
+	if got := cmd.Flags().Lookup("from-width").DefValue; got != "30" {
+		t.Errorf("from-width default = %s, want 30", got)
+	}
+
+	t.Setenv("FASTMAIL_SUBJECT_WIDTH", "0")
+	t.Setenv("FASTMAIL_FROM_WIDTH", "not-a-number")
+
+	cmd = newEmailSearchCmd(app)
+	if got := cmd.Flags().Lookup("subject-width").DefValue; got != "0" {
+		t.Errorf("subject-width default with env = %s, want 0", got)
+	}
+	if got := cmd.Flags().Lookup("from-width").DefValue; got != "30" {
+		t.Errorf("from-width default with invalid env = %s, want 30", got)
+	}
+}
+
+func TestEmailUpdateFlags(t *testing.T) {
+	if err := (&emailUpdateFlags{}).validate(); err == nil {
+		t.Error("validate() expected error when no change is requested")
+	}
+	if err := (&emailUpdateFlags{flag: true, unflag: true}).validate(); err == nil {
+		t.Error("validate() expected error for --flag with --unflag")
+	}
+	if err := (&emailUpdateFlags{read: true, unread: true}).validate(); err == nil {
+		t.Error("validate() expected error for --read with --unread")
+	}
+
+	flags := &emailUpdateFlags{moveTo: "Archive", flag: true, unread: true, keywords: []string{"$important"}}
+	if err := flags.validate(); err != nil {
+		t.Fatalf("validate() error = %v", err)
+	}
+
+	patch := flags.patch("mb-archive")
+	if patch["keywords/$flagged"] != true || patch["keywords/$important"] != true {
+		t.Errorf("patch = %v, want flagged and $important set", patch)
+	}
+	if v, ok := patch["keywords/$seen"]; !ok || v != nil {
+		t.Errorf("keywords/$seen = %v, want nil", v)
+	}
+	if mb, _ := patch["mailboxIds"].(map[string]bool); !mb["mb-archive"] {
+		t.Errorf("mailboxIds = %v, want mb-archive", patch["mailboxIds"])
+	}
+}
+
+func TestAttachmentCount_ColumnAndJSON(t *testing.T) {
+	emails := []jmap.Email{
+		{ID: "e1", HasAttachment: true, Attachments: []jmap.Attachment{
+			{BlobID: "b1", Name: "a.pdf"},
+			{BlobID: "b2", Name: "b.pdf"},
+			{BlobID: "b3", Name: "c.pdf"},
+		}},
+		{ID: "e2"},
+	}
+
+	if got := formatAttachmentCount(emails[0]); got != "3" {
+		t.Errorf("formatAttachmentCount(3 attachments) = %q, want 3", got)
+	}
+	if got := formatAttachmentCount(emails[1]); got != "-" {
+		t.Errorf("formatAttachmentCount(none) = %q, want -", got)
+	}
+
+	out := emailsToOutput(emails)
+	if len(out[0].Attachments) != 3 || out[0].Attachments[1].Name != "b.pdf" {
+		t.Errorf("out[0].Attachments = %+v, want 3 attachments", out[0].Attachments)
+	}
+
+	data, err := json.Marshal(out[1])
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+	if strings.Contains(string(data), `"attachments"`) {
+		t.Errorf("email without fetched attachments should omit them: %s", data)
+	}
+}
+
+func TestMarkFetchedEmailRead(t *testing.T) {
+	var calls []string
+	client := &jmap.MockEmailService{
+		MarkEmailReadFunc: func(ctx context.Context, id string, read bool) error {
+			if !read {
+				t.Errorf("MarkEmailRead(%s, false), want read=true", id)
+			}
+			calls = append(calls, id)
+			return nil
+		},
+	}
+
+	unread := &jmap.Email{ID: "e1"}
+	if marked, err := markFetchedEmailRead(context.Background(), client, unread); err != nil || !marked {
+		t.Fatalf("markFetchedEmailRead() = %v, %v; want true, nil", marked, err)
+	}
+	if !unread.Keywords["$seen"] {
+		t.Errorf("keywords = %v, want $seen after marking", unread.Keywords)
+	}
+
+	seen := &jmap.Email{ID: "e2", Keywords: map[string]bool{"$seen": true}}
+	if marked, err := markFetchedEmailRead(context.Background(), client, seen); err != nil || marked {
+		t.Fatalf("markFetchedEmailRead() = %v, %v; want false, nil (already seen)", marked, err)
+	}
+
+	if len(calls) != 1 || calls[0] != "e1" {
+		t.Errorf("MarkEmailRead calls = %v, want only e1 (e2 was already seen)", calls)
+	}
+
+	out, err := json.Marshal(emailGetOutput{EmailOutput: emailToOutput(*unread), MarkedRead: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(out), `"markedRead":true`) || !strings.Contains(string(out), `"isUnread":false`) {
+		t.Errorf("JSON = %s, want markedRead true and isUnread false", out)
+	}
+}
+
+func TestGetEmails_MixedIDs(t *testing.T) {
+	mock := &jmap.MockEmailService{
+		GetEmailsByIDsFunc: func(ctx context.Context, ids []string) ([]jmap.Email, []string, error) {
+			if len(ids) != 3 {
+				t.Fatalf("ids = %v, want all three in one call", ids)
+			}
+			return []jmap.Email{{ID: "e1", Subject: "One"}, {ID: "e2", Subject: "Two"}}, []string{"nope"}, nil
+		},
+	}
+
+	cmd := &cobra.Command{}
+	ctx := context.WithValue(context.Background(), outputModeKey, outfmt.JSON)
+	ctx = context.WithValue(ctx, queryKey, "")
+	cmd.SetContext(ctx)
+
+	var runErr error
+	stdout := captureStdout(t, func() {
+		runErr = getEmails(cmd, newTestApp(), mock, []string{"e1", "nope", "e2"}, false, false)
+	})
+
+	if !errors.Is(runErr, jmap.ErrEmailNotFound) || !strings.Contains(runErr.Error(), "nope") {
+		t.Errorf("error = %v, want not found naming the missing ID", runErr)
+	}
+	if ExitCode(runErr) != ExitNotFound {
+		t.Errorf("ExitCode = %d, want %d", ExitCode(runErr), ExitNotFound)
+	}
+
+	var out emailGetManyOutput
+	if err := json.Unmarshal([]byte(stdout), &out); err != nil {
+		t.Fatalf("stdout is not valid JSON: %v; stdout=%q", err, stdout)
+	}
+	if len(out.Emails) != 2 || out.Emails[0].ID != "e1" || out.Emails[1].ID != "e2" {
+		t.Errorf("emails = %+v", out.Emails)
+	}
+	if len(out.NotFound) != 1 || out.NotFound[0] != "nope" {
+		t.Errorf("notFound = %v", out.NotFound)
+	}
+}
+
+func TestEmailRowStyling(t *testing.T) {
+	t.Setenv("NO_COLOR", "")
+	unread := jmap.Email{Keywords: map[string]bool{"$flagged": true}}
+	read := jmap.Email{Keywords: map[string]bool{"$seen": true}}
+
+	if u, f := emailMarkers(unread); u != "*" || f != "*" {
+		t.Errorf("markers(unread, flagged) = %q, %q", u, f)
+	}
+	if u, f := emailMarkers(read); u != "" || f != "" {
+		t.Errorf("markers(read) = %q, %q", u, f)
+	}
+
+	plain := outfmt.TableStyle{}
+	if got := styleSubject(plain, unread, "Hi"); got != "Hi" {
+		t.Errorf("uncolored subject = %q", got)
+	}
+
+	color := outfmt.NewTableStyle("always")
+	bold, dim := styleSubject(color, unread, "Hi"), styleSubject(color, read, "Hi")
+	if bold != "\x1b[1mHi\x1b[0m" || dim != "\x1b[2mHi\x1b[0m" {
+		t.Errorf("colored subjects = %q, %q", bold, dim)
+	}
+
+	t.Setenv("NO_COLOR", "1")
+	if outfmt.NewTableStyle("always").Enabled() {
+		t.Error("NO_COLOR should disable color")
+	}
+}
+
+func TestPrintEmailList_ColorKeepsColumnsAligned(t *testing.T) {
+	t.Setenv("NO_COLOR", "")
+	emails := []jmap.Email{
+		{ID: "e1", Subject: "Read", Keywords: map[string]bool{"$seen": true, "$flagged": true}},
+		{ID: "e2", Subject: "Unread one", Keywords: map[string]bool{}},
+	}
+
+	out := captureStdout(t, func() {
+		printEmailList(emails, nil, format.DateStyle{}, outfmt.NewTableStyle("always"))
+	})
+	if !strings.Contains(out, "\x1b[") {
+		t.Fatalf("expected colored output, got %q", out)
+	}
+
+	want := captureStdout(t, func() {
+		printEmailList(emails, nil, format.DateStyle{}, outfmt.TableStyle{})
+	})
+	if got := regexp.MustCompile("\x1b\\[[0-9;]*m").ReplaceAllString(out, ""); got != want {
+		t.Errorf("colored table without its colors =\n%s\nwant the uncolored layout\n%s", got, want)
+	}
+}
+
+func TestBlankSendPrompt(t *testing.T) {
+	tests := []struct {
+		name                string
+		subject, text, html string
+		wantPrompt          bool
+		wantSubstr          string
+	}{
+		{name: "complete", subject: "Hi", text: "Hello there", wantPrompt: false},
+		{name: "empty subject", subject: " ", text: "Hello there", wantPrompt: true, wantSubstr: "empty subject?"},
+		{name: "short body", subject: "Hi", text: " ok ", wantPrompt: true, wantSubstr: `"ok"`},
+		{name: "empty html", subject: "Hi", html: "<p> </p>", wantPrompt: true, wantSubstr: "empty body"},
+		{name: "html with text", subject: "Hi", html: "<p>Hello</p>", wantPrompt: false},
+		{name: "both", text: "", html: "", wantPrompt: true, wantSubstr: "empty subject and"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := blankSendPrompt(tt.subject, tt.text, tt.html)
+			if (got != "") != tt.wantPrompt {
+				t.Fatalf("blankSendPrompt() = %q, want prompt %v", got, tt.wantPrompt)
+			}
+			if !strings.Contains(got, tt.wantSubstr) {
+				t.Errorf("blankSendPrompt() = %q, want it to contain %q", got, tt.wantSubstr)
+			}
+		})
+	}
+}
+
+func TestThreadingHeaders(t *testing.T) {
+	parent, refs, err := threadingHeaders("<b@example.com>", []string{"<a@example.com> <b@example.com>"})
+	if err != nil {
+		t.Fatalf("threadingHeaders() error = %v", err)
+	}
+	if len(parent) != 1 || parent[0] != "b@example.com" {
+		t.Errorf("inReplyTo = %v", parent)
+	}
+	if strings.Join(refs, ",") != "a@example.com,b@example.com" {
+		t.Errorf("references = %v", refs)
+	}
+
+	// References default to the parent
+	_, refs, err = threadingHeaders("c@example.com", nil)
+	if err != nil || len(refs) != 1 || refs[0] != "c@example.com" {
+		t.Errorf("references = %v, err = %v", refs, err)
+	}
+
+	if _, _, err := threadingHeaders("not-a-message-id", nil); err == nil || !strings.Contains(err.Error(), "--in-reply-to") {
+		t.Errorf("threadingHeaders() error = %v, want --in-reply-to error", err)
+	}
+	if _, _, err := threadingHeaders("", []string{"<a@example.com"}); err == nil || !strings.Contains(err.Error(), "--references") {
+		t.Errorf("threadingHeaders() error = %v, want --references error", err)
+	}
+}
+
+func TestParseHeaderFlags(t *testing.T) {
+	headers, err := parseHeaderFlags([]string{"X-Priority: 1", "List-Unsubscribe:<mailto:u@example.com>"})
+	if err != nil {
+		t.Fatalf("parseHeaderFlags() error = %v", err)
+	}
+	if headers["X-Priority"] != "1" || headers["List-Unsubscribe"] != "<mailto:u@example.com>" {
+		t.Errorf("headers = %v", headers)
+	}
+
+	for _, specs := range [][]string{
+		{"X-Priority"},
+		{"Message-ID: <x@example.com>"},
+		{"To: someone@example.com"},
+		{"X-Foo: a\nBcc: evil@example.com"},
+		{"X-Foo: a", "x-foo: b"},
+	} {
+		if _, err := parseHeaderFlags(specs); err == nil {
+			t.Errorf("parseHeaderFlags(%q) should fail", specs)
+		}
+	}
+}
+
+func TestEmailReplyCmd_RequiresBody(t *testing.T) {
+	cmd := newEmailReplyCmd(newTestApp())
+	cmd.SetArgs([]string{"Mf1234abc", "--all"})
+	cmd.SilenceUsage = true
+
+	err := cmd.Execute()
+	if err == nil || !strings.Contains(err.Error(), "--body or --html is required") {
+		t.Errorf("Execute() error = %v, want the body error", err)
+	}
+}
+
+func TestEmailReplyCmd_ChecksAttachmentsFirst(t *testing.T) {
+	cmd := newEmailReplyCmd(newTestApp())
+	cmd.SetArgs([]string{"M1", "--body", "Thanks", "--attach", "/nonexistent/notes.pdf"})
+	cmd.SilenceUsage = true
+	cmd.SilenceErrors = true
+
+	// No account is configured, so reaching the client would fail differently
+	err := cmd.Execute()
+	if err == nil || !strings.Contains(err.Error(), "cannot access attachment") {
+		t.Errorf("error = %v, want the attachment error", err)
+	}
+}
+
+func TestEmailReplyCmd_SignatureFlags(t *testing.T) {
+	cmd := newEmailReplyCmd(newTestApp())
+	for _, name := range []string{"signature", "no-signature"} {
+		if cmd.Flags().Lookup(name) == nil {
+			t.Errorf("reply has no --%s flag", name)
+		}
+	}
+}
+
+func TestPrintEmailDetails_StripQuotes(t *testing.T) {
+	email := &jmap.Email{
+		ID:       "e1",
+		TextBody: []jmap.BodyPart{{PartID: "1"}},
+		BodyValues: map[string]jmap.BodyValue{
+			"1": {Value: "Sounds good.\n\nOn Mon, Alice wrote:\n> Lunch?\n"},
+		},
+	}
+
+	out := captureStdout(t, func() { printEmailDetails(email, true) })
+	if !strings.Contains(out, "Sounds good.") || strings.Contains(out, "Lunch?") || strings.Contains(out, "wrote:") {
+		t.Errorf("stripped output = %q", out)
+	}
+
+	out = captureStdout(t, func() { printEmailDetails(email, false) })
+	if !strings.Contains(out, "> Lunch?") {
+		t.Errorf("full output = %q, want the quote", out)
+	}
+}
+
+func TestResolveToRecipients(t *testing.T) {
+	contacts := &jmap.MockContactsService{
+		ResolveRecipientFunc: func(ctx context.Context, nameOrEmail string) ([]jmap.EmailAddress, error) {
+			switch nameOrEmail {
+			case "jane":
+				return []jmap.EmailAddress{{Name: "Jane Smith", Email: "jane@example.com"}}, nil
+			case "smith":
+				return []jmap.EmailAddress{
+					{Name: "Jane Smith", Email: "jane@example.com"},
+					{Name: "John Smith", Email: "john@example.com"},
+				}, nil
+			}
+			return nil, nil
+		},
+	}
+
+	got, err := resolveToRecipients(context.Background(), contacts, []string{"bob@example.com", "jane"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(got) != 2 || got[0] != "bob@example.com" || got[1] != "jane@example.com" {
+		t.Errorf("got %v, want [bob@example.com jane@example.com]", got)
+	}
+
+	_, err = resolveToRecipients(context.Background(), contacts, []string{"smith"})
+	if err == nil || !strings.Contains(err.Error(), "John Smith <john@example.com>") {
+		t.Errorf("expected ambiguity error listing candidates, got %v", err)
+	}
+
+	_, err = resolveToRecipients(context.Background(), contacts, []string{"nobody"})
+	if err == nil || !strings.Contains(err.Error(), "no contact matches") {
+		t.Errorf("expected no-match error, got %v", err)
+	}
+}
+
+func TestGetEmails_MarkedReadPerEmail(t *testing.T) {
+	mock := &jmap.MockEmailService{
+		GetEmailsByIDsFunc: func(ctx context.Context, ids []string) ([]jmap.Email, []string, error) {
+			return []jmap.Email{{ID: "e1"}, {ID: "e2", Keywords: map[string]bool{"$seen": true}}}, nil, nil
+		},
+		MarkEmailReadFunc: func(ctx context.Context, id string, read bool) error { return nil },
+	}
+
+	cmd := &cobra.Command{}
+	ctx := context.WithValue(context.Background(), outputModeKey, outfmt.JSON)
+	ctx = context.WithValue(ctx, queryKey, "")
+	cmd.SetContext(ctx)
+
+	var runErr error
+	stdout := captureStdout(t, func() {
+		runErr = getEmails(cmd, newTestApp(), mock, []string{"e1", "e2"}, true, false)
+	})
+	if runErr != nil {
+		t.Fatalf("getEmails() error = %v", runErr)
+	}
+
+	var out emailGetManyOutput
+	if err := json.Unmarshal([]byte(stdout), &out); err != nil {
+		t.Fatalf("stdout is not valid JSON: %v; stdout=%q", err, stdout)
+	}
+	if len(out.Emails) != 2 || !out.Emails[0].MarkedRead || out.Emails[1].MarkedRead {
+		t.Errorf("emails = %+v, want only e1 marked (e2 was already read)", out.Emails)
+	}
+}
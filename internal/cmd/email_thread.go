@@ -3,6 +3,7 @@ package cmd
 import (
 	"fmt"
 
+	cerrors "github.com/salmonumbrella/fastmail-cli/internal/errors"
 	"github.com/salmonumbrella/fastmail-cli/internal/format"
 	"github.com/salmonumbrella/fastmail-cli/internal/outfmt"
 	"github.com/spf13/cobra"
@@ -43,7 +44,7 @@ func newEmailThreadCmd(app *App) *cobra.Command {
 			fmt.Fprintln(tw, "ID\tSUBJECT\tFROM\tDATE")
 			for _, email := range emails {
 				from := format.FormatEmailAddressList(email.From)
-				date := format.FormatEmailDate(email.ReceivedAt)
+				date := app.DateStyle().FormatString(email.ReceivedAt)
 				fmt.Fprintf(tw, "%s\t%s\t%s\t%s\n",
 					email.ID,
 					outfmt.SanitizeTab(format.Truncate(email.Subject, 40)),
@@ -59,3 +60,148 @@ func newEmailThreadCmd(app *App) *cobra.Command {
 
 	return cmd
 }
+
+func newEmailThreadReadCmd(app *App) *cobra.Command {
+	var unread bool
+
+	cmd := &cobra.Command{
+		Use:   "thread-read <threadId>",
+		Short: "Mark every email in a thread as read/unread",
+		Long: `Mark every email in a thread as read (or unread with --unread) in a
+single request. An email ID can be given instead of a thread ID.`,
+		Args: cobra.ExactArgs(1),
+		RunE: runE(app, func(cmd *cobra.Command, args []string, app *App) error {
+			client, err := app.JMAPClient()
+			if err != nil {
+				return err
+			}
+
+			status := "read"
+			if unread {
+				status = "unread"
+			}
+
+			results, err := client.MarkThreadRead(cmd.Context(), args[0], !unread)
+			if err != nil {
+				return cerrors.WithContext(err, "marking thread")
+			}
+
+			if app.IsJSON(cmd.Context()) {
+				output := map[string]any{
+					"threadId":  args[0],
+					"status":    status,
+					"succeeded": results.Succeeded,
+				}
+				if len(results.Failed) > 0 {
+					output["failed"] = results.Failed
+				}
+				return app.PrintJSON(cmd, output)
+			}
+
+			printBulkResults("Marked", fmt.Sprintf("emails as %s", status), len(results.Succeeded), len(results.Failed), results.Failed)
+			return nil
+		}),
+	}
+
+	cmd.Flags().BoolVar(&unread, "unread", false, "Mark as unread instead of read")
+
+	return cmd
+}
+
+func newEmailThreadFlagCmd(app *App) *cobra.Command {
+	var unflag bool
+
+	cmd := &cobra.Command{
+		Use:   "thread-flag <threadId>",
+		Short: "Flag or unflag every email in a thread",
+		Long: `Flag every email in a thread (or unflag with --unflag) in a single
+request. An email ID can be given instead of a thread ID.`,
+		Args: cobra.ExactArgs(1),
+		RunE: runE(app, func(cmd *cobra.Command, args []string, app *App) error {
+			client, err := app.JMAPClient()
+			if err != nil {
+				return err
+			}
+
+			status := "flagged"
+			if unflag {
+				status = "unflagged"
+			}
+
+			results, err := client.FlagThread(cmd.Context(), args[0], !unflag)
+			if err != nil {
+				return cerrors.WithContext(err, "flagging thread")
+			}
+
+			if app.IsJSON(cmd.Context()) {
+				output := map[string]any{
+					"threadId":  args[0],
+					"status":    status,
+					"succeeded": results.Succeeded,
+				}
+				if len(results.Failed) > 0 {
+					output["failed"] = results.Failed
+				}
+				return app.PrintJSON(cmd, output)
+			}
+
+			printBulkResults("Marked", fmt.Sprintf("emails as %s", status), len(results.Succeeded), len(results.Failed), results.Failed)
+			return nil
+		}),
+	}
+
+	cmd.Flags().BoolVar(&unflag, "unflag", false, "Remove the flag instead of setting it")
+
+	return cmd
+}
+
+func newEmailThreadMoveCmd(app *App) *cobra.Command {
+	var targetMailbox string
+
+	cmd := &cobra.Command{
+		Use:   "thread-move <threadId> --to <mailbox>",
+		Short: "Move every email in a thread to a mailbox",
+		Long: `Move every email in a thread to a mailbox in a single request. An email
+ID can be given instead of a thread ID.`,
+		Args: cobra.ExactArgs(1),
+		RunE: runE(app, func(cmd *cobra.Command, args []string, app *App) error {
+			if targetMailbox == "" {
+				return fmt.Errorf("--to is required")
+			}
+
+			client, err := app.JMAPClient()
+			if err != nil {
+				return err
+			}
+
+			mailboxID, err := client.ResolveMailboxID(cmd.Context(), targetMailbox)
+			if err != nil {
+				return fmt.Errorf("invalid target mailbox: %w", err)
+			}
+
+			results, err := client.MoveThread(cmd.Context(), args[0], mailboxID)
+			if err != nil {
+				return cerrors.WithContext(err, "moving thread")
+			}
+
+			if app.IsJSON(cmd.Context()) {
+				output := map[string]any{
+					"threadId":  args[0],
+					"mailboxId": mailboxID,
+					"succeeded": results.Succeeded,
+				}
+				if len(results.Failed) > 0 {
+					output["failed"] = results.Failed
+				}
+				return app.PrintJSON(cmd, output)
+			}
+
+			printBulkResults("Moved", fmt.Sprintf("emails to %s", targetMailbox), len(results.Succeeded), len(results.Failed), results.Failed)
+			return nil
+		}),
+	}
+
+	cmd.Flags().StringVar(&targetMailbox, "to", "", "Target mailbox ID, name or role (role:archive matches only by role)")
+
+	return cmd
+}
@@ -0,0 +1,177 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/salmonumbrella/fastmail-cli/internal/format"
+	"github.com/salmonumbrella/fastmail-cli/internal/jmap"
+	"github.com/salmonumbrella/fastmail-cli/internal/ui"
+	"github.com/spf13/cobra"
+	"golang.org/x/term"
+)
+
+// threadSearchMatch describes an email in a thread that matched the query.
+type threadSearchMatch struct {
+	Email     jmap.Email
+	MatchedIn []string // "subject" and/or "body"
+	Lines     []string // body lines containing the query
+}
+
+func newEmailThreadSearchCmd(app *App) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "thread-search <threadId> <query>",
+		Short: "Search for text within a single thread",
+		Long: `Search the subjects and bodies of emails in one thread.
+
+Matching is case-insensitive and done locally after fetching the thread,
+so it is useful for long conversations where global search returns too much.
+Matches are highlighted when writing to a terminal.`,
+		Example: `  fastmail email thread-search T123abc "invoice"
+  fastmail email thread-search M456def "deadline" --output json`,
+		Args: cobra.ExactArgs(2),
+		RunE: runE(app, func(cmd *cobra.Command, args []string, app *App) error {
+			threadID, query := args[0], args[1]
+			if strings.TrimSpace(query) == "" {
+				return fmt.Errorf("query must not be empty")
+			}
+
+			client, err := app.JMAPClient()
+			if err != nil {
+				return err
+			}
+
+			emails, err := client.GetThreadWithBodies(cmd.Context(), threadID)
+			if err != nil {
+				return fmt.Errorf("failed to get thread: %w", err)
+			}
+
+			matches := searchThreadEmails(emails, query)
+
+			if app.IsJSON(cmd.Context()) {
+				output := make([]map[string]any, len(matches))
+				for i, m := range matches {
+					output[i] = map[string]any{
+						"id":         m.Email.ID,
+						"subject":    m.Email.Subject,
+						"from":       m.Email.From,
+						"receivedAt": m.Email.ReceivedAt,
+						"matchedIn":  m.MatchedIn,
+						"lines":      m.Lines,
+					}
+				}
+				return app.PrintJSON(cmd, map[string]any{
+					"threadId": threadID,
+					"query":    query,
+					"matches":  output,
+				})
+			}
+
+			if len(matches) == 0 {
+				printNoResults("No matches for %q in thread %s", query, threadID)
+				return nil
+			}
+
+			highlight := threadSearchHighlighter(cmd, app)
+
+			fmt.Printf("Thread: %s (%d of %d messages match)\n", threadID, len(matches), len(emails))
+			for _, m := range matches {
+				fmt.Println()
+				fmt.Printf("%s  %s  %s\n",
+					m.Email.ID,
+					app.DateStyle().FormatString(m.Email.ReceivedAt),
+					format.FormatEmailAddressList(m.Email.From),
+				)
+				fmt.Printf("  Subject: %s\n", highlightMatches(m.Email.Subject, query, highlight))
+				for _, line := range m.Lines {
+					fmt.Printf("  > %s\n", highlightMatches(line, query, highlight))
+				}
+			}
+
+			return nil
+		}),
+	}
+
+	return cmd
+}
+
+// searchThreadEmails returns the emails whose subject or body contains query (case-insensitive).
+func searchThreadEmails(emails []jmap.Email, query string) []threadSearchMatch {
+	needle := strings.ToLower(query)
+
+	var matches []threadSearchMatch
+	for _, email := range emails {
+		var m threadSearchMatch
+		if strings.Contains(strings.ToLower(email.Subject), needle) {
+			m.MatchedIn = append(m.MatchedIn, "subject")
+		}
+
+		for _, line := range strings.Split(threadEmailBodyText(email), "\n") {
+			if strings.Contains(strings.ToLower(line), needle) {
+				m.Lines = append(m.Lines, strings.TrimSpace(line))
+			}
+		}
+		if len(m.Lines) > 0 {
+			m.MatchedIn = append(m.MatchedIn, "body")
+		}
+
+		if len(m.MatchedIn) > 0 {
+			m.Email = email
+			matches = append(matches, m)
+		}
+	}
+
+	return matches
+}
+
+// threadEmailBodyText returns the plain-text body of an email, falling back to the preview.
+func threadEmailBodyText(email jmap.Email) string {
+	var parts []string
+	for _, part := range email.TextBody {
+		if body, ok := email.BodyValues[part.PartID]; ok {
+			parts = append(parts, body.Value)
+		}
+	}
+	if len(parts) == 0 {
+		return email.Preview
+	}
+	return strings.Join(parts, "\n")
+}
+
+// highlightMatches wraps every case-insensitive occurrence of query in text with highlight.
+func highlightMatches(text, query string, highlight func(string) string) string {
+	if query == "" {
+		return text
+	}
+
+	lowerText := strings.ToLower(text)
+	lowerQuery := strings.ToLower(query)
+
+	// Lowercasing can change byte lengths for some runes; fall back to no highlighting.
+	if len(lowerText) != len(text) || len(lowerQuery) != len(query) {
+		return text
+	}
+
+	var b strings.Builder
+	for {
+		i := strings.Index(lowerText, lowerQuery)
+		if i < 0 {
+			b.WriteString(text)
+			return b.String()
+		}
+		b.WriteString(text[:i])
+		b.WriteString(highlight(text[i : i+len(query)]))
+		text = text[i+len(query):]
+		lowerText = lowerText[i+len(query):]
+	}
+}
+
+// threadSearchHighlighter returns the highlighter for matches: styled output when
+// stdout is a terminal (or --color=always), otherwise the identity function.
+func threadSearchHighlighter(cmd *cobra.Command, app *App) func(string) string {
+	if app.Flags.Color != "always" && !term.IsTerminal(int(os.Stdout.Fd())) {
+		return func(s string) string { return s }
+	}
+	return ui.FromContext(cmd.Context()).Highlight
+}
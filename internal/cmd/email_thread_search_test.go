@@ -0,0 +1,71 @@
+package cmd
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/salmonumbrella/fastmail-cli/internal/jmap"
+)
+
+func TestSearchThreadEmails(t *testing.T) {
+	emails := []jmap.Email{
+		{
+			ID:         "e1",
+			Subject:    "Invoice for March",
+			TextBody:   []jmap.BodyPart{{PartID: "1"}},
+			BodyValues: map[string]jmap.BodyValue{"1": {Value: "Hi,\nsee the attached file.\nThanks"}},
+		},
+		{
+			ID:         "e2",
+			Subject:    "Re: Invoice for March",
+			TextBody:   []jmap.BodyPart{{PartID: "1"}},
+			BodyValues: map[string]jmap.BodyValue{"1": {Value: "The INVOICE total is wrong.\n  Please resend the invoice.  "}},
+		},
+		{
+			ID:      "e3",
+			Subject: "Lunch?",
+			Preview: "Are you free for lunch",
+		},
+	}
+
+	matches := searchThreadEmails(emails, "invoice")
+	if len(matches) != 2 {
+		t.Fatalf("expected 2 matches, got %d", len(matches))
+	}
+
+	if matches[0].Email.ID != "e1" || !reflect.DeepEqual(matches[0].MatchedIn, []string{"subject"}) {
+		t.Errorf("unexpected first match: %+v", matches[0])
+	}
+
+	wantLines := []string{"The INVOICE total is wrong.", "Please resend the invoice."}
+	if !reflect.DeepEqual(matches[1].Lines, wantLines) {
+		t.Errorf("lines = %q, want %q", matches[1].Lines, wantLines)
+	}
+	if !reflect.DeepEqual(matches[1].MatchedIn, []string{"subject", "body"}) {
+		t.Errorf("matchedIn = %v, want [subject body]", matches[1].MatchedIn)
+	}
+
+	if got := searchThreadEmails(emails, "lunch"); len(got) != 1 || got[0].Email.ID != "e3" {
+		t.Errorf("expected preview fallback to match e3, got %+v", got)
+	}
+}
+
+func TestHighlightMatches(t *testing.T) {
+	mark := func(s string) string { return "[" + s + "]" }
+
+	tests := []struct {
+		text  string
+		query string
+		want  string
+	}{
+		{"Invoice and invoice", "invoice", "[Invoice] and [invoice]"},
+		{"no hits here", "invoice", "no hits here"},
+		{"INVOICE", "Invoice", "[INVOICE]"},
+	}
+
+	for _, tt := range tests {
+		if got := highlightMatches(tt.text, tt.query, mark); got != tt.want {
+			t.Errorf("highlightMatches(%q, %q) = %q, want %q", tt.text, tt.query, got, tt.want)
+		}
+	}
+}
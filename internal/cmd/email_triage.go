@@ -0,0 +1,154 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	cerrors "github.com/salmonumbrella/fastmail-cli/internal/errors"
+	"github.com/salmonumbrella/fastmail-cli/internal/jmap"
+	"github.com/spf13/cobra"
+)
+
+// emailTriageFlags holds the actions of email triage.
+type emailTriageFlags struct {
+	read    bool
+	archive bool
+	to      string
+}
+
+// target returns the mailbox to move to: role:archive for --archive, the
+// --to value, or "" when not moving.
+func (f *emailTriageFlags) target() string {
+	if f.archive {
+		return jmap.MailboxRolePrefix + "archive"
+	}
+	return f.to
+}
+
+// validate checks that at least one action was requested and that
+// --archive and --to are not combined.
+func (f *emailTriageFlags) validate() error {
+	if f.archive && f.to != "" {
+		return fmt.Errorf("--archive and --to cannot be used together")
+	}
+	if !f.read && f.target() == "" {
+		return fmt.Errorf("nothing to do: use --read, --archive, or --to")
+	}
+	return nil
+}
+
+// targetLabel names the target mailbox for dry-run output, which doesn't
+// resolve it: "the archive mailbox" for --archive, else the --to value.
+func (f *emailTriageFlags) targetLabel() string {
+	if f.archive {
+		return "the archive mailbox"
+	}
+	return f.to
+}
+
+// describe returns the actions as a phrase, e.g. "mark read and move to Archive".
+func (f *emailTriageFlags) describe(mailboxName string) string {
+	var actions []string
+	if f.read {
+		actions = append(actions, "mark read")
+	}
+	if mailboxName != "" {
+		actions = append(actions, "move to "+mailboxName)
+	}
+	return strings.Join(actions, " and ")
+}
+
+func newEmailTriageCmd(app *App) *cobra.Command {
+	var flags emailTriageFlags
+	var dryRun bool
+
+	cmd := &cobra.Command{
+		Use:   "triage <emailId>...",
+		Short: "Mark emails read and archive or move them in one request",
+		Long: `Mark emails read and move them in a single pass.
+
+Both changes go into one JMAP Email/set update per email, so triaging a
+batch costs a single round-trip. --archive moves to the mailbox with the
+archive role; --to moves anywhere else.`,
+		Example: `  fastmail email triage <id1> <id2> --read --archive
+  fastmail email triage <id1> <id2> --read --to Receipts
+  fastmail email triage <id1> <id2> --read --archive --dry-run`,
+		Args: cobra.MinimumNArgs(1),
+		RunE: runE(app, func(cmd *cobra.Command, args []string, app *App) error {
+			if err := flags.validate(); err != nil {
+				return err
+			}
+			target := flags.target()
+
+			// Handle dry-run mode without requiring keyring / network.
+			if dryRun {
+				extra := map[string]any{"read": flags.read}
+				if flags.archive {
+					extra["mailboxRole"] = "archive"
+				} else if flags.to != "" {
+					extra["mailbox"] = flags.to
+				}
+				return printDryRunList(app, cmd, "", fmt.Sprintf("Would triage %d emails (%s):", len(args), flags.describe(flags.targetLabel())), "wouldTriage", args, extra)
+			}
+
+			client, err := app.JMAPClient()
+			if err != nil {
+				return err
+			}
+
+			patch := jmap.NewEmailPatch()
+			if flags.read {
+				patch.Read(true)
+			}
+			var mailboxName string
+			if target != "" {
+				mailboxID, err := client.ResolveMailboxID(cmd.Context(), target)
+				if err != nil {
+					return fmt.Errorf("invalid target mailbox: %w", err)
+				}
+				patch.MoveTo(mailboxID)
+
+				// Get mailbox name for output
+				mailboxName = mailboxID
+				if mailboxes, err := client.GetMailboxes(cmd.Context()); err == nil {
+					for _, mb := range mailboxes {
+						if mb.ID == mailboxID {
+							mailboxName = mb.Name
+							break
+						}
+					}
+				}
+			}
+
+			results, err := client.UpdateEmails(cmd.Context(), args, patch)
+			if err != nil {
+				return cerrors.WithContext(err, "triaging emails")
+			}
+
+			if app.IsJSON(cmd.Context()) {
+				output := map[string]any{
+					"status":    "triaged",
+					"read":      flags.read,
+					"succeeded": results.Succeeded,
+				}
+				if mailboxName != "" {
+					output["mailbox"] = mailboxName
+				}
+				if len(results.Failed) > 0 {
+					output["failed"] = results.Failed
+				}
+				return app.PrintJSON(cmd, output)
+			}
+
+			printBulkResults("Triaged", "emails ("+flags.describe(mailboxName)+")", len(results.Succeeded), len(results.Failed), results.Failed)
+			return nil
+		}),
+	}
+
+	cmd.Flags().BoolVar(&flags.read, "read", false, "Mark as read")
+	cmd.Flags().BoolVar(&flags.archive, "archive", false, "Move to the archive mailbox")
+	cmd.Flags().StringVar(&flags.to, "to", "", "Move to mailbox ID, name or role (role:archive matches only by role)")
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "Show what would be changed without making changes")
+
+	return cmd
+}
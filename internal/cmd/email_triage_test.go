@@ -0,0 +1,77 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/salmonumbrella/fastmail-cli/internal/outfmt"
+)
+
+func TestEmailTriageFlags_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		flags   emailTriageFlags
+		wantErr string
+	}{
+		{"nothing", emailTriageFlags{}, "nothing to do"},
+		{"archive and to", emailTriageFlags{archive: true, to: "Receipts"}, "cannot be used together"},
+		{"read only", emailTriageFlags{read: true}, ""},
+		{"read and archive", emailTriageFlags{read: true, archive: true}, ""},
+		{"move only", emailTriageFlags{to: "Receipts"}, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.flags.validate()
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("validate() error = %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("validate() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestEmailTriageCmd_DryRun(t *testing.T) {
+	app := newTestApp()
+	cmd := newEmailTriageCmd(app)
+	cmd.SetContext(context.WithValue(context.Background(), outputModeKey, outfmt.JSON))
+	cmd.SetArgs([]string{"e1", "e2", "--read", "--archive", "--dry-run"})
+
+	out := captureStdout(t, func() {
+		if err := cmd.Execute(); err != nil {
+			t.Fatalf("Execute() error = %v", err)
+		}
+	})
+
+	var payload map[string]any
+	if err := json.Unmarshal([]byte(out), &payload); err != nil {
+		t.Fatalf("json unmarshal: %v (output %q)", err, out)
+	}
+	if payload["dryRun"] != true || payload["read"] != true || payload["mailboxRole"] != "archive" {
+		t.Errorf("payload = %v", payload)
+	}
+	if items, ok := payload["wouldTriage"].([]any); !ok || len(items) != 2 {
+		t.Errorf("wouldTriage = %v, want 2 IDs", payload["wouldTriage"])
+	}
+}
+
+func TestEmailTriageCmd_DryRunText(t *testing.T) {
+	cmd := newEmailTriageCmd(newTestApp())
+	cmd.SetContext(context.Background())
+	cmd.SetArgs([]string{"e1", "--read", "--archive", "--dry-run"})
+
+	out := captureStdout(t, func() {
+		if err := cmd.Execute(); err != nil {
+			t.Fatalf("Execute() error = %v", err)
+		}
+	})
+	if !strings.HasPrefix(out, "Would triage 1 emails (mark read and move to the archive mailbox):") {
+		t.Errorf("output = %q", out)
+	}
+}
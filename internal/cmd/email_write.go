@@ -0,0 +1,263 @@
+package cmd
+
+import (
+	"fmt"
+	"net/mail"
+	"os"
+	"strings"
+
+	cerrors "github.com/salmonumbrella/fastmail-cli/internal/errors"
+	"github.com/salmonumbrella/fastmail-cli/internal/jmap"
+	"github.com/salmonumbrella/fastmail-cli/internal/validation"
+	"github.com/spf13/cobra"
+)
+
+// composedMessage is a message written in the editor.
+type composedMessage struct {
+	To      []string
+	CC      []string
+	BCC     []string
+	Subject string
+	Body    string
+}
+
+func newEmailWriteCmd(app *App) *cobra.Command {
+	var to, cc, bcc []string
+	var subject string
+	var fromIdentity string
+	var draft bool
+	var sig signatureFlags
+
+	cmd := &cobra.Command{
+		Use:   "write",
+		Short: "Write an email in $EDITOR and send it",
+		Long: `Open $EDITOR with a message template, then send the message (or save it
+as a draft with --draft) when the editor exits.
+
+The template starts with To:, Cc:, Bcc: and Subject: lines; addresses are
+separated by commas and may be written as "Name <address>". As with send,
+a To entry that isn't an address is looked up in contacts. Everything after
+the first blank line is the body. Lines starting with # in the header block
+are ignored.
+
+If the message cannot be used (a bad address or unknown contact, no
+recipient or subject, an empty body), the editor opens again with the problem noted at the top.
+Exiting the editor without changing the file cancels.
+
+Use 'fastmail email send' for scripts.`,
+		Example: `  fastmail email write
+  fastmail email write --to user@example.com --subject "Notes"
+  fastmail email write --draft`,
+		Args: cobra.NoArgs,
+		RunE: runE(app, func(cmd *cobra.Command, args []string, app *App) error {
+			// Writing is inherently interactive (spawns $EDITOR).
+			if app.IsJSON(cmd.Context()) || (app.Flags != nil && app.Flags.Yes) {
+				return Suggest(
+					fmt.Errorf("email write is interactive"),
+					"Use 'fastmail email send --body-file <file>' for non-interactive sending",
+				)
+			}
+
+			client, err := app.JMAPClient()
+			if err != nil {
+				return err
+			}
+
+			// Names on the To line are looked up in contacts
+			resolve := func(msg *composedMessage) error {
+				resolved, err := resolveToRecipients(cmd.Context(), client, msg.To)
+				if err != nil {
+					return err
+				}
+				msg.To = resolved
+				return nil
+			}
+
+			msg, err := editComposed(composeTemplate(to, cc, bcc, subject), draft, runEditor, resolve)
+			if err != nil {
+				return err
+			}
+			if msg == nil {
+				printCancelled()
+				return nil
+			}
+
+			from := fromIdentity
+			if from == "" {
+				accountEmail, err := app.RequireAccount()
+				if err != nil {
+					return err
+				}
+				from = app.DefaultFrom(accountEmail)
+			}
+
+			opts := jmap.SendEmailOpts{
+				To:        msg.To,
+				CC:        msg.CC,
+				BCC:       msg.BCC,
+				Subject:   msg.Subject,
+				TextBody:  msg.Body,
+				From:      from,
+				Signature: sig.enabled(),
+			}
+
+			if draft {
+				draftID, err := client.SaveDraft(cmd.Context(), opts)
+				if err != nil {
+					return fmt.Errorf("failed to save draft: %w", err)
+				}
+				fmt.Printf("Draft saved (ID: %s)\n", draftID)
+				return nil
+			}
+
+			sent, err := client.SendEmailResult(cmd.Context(), opts)
+			if err != nil {
+				return cerrors.WithContext(err, "sending email")
+			}
+			fmt.Printf("Email sent successfully (email ID: %s, submission ID: %s)\n", sent.EmailID, sent.SubmissionID)
+			return nil
+		}),
+	}
+
+	cmd.Flags().StringSliceVar(&to, "to", nil, "Prefill the To line")
+	cmd.Flags().StringSliceVar(&cc, "cc", nil, "Prefill the Cc line")
+	cmd.Flags().StringSliceVar(&bcc, "bcc", nil, "Prefill the Bcc line")
+	cmd.Flags().StringVar(&subject, "subject", "", "Prefill the Subject line")
+	cmd.Flags().StringVar(&fromIdentity, "from", "", "Send from this identity or masked email address")
+	cmd.Flags().BoolVar(&draft, "draft", false, "Save as draft instead of sending")
+	registerSignatureFlags(cmd, &sig)
+
+	return cmd
+}
+
+// composeTemplate returns the initial editor contents.
+func composeTemplate(to, cc, bcc []string, subject string) string {
+	return fmt.Sprintf("To: %s\nCc: %s\nBcc: %s\nSubject: %s\n\n",
+		strings.Join(to, ", "), strings.Join(cc, ", "), strings.Join(bcc, ", "), subject)
+}
+
+// editComposed has edit change a temp file holding content until it parses
+// into a message that can be sent (or saved, with draft) and check accepts
+// it. After a failure the file is edited again with the error noted at the
+// top. It returns nil if the file is left unchanged.
+func editComposed(content string, draft bool, edit func(path string) error, check func(*composedMessage) error) (*composedMessage, error) {
+	tmpFile, err := os.CreateTemp("", "fastmail-compose-*.eml")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create temp file: %w", err)
+	}
+	tmpPath := tmpFile.Name()
+	defer func() { _ = os.Remove(tmpPath) }()
+	if closeErr := tmpFile.Close(); closeErr != nil {
+		return nil, fmt.Errorf("failed to close temp file: %w", closeErr)
+	}
+
+	for {
+		if err := os.WriteFile(tmpPath, []byte(content), 0o600); err != nil {
+			return nil, fmt.Errorf("failed to write temp file: %w", err)
+		}
+		if err := edit(tmpPath); err != nil {
+			return nil, err
+		}
+		edited, err := os.ReadFile(tmpPath)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read edited file: %w", err)
+		}
+		if string(edited) == content {
+			return nil, nil
+		}
+
+		msg, err := parseComposed(string(edited), draft)
+		if err == nil && check != nil {
+			err = check(msg)
+		}
+		if err == nil {
+			return msg, nil
+		}
+		content = "# Error: " + err.Error() + "\n" + stripComposeErrors(string(edited))
+	}
+}
+
+// parseComposed parses the editor contents: To/Cc/Bcc/Subject lines (case
+// insensitive, # lines ignored), a blank line, then the body. Drafts need
+// neither recipients nor a subject.
+func parseComposed(text string, draft bool) (*composedMessage, error) {
+	text = strings.ReplaceAll(text, "\r\n", "\n")
+	header, body, _ := strings.Cut(text, "\n\n")
+
+	msg := &composedMessage{Body: strings.TrimSpace(body)}
+	for _, line := range strings.Split(header, "\n") {
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" || strings.HasPrefix(trimmed, "#") {
+			continue
+		}
+		name, value, ok := strings.Cut(trimmed, ":")
+		if !ok {
+			return nil, fmt.Errorf("expected a header line such as \"To: ...\", got %q (leave a blank line before the body)", trimmed)
+		}
+		value = strings.TrimSpace(value)
+
+		var err error
+		switch strings.ToLower(strings.TrimSpace(name)) {
+		case "to":
+			msg.To, err = parseComposeAddresses(value, true)
+		case "cc":
+			msg.CC, err = parseComposeAddresses(value, false)
+		case "bcc":
+			msg.BCC, err = parseComposeAddresses(value, false)
+		case "subject":
+			msg.Subject = value
+		default:
+			return nil, fmt.Errorf("unknown header %q (use To, Cc, Bcc or Subject)", name)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", strings.TrimSpace(name), err)
+		}
+	}
+
+	if !draft {
+		if len(msg.To) == 0 {
+			return nil, fmt.Errorf("the To line needs at least one address")
+		}
+		if msg.Subject == "" {
+			return nil, fmt.Errorf("the Subject line is empty")
+		}
+	}
+	if msg.Body == "" {
+		return nil, fmt.Errorf("the body is empty")
+	}
+	return msg, nil
+}
+
+// parseComposeAddresses splits a comma or semicolon separated address list,
+// accepting plain addresses and "Name <address>". With names, an entry
+// without an @ is kept as is for a contacts lookup.
+func parseComposeAddresses(value string, names bool) ([]string, error) {
+	var addrs []string
+	for _, part := range strings.FieldsFunc(value, func(r rune) bool { return r == ',' || r == ';' }) {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		addr := part
+		if parsed, err := mail.ParseAddress(part); err == nil {
+			addr = parsed.Address
+		}
+		if names && !strings.Contains(addr, "@") {
+			addrs = append(addrs, addr)
+			continue
+		}
+		if !validation.IsValidEmail(addr) {
+			return nil, fmt.Errorf("invalid email address: %s", part)
+		}
+		addrs = append(addrs, addr)
+	}
+	return addrs, nil
+}
+
+// stripComposeErrors drops the error lines editComposed adds at the top.
+func stripComposeErrors(text string) string {
+	for strings.HasPrefix(text, "# Error: ") {
+		_, text, _ = strings.Cut(text, "\n")
+	}
+	return text
+}
@@ -0,0 +1,128 @@
+package cmd
+
+import (
+	"context"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/salmonumbrella/fastmail-cli/internal/jmap"
+)
+
+func TestParseComposed(t *testing.T) {
+	text := "to: Jane Doe <jane@example.com>, bob@example.com\n" +
+		"# a note to self\n" +
+		"CC: carol@example.com; \n" +
+		"Bcc:\n" +
+		"Subject:  Weekly notes \n" +
+		"\n" +
+		"Hi all,\n\nNotes below.\n"
+
+	msg, err := parseComposed(text, false)
+	if err != nil {
+		t.Fatalf("parseComposed() error = %v", err)
+	}
+	if strings.Join(msg.To, ",") != "jane@example.com,bob@example.com" {
+		t.Errorf("To = %v", msg.To)
+	}
+	if len(msg.CC) != 1 || msg.CC[0] != "carol@example.com" || len(msg.BCC) != 0 {
+		t.Errorf("CC = %v, BCC = %v", msg.CC, msg.BCC)
+	}
+	if msg.Subject != "Weekly notes" {
+		t.Errorf("Subject = %q", msg.Subject)
+	}
+	if msg.Body != "Hi all,\n\nNotes below." {
+		t.Errorf("Body = %q", msg.Body)
+	}
+}
+
+func TestParseComposed_Errors(t *testing.T) {
+	tests := []struct {
+		name  string
+		text  string
+		draft bool
+		want  string
+	}{
+		{name: "bad address", text: "To: a@\nSubject: Hi\n\nBody", want: "invalid email address"},
+		{name: "bad cc", text: "To: a@example.com\nCc: carol\nSubject: Hi\n\nBody", want: "invalid email address"},
+		{name: "no recipient", text: "To:\nSubject: Hi\n\nBody", want: "To line"},
+		{name: "no subject", text: "To: a@example.com\nSubject:\n\nBody", want: "Subject line"},
+		{name: "empty body", text: "To: a@example.com\nSubject: Hi\n\n  \n", want: "body is empty"},
+		{name: "unknown header", text: "From: a@example.com\n\nBody", want: "unknown header"},
+		{name: "no blank line", text: "To: a@example.com\nHello there", want: "blank line"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := parseComposed(tt.text, tt.draft)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("parseComposed() error = %v, want it to contain %q", err, tt.want)
+			}
+		})
+	}
+
+	// Drafts may lack recipients and subject
+	if _, err := parseComposed("To:\nSubject:\n\nIdea", true); err != nil {
+		t.Errorf("parseComposed(draft) error = %v", err)
+	}
+}
+
+func TestEditComposed_ReopensOnError(t *testing.T) {
+	var seen []string
+	edits := []string{
+		"To: a@\nSubject: Hi\n\nBody\n",
+		"To: nobody\nSubject: Hi\n\nBody\n",
+		"To: jane\nSubject: Hi\n\nBody\n",
+	}
+	edit := func(path string) error {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		seen = append(seen, string(data))
+		return os.WriteFile(path, []byte(edits[len(seen)-1]), 0o600)
+	}
+
+	contacts := &jmap.MockContactsService{
+		ResolveRecipientFunc: func(ctx context.Context, name string) ([]jmap.EmailAddress, error) {
+			if name == "jane" {
+				return []jmap.EmailAddress{{Name: "Jane Smith", Email: "jane@example.com"}}, nil
+			}
+			return nil, nil
+		},
+	}
+	resolve := func(msg *composedMessage) error {
+		to, err := resolveToRecipients(context.Background(), contacts, msg.To)
+		msg.To = to
+		return err
+	}
+
+	msg, err := editComposed(composeTemplate(nil, nil, nil, ""), false, edit, resolve)
+	if err != nil {
+		t.Fatalf("editComposed() error = %v", err)
+	}
+	if msg == nil || msg.To[0] != "jane@example.com" {
+		t.Fatalf("msg = %+v", msg)
+	}
+	if len(seen) != 3 || !strings.HasPrefix(seen[1], "# Error: To: invalid email address: a@\n") ||
+		!strings.HasPrefix(seen[2], "# Error: invalid email address: nobody (no contact matches it)\n") {
+		t.Errorf("edits started with %q", seen)
+	}
+}
+
+func TestEditComposed_UnchangedCancels(t *testing.T) {
+	msg, err := editComposed(composeTemplate([]string{"a@example.com"}, nil, nil, "Hi"), false, func(string) error { return nil }, nil)
+	if err != nil || msg != nil {
+		t.Errorf("editComposed() = %+v, %v; want nil, nil", msg, err)
+	}
+}
+
+func TestEmailComposeStillSends(t *testing.T) {
+	cmd, _, err := newEmailCmd(newTestApp()).Find([]string{"compose"})
+	if err != nil || cmd.Name() != "send" {
+		t.Errorf("email compose = %v, %v; want the send command", cmd, err)
+	}
+	cmd, _, err = newEmailCmd(newTestApp()).Find([]string{"write"})
+	if err != nil || cmd.Name() != "write" {
+		t.Errorf("email write = %v, %v; want the editor command", cmd, err)
+	}
+}
@@ -1,15 +1,65 @@
 package cmd
 
 import (
+	"context"
 	"errors"
+	"net"
+	"net/http"
 
 	cerrors "github.com/salmonumbrella/fastmail-cli/internal/errors"
 	"github.com/salmonumbrella/fastmail-cli/internal/jmap"
 	"github.com/salmonumbrella/fastmail-cli/internal/transport"
 )
 
-// mapCommandError adds common suggestions for known error types.
-func mapCommandError(err error) error {
+// Process exit codes for common failure classes, so scripts can tell them
+// apart without parsing stderr.
+const (
+	ExitOK             = 0
+	ExitError          = 1 // any failure not listed below
+	ExitNotFound       = 3
+	ExitAuth           = 4
+	ExitRateLimited    = 5
+	ExitValidation     = 6
+	ExitCircuitBreaker = 7
+	ExitNetwork        = 8 // the server could not be reached
+)
+
+// ExitCode returns the process exit code for err.
+func ExitCode(err error) int {
+	switch {
+	case err == nil:
+		return ExitOK
+	case jmap.IsAuthError(err), transport.IsUnauthorized(err):
+		return ExitAuth
+	case jmap.IsRateLimitError(err), transport.IsHTTPStatus(err, http.StatusTooManyRequests):
+		return ExitRateLimited
+	case jmap.IsCircuitBreakerError(err):
+		return ExitCircuitBreaker
+	case jmap.IsNotFoundError(err), transport.IsHTTPStatus(err, http.StatusNotFound):
+		return ExitNotFound
+	case jmap.IsValidationError(err):
+		return ExitValidation
+	case isNetworkError(err):
+		return ExitNetwork
+	}
+	return ExitError
+}
+
+// isNetworkError reports whether err is a failure to reach the server
+// (DNS, connection, TLS or a network timeout) rather than an error response.
+// Hitting a --timeout/--deadline or being cancelled is not one, even though
+// context.DeadlineExceeded satisfies net.Error.
+func isNetworkError(err error) bool {
+	if errors.Is(err, context.DeadlineExceeded) || errors.Is(err, context.Canceled) {
+		return false
+	}
+	var netErr net.Error
+	return errors.As(err, &netErr)
+}
+
+// mapCommandError adds common suggestions for known error types. account is
+// the account the command ran as, or empty when unknown.
+func mapCommandError(err error, account string) error {
 	if err == nil {
 		return nil
 	}
@@ -18,13 +68,31 @@ func mapCommandError(err error) error {
 	}
 
 	switch {
+	case jmap.IsAuthError(err):
+		return cerrors.WithSuggestion(err, authSuggestion(err, account))
 	case transport.IsUnauthorized(err):
-		return cerrors.WithSuggestion(err, cerrors.SuggestionReauth)
+		return cerrors.WithSuggestion(err, cerrors.SuggestionReauthForAccount(account))
 	case jmap.IsInvalidFromAddressError(err):
 		return cerrors.WithSuggestion(err, cerrors.SuggestionListIdentity)
-	case errors.Is(err, jmap.ErrNoIdentities):
+	case errors.Is(err, jmap.ErrNoIdentities), errors.Is(err, jmap.ErrIdentityNotFound):
 		return cerrors.WithSuggestion(err, cerrors.SuggestionListIdentity)
 	}
 
 	return err
 }
+
+// authSuggestion picks a reauth suggestion based on why the token was rejected.
+func authSuggestion(err error, account string) string {
+	var ae *jmap.AuthError
+	if !errors.As(err, &ae) {
+		return cerrors.SuggestionReauthForAccount(account)
+	}
+
+	switch ae.Message {
+	case jmap.AuthTokenExpired, jmap.AuthTokenRevoked:
+		return cerrors.SuggestionNewToken
+	case jmap.AuthTokenMalformed, jmap.AuthTokenInvalid:
+		return cerrors.SuggestionReenterToken
+	}
+	return cerrors.SuggestionReauthForAccount(account)
+}
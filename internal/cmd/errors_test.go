@@ -0,0 +1,136 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/salmonumbrella/fastmail-cli/internal/config"
+	cerrors "github.com/salmonumbrella/fastmail-cli/internal/errors"
+	"github.com/salmonumbrella/fastmail-cli/internal/jmap"
+	"github.com/salmonumbrella/fastmail-cli/internal/transport"
+	"github.com/spf13/cobra"
+)
+
+func TestMapCommandError_AuthSuggestion(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want string
+	}{
+		{"expired", &jmap.AuthError{Message: jmap.AuthTokenExpired}, cerrors.SuggestionNewToken},
+		{"revoked", &jmap.AuthError{Message: jmap.AuthTokenRevoked}, cerrors.SuggestionNewToken},
+		{"malformed", &jmap.AuthError{Message: jmap.AuthTokenMalformed}, cerrors.SuggestionReenterToken},
+		{"invalid", &jmap.AuthError{Message: jmap.AuthTokenInvalid}, cerrors.SuggestionReenterToken},
+		{"unclassified", &jmap.AuthError{Message: "unauthorized"}, cerrors.SuggestionReauth},
+		{"not auth", errors.New("boom"), ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := cerrors.GetSuggestion(mapCommandError(tt.err, "")); got != tt.want {
+				t.Errorf("suggestion = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMapCommandError_ReauthNamesAccount(t *testing.T) {
+	want := "Run 'fastmail auth add work@example.com' to re-authenticate work@example.com"
+
+	tests := []struct {
+		name string
+		err  error
+		want string
+	}{
+		{"unclassified auth error", &jmap.AuthError{Message: "unauthorized"}, want},
+		{"http 401", &transport.HTTPError{StatusCode: http.StatusUnauthorized, Status: "401 Unauthorized"}, want},
+		{"expired keeps token advice", &jmap.AuthError{Message: jmap.AuthTokenExpired}, cerrors.SuggestionNewToken},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := cerrors.GetSuggestion(mapCommandError(tt.err, "work@example.com")); got != tt.want {
+				t.Errorf("suggestion = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRunE_ReauthUsesResolvedAccount(t *testing.T) {
+	app := newTestApp()
+	app.Flags.Account = "me"
+	app.listTokens = func() ([]config.Token, error) {
+		return []config.Token{{Email: "me@example.com"}}, nil
+	}
+
+	run := runE(app, func(cmd *cobra.Command, args []string, app *App) error {
+		if _, err := app.RequireAccount(); err != nil {
+			return err
+		}
+		return &jmap.AuthError{Message: "unauthorized"}
+	})
+
+	err := run(&cobra.Command{}, nil)
+	want := cerrors.SuggestionReauthForAccount("me@example.com")
+	if got := cerrors.GetSuggestion(err); got != want {
+		t.Errorf("suggestion = %q, want %q", got, want)
+	}
+}
+
+func TestExitCode(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want int
+	}{
+		{"nil", nil, ExitOK},
+		{"generic", errors.New("boom"), ExitError},
+		{"not found", &jmap.NotFoundError{Resource: "email", ID: "M1"}, ExitNotFound},
+		{"not found sentinel", cerrors.WithContext(jmap.ErrMailboxNotFound, "moving"), ExitNotFound},
+		{"auth", &jmap.AuthError{Message: jmap.AuthTokenExpired}, ExitAuth},
+		{"http 401", &transport.HTTPError{StatusCode: http.StatusUnauthorized}, ExitAuth},
+		{"rate limited", &jmap.RateLimitError{}, ExitRateLimited},
+		{"circuit breaker", &jmap.CircuitBreakerError{}, ExitCircuitBreaker},
+		{"network", cerrors.WithContext(&url.Error{Op: "Post", URL: "https://api.fastmail.com", Err: errors.New("connection refused")}, "fetching session"), ExitNetwork},
+		{"validation", &jmap.ValidationError{Field: "id", Message: "required"}, ExitValidation},
+		{"deadline", &url.Error{Op: "Post", URL: "https://api.fastmail.com", Err: context.DeadlineExceeded}, ExitError},
+		{"cancelled", &url.Error{Op: "Post", URL: "https://api.fastmail.com", Err: context.Canceled}, ExitError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ExitCode(tt.err); got != tt.want {
+				t.Errorf("ExitCode() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+// Auth handling keys off the typed status, never the message text, which
+// may mention "401" or "unauthorized" for unrelated reasons.
+func TestAuthClassification_IgnoresMessageText(t *testing.T) {
+	notAuth := []error{
+		errors.New("invoice 401 not found"),
+		errors.New("Unauthorized"),
+		&transport.HTTPError{Op: "JMAP request", StatusCode: http.StatusInternalServerError, Body: "upstream returned 401 Unauthorized"},
+	}
+	for _, err := range notAuth {
+		if got := ExitCode(err); got == ExitAuth {
+			t.Errorf("ExitCode(%q) = ExitAuth, want a non-auth code", err)
+		}
+		if s := cerrors.GetSuggestion(mapCommandError(err, "me@example.com")); s != "" {
+			t.Errorf("mapCommandError(%q) suggested %q, want none", err, s)
+		}
+	}
+
+	typed := &transport.HTTPError{Op: "CalDAV PUT", StatusCode: http.StatusUnauthorized, Body: "access denied"}
+	if got := ExitCode(typed); got != ExitAuth {
+		t.Errorf("ExitCode(typed 401) = %d, want %d", got, ExitAuth)
+	}
+	if s := cerrors.GetSuggestion(mapCommandError(typed, "me@example.com")); s != cerrors.SuggestionReauthForAccount("me@example.com") {
+		t.Errorf("typed 401 suggestion = %q", s)
+	}
+}
@@ -1,8 +1,12 @@
 package cmd
 
 import (
+	"context"
 	"strings"
 	"testing"
+
+	"github.com/salmonumbrella/fastmail-cli/internal/outfmt"
+	"github.com/spf13/cobra"
 )
 
 func TestPrintList(t *testing.T) {
@@ -21,3 +25,46 @@ func TestPrintList(t *testing.T) {
 		t.Fatalf("unexpected items: %q", lines[1:])
 	}
 }
+
+func TestPrintJSON_NDJSON(t *testing.T) {
+	app := &App{Flags: &rootFlags{}}
+	items := []map[string]any{
+		{"id": "M1", "tags": []string{"a", "b"}},
+		{"id": "M2", "tags": []string{}},
+	}
+
+	tests := []struct {
+		name  string
+		query string
+		v     any
+		want  string
+	}{
+		{"slice", "", items, `{"id":"M1","tags":["a","b"]}` + "\n" + `{"id":"M2","tags":[]}` + "\n"},
+		{"single value", "", map[string]any{"status": "ok"}, `{"status":"ok"}` + "\n"},
+		{"query per item", ".id", items, `"M1"` + "\n" + `"M2"` + "\n"},
+		{"query with several results", ".tags[]", items, `"a"` + "\n" + `"b"` + "\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.WithValue(context.Background(), outputModeKey, outfmt.NDJSON)
+			ctx = context.WithValue(ctx, queryKey, tt.query)
+			cmd := &cobra.Command{}
+			cmd.SetContext(ctx)
+
+			var err error
+			out := captureStdout(t, func() {
+				err = app.PrintJSON(cmd, tt.v)
+			})
+			if err != nil {
+				t.Fatalf("PrintJSON() error = %v", err)
+			}
+			if out != tt.want {
+				t.Errorf("output = %q, want %q", out, tt.want)
+			}
+			if !app.IsJSON(ctx) {
+				t.Error("IsJSON() = false in ndjson mode")
+			}
+		})
+	}
+}
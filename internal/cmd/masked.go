@@ -13,7 +13,7 @@ import (
 func newMaskedCmd(app *App) *cobra.Command {
 	cmd := &cobra.Command{
 		Use:     "masked",
-		Aliases: []string{"mask", "alias"},
+		Aliases: []string{"mask", "alias", "maskedemail"},
 		Short:   "Masked email (alias) operations",
 		Long: `Manage Fastmail masked email addresses.
 
@@ -46,7 +46,7 @@ With a domain, lists only aliases for that domain.`,
   fastmail masked list example.com`,
 		Args: cobra.MaximumNArgs(1),
 		RunE: runE(app, func(cmd *cobra.Command, args []string, app *App) error {
-			client, err := app.JMAPClient()
+			client, err := app.JMAPClientFor(cmd.Context(), jmap.CapabilityMaskedEmail)
 			if err != nil {
 				return err
 			}
@@ -131,9 +131,10 @@ With a domain, lists only aliases for that domain.`,
 
 func newMaskedCreateCmd(app *App) *cobra.Command {
 	var description string
+	var forDomain string
 
 	cmd := &cobra.Command{
-		Use:   "create <domain> [description]",
+		Use:   "create [domain] [description]",
 		Short: "Create or get a masked email for a domain",
 		Long: `Create a new masked email alias for a domain.
 
@@ -141,21 +142,34 @@ If an alias already exists for the domain, it returns the existing one.
 The domain is normalized (paths and ports are stripped).`,
 		Example: `  fastmail masked create example.com
   fastmail masked create example.com "Shopping account"
-  fastmail masked create https://shop.example.com/signup`,
-		Args: cobra.RangeArgs(1, 2),
+  fastmail masked create https://shop.example.com/signup
+  fastmail masked create --for-domain example.com --description "Newsletter"`,
+		Args: cobra.MaximumNArgs(2),
 		RunE: runE(app, func(cmd *cobra.Command, args []string, app *App) error {
-			client, err := app.JMAPClient()
-			if err != nil {
-				return err
+			domain := forDomain
+			if len(args) > 0 {
+				if forDomain != "" {
+					return fmt.Errorf("cannot use both domain argument and --for-domain flag")
+				}
+				domain = args[0]
+			}
+			if domain == "" {
+				return fmt.Errorf("either provide a domain or use --for-domain flag")
 			}
-
-			domain := args[0]
 
 			// Check if positional description provided
 			if len(args) > 1 {
+				if cmd.Flags().Changed("description") {
+					return fmt.Errorf("cannot use both description argument and --description flag")
+				}
 				description = args[1]
 			}
 
+			client, err := app.JMAPClientFor(cmd.Context(), jmap.CapabilityMaskedEmail)
+			if err != nil {
+				return err
+			}
+
 			// Validate input is a domain, not an email
 			if jmap.LooksLikeEmail(domain) {
 				return fmt.Errorf("expected a domain, got an email address: %s\nUse 'masked get' to lookup an existing alias", domain)
@@ -206,6 +220,9 @@ The domain is normalized (paths and ports are stripped).`,
 		}),
 	}
 
+	cmd.Flags().StringVar(&forDomain, "for-domain", "", "Domain the alias is for (alternative to the domain argument)")
+	cmd.Flags().StringVar(&description, "description", "", "Description of the alias")
+
 	return cmd
 }
 
@@ -215,7 +232,7 @@ func newMaskedGetCmd(app *App) *cobra.Command {
 		Short: "Get details of a masked email",
 		Args:  cobra.ExactArgs(1),
 		RunE: runE(app, func(cmd *cobra.Command, args []string, app *App) error {
-			client, err := app.JMAPClient()
+			client, err := app.JMAPClientFor(cmd.Context(), jmap.CapabilityMaskedEmail)
 			if err != nil {
 				return err
 			}
@@ -257,7 +274,7 @@ func newMaskedEnableCmd(app *App) *cobra.Command {
 	var dryRun bool
 
 	cmd := &cobra.Command{
-		Use:   "enable [email]",
+		Use:   "enable [email|id]",
 		Short: "Enable masked email(s)",
 		Long: `Enable a masked email alias or all aliases for a domain.
 
@@ -265,15 +282,16 @@ New aliases start in 'pending' state and are auto-enabled on first email.
 If no email is received within 24 hours, pending aliases are deleted.
 Use this command to manually enable an alias.`,
 		Example: `  fastmail masked enable user.1234@fastmail.com
+  fastmail masked enable masked-123
   fastmail masked enable --domain example.com
   fastmail masked enable --domain example.com --dry-run`,
 		Args: cobra.MaximumNArgs(1),
 		RunE: runE(app, func(cmd *cobra.Command, args []string, app *App) error {
 			if len(args) == 0 && domain == "" {
-				return fmt.Errorf("either provide an email address or use --domain flag")
+				return fmt.Errorf("either provide an email address or ID, or use --domain flag")
 			}
 			if len(args) > 0 && domain != "" {
-				return fmt.Errorf("cannot use both email argument and --domain flag")
+				return fmt.Errorf("cannot use both email/ID argument and --domain flag")
 			}
 
 			if domain != "" {
@@ -294,21 +312,22 @@ func newMaskedDisableCmd(app *App) *cobra.Command {
 	var dryRun bool
 
 	cmd := &cobra.Command{
-		Use:   "disable [email]",
+		Use:   "disable [email|id]",
 		Short: "Disable masked email(s) (emails go to trash)",
 		Long: `Disable a masked email alias or all aliases for a domain.
 
 When disabled, emails sent to the alias are moved to trash.`,
 		Example: `  fastmail masked disable user.1234@fastmail.com
+  fastmail masked disable masked-123
   fastmail masked disable --domain example.com
   fastmail masked disable --domain example.com --dry-run`,
 		Args: cobra.MaximumNArgs(1),
 		RunE: runE(app, func(cmd *cobra.Command, args []string, app *App) error {
 			if len(args) == 0 && domain == "" {
-				return fmt.Errorf("either provide an email address or use --domain flag")
+				return fmt.Errorf("either provide an email address or ID, or use --domain flag")
 			}
 			if len(args) > 0 && domain != "" {
-				return fmt.Errorf("cannot use both email argument and --domain flag")
+				return fmt.Errorf("cannot use both email/ID argument and --domain flag")
 			}
 
 			if domain != "" {
@@ -329,21 +348,22 @@ func newMaskedDeleteCmd(app *App) *cobra.Command {
 	var dryRun bool
 
 	cmd := &cobra.Command{
-		Use:   "delete [email]",
+		Use:   "delete [email|id]",
 		Short: "Delete masked email(s) (emails will bounce)",
 		Long: `Delete a masked email alias or all aliases for a domain.
 
 When deleted, emails sent to the alias will bounce.`,
 		Example: `  fastmail masked delete user.1234@fastmail.com
+  fastmail masked delete masked-123
   fastmail masked delete --domain example.com
   fastmail masked delete --domain example.com --dry-run`,
 		Args: cobra.MaximumNArgs(1),
 		RunE: runE(app, func(cmd *cobra.Command, args []string, app *App) error {
 			if len(args) == 0 && domain == "" {
-				return fmt.Errorf("either provide an email address or use --domain flag")
+				return fmt.Errorf("either provide an email address or ID, or use --domain flag")
 			}
 			if len(args) > 0 && domain != "" {
-				return fmt.Errorf("cannot use both email argument and --domain flag")
+				return fmt.Errorf("cannot use both email/ID argument and --domain flag")
 			}
 
 			if domain != "" {
@@ -365,7 +385,7 @@ func newMaskedDescriptionCmd(app *App) *cobra.Command {
 		Short: "Update the description of a masked email",
 		Args:  cobra.ExactArgs(2),
 		RunE: runE(app, func(cmd *cobra.Command, args []string, app *App) error {
-			client, err := app.JMAPClient()
+			client, err := app.JMAPClientFor(cmd.Context(), jmap.CapabilityMaskedEmail)
 			if err != nil {
 				return err
 			}
@@ -409,21 +429,18 @@ func newMaskedDescriptionCmd(app *App) *cobra.Command {
 	return cmd
 }
 
-func updateMaskedEmailState(cmd *cobra.Command, app *App, email string, state jmap.MaskedEmailState, dryRun bool) error {
-	client, err := app.JMAPClient()
+func updateMaskedEmailState(cmd *cobra.Command, app *App, idOrEmail string, state jmap.MaskedEmailState, dryRun bool) error {
+	client, err := app.JMAPClientFor(cmd.Context(), jmap.CapabilityMaskedEmail)
 	if err != nil {
 		return err
 	}
 
-	if !jmap.LooksLikeEmail(email) {
-		return fmt.Errorf("expected an email address, got: %s", email)
-	}
-
 	// Get the alias to find its ID and check current state
-	alias, err := client.GetMaskedEmailByEmail(cmd.Context(), email)
+	alias, err := client.ResolveMaskedEmail(cmd.Context(), idOrEmail)
 	if err != nil {
 		return fmt.Errorf("failed to get masked email: %w", err)
 	}
+	email := alias.Email
 
 	if alias.State == state {
 		return fmt.Errorf("alias %s is already %s", email, state)
@@ -443,6 +460,7 @@ func updateMaskedEmailState(cmd *cobra.Command, app *App, email string, state jm
 
 	if app.IsJSON(cmd.Context()) {
 		return app.PrintJSON(cmd, map[string]any{
+			"id":    alias.ID,
 			"email": email,
 			"state": state,
 		})
@@ -453,7 +471,7 @@ func updateMaskedEmailState(cmd *cobra.Command, app *App, email string, state jm
 }
 
 func bulkUpdateMaskedEmailState(cmd *cobra.Command, app *App, domain string, state jmap.MaskedEmailState, dryRun bool) error {
-	client, err := app.JMAPClient()
+	client, err := app.JMAPClientFor(cmd.Context(), jmap.CapabilityMaskedEmail)
 	if err != nil {
 		return err
 	}
@@ -1,6 +1,7 @@
 package cmd
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/salmonumbrella/fastmail-cli/internal/jmap"
@@ -81,3 +82,32 @@ func TestSelectBestAlias_Empty(t *testing.T) {
 		t.Fatalf("expected nil, got %#v", got)
 	}
 }
+
+func TestMaskedCreate_RequiresDomain(t *testing.T) {
+	cases := [][]string{
+		{"masked", "create"},
+		{"masked", "create", "example.com", "--for-domain", "example.com"},
+	}
+
+	for _, args := range cases {
+		cmd := newMaskedCmd(newTestApp())
+		cmd.SetArgs(args[1:])
+		cmd.SilenceUsage = true
+		cmd.SilenceErrors = true
+		err := cmd.Execute()
+		if err == nil || !strings.Contains(err.Error(), "domain") {
+			t.Errorf("%v: error = %v, want domain usage error", args, err)
+		}
+	}
+}
+
+func TestMaskedCreate_RejectsTwoDescriptions(t *testing.T) {
+	cmd := newMaskedCmd(newTestApp())
+	cmd.SetArgs([]string{"create", "example.com", "Shopping", "--description", "Newsletter"})
+	cmd.SilenceUsage = true
+	cmd.SilenceErrors = true
+	err := cmd.Execute()
+	if err == nil || !strings.Contains(err.Error(), "--description") {
+		t.Errorf("error = %v, want description conflict", err)
+	}
+}
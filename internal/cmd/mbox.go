@@ -0,0 +1,91 @@
+package cmd
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"io"
+)
+
+// errNotMbox is returned when the input does not start with a "From " line.
+var errNotMbox = errors.New(`not an mbox file: expected the first line to start with "From "`)
+
+// mboxReader splits an mbox stream into messages one at a time, so files of
+// any size are read with memory bounded by the largest message.
+//
+// A message starts at a "From " line at the start of the file or after a
+// blank line. That separator line is dropped, as is the blank line before the
+// next separator. Quoted lines (">From ", ">>From ", ...) lose one ">", which
+// undoes the mboxrd escaping; for mboxo files it restores the common case.
+type mboxReader struct {
+	r         *bufio.Reader
+	atStart   bool // nothing read yet
+	prevBlank bool // the previous line was empty
+	inMessage bool // the separator of the next message has been consumed
+}
+
+func newMboxReader(r io.Reader) *mboxReader {
+	return &mboxReader{r: bufio.NewReaderSize(r, 64*1024), atStart: true}
+}
+
+// Next returns the next raw RFC 5322 message, or io.EOF when none are left.
+func (m *mboxReader) Next() ([]byte, error) {
+	var msg bytes.Buffer
+	for {
+		line, err := m.r.ReadBytes('\n')
+		if len(line) > 0 {
+			blank := len(bytes.TrimRight(line, "\r\n")) == 0
+
+			switch {
+			case bytes.HasPrefix(line, []byte("From ")) && (m.atStart || m.prevBlank):
+				m.atStart, m.prevBlank = false, false
+				if m.inMessage && msg.Len() > 0 {
+					// This line separates the message just read from the next
+					return trimMboxMessage(msg.Bytes()), nil
+				}
+				m.inMessage = true
+				continue
+			case !m.inMessage:
+				// Only blank lines may precede the first separator
+				if !blank {
+					return nil, errNotMbox
+				}
+			default:
+				msg.Write(unescapeMboxLine(line))
+			}
+			m.atStart = false
+			m.prevBlank = blank
+		}
+
+		if err == io.EOF {
+			m.inMessage = false
+			if msg.Len() == 0 {
+				return nil, io.EOF
+			}
+			return trimMboxMessage(msg.Bytes()), nil
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+}
+
+// unescapeMboxLine removes one ">" from a quoted ">From " line.
+func unescapeMboxLine(line []byte) []byte {
+	quoted := bytes.TrimLeft(line, ">")
+	if len(quoted) < len(line) && bytes.HasPrefix(quoted, []byte("From ")) {
+		return line[1:]
+	}
+	return line
+}
+
+// trimMboxMessage drops the blank line that precedes the next separator.
+func trimMboxMessage(msg []byte) []byte {
+	switch {
+	case bytes.HasSuffix(msg, []byte("\r\n\r\n")):
+		return msg[:len(msg)-2]
+	case bytes.HasSuffix(msg, []byte("\n\n")):
+		return msg[:len(msg)-1]
+	}
+	return msg
+}
@@ -0,0 +1,130 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/salmonumbrella/fastmail-cli/internal/jmap"
+)
+
+func readAllMbox(t *testing.T, input string) []string {
+	t.Helper()
+	reader := newMboxReader(strings.NewReader(input))
+	var msgs []string
+	for {
+		msg, err := reader.Next()
+		if errors.Is(err, io.EOF) {
+			return msgs
+		}
+		if err != nil {
+			t.Fatalf("Next() error = %v", err)
+		}
+		msgs = append(msgs, string(msg))
+	}
+}
+
+func TestMboxReader(t *testing.T) {
+	input := "From alice@example.com Mon Jan  1 00:00:00 2024\n" +
+		"Subject: one\n" +
+		"\n" +
+		">From the top\n" +
+		">>From quoted\n" +
+		"From inside a paragraph is not a separator\n" +
+		"\n" +
+		"From bob@example.com Tue Jan  2 00:00:00 2024\n" +
+		"Subject: two\n" +
+		"\n" +
+		"body two\n"
+
+	msgs := readAllMbox(t, input)
+	if len(msgs) != 2 {
+		t.Fatalf("got %d messages, want 2: %q", len(msgs), msgs)
+	}
+
+	wantFirst := "Subject: one\n\nFrom the top\n>From quoted\nFrom inside a paragraph is not a separator\n"
+	if msgs[0] != wantFirst {
+		t.Errorf("first message = %q, want %q", msgs[0], wantFirst)
+	}
+	if msgs[1] != "Subject: two\n\nbody two\n" {
+		t.Errorf("second message = %q", msgs[1])
+	}
+}
+
+func TestMboxReader_CRLFAndNoTrailingNewline(t *testing.T) {
+	input := "From a@example.com\r\nSubject: one\r\n\r\nbody\r\n\r\nFrom b@example.com\r\nSubject: two\r\n\r\nlast"
+	msgs := readAllMbox(t, input)
+	if len(msgs) != 2 {
+		t.Fatalf("got %d messages, want 2: %q", len(msgs), msgs)
+	}
+	if msgs[0] != "Subject: one\r\n\r\nbody\r\n" {
+		t.Errorf("first message = %q", msgs[0])
+	}
+	if msgs[1] != "Subject: two\r\n\r\nlast" {
+		t.Errorf("second message = %q", msgs[1])
+	}
+}
+
+func TestMboxReader_EmptyAndInvalid(t *testing.T) {
+	if msgs := readAllMbox(t, ""); len(msgs) != 0 {
+		t.Errorf("empty input gave %d messages", len(msgs))
+	}
+
+	_, err := newMboxReader(strings.NewReader("Subject: not mbox\n\nbody\n")).Next()
+	if !errors.Is(err, errNotMbox) {
+		t.Errorf("error = %v, want errNotMbox", err)
+	}
+}
+
+func TestImportMbox_ContinuesPastFailures(t *testing.T) {
+	input := "From a\nSubject: 1\n\nx\n\nFrom b\nSubject: 2\n\ny\n\nFrom c\nSubject: 3\n\nz\n"
+
+	var uploaded []string
+	var keywords []map[string]bool
+	client := &jmap.MockEmailService{
+		UploadBlobFunc: func(ctx context.Context, reader io.Reader, contentType string) (*jmap.UploadBlobResult, error) {
+			if contentType != "message/rfc822" {
+				t.Errorf("contentType = %q", contentType)
+			}
+			data, _ := io.ReadAll(reader)
+			uploaded = append(uploaded, string(data))
+			return &jmap.UploadBlobResult{BlobID: fmt.Sprintf("blob%d", len(uploaded))}, nil
+		},
+		ImportEmailFunc: func(ctx context.Context, opts jmap.ImportEmailOpts) (string, error) {
+			if !opts.MailboxIDs["mb-archive"] {
+				t.Errorf("MailboxIDs = %v", opts.MailboxIDs)
+			}
+			keywords = append(keywords, opts.Keywords)
+			if opts.BlobID == "blob2" {
+				return "", errors.New("invalidEmail")
+			}
+			return "email-" + opts.BlobID, nil
+		},
+	}
+
+	var progressed int
+	results, err := importMbox(context.Background(), client, strings.NewReader(input), "mb-archive", true,
+		func(mboxImportResult) { progressed++ })
+	if err != nil {
+		t.Fatalf("importMbox() error = %v", err)
+	}
+
+	if len(results) != 3 || progressed != 3 {
+		t.Fatalf("results = %+v, progress calls = %d", results, progressed)
+	}
+	if results[0].EmailID != "email-blob1" || results[2].EmailID != "email-blob3" {
+		t.Errorf("results = %+v", results)
+	}
+	if results[1].Index != 2 || !strings.Contains(results[1].Error, "invalidEmail") {
+		t.Errorf("failed result = %+v", results[1])
+	}
+	if uploaded[1] != "Subject: 2\n\ny\n" {
+		t.Errorf("second upload = %q", uploaded[1])
+	}
+	if !keywords[0]["$seen"] {
+		t.Errorf("keywords = %v, want $seen with --mark-read", keywords[0])
+	}
+}
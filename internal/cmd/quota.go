@@ -1,6 +1,7 @@
 package cmd
 
 import (
+	"errors"
 	"fmt"
 	"strings"
 
@@ -10,8 +11,16 @@ import (
 	"github.com/spf13/cobra"
 )
 
+// quotaOutput is the JSON form of a quota, with the computed usage percentage.
+type quotaOutput struct {
+	jmap.Quota
+	Percentage    float64 `json:"percentage"`
+	OverThreshold bool    `json:"overThreshold,omitempty"`
+}
+
 func newQuotaCmd(app *App) *cobra.Command {
 	var formatFlag string
+	var warnThreshold float64
 
 	cmd := &cobra.Command{
 		Use:     "quota",
@@ -24,8 +33,13 @@ Shows used and available storage with a visual progress bar.
 Examples:
   fastmail quota                    # Show quotas with human-readable sizes
   fastmail quota --format bytes     # Show raw byte values
-  fastmail quota --format human     # Explicitly use human-readable format`,
+  fastmail quota --format human     # Explicitly use human-readable format
+  fastmail quota --warn-threshold 90  # Exit non-zero when usage is above 90%`,
 		RunE: runE(app, func(cmd *cobra.Command, args []string, app *App) error {
+			if warnThreshold < 0 || warnThreshold > 100 {
+				return fmt.Errorf("--warn-threshold must be between 0 and 100")
+			}
+
 			client, err := app.JMAPClient()
 			if err != nil {
 				return err
@@ -33,6 +47,9 @@ Examples:
 
 			quotas, err := client.GetQuotas(cmd.Context())
 			if err != nil {
+				if errors.Is(err, jmap.ErrQuotaNotEnabled) {
+					return fmt.Errorf("quota information is not supported for this account: %w", err)
+				}
 				return fmt.Errorf("failed to get quotas: %w", err)
 			}
 
@@ -44,28 +61,75 @@ Examples:
 				return nil
 			}
 
+			over := quotasOverThreshold(quotas, warnThreshold)
+
 			// JSON output
 			if app.IsJSON(cmd.Context()) {
-				return app.PrintJSON(cmd, quotas)
-			}
-
-			// Human-readable output
-			for i, quota := range quotas {
-				if i > 0 {
-					fmt.Println()
+				output := make([]quotaOutput, len(quotas))
+				for i, quota := range quotas {
+					output[i] = quotaOutput{
+						Quota:         quota,
+						Percentage:    quota.Percent(),
+						OverThreshold: over[quota.ID],
+					}
+				}
+				if err := app.PrintJSON(cmd, output); err != nil {
+					return err
+				}
+			} else {
+				// Human-readable output
+				for i, quota := range quotas {
+					if i > 0 {
+						fmt.Println()
+					}
+					displayQuota(quota, formatFlag)
 				}
-				displayQuota(quota, formatFlag)
 			}
 
+			if len(over) > 0 {
+				return quotaThresholdError(quotas, over, warnThreshold)
+			}
 			return nil
 		}),
 	}
 
 	cmd.Flags().StringVar(&formatFlag, "format", "human", "Output format: human, bytes")
+	cmd.Flags().Float64Var(&warnThreshold, "warn-threshold", 0, "Exit non-zero when any quota's usage exceeds this percentage (0 disables)")
 
 	return cmd
 }
 
+// quotasOverThreshold returns the IDs of limited quotas whose usage exceeds
+// threshold percent. A threshold of 0 disables the check.
+func quotasOverThreshold(quotas []jmap.Quota, threshold float64) map[string]bool {
+	over := map[string]bool{}
+	if threshold <= 0 {
+		return over
+	}
+	for _, quota := range quotas {
+		if quota.Limit > 0 && quota.Percent() > threshold {
+			over[quota.ID] = true
+		}
+	}
+	return over
+}
+
+// quotaThresholdError describes the quotas that exceeded the warning threshold.
+func quotaThresholdError(quotas []jmap.Quota, over map[string]bool, threshold float64) error {
+	var parts []string
+	for _, quota := range quotas {
+		if !over[quota.ID] {
+			continue
+		}
+		name := quota.Name
+		if quota.Description != "" {
+			name = quota.Description
+		}
+		parts = append(parts, fmt.Sprintf("%s at %.1f%%", name, quota.Percent()))
+	}
+	return fmt.Errorf("quota usage above %.0f%% warning threshold: %s", threshold, strings.Join(parts, ", "))
+}
+
 // displayQuota displays a single quota with formatting
 func displayQuota(quota jmap.Quota, formatMode string) {
 	tw := outfmt.NewTabWriter()
@@ -86,7 +150,7 @@ func displayQuota(quota jmap.Quota, formatMode string) {
 		if quota.Limit > 0 {
 			limitStr = fmt.Sprintf("%d bytes", quota.Limit)
 			availStr = fmt.Sprintf("%d bytes", quota.Limit-quota.Used)
-			percentage = float64(quota.Used) / float64(quota.Limit) * 100
+			percentage = quota.Percent()
 		} else {
 			limitStr = "unlimited"
 			availStr = "unlimited"
@@ -97,7 +161,7 @@ func displayQuota(quota jmap.Quota, formatMode string) {
 		if quota.Limit > 0 {
 			limitStr = format.FormatBytes(quota.Limit)
 			availStr = format.FormatBytes(quota.Limit - quota.Used)
-			percentage = float64(quota.Used) / float64(quota.Limit) * 100
+			percentage = quota.Percent()
 		} else {
 			limitStr = "unlimited"
 			availStr = "unlimited"
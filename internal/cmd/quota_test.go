@@ -0,0 +1,34 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/salmonumbrella/fastmail-cli/internal/jmap"
+)
+
+func TestQuotasOverThreshold(t *testing.T) {
+	quotas := []jmap.Quota{
+		{ID: "mail", Name: "Mail", Used: 95, Limit: 100},
+		{ID: "files", Name: "Files", Description: "File storage", Used: 91, Limit: 100},
+		{ID: "ok", Name: "OK", Used: 90, Limit: 100},
+		{ID: "unlimited", Name: "Unlimited", Used: 1000, Limit: 0},
+	}
+
+	if over := quotasOverThreshold(quotas, 0); len(over) != 0 {
+		t.Errorf("threshold 0 should disable the check, got %v", over)
+	}
+
+	over := quotasOverThreshold(quotas, 90)
+	if len(over) != 2 || !over["mail"] || !over["files"] {
+		t.Fatalf("quotasOverThreshold() = %v, want mail and files", over)
+	}
+
+	err := quotaThresholdError(quotas, over, 90)
+	msg := err.Error()
+	for _, want := range []string{"90%", "Mail at 95.0%", "File storage at 91.0%"} {
+		if !strings.Contains(msg, want) {
+			t.Errorf("error %q missing %q", msg, want)
+		}
+	}
+}
@@ -2,9 +2,12 @@ package cmd
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"os"
+	"strconv"
 	"strings"
+	"time"
 
 	cerrors "github.com/salmonumbrella/fastmail-cli/internal/errors"
 	"github.com/salmonumbrella/fastmail-cli/internal/logging"
@@ -23,12 +26,23 @@ var (
 type rootFlags struct {
 	Color          string
 	Account        string
+	AccountID      string
 	Output         string
 	Debug          bool
+	Verbose        bool
+	DryRunRequests bool
+	NoSessionCache bool
 	Query          string
 	Yes            bool
 	NoInput        bool
 	NonInteractive bool
+	Timeout        time.Duration
+	Deadline       time.Duration
+	DateFormat     string
+	RelativeDates  bool
+	UTC            bool
+	UserAgent      string
+	Config         string
 }
 
 type contextKey string
@@ -44,8 +58,9 @@ func Execute(args []string) error {
 	root.SetArgs(args)
 
 	err := root.Execute()
+	err = app.finishDeadline(err)
 	if err != nil {
-		if app.Flags.Output == "json" {
+		if app.Flags.Output == "json" || app.Flags.Output == "ndjson" {
 			payload := map[string]any{
 				"error": map[string]any{
 					"message": err.Error(),
@@ -54,7 +69,11 @@ func Execute(args []string) error {
 			if cerrors.ContainsSuggestion(err) {
 				payload["error"].(map[string]any)["suggestion"] = cerrors.GetSuggestion(err)
 			}
-			_ = outfmt.WriteJSON(os.Stderr, payload)
+			if app.Flags.Output == "ndjson" {
+				_ = outfmt.NewNDJSONWriter(os.Stderr, "").Write(payload)
+			} else {
+				_ = outfmt.WriteJSON(os.Stderr, payload)
+			}
 		} else {
 			// Print the main error
 			fmt.Fprintln(os.Stderr, "Error:", err)
@@ -102,15 +121,25 @@ func NewRootCmd(app *App) *cobra.Command {
   fastmail --output=json email list | jq .
 `),
 		PersistentPreRunE: func(cmd *cobra.Command, _ []string) error {
-			// UI (must come first)
+			// Config file defaults, beneath flags (must come before the UI)
+			if err := app.loadSettings(cmd); err != nil {
+				return err
+			}
+
+			// UI
 			u := ui.New(app.Flags.Color)
 			ctx := ui.WithUI(cmd.Context(), u)
 			app.UI = u
 
 			// Output format
 			mode := outfmt.Text
-			if app.Flags.Output == "json" {
+			switch app.Flags.Output {
+			case "json":
 				mode = outfmt.JSON
+			case "ndjson":
+				mode = outfmt.NDJSON
+			case "csv":
+				mode = outfmt.CSV
 			}
 			ctx = context.WithValue(ctx, outputModeKey, mode)
 
@@ -127,16 +156,30 @@ func NewRootCmd(app *App) *cobra.Command {
 			ctx = logging.WithLogger(ctx, logger)
 			app.Logger = logger
 
+			// Whole-command deadline (released in Execute)
+			ctx = app.startDeadline(ctx)
+
 			ctx = WithApp(ctx, app)
 			cmd.SetContext(ctx)
 			return nil
 		},
 	}
 	root.PersistentFlags().StringVar(&app.Flags.Color, "color", app.Flags.Color, "Color output: auto|always|never")
-	root.PersistentFlags().StringVar(&app.Flags.Account, "account", envOr("FASTMAIL_ACCOUNT", ""), "Account email for API commands")
-	root.PersistentFlags().StringVar(&app.Flags.Output, "output", app.Flags.Output, "Output format: text|json")
+	root.PersistentFlags().StringVar(&app.Flags.Account, "account", envOr("FASTMAIL_ACCOUNT", ""), "Account for API commands: its email, default identity or the part before @")
+	root.PersistentFlags().StringVar(&app.Flags.AccountID, "account-id", envOr("FASTMAIL_ACCOUNT_ID", ""), "JMAP account ID to target when the token can access several (default: first)")
+	root.PersistentFlags().StringVar(&app.Flags.Output, "output", app.Flags.Output, "Output format: text|json|ndjson|csv")
 	root.PersistentFlags().BoolVar(&app.Flags.Debug, "debug", false, "Enable debug logging")
+	root.PersistentFlags().BoolVar(&app.Flags.Verbose, "verbose", false, "Trace JMAP requests (methods, status, timing) to stderr")
+	root.PersistentFlags().BoolVar(&app.Flags.DryRunRequests, "dry-run-requests", false, "Print JMAP requests to stderr instead of sending them; uploads are skipped, and Sieve, CalDAV and WebDAV commands refuse to run")
+	root.PersistentFlags().BoolVar(&app.Flags.NoSessionCache, "no-session-cache", false, "Always fetch the JMAP session instead of reusing the on-disk copy")
 	root.PersistentFlags().StringVar(&app.Flags.Query, "query", "", "JQ filter expression for JSON output")
+	root.PersistentFlags().DurationVar(&app.Flags.Timeout, "timeout", 0, "Overall deadline per API call including retries, e.g. 2m (default 30s; uploads and downloads always get 5m)")
+	root.PersistentFlags().DurationVar(&app.Flags.Deadline, "deadline", 0, "Abort the whole command after this long, across all API calls, e.g. 10m (default: none)")
+	root.PersistentFlags().StringVar(&app.Flags.DateFormat, "date-format", "", "Go time layout for dates in text output, e.g. \"02 Jan 2006 15:04\" (default \"2006-01-02 15:04\")")
+	root.PersistentFlags().BoolVar(&app.Flags.RelativeDates, "relative", false, "Show recent dates relative to now, e.g. \"3h ago\", \"yesterday\"")
+	root.PersistentFlags().BoolVar(&app.Flags.UTC, "utc", false, "Show dates in UTC instead of the local time zone")
+	root.PersistentFlags().StringVar(&app.Flags.Config, "config", envOr("FASTMAIL_CONFIG", ""), "Config file with defaults (default $XDG_CONFIG_HOME/fastmail-cli/config)")
+	root.PersistentFlags().StringVar(&app.Flags.UserAgent, "user-agent", envOr("FASTMAIL_USER_AGENT", ""), "User-Agent header for API requests (default \"fastmail-cli/<version>\")")
 	root.PersistentFlags().BoolVarP(&app.Flags.Yes, "yes", "y", false, "Skip confirmation prompts (non-interactive)")
 	root.PersistentFlags().BoolVar(&app.Flags.NoInput, "no-input", false, "Alias for --yes (non-interactive)")
 	root.PersistentFlags().BoolVar(&app.Flags.NonInteractive, "non-interactive", false, "Alias for --yes (non-interactive)")
@@ -153,6 +196,8 @@ func NewRootCmd(app *App) *cobra.Command {
 	root.AddCommand(newFilesCmd(app))
 	root.AddCommand(newSieveCmd(app))
 	root.AddCommand(newDraftCmd(app))
+	root.AddCommand(newVersionCmd(app))
+	root.AddCommand(newWhoamiCmd(app))
 
 	// Desire paths: top-level shortcuts for common email workflows.
 	root.AddCommand(newSearchShortcutCmd(app))
@@ -161,9 +206,36 @@ func NewRootCmd(app *App) *cobra.Command {
 	root.AddCommand(newSendShortcutCmd(app))
 	root.AddCommand(newThreadShortcutCmd(app))
 	root.AddCommand(newMailboxesShortcutCmd(app))
+	root.AddCommand(newInboxStatusShortcutCmd(app))
 	return root
 }
 
+// startDeadline bounds ctx by --deadline, if set.
+func (a *App) startDeadline(ctx context.Context) context.Context {
+	if a.Flags.Deadline <= 0 {
+		return ctx
+	}
+	ctx, a.cancelDeadline = context.WithTimeout(ctx, a.Flags.Deadline)
+	a.deadline = ctx
+	return ctx
+}
+
+// finishDeadline releases the --deadline context and, when the command
+// failed because the deadline passed, says so in the error.
+func (a *App) finishDeadline(err error) error {
+	if a.deadline == nil {
+		return err
+	}
+	if err != nil && errors.Is(a.deadline.Err(), context.DeadlineExceeded) {
+		err = Suggest(
+			fmt.Errorf("command exceeded --deadline of %s: %w", a.Flags.Deadline, err),
+			"Increase --deadline or narrow the operation (e.g. a smaller --limit)",
+		)
+	}
+	a.cancelDeadline()
+	return err
+}
+
 func envOr(key, fallback string) string {
 	if v := os.Getenv(key); v != "" {
 		return v
@@ -171,6 +243,18 @@ func envOr(key, fallback string) string {
 	return fallback
 }
 
+func envInt(key string, fallback int) int {
+	v := strings.TrimSpace(os.Getenv(key))
+	if v == "" {
+		return fallback
+	}
+	n, err := strconv.Atoi(v)
+	if err != nil {
+		return fallback
+	}
+	return n
+}
+
 func envBool(key string, fallback bool) bool {
 	v := strings.TrimSpace(strings.ToLower(os.Getenv(key)))
 	if v == "" {
@@ -0,0 +1,185 @@
+package cmd
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/salmonumbrella/fastmail-cli/internal/format"
+	"github.com/salmonumbrella/fastmail-cli/internal/validation"
+	"gopkg.in/yaml.v3"
+)
+
+// sendManifest is a YAML description of one email for email send --manifest.
+//
+//	from: me@example.com
+//	to: [alice@example.com]
+//	cc: [bob@example.com]
+//	subject: Q4 report
+//	body: |
+//	  Numbers attached.
+//	attachments:
+//	  - reports/q4.pdf
+//	  - path: ${REPORT_DIR}/summary.xlsx
+//	    name: Summary.xlsx
+//
+// String fields may reference environment variables as ${NAME}. Relative
+// attachment paths are resolved against the manifest's directory.
+type sendManifest struct {
+	From        string               `yaml:"from"`
+	To          []string             `yaml:"to"`
+	CC          []string             `yaml:"cc"`
+	BCC         []string             `yaml:"bcc"`
+	Subject     string               `yaml:"subject"`
+	Body        string               `yaml:"body"`
+	HTML        string               `yaml:"html"`
+	Attachments []manifestAttachment `yaml:"attachments"`
+}
+
+// manifestAttachment is either a "path[:name[:disposition]]" string, as for
+// --attach, or a mapping with path, name and disposition keys.
+type manifestAttachment struct {
+	Path        string `yaml:"path"`
+	Name        string `yaml:"name"`
+	Disposition string `yaml:"disposition"`
+}
+
+func (a *manifestAttachment) UnmarshalYAML(node *yaml.Node) error {
+	if node.Kind == yaml.ScalarNode {
+		path, name, disposition, err := format.ParseAttachmentFlag(node.Value)
+		if err != nil {
+			return err
+		}
+		a.Path, a.Name, a.Disposition = path, name, disposition
+		return nil
+	}
+	type plain manifestAttachment
+	return node.Decode((*plain)(a))
+}
+
+// envRefPattern matches ${NAME} references.
+var envRefPattern = regexp.MustCompile(`\$\{([A-Za-z_][A-Za-z0-9_]*)\}`)
+
+// loadSendManifest reads and validates a manifest. Everything that can be
+// checked locally is checked here, before any API call.
+func loadSendManifest(path string) (*sendManifest, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read manifest: %w", err)
+	}
+
+	var m sendManifest
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	dec.KnownFields(true)
+	if err := dec.Decode(&m); err != nil {
+		return nil, fmt.Errorf("parse manifest %s: %w", path, err)
+	}
+
+	missing := map[string]bool{}
+	expand := func(s string) string {
+		return envRefPattern.ReplaceAllStringFunc(s, func(ref string) string {
+			name := envRefPattern.FindStringSubmatch(ref)[1]
+			value, ok := os.LookupEnv(name)
+			if !ok {
+				missing[name] = true
+			}
+			return value
+		})
+	}
+	expandAll := func(list []string) {
+		for i := range list {
+			list[i] = strings.TrimSpace(expand(list[i]))
+		}
+	}
+
+	m.From = strings.TrimSpace(expand(m.From))
+	expandAll(m.To)
+	expandAll(m.CC)
+	expandAll(m.BCC)
+	m.Subject = expand(m.Subject)
+	m.Body = expand(m.Body)
+	m.HTML = expand(m.HTML)
+
+	dir := filepath.Dir(path)
+	for i := range m.Attachments {
+		att := &m.Attachments[i]
+		att.Path = expand(att.Path)
+		att.Name = expand(att.Name)
+		if att.Path != "" && !filepath.IsAbs(att.Path) {
+			att.Path = filepath.Join(dir, att.Path)
+		}
+	}
+
+	if len(missing) > 0 {
+		names := make([]string, 0, len(missing))
+		for name := range missing {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		return nil, fmt.Errorf("manifest %s references unset environment variables: %s", path, strings.Join(names, ", "))
+	}
+
+	if err := m.validate(); err != nil {
+		return nil, fmt.Errorf("invalid manifest %s: %w", path, err)
+	}
+	return &m, nil
+}
+
+// validate reports every problem at once so a manifest can be fixed in one go.
+func (m *sendManifest) validate() error {
+	var problems []string
+	if len(m.To) == 0 {
+		problems = append(problems, "to: at least one recipient is required")
+	}
+	if strings.TrimSpace(m.Subject) == "" {
+		problems = append(problems, "subject: is required")
+	}
+	if m.Body == "" && m.HTML == "" {
+		problems = append(problems, "body or html: is required")
+	}
+	for field, addrs := range map[string][]string{"to": m.To, "cc": m.CC, "bcc": m.BCC} {
+		for _, addr := range addrs {
+			if !validation.IsValidEmail(addr) {
+				problems = append(problems, fmt.Sprintf("%s: invalid email address %q", field, addr))
+			}
+		}
+	}
+	if m.From != "" && !validation.IsValidEmail(m.From) {
+		problems = append(problems, fmt.Sprintf("from: invalid email address %q", m.From))
+	}
+	for i, att := range m.Attachments {
+		if att.Path == "" {
+			problems = append(problems, fmt.Sprintf("attachments[%d]: path is required", i))
+		}
+		switch att.Disposition {
+		case "", format.DispositionAttachment, format.DispositionInline:
+		default:
+			problems = append(problems, fmt.Sprintf("attachments[%d]: disposition must be attachment or inline, not %q", i, att.Disposition))
+		}
+	}
+
+	if len(problems) == 0 {
+		return nil
+	}
+	sort.Strings(problems)
+	return errors.New(strings.Join(problems, "; "))
+}
+
+// attachmentSpecs converts the attachments to --attach style
+// "path:name:disposition" specs for prepareAttachments.
+func (m *sendManifest) attachmentSpecs() []string {
+	specs := make([]string, 0, len(m.Attachments))
+	for _, att := range m.Attachments {
+		name := att.Name
+		if name == "" {
+			name = filepath.Base(att.Path)
+		}
+		specs = append(specs, att.Path+":"+name+":"+att.Disposition)
+	}
+	return specs
+}
@@ -0,0 +1,100 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func writeManifest(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "send.yaml")
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestLoadSendManifest(t *testing.T) {
+	t.Setenv("MANIFEST_TO", "alice@example.com")
+	t.Setenv("REPORT_DIR", "/srv/reports")
+	path := writeManifest(t, `to: ["${MANIFEST_TO}"]
+cc: [bob@example.com]
+subject: Q4 report
+body: |
+  Numbers attached.
+attachments:
+  - q4.pdf
+  - notes.txt:Notes.txt
+  - logo.png:logo.png:inline
+  - path: ${REPORT_DIR}/summary.xlsx
+    name: Summary.xlsx
+`)
+
+	m, err := loadSendManifest(path)
+	if err != nil {
+		t.Fatalf("loadSendManifest: %v", err)
+	}
+	if len(m.To) != 1 || m.To[0] != "alice@example.com" {
+		t.Errorf("To = %v", m.To)
+	}
+	if m.Body != "Numbers attached.\n" {
+		t.Errorf("Body = %q", m.Body)
+	}
+
+	dir := filepath.Dir(path)
+	want := []string{
+		filepath.Join(dir, "q4.pdf") + ":q4.pdf:attachment",
+		filepath.Join(dir, "notes.txt") + ":Notes.txt:attachment",
+		filepath.Join(dir, "logo.png") + ":logo.png:inline",
+		"/srv/reports/summary.xlsx:Summary.xlsx:",
+	}
+	got := m.attachmentSpecs()
+	if strings.Join(got, "|") != strings.Join(want, "|") {
+		t.Errorf("attachmentSpecs() = %v, want %v", got, want)
+	}
+}
+
+func TestLoadSendManifestErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    []string
+	}{
+		{
+			name:    "missing fields",
+			content: "cc: [not-an-address]\n",
+			want:    []string{"to: at least one recipient", "subject: is required", "body or html", `cc: invalid email address "not-an-address"`},
+		},
+		{
+			name:    "unset env",
+			content: "to: [a@example.com]\nsubject: ${MANIFEST_UNSET_A}${MANIFEST_UNSET_B}\nbody: x\n",
+			want:    []string{"MANIFEST_UNSET_A, MANIFEST_UNSET_B"},
+		},
+		{
+			name:    "bad disposition",
+			content: "to: [a@example.com]\nsubject: s\nbody: x\nattachments:\n  - path: a.png\n    disposition: embedded\n",
+			want:    []string{`attachments[0]: disposition must be attachment or inline, not "embedded"`},
+		},
+		{
+			name:    "unknown field",
+			content: "to: [a@example.com]\nsubjct: typo\n",
+			want:    []string{"subjct"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := loadSendManifest(writeManifest(t, tt.content))
+			if err == nil {
+				t.Fatal("expected error")
+			}
+			for _, want := range tt.want {
+				if !strings.Contains(err.Error(), want) {
+					t.Errorf("error %q does not contain %q", err, want)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,58 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/salmonumbrella/fastmail-cli/internal/config"
+	"github.com/spf13/cobra"
+)
+
+// loadSettings reads the config file (--config, or the default path) and
+// fills in the root flags it covers that weren't given on the command line
+// or through their environment variable. A default config file that can't
+// be read only gets a warning, so a typo in it doesn't break every command;
+// a file named with --config must load.
+func (a *App) loadSettings(cmd *cobra.Command) error {
+	settings, err := config.LoadSettings(a.Flags.Config)
+	if err != nil {
+		if a.Flags.Config != "" {
+			return err
+		}
+		fmt.Fprintf(os.Stderr, "Warning: ignoring config file: %v\n", err)
+		return nil
+	}
+	a.Settings = settings
+
+	if settings.Color != "" && !flagChanged(cmd, "color") && os.Getenv("FASTMAIL_COLOR") == "" {
+		a.Flags.Color = settings.Color
+	}
+	if settings.DateFormat != "" && !flagChanged(cmd, "date-format") {
+		a.Flags.DateFormat = settings.DateFormat
+	}
+	return nil
+}
+
+// applyDefaultLimit replaces the built-in --limit with the config file's
+// default_limit unless --limit was given.
+func (a *App) applyDefaultLimit(cmd *cobra.Command, limit *int) {
+	if a.Settings.DefaultLimit > 0 && !flagChanged(cmd, "limit") {
+		*limit = a.Settings.DefaultLimit
+	}
+}
+
+// DefaultFrom returns the address to send from when --from isn't given:
+// the account's default identity, else the config file's default_from.
+func (a *App) DefaultFrom(account string) string {
+	if account != "" {
+		if identity, _ := config.GetDefaultIdentity(account); identity != "" {
+			return identity
+		}
+	}
+	return a.Settings.DefaultFrom
+}
+
+func flagChanged(cmd *cobra.Command, name string) bool {
+	f := cmd.Flag(name)
+	return f != nil && f.Changed
+}
@@ -0,0 +1,81 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/salmonumbrella/fastmail-cli/internal/config"
+	"github.com/spf13/cobra"
+)
+
+func TestLoadSettings_Precedence(t *testing.T) {
+	t.Setenv("FASTMAIL_COLOR", "")
+	path := filepath.Join(t.TempDir(), "config")
+	if err := os.WriteFile(path, []byte("color: never\ndate_format: \"Jan 2\"\ndefault_limit: 40\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	app := NewApp()
+	root := NewRootCmd(app)
+	if err := root.ParseFlags([]string{"--config", path, "--date-format", "2006"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := app.loadSettings(root); err != nil {
+		t.Fatalf("loadSettings: %v", err)
+	}
+
+	if app.Flags.Color != "never" {
+		t.Errorf("Color = %q, want the config file's never", app.Flags.Color)
+	}
+	if app.Flags.DateFormat != "2006" {
+		t.Errorf("DateFormat = %q, want the --date-format flag to win", app.Flags.DateFormat)
+	}
+
+	cmd := &cobra.Command{}
+	var limit int
+	cmd.Flags().IntVar(&limit, "limit", 25, "")
+	app.applyDefaultLimit(cmd, &limit)
+	if limit != 40 {
+		t.Errorf("limit = %d, want default_limit 40", limit)
+	}
+
+	_ = cmd.Flags().Set("limit", "5")
+	app.applyDefaultLimit(cmd, &limit)
+	if limit != 5 {
+		t.Errorf("limit = %d, want --limit 5 to win", limit)
+	}
+}
+
+func TestLoadSettings_MissingExplicitFile(t *testing.T) {
+	app := NewApp()
+	app.Flags.Config = filepath.Join(t.TempDir(), "missing")
+	if err := app.loadSettings(&cobra.Command{}); err == nil {
+		t.Fatal("expected error for a missing --config file")
+	}
+}
+
+func TestLoadSettings_MalformedDefaultFileWarns(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", dir)
+	path, err := config.SettingsPath()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte("colour: never\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	app := NewApp()
+	if err := app.loadSettings(&cobra.Command{}); err != nil {
+		t.Fatalf("loadSettings() error = %v, want a warning only", err)
+	}
+
+	app.Flags.Config = path
+	if err := app.loadSettings(&cobra.Command{}); err == nil {
+		t.Fatal("expected error for a malformed --config file")
+	}
+}
@@ -58,3 +58,9 @@ func newMailboxesShortcutCmd(app *App) *cobra.Command {
 	cmd.Short = "List mailboxes (shortcut for 'fastmail email mailboxes')"
 	return cmd
 }
+
+func newInboxStatusShortcutCmd(app *App) *cobra.Command {
+	cmd := newEmailInboxStatusCmd(app)
+	cmd.Short = "Show unread and total counts (shortcut for 'fastmail email inbox-status')"
+	return cmd
+}
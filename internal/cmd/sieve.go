@@ -114,6 +114,10 @@ To get these credentials:
 }
 
 func (app *App) SieveClient() (*jmap.SieveClient, error) {
+	if err := app.requireJMAPOnly("Sieve"); err != nil {
+		return nil, err
+	}
+
 	accountEmail, err := app.RequireAccount()
 	if err != nil {
 		return nil, err
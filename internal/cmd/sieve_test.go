@@ -1,6 +1,9 @@
 package cmd
 
-import "testing"
+import (
+	"strings"
+	"testing"
+)
 
 func TestSieveCmdStructure(t *testing.T) {
 	app := newTestApp()
@@ -66,3 +69,16 @@ func TestSieveEditFlags(t *testing.T) {
 		t.Error("expected --block flag")
 	}
 }
+
+func TestSieveClientRefusesDryRunRequests(t *testing.T) {
+	app := newTestApp()
+	app.Flags.DryRunRequests = true
+
+	_, err := app.SieveClient()
+	if err == nil || !strings.Contains(err.Error(), "--dry-run-requests") {
+		t.Fatalf("SieveClient() error = %v, want --dry-run-requests refusal", err)
+	}
+	if _, err := app.WebDAVClient(); err == nil {
+		t.Fatal("WebDAVClient() should fail under --dry-run-requests")
+	}
+}
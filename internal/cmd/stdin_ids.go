@@ -0,0 +1,50 @@
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"golang.org/x/term"
+)
+
+// readIDArgs returns the IDs a bulk command acts on. They come from args,
+// or from stdin (one per line) when args is just "-", or empty with stdin
+// not a terminal. This lets search output be piped in without xargs and
+// its argument-length limits.
+func readIDArgs(cmd *cobra.Command, args []string) ([]string, error) {
+	fromStdin := len(args) == 1 && args[0] == "-"
+	if len(args) == 0 {
+		in := cmd.InOrStdin()
+		f, isFile := in.(*os.File)
+		fromStdin = !isFile || !term.IsTerminal(int(f.Fd()))
+	}
+	ids := args
+	if fromStdin {
+		var err error
+		ids, err = readIDLines(cmd.InOrStdin())
+		if err != nil {
+			return nil, fmt.Errorf("reading IDs from stdin: %w", err)
+		}
+	}
+	if len(ids) == 0 {
+		return nil, fmt.Errorf("requires at least 1 arg(s): pass IDs as arguments or one per line on stdin")
+	}
+	return ids, nil
+}
+
+// readIDLines reads newline-separated IDs, trimming whitespace and skipping
+// blank lines.
+func readIDLines(r io.Reader) ([]string, error) {
+	var ids []string
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		if id := strings.TrimSpace(scanner.Text()); id != "" {
+			ids = append(ids, id)
+		}
+	}
+	return ids, scanner.Err()
+}
@@ -0,0 +1,57 @@
+package cmd
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+)
+
+func TestReadIDArgs(t *testing.T) {
+	tests := []struct {
+		name  string
+		args  []string
+		stdin string
+		want  []string
+	}{
+		{"args", []string{"a", "b"}, "ignored\n", []string{"a", "b"}},
+		{"dash", []string{"-"}, " a \n\n\tb\r\nc", []string{"a", "b", "c"}},
+		{"no args with piped stdin", nil, "a\nb\n", []string{"a", "b"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd := &cobra.Command{}
+			cmd.SetIn(strings.NewReader(tt.stdin))
+			got, err := readIDArgs(cmd, tt.args)
+			if err != nil {
+				t.Fatalf("readIDArgs() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("readIDArgs() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+
+	cmd := &cobra.Command{}
+	cmd.SetIn(strings.NewReader("\n  \n"))
+	if _, err := readIDArgs(cmd, []string{"-"}); err == nil || !strings.Contains(err.Error(), "requires at least 1 arg") {
+		t.Errorf("readIDArgs() with blank stdin error = %v", err)
+	}
+}
+
+func TestEmailBulkMarkReadCmd_DryRunFromStdin(t *testing.T) {
+	app := newTestApp()
+	cmd := newEmailBulkMarkReadCmd(app)
+	cmd.SetArgs([]string{"--dry-run"})
+	cmd.SetIn(strings.NewReader("e1\ne2\n"))
+
+	out := captureStdout(t, func() {
+		if err := cmd.Execute(); err != nil {
+			t.Fatalf("Execute() error = %v", err)
+		}
+	})
+	if !strings.Contains(out, "Would mark 2 emails as read") || !strings.Contains(out, "e2") {
+		t.Errorf("output = %q", out)
+	}
+}
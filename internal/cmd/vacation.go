@@ -30,7 +30,7 @@ func newVacationGetCmd(app *App) *cobra.Command {
 		Use:   "get",
 		Short: "Get current vacation/auto-reply settings",
 		RunE: runE(app, func(cmd *cobra.Command, args []string, app *App) error {
-			client, err := app.JMAPClient()
+			client, err := app.JMAPClientFor(cmd.Context(), jmap.CapabilityVacation)
 			if err != nil {
 				return err
 			}
@@ -45,11 +45,7 @@ func newVacationGetCmd(app *App) *cobra.Command {
 			}
 
 			tw := outfmt.NewTabWriter()
-			status := "Disabled"
-			if vr.IsEnabled {
-				status = "Enabled"
-			}
-			fmt.Fprintf(tw, "Status:\t%s\n", status)
+			fmt.Fprintf(tw, "Status:\t%s\n", vacationStatus(vr, time.Now()))
 
 			if vr.FromDate != "" {
 				fmt.Fprintf(tw, "From:\t%s\n", formatVacationDate(vr.FromDate))
@@ -86,16 +82,14 @@ func newVacationSetCmd(app *App) *cobra.Command {
 
 Dates should be in RFC3339 format (e.g., 2025-12-25T00:00:00Z),
 simple date format (YYYY-MM-DD), or relative expressions like yesterday, 2h ago, or monday.
+When both are given, --from must be before --to.
 
 Examples:
   fastmail vacation set --enable --subject "Away" --body "I'm on vacation"
-  fastmail vacation set --enable --from 2025-12-20 --until 2025-12-27 --body "Away for holidays"
+  fastmail vacation set --enable --from 2025-12-20 --to 2025-12-27 --message "Away for holidays"
   fastmail vacation set --enable --subject "Out of office" --from 2025-12-20 --body "I'll respond after the holidays"`,
 		RunE: runE(app, func(cmd *cobra.Command, args []string, app *App) error {
-			client, err := app.JMAPClient()
-			if err != nil {
-				return err
-			}
+			var err error
 
 			// Parse simple date formats to RFC3339
 			if fromDate != "" {
@@ -107,9 +101,17 @@ Examples:
 			if untilDate != "" {
 				untilDate, err = parseVacationDate(untilDate)
 				if err != nil {
-					return fmt.Errorf("invalid --until date: %w", err)
+					return fmt.Errorf("invalid --to date: %w", err)
 				}
 			}
+			if err := validateVacationWindow(fromDate, untilDate); err != nil {
+				return err
+			}
+
+			client, err := app.JMAPClientFor(cmd.Context(), jmap.CapabilityVacation)
+			if err != nil {
+				return err
+			}
 
 			// Warn about unsanitized HTML
 			if htmlBody != "" && !app.IsJSON(cmd.Context()) {
@@ -131,10 +133,17 @@ Examples:
 			}
 
 			if app.IsJSON(cmd.Context()) {
-				return app.PrintJSON(cmd, map[string]any{
+				result := map[string]any{
 					"status":  "updated",
 					"enabled": enable,
-				})
+				}
+				if fromDate != "" {
+					result["fromDate"] = fromDate
+				}
+				if untilDate != "" {
+					result["toDate"] = untilDate
+				}
+				return app.PrintJSON(cmd, result)
 			}
 
 			if enable {
@@ -149,9 +158,11 @@ Examples:
 	cmd.Flags().BoolVar(&enable, "enable", false, "Enable the vacation responder")
 	cmd.Flags().StringVar(&subject, "subject", "", "Auto-reply subject line")
 	cmd.Flags().StringVar(&body, "body", "", "Auto-reply message body")
+	cmd.Flags().StringVar(&body, "message", "", "Auto-reply message body (alias for --body)")
 	cmd.Flags().StringVar(&htmlBody, "html", "", "Auto-reply HTML body (not sanitized, use with caution)")
 	cmd.Flags().StringVar(&fromDate, "from", "", "Start date (RFC3339, YYYY-MM-DD, or relative like yesterday, 2h ago, monday)")
-	cmd.Flags().StringVar(&untilDate, "until", "", "End date (RFC3339, YYYY-MM-DD, or relative like yesterday, 2h ago, monday)")
+	cmd.Flags().StringVar(&untilDate, "to", "", "End date (RFC3339, YYYY-MM-DD, or relative like yesterday, 2h ago, monday)")
+	cmd.Flags().StringVar(&untilDate, "until", "", "End date (alias for --to)")
 
 	return cmd
 }
@@ -161,7 +172,7 @@ func newVacationDisableCmd(app *App) *cobra.Command {
 		Use:   "disable",
 		Short: "Disable vacation/auto-reply",
 		RunE: runE(app, func(cmd *cobra.Command, args []string, app *App) error {
-			client, err := app.JMAPClient()
+			client, err := app.JMAPClientFor(cmd.Context(), jmap.CapabilityVacation)
 			if err != nil {
 				return err
 			}
@@ -202,6 +213,41 @@ func parseVacationDate(s string) (string, error) {
 	return t.UTC().Format(time.RFC3339), nil
 }
 
+// validateVacationWindow checks that an RFC3339 start date is before the end date.
+// Either bound may be empty (open-ended).
+func validateVacationWindow(from, until string) error {
+	if from == "" || until == "" {
+		return nil
+	}
+	start, err := time.Parse(time.RFC3339, from)
+	if err != nil {
+		return fmt.Errorf("invalid --from date: %w", err)
+	}
+	end, err := time.Parse(time.RFC3339, until)
+	if err != nil {
+		return fmt.Errorf("invalid --to date: %w", err)
+	}
+	if !start.Before(end) {
+		return fmt.Errorf("--from (%s) must be before --to (%s)", formatVacationDate(from), formatVacationDate(until))
+	}
+	return nil
+}
+
+// vacationStatus describes whether the responder is enabled and, if so,
+// whether now falls inside its date window.
+func vacationStatus(vr *jmap.VacationResponse, now time.Time) string {
+	if !vr.IsEnabled {
+		return "Disabled"
+	}
+	if from, err := time.Parse(time.RFC3339, vr.FromDate); err == nil && now.Before(from) {
+		return "Enabled (scheduled)"
+	}
+	if to, err := time.Parse(time.RFC3339, vr.ToDate); err == nil && !now.Before(to) {
+		return "Enabled (window ended)"
+	}
+	return "Enabled (active)"
+}
+
 // formatVacationDate formats an RFC3339 date for display.
 func formatVacationDate(s string) string {
 	t, err := time.Parse(time.RFC3339, s)
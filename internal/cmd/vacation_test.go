@@ -0,0 +1,91 @@
+package cmd
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	cerrors "github.com/salmonumbrella/fastmail-cli/internal/errors"
+	"github.com/salmonumbrella/fastmail-cli/internal/jmap"
+)
+
+func TestParseVacationDate_SimpleDate(t *testing.T) {
+	got, err := parseVacationDate("2025-12-20")
+	if err != nil {
+		t.Fatalf("parseVacationDate() error = %v", err)
+	}
+	if _, err := time.Parse(time.RFC3339, got); err != nil {
+		t.Errorf("parseVacationDate() = %q, not RFC3339: %v", got, err)
+	}
+
+	if _, err := parseVacationDate("not a date"); err == nil {
+		t.Error("parseVacationDate() expected error for invalid input")
+	}
+}
+
+func TestValidateVacationWindow(t *testing.T) {
+	tests := []struct {
+		name    string
+		from    string
+		until   string
+		wantErr bool
+	}{
+		{"open ended", "2025-12-20T00:00:00Z", "", false},
+		{"no start", "", "2025-12-27T00:00:00Z", false},
+		{"ordered", "2025-12-20T00:00:00Z", "2025-12-27T00:00:00Z", false},
+		{"reversed", "2025-12-27T00:00:00Z", "2025-12-20T00:00:00Z", true},
+		{"equal", "2025-12-20T00:00:00Z", "2025-12-20T00:00:00Z", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateVacationWindow(tt.from, tt.until)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("validateVacationWindow() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestVacationStatus(t *testing.T) {
+	now := time.Date(2025, 12, 22, 12, 0, 0, 0, time.UTC)
+	window := func(enabled bool, from, to string) *jmap.VacationResponse {
+		return &jmap.VacationResponse{IsEnabled: enabled, FromDate: from, ToDate: to}
+	}
+
+	tests := []struct {
+		name string
+		vr   *jmap.VacationResponse
+		want string
+	}{
+		{"disabled", window(false, "", ""), "Disabled"},
+		{"no window", window(true, "", ""), "Enabled (active)"},
+		{"inside window", window(true, "2025-12-20T00:00:00Z", "2025-12-27T00:00:00Z"), "Enabled (active)"},
+		{"before window", window(true, "2025-12-24T00:00:00Z", "2025-12-27T00:00:00Z"), "Enabled (scheduled)"},
+		{"after window", window(true, "2025-12-01T00:00:00Z", "2025-12-10T00:00:00Z"), "Enabled (window ended)"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := vacationStatus(tt.vr, now); got != tt.want {
+				t.Errorf("vacationStatus() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCapabilityError_AddsSuggestion(t *testing.T) {
+	err := capabilityError(jmap.ErrVacationNotEnabled)
+	if !errors.Is(err, jmap.ErrVacationNotEnabled) {
+		t.Fatalf("capabilityError() lost the sentinel: %v", err)
+	}
+	if !cerrors.ContainsSuggestion(err) || !strings.Contains(cerrors.GetSuggestion(err), "fastmail whoami") {
+		t.Errorf("capabilityError() = %v, want a suggestion pointing at whoami", err)
+	}
+
+	other := errors.New("boom")
+	if got := capabilityError(other); got != other {
+		t.Errorf("capabilityError(other) = %v, want it unchanged", got)
+	}
+}
@@ -0,0 +1,83 @@
+package cmd
+
+import (
+	"fmt"
+	"runtime"
+	"runtime/debug"
+
+	"github.com/salmonumbrella/fastmail-cli/internal/jmap"
+	"github.com/spf13/cobra"
+)
+
+// buildInfo describes the running binary for bug reports.
+type buildInfo struct {
+	Version      string   `json:"version"`
+	Commit       string   `json:"commit"`
+	Date         string   `json:"date"`
+	GoVersion    string   `json:"goVersion"`
+	Platform     string   `json:"platform"`
+	JMAPBaseURL  string   `json:"jmapBaseUrl"`
+	Capabilities []string `json:"capabilities"`
+}
+
+// currentBuildInfo returns the ldflags build values, falling back to the
+// module and VCS info embedded by 'go install' when they were not set.
+func currentBuildInfo() buildInfo {
+	info := buildInfo{
+		Version:      Version,
+		Commit:       Commit,
+		Date:         Date,
+		GoVersion:    runtime.Version(),
+		Platform:     runtime.GOOS + "/" + runtime.GOARCH,
+		JMAPBaseURL:  jmap.DefaultBaseURL,
+		Capabilities: jmap.TargetCapabilities,
+	}
+
+	bi, ok := debug.ReadBuildInfo()
+	if !ok {
+		return info
+	}
+	if info.Version == "dev" && bi.Main.Version != "" && bi.Main.Version != "(devel)" {
+		info.Version = bi.Main.Version
+	}
+	for _, s := range bi.Settings {
+		switch s.Key {
+		case "vcs.revision":
+			if info.Commit == "none" {
+				info.Commit = s.Value
+			}
+		case "vcs.time":
+			if info.Date == "unknown" {
+				info.Date = s.Value
+			}
+		}
+	}
+
+	return info
+}
+
+func newVersionCmd(app *App) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "version",
+		Short: "Show version and build information",
+		Example: `  fastmail version
+  fastmail version --output json`,
+		Args: cobra.NoArgs,
+		RunE: runE(app, func(cmd *cobra.Command, args []string, app *App) error {
+			info := currentBuildInfo()
+
+			if app.IsJSON(cmd.Context()) {
+				return app.PrintJSON(cmd, info)
+			}
+
+			fmt.Printf("fastmail %s\n", info.Version)
+			fmt.Printf("Commit:     %s\n", info.Commit)
+			fmt.Printf("Built:      %s\n", info.Date)
+			fmt.Printf("Go:         %s (%s)\n", info.GoVersion, info.Platform)
+			fmt.Printf("JMAP API:   %s\n", info.JMAPBaseURL)
+			return nil
+		}),
+	}
+
+	return cmd
+}
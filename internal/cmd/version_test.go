@@ -0,0 +1,47 @@
+package cmd
+
+import (
+	"encoding/json"
+	"runtime"
+	"testing"
+
+	"github.com/salmonumbrella/fastmail-cli/internal/jmap"
+)
+
+func TestExecute_VersionJSON(t *testing.T) {
+	stdout := captureStdout(t, func() {
+		if err := Execute([]string{"version", "--output", "json"}); err != nil {
+			t.Fatalf("Execute returned error: %v", err)
+		}
+	})
+
+	var info buildInfo
+	if err := json.Unmarshal([]byte(stdout), &info); err != nil {
+		t.Fatalf("stdout is not valid JSON: %v; stdout=%q", err, stdout)
+	}
+
+	if info.Version == "" || info.Commit == "" || info.Date == "" {
+		t.Errorf("missing build fields: %+v", info)
+	}
+	if info.GoVersion != runtime.Version() {
+		t.Errorf("goVersion = %q, want %q", info.GoVersion, runtime.Version())
+	}
+	if info.JMAPBaseURL != jmap.DefaultBaseURL {
+		t.Errorf("jmapBaseUrl = %q, want %q", info.JMAPBaseURL, jmap.DefaultBaseURL)
+	}
+	if len(info.Capabilities) == 0 || info.Capabilities[0] != "urn:ietf:params:jmap:core" {
+		t.Errorf("capabilities = %v, want core first", info.Capabilities)
+	}
+}
+
+func TestAppUserAgent(t *testing.T) {
+	app := newTestApp()
+	if got, want := app.UserAgent(), "fastmail-cli/"+currentBuildInfo().Version; got != want {
+		t.Errorf("UserAgent() = %q, want %q", got, want)
+	}
+
+	app.Flags.UserAgent = "my-script/1.0"
+	if got := app.UserAgent(); got != "my-script/1.0" {
+		t.Errorf("UserAgent() = %q, want the --user-agent value", got)
+	}
+}
@@ -0,0 +1,124 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	cerrors "github.com/salmonumbrella/fastmail-cli/internal/errors"
+	"github.com/salmonumbrella/fastmail-cli/internal/jmap"
+	"github.com/spf13/cobra"
+)
+
+// whoamiInfo is the JSON shape of whoami.
+type whoamiInfo struct {
+	Account         string             `json:"account"`
+	AccountID       string             `json:"accountId"`
+	Accounts        []jmap.AccountInfo `json:"accounts"`
+	DefaultIdentity string             `json:"defaultIdentity,omitempty"`
+	Identities      []string           `json:"identities"`
+	// Capabilities are the raw capability URIs, for feature detection
+	Capabilities []string `json:"capabilities"`
+}
+
+// defaultIdentityEmail picks the identity sends use without --from: the
+// configured default if it is one of identities, else the primary one.
+func defaultIdentityEmail(identities []jmap.Identity, configured string) string {
+	for _, id := range identities {
+		if configured != "" && strings.EqualFold(id.Email, configured) {
+			return id.Email
+		}
+	}
+	for _, id := range identities {
+		if !id.MayDelete {
+			return id.Email
+		}
+	}
+	return configured
+}
+
+func newWhoamiCmd(app *App) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "whoami",
+		Short: "Show the signed-in account, its identities and enabled features",
+		Long: `Show which account the CLI is using: the account ID (and any other
+accounts the token can reach), the default sending identity, all identities,
+and the JMAP capabilities the API token grants (mail, submission, contacts,
+calendars, masked email, vacation...). The JSON output lists the raw
+capability URIs.
+
+Examples:
+  fastmail whoami
+  fastmail whoami --output json | jq -r '.capabilities[]'`,
+		Args: cobra.NoArgs,
+		RunE: runE(app, func(cmd *cobra.Command, args []string, app *App) error {
+			account, err := app.RequireAccount()
+			if err != nil {
+				return err
+			}
+			client, err := app.JMAPClient()
+			if err != nil {
+				return err
+			}
+
+			session, err := client.GetSession(cmd.Context())
+			if err != nil {
+				return cerrors.WithContext(err, "fetching session")
+			}
+
+			info := whoamiInfo{
+				Account:      account,
+				AccountID:    session.AccountID,
+				Accounts:     session.Accounts,
+				Identities:   []string{},
+				Capabilities: session.CapabilityURIs(),
+			}
+
+			// Identities need the submission scope; report the rest without them
+			var identitiesErr error
+			if session.HasCapability("urn:ietf:params:jmap:submission") {
+				identities, err := client.GetIdentities(cmd.Context())
+				if err != nil {
+					identitiesErr = err
+				}
+				for _, id := range identities {
+					info.Identities = append(info.Identities, id.Email)
+				}
+				info.DefaultIdentity = defaultIdentityEmail(identities, app.DefaultFrom(account))
+			}
+
+			if app.IsJSON(cmd.Context()) {
+				return app.PrintJSON(cmd, info)
+			}
+
+			fmt.Printf("Account:          %s\n", info.Account)
+			fmt.Printf("Account ID:       %s\n", info.AccountID)
+			if len(info.Accounts) > 1 {
+				others := make([]string, 0, len(info.Accounts)-1)
+				for _, acc := range info.Accounts {
+					if acc.ID != info.AccountID {
+						others = append(others, fmt.Sprintf("%s (%s)", acc.ID, acc.Name))
+					}
+				}
+				fmt.Printf("Other accounts:   %s\n", strings.Join(others, ", "))
+			}
+			switch {
+			case identitiesErr != nil:
+				fmt.Printf("Identities:       unavailable (%v)\n", identitiesErr)
+			case len(info.Identities) > 0:
+				fmt.Printf("Default identity: %s\n", info.DefaultIdentity)
+				fmt.Printf("Identities:       %s\n", strings.Join(info.Identities, ", "))
+			default:
+				fmt.Println("Identities:       none (the token lacks the submission scope)")
+			}
+
+			names := make([]string, len(info.Capabilities))
+			for i, uri := range info.Capabilities {
+				names[i] = jmap.CapabilityName(uri)
+			}
+			fmt.Printf("Capabilities:     %s\n", strings.Join(names, ", "))
+			return nil
+		}),
+	}
+
+	return cmd
+}
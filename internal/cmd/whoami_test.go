@@ -0,0 +1,35 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/salmonumbrella/fastmail-cli/internal/jmap"
+)
+
+func TestDefaultIdentityEmail(t *testing.T) {
+	identities := []jmap.Identity{
+		{Email: "alias@example.com", MayDelete: true},
+		{Email: "me@example.com", MayDelete: false},
+	}
+
+	tests := []struct {
+		name       string
+		configured string
+		want       string
+	}{
+		{"primary when nothing configured", "", "me@example.com"},
+		{"configured identity", "ALIAS@example.com", "alias@example.com"},
+		{"configured address that isn't an identity", "masked@example.com", "me@example.com"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := defaultIdentityEmail(identities, tt.configured); got != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+
+	if got := defaultIdentityEmail(nil, "me@example.com"); got != "me@example.com" {
+		t.Errorf("no identities: got %q, want the configured address", got)
+	}
+}
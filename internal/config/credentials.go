@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"os"
+	"sort"
 	"strings"
 	"time"
 
@@ -349,6 +350,48 @@ func ListTokens() ([]Token, error) {
 	return tokens, nil
 }
 
+// ResolveAccount returns the stored account that name refers to: an
+// account email, an account's default sending identity, or, for a name
+// without '@', the local part of an account email ("work" for
+// work@example.com). Matching is case-insensitive and an exact account
+// email wins. It is an error if no account or several accounts match.
+func ResolveAccount(tokens []Token, name string) (string, error) {
+	name = normalize(name)
+	if name == "" {
+		return "", fmt.Errorf("missing account")
+	}
+
+	var matches []string
+	for _, t := range tokens {
+		email := normalize(t.Email)
+		if email == name {
+			return t.Email, nil
+		}
+		local, _, _ := strings.Cut(email, "@")
+		if normalize(t.DefaultIdentity) == name || (!strings.Contains(name, "@") && local == name) {
+			matches = append(matches, t.Email)
+		}
+	}
+
+	switch len(matches) {
+	case 1:
+		return matches[0], nil
+	case 0:
+		if len(tokens) == 0 {
+			return "", fmt.Errorf("account not found: %s (no accounts configured: run 'fastmail auth')", name)
+		}
+		accounts := make([]string, len(tokens))
+		for i, t := range tokens {
+			accounts[i] = t.Email
+		}
+		sort.Strings(accounts)
+		return "", fmt.Errorf("account not found: %s (configured: %s)", name, strings.Join(accounts, ", "))
+	default:
+		sort.Strings(matches)
+		return "", fmt.Errorf("%s matches several accounts: %s; use the full account email", name, strings.Join(matches, ", "))
+	}
+}
+
 func parseTokenKey(k string) (email string, ok bool) {
 	const prefix = "token:"
 	if !strings.HasPrefix(k, prefix) {
@@ -2,6 +2,7 @@ package config
 
 import (
 	"encoding/json"
+	"strings"
 	"testing"
 
 	"github.com/99designs/keyring"
@@ -212,3 +213,45 @@ func TestListTokens_IncludesDefaultIdentity(t *testing.T) {
 		t.Errorf("Token.DefaultIdentity = %q, want %q", tokens[0].DefaultIdentity, identityEmail)
 	}
 }
+
+func TestResolveAccount(t *testing.T) {
+	tokens := []Token{
+		{Email: "me@example.com", DefaultIdentity: "alias@example.com"},
+		{Email: "work@company.com"},
+		{Email: "work@other.org"},
+	}
+
+	tests := []struct {
+		name    string
+		input   string
+		want    string
+		wantErr string
+	}{
+		{name: "exact", input: "me@example.com", want: "me@example.com"},
+		{name: "case-insensitive", input: " ME@Example.com ", want: "me@example.com"},
+		{name: "default identity", input: "Alias@example.com", want: "me@example.com"},
+		{name: "local part", input: "me", want: "me@example.com"},
+		{name: "exact beats local part", input: "work@other.org", want: "work@other.org"},
+		{name: "ambiguous", input: "work", wantErr: "work@company.com, work@other.org"},
+		{name: "unknown", input: "nobody@example.com", wantErr: "configured: me@example.com, work@company.com, work@other.org"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ResolveAccount(tokens, tt.input)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("ResolveAccount(%q) error = %v, want it to contain %q", tt.input, err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil || got != tt.want {
+				t.Errorf("ResolveAccount(%q) = %q, %v; want %q", tt.input, got, err, tt.want)
+			}
+		})
+	}
+
+	if _, err := ResolveAccount(nil, "me@example.com"); err == nil || !strings.Contains(err.Error(), "fastmail auth") {
+		t.Errorf("expected a hint to run auth without accounts, got %v", err)
+	}
+}
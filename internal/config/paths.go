@@ -1,3 +1,24 @@
 package config
 
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
 const AppName = "fastmail-cli"
+
+// Path returns the path of name in the CLI's config directory,
+// $XDG_CONFIG_HOME/fastmail-cli (or the OS equivalent).
+func Path(name string) (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", fmt.Errorf("user config dir: %w", err)
+	}
+	return filepath.Join(dir, AppName, name), nil
+}
+
+// SessionCacheDir returns the directory holding cached JMAP sessions.
+func SessionCacheDir() (string, error) {
+	return Path("sessions")
+}
@@ -0,0 +1,99 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"regexp"
+)
+
+// Rule moves emails whose sender and/or subject match to Mailbox.
+// From and Subject are case-insensitive substrings, or regular expressions
+// when Regex is set. When both are given, both must match.
+type Rule struct {
+	Name    string `json:"name,omitempty"`
+	From    string `json:"from,omitempty"`
+	Subject string `json:"subject,omitempty"`
+	Regex   bool   `json:"regex,omitempty"`
+	Mailbox string `json:"mailbox"`
+}
+
+// rulesFile is the on-disk layout of the rules file.
+type rulesFile struct {
+	Rules []Rule `json:"rules"`
+}
+
+// RulesPath returns the path to the client-side foldering rules file.
+func RulesPath() (string, error) {
+	return Path("rules.json")
+}
+
+// LoadRules reads and validates the rules file. A missing file yields no rules.
+func LoadRules() ([]Rule, error) {
+	path, err := RulesPath()
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("read rules: %w", err)
+	}
+
+	return ParseRules(data)
+}
+
+// ParseRules decodes and validates rules file content.
+func ParseRules(data []byte) ([]Rule, error) {
+	var file rulesFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("parse rules: %w", err)
+	}
+
+	for i, rule := range file.Rules {
+		if err := rule.Validate(); err != nil {
+			return nil, fmt.Errorf("rule %d (%s): %w", i+1, rule.Label(), err)
+		}
+	}
+	return file.Rules, nil
+}
+
+// Validate checks that the rule has a target mailbox, at least one pattern,
+// and that regex patterns compile.
+func (r Rule) Validate() error {
+	if r.Mailbox == "" {
+		return fmt.Errorf("mailbox is required")
+	}
+	if r.From == "" && r.Subject == "" {
+		return fmt.Errorf("from or subject is required")
+	}
+	if r.Regex {
+		for _, pattern := range []string{r.From, r.Subject} {
+			if pattern == "" {
+				continue
+			}
+			if _, err := regexp.Compile(pattern); err != nil {
+				return fmt.Errorf("invalid regex %q: %w", pattern, err)
+			}
+		}
+	}
+	return nil
+}
+
+// Label returns the rule's name, or a description built from its patterns.
+func (r Rule) Label() string {
+	if r.Name != "" {
+		return r.Name
+	}
+	switch {
+	case r.From != "" && r.Subject != "":
+		return fmt.Sprintf("from:%s subject:%s", r.From, r.Subject)
+	case r.From != "":
+		return "from:" + r.From
+	default:
+		return "subject:" + r.Subject
+	}
+}
@@ -0,0 +1,32 @@
+package config
+
+import "testing"
+
+func TestParseRules(t *testing.T) {
+	rules, err := ParseRules([]byte(`{"rules": [
+		{"name": "receipts", "from": "@shop.example.com", "mailbox": "Receipts"},
+		{"subject": "^\\[ci\\]", "regex": true, "mailbox": "CI"}
+	]}`))
+	if err != nil {
+		t.Fatalf("ParseRules: %v", err)
+	}
+	if len(rules) != 2 || rules[1].Label() != `subject:^\[ci\]` {
+		t.Fatalf("unexpected rules: %+v", rules)
+	}
+}
+
+func TestParseRules_Invalid(t *testing.T) {
+	tests := map[string]string{
+		"missing mailbox": `{"rules": [{"from": "a@example.com"}]}`,
+		"missing pattern": `{"rules": [{"mailbox": "Archive"}]}`,
+		"bad regex":       `{"rules": [{"subject": "(", "regex": true, "mailbox": "Archive"}]}`,
+		"bad json":        `{"rules": [`,
+	}
+	for name, data := range tests {
+		t.Run(name, func(t *testing.T) {
+			if _, err := ParseRules([]byte(data)); err == nil {
+				t.Fatal("expected error")
+			}
+		})
+	}
+}
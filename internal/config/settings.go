@@ -0,0 +1,82 @@
+package config
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Settings are the user defaults read from the config file. Zero values
+// mean "not set"; command-line flags and environment variables win over
+// anything set here.
+type Settings struct {
+	// DefaultLimit replaces the built-in --limit of email list and search
+	DefaultLimit int `yaml:"default_limit"`
+	// DefaultFrom is the sending address used when neither --from nor a
+	// per-account default identity is set
+	DefaultFrom string `yaml:"default_from"`
+	// DateFormat is the Go time layout for --date-format
+	DateFormat string `yaml:"date_format"`
+	// Color is auto, always or never, as for --color
+	Color string `yaml:"color"`
+}
+
+// SettingsPath returns the default config file path,
+// $XDG_CONFIG_HOME/fastmail-cli/config (or the OS equivalent).
+func SettingsPath() (string, error) {
+	return Path("config")
+}
+
+// LoadSettings reads the config file at path, or at SettingsPath when path
+// is empty. A missing default file yields empty settings; a missing file
+// given explicitly is an error.
+func LoadSettings(path string) (Settings, error) {
+	explicit := path != ""
+	if !explicit {
+		var err error
+		if path, err = SettingsPath(); err != nil {
+			return Settings{}, err
+		}
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if !explicit && os.IsNotExist(err) {
+			return Settings{}, nil
+		}
+		return Settings{}, fmt.Errorf("read config: %w", err)
+	}
+
+	settings, err := ParseSettings(data)
+	if err != nil {
+		return Settings{}, fmt.Errorf("%s: %w", path, err)
+	}
+	return settings, nil
+}
+
+// ParseSettings decodes and validates config file content (YAML).
+// Unknown keys are rejected so typos don't go unnoticed.
+func ParseSettings(data []byte) (Settings, error) {
+	var settings Settings
+
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	dec.KnownFields(true)
+	if err := dec.Decode(&settings); err != nil && !errors.Is(err, io.EOF) {
+		return Settings{}, fmt.Errorf("parse config: %w", err)
+	}
+
+	if settings.DefaultLimit < 0 {
+		return Settings{}, fmt.Errorf("default_limit must be positive")
+	}
+	switch settings.Color {
+	case "", "auto", "always", "never":
+	default:
+		return Settings{}, fmt.Errorf("color must be auto, always or never, not %q", settings.Color)
+	}
+
+	return settings, nil
+}
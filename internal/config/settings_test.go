@@ -0,0 +1,65 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestParseSettings(t *testing.T) {
+	settings, err := ParseSettings([]byte(`
+default_limit: 50
+default_from: me@example.com
+date_format: "02 Jan 2006 15:04"
+color: never
+`))
+	if err != nil {
+		t.Fatalf("ParseSettings: %v", err)
+	}
+	want := Settings{DefaultLimit: 50, DefaultFrom: "me@example.com", DateFormat: "02 Jan 2006 15:04", Color: "never"}
+	if settings != want {
+		t.Fatalf("got %+v, want %+v", settings, want)
+	}
+
+	if settings, err := ParseSettings(nil); err != nil || settings != (Settings{}) {
+		t.Fatalf("empty file: got %+v, %v", settings, err)
+	}
+}
+
+func TestParseSettings_Invalid(t *testing.T) {
+	tests := map[string]string{
+		"unknown key":    "default_limt: 10",
+		"negative limit": "default_limit: -1",
+		"bad color":      "color: sometimes",
+		"bad yaml":       "default_limit: [",
+	}
+	for name, data := range tests {
+		t.Run(name, func(t *testing.T) {
+			if _, err := ParseSettings([]byte(data)); err == nil {
+				t.Fatal("expected error")
+			}
+		})
+	}
+}
+
+func TestLoadSettings(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	t.Setenv("HOME", t.TempDir())
+
+	// A missing default file is fine, a missing explicit one isn't
+	if _, err := LoadSettings(""); err != nil {
+		t.Fatalf("missing default file: %v", err)
+	}
+	if _, err := LoadSettings(filepath.Join(t.TempDir(), "nope")); err == nil {
+		t.Fatal("expected error for a missing --config file")
+	}
+
+	path := filepath.Join(t.TempDir(), "config")
+	if err := os.WriteFile(path, []byte("default_limit: 10\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	settings, err := LoadSettings(path)
+	if err != nil || settings.DefaultLimit != 10 {
+		t.Fatalf("got %+v, %v", settings, err)
+	}
+}
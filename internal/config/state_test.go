@@ -0,0 +1,54 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestJSONStateRoundTrip(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+
+	type state struct {
+		Items map[string]int `json:"items"`
+	}
+
+	var loaded state
+	if err := LoadJSONState("test.json", "test state", &loaded); err != nil {
+		t.Fatalf("LoadJSONState() on a missing file error = %v", err)
+	}
+	if loaded.Items != nil {
+		t.Fatalf("missing file filled in %v", loaded.Items)
+	}
+
+	if err := SaveJSONState("test.json", "test state", state{Items: map[string]int{"a": 1}}); err != nil {
+		t.Fatalf("SaveJSONState() error = %v", err)
+	}
+	path, err := Path("test.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if perm := info.Mode().Perm(); perm != 0o600 {
+		t.Errorf("file mode = %o, want 600", perm)
+	}
+
+	if err := LoadJSONState("test.json", "test state", &loaded); err != nil {
+		t.Fatalf("LoadJSONState() error = %v", err)
+	}
+	if loaded.Items["a"] != 1 {
+		t.Errorf("Items = %v, want a=1", loaded.Items)
+	}
+
+	if err := os.WriteFile(filepath.Join(filepath.Dir(path), "bad.json"), []byte("{"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	err = LoadJSONState("bad.json", "test state", &loaded)
+	if err == nil || !strings.HasPrefix(err.Error(), "parse test state:") {
+		t.Errorf("LoadJSONState() on bad JSON error = %v, want parse test state", err)
+	}
+}
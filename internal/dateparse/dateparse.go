@@ -13,7 +13,7 @@ func ParseDateTimeNow(s string) (time.Time, error) {
 	return ParseDateTime(s, time.Now())
 }
 
-// ParseDateTime parses RFC3339, YYYY-MM-DD, or relative expressions like yesterday, 2h ago, or monday.
+// ParseDateTime parses RFC3339, YYYY-MM-DD, local YYYY-MM-DDTHH:MM, or relative expressions like yesterday, 2h ago, or monday.
 func ParseDateTime(s string, now time.Time) (time.Time, error) {
 	raw := strings.TrimSpace(s)
 	if raw == "" {
@@ -50,6 +50,13 @@ func ParseDateTime(s string, now time.Time) (time.Time, error) {
 		return t, nil
 	}
 
+	// Local date-times without a zone, e.g. 2025-01-10T09:00
+	for _, layout := range []string{"2006-01-02T15:04:05", "2006-01-02T15:04", "2006-01-02 15:04"} {
+		if t, err := time.ParseInLocation(layout, raw, now.Location()); err == nil {
+			return t, nil
+		}
+	}
+
 	return time.Time{}, fmt.Errorf("invalid date %q", raw)
 }
 
@@ -142,6 +142,21 @@ func TestParseDateTime_Absolute(t *testing.T) {
 	}
 }
 
+func TestParseDateTime_LocalDateTime(t *testing.T) {
+	loc := time.FixedZone("Test", -5*60*60)
+	now := time.Date(2025, 1, 15, 10, 30, 0, 0, loc)
+
+	got, err := ParseDateTime("2025-01-20T09:00", now)
+	if err != nil {
+		t.Fatalf("ParseDateTime local date-time error = %v", err)
+	}
+
+	want := time.Date(2025, 1, 20, 9, 0, 0, 0, loc)
+	if !got.Equal(want) {
+		t.Fatalf("ParseDateTime local date-time = %v, want %v", got, want)
+	}
+}
+
 func TestParseDateTime_Invalid(t *testing.T) {
 	now := time.Date(2025, 1, 15, 10, 30, 0, 0, time.UTC)
 	if _, err := ParseDateTime("not-a-date", now); err == nil {
@@ -9,12 +9,24 @@ import (
 // Common suggestion constants for user-facing error messages
 const (
 	SuggestionReauth        = "Run 'fastmail auth' to re-authenticate"
+	SuggestionNewToken      = "Your API token is no longer valid; create a new one in Fastmail settings and run 'fastmail auth'"
+	SuggestionReenterToken  = "Check that the API token was copied completely, then run 'fastmail auth add <email>' to re-enter it"
 	SuggestionCheckEmail    = "Verify your email address is correct"
 	SuggestionCheckNet      = "Check your network connection and try again"
 	SuggestionListIdentity  = "Run 'fastmail email identities' to see available sending addresses"
 	SuggestionUnlockKeyring = "Unlock your system keyring and retry"
 )
 
+// SuggestionReauthForAccount returns a reauth suggestion naming the account
+// and the exact command that replaces its token. An empty name yields
+// SuggestionReauth.
+func SuggestionReauthForAccount(name string) string {
+	if name == "" {
+		return SuggestionReauth
+	}
+	return fmt.Sprintf("Run 'fastmail auth add %s' to re-authenticate %s", name, name)
+}
+
 // ContextError wraps an error with additional context and optional user-facing suggestion.
 type ContextError struct {
 	Context    string // Contextual information (e.g., "while fetching emails")
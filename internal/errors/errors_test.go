@@ -175,6 +175,18 @@ func TestSuggestionConstants(t *testing.T) {
 	}
 }
 
+func TestSuggestionReauthForAccount(t *testing.T) {
+	got := SuggestionReauthForAccount("work@fastmail.com")
+	want := "Run 'fastmail auth add work@fastmail.com' to re-authenticate work@fastmail.com"
+	if got != want {
+		t.Errorf("SuggestionReauthForAccount() = %q, want %q", got, want)
+	}
+
+	if got := SuggestionReauthForAccount(""); got != SuggestionReauth {
+		t.Errorf("SuggestionReauthForAccount(\"\") = %q, want %q", got, SuggestionReauth)
+	}
+}
+
 func TestContextError_WrappedSuggestion(t *testing.T) {
 	// Create a ContextError with a suggestion
 	baseErr := errors.New("auth failed")
@@ -14,6 +14,25 @@ func Apply(data any, expression string) (any, error) {
 		return data, nil
 	}
 
+	results, err := ApplyAll(data, expression)
+	if err != nil {
+		return nil, err
+	}
+
+	// Return single result unwrapped, multiple as array
+	if len(results) == 1 {
+		return results[0], nil
+	}
+	return results, nil
+}
+
+// ApplyAll applies a JQ filter expression and returns every value it
+// produces, so a single array result can be told apart from several values.
+func ApplyAll(data any, expression string) ([]any, error) {
+	if expression == "" {
+		return []any{data}, nil
+	}
+
 	query, err := gojq.Parse(expression)
 	if err != nil {
 		return nil, fmt.Errorf("invalid filter expression: %w", err)
@@ -32,11 +51,6 @@ func Apply(data any, expression string) (any, error) {
 		}
 		results = append(results, v)
 	}
-
-	// Return single result unwrapped, multiple as array
-	if len(results) == 1 {
-		return results[0], nil
-	}
 	return results, nil
 }
 
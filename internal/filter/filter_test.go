@@ -84,3 +84,23 @@ func TestApplyToJSON(t *testing.T) {
 		t.Errorf("ApplyToJSON() = %s, want %s", result, expected)
 	}
 }
+
+func TestApplyAll(t *testing.T) {
+	data := map[string]any{"ids": []any{"a", "b"}}
+
+	got, err := ApplyAll(data, ".ids[]")
+	if err != nil {
+		t.Fatalf("ApplyAll() error = %v", err)
+	}
+	if !reflect.DeepEqual(got, []any{"a", "b"}) {
+		t.Errorf("ApplyAll() = %v, want two values", got)
+	}
+
+	got, err = ApplyAll(data, ".ids")
+	if err != nil {
+		t.Fatalf("ApplyAll() error = %v", err)
+	}
+	if !reflect.DeepEqual(got, []any{[]any{"a", "b"}}) {
+		t.Errorf("ApplyAll() = %v, want one array value", got)
+	}
+}
@@ -6,35 +6,49 @@ import (
 	"strings"
 )
 
+// Attachment dispositions accepted by ParseAttachmentFlag.
+const (
+	DispositionAttachment = "attachment"
+	DispositionInline     = "inline"
+)
+
 // ParseAttachmentFlag parses an attachment flag value.
-// Format: /path/to/file[:displayname]
-// Returns the file path and display name (defaults to basename if not specified).
-func ParseAttachmentFlag(value string) (path, name string, err error) {
+// Format: /path/to/file[:displayname[:disposition]]
+// Returns the file path, the display name (defaults to basename if empty or
+// not specified) and the disposition ("attachment" unless "inline" is given).
+// The last field is a disposition only if it is attachment or inline;
+// otherwise the value splits at its last colon, so /tmp/a:b:name.pdf is the
+// file /tmp/a:b named name.pdf.
+// A leading Windows drive letter such as C:\ is part of the path.
+func ParseAttachmentFlag(value string) (path, name, disposition string, err error) {
 	if value == "" {
-		return "", "", fmt.Errorf("attachment path cannot be empty")
+		return "", "", "", fmt.Errorf("attachment path cannot be empty")
 	}
 
-	// Check for custom name separator (last colon that's not part of Windows drive letter)
-	// Handle Windows paths like C:\path\file.pdf
-	lastColon := strings.LastIndex(value, ":")
-
-	// On Windows, skip the drive letter colon (e.g., C:)
-	isWindowsDrive := lastColon == 1 && len(value) > 2 && (value[2] == '\\' || value[2] == '/')
+	// On Windows, skip the drive letter colon (e.g., C:\ or C:/)
+	drive := ""
+	if len(value) > 2 && value[1] == ':' && (value[2] == '\\' || value[2] == '/') {
+		drive, value = value[:2], value[2:]
+	}
 
-	if lastColon > 1 && !isWindowsDrive {
-		// Found a colon for custom name
-		path = value[:lastColon]
-		name = value[lastColon+1:]
-		if name == "" {
-			name = filepath.Base(path)
+	path, disposition = value, DispositionAttachment
+	if i := strings.LastIndex(value, ":"); i >= 0 {
+		path, name = value[:i], value[i+1:]
+		if d := strings.ToLower(name); d == DispositionAttachment || d == DispositionInline {
+			if j := strings.LastIndex(path, ":"); j >= 0 {
+				path, name, disposition = path[:j], path[j+1:], d
+			}
 		}
-		return path, name, nil
 	}
 
-	// No custom name specified (or Windows drive letter)
-	path = value
-	name = filepath.Base(path)
-	return path, name, nil
+	path = drive + path
+	if path == drive {
+		return "", "", "", fmt.Errorf("attachment path cannot be empty")
+	}
+	if name == "" {
+		name = filepath.Base(path)
+	}
+	return path, name, disposition, nil
 }
 
 // MimeType returns the MIME type for a file based on extension.
@@ -0,0 +1,117 @@
+package format
+
+import (
+	"fmt"
+	"time"
+)
+
+// DefaultDateLayout is the layout dates are shown in unless --date-format is given.
+const DefaultDateLayout = "2006-01-02 15:04"
+
+// DateStyle controls how dates are displayed in text output.
+type DateStyle struct {
+	// Layout is a Go time layout ("" = DefaultDateLayout)
+	Layout string
+	// Relative shows recent dates as "3h ago", "yesterday", "in 2d", ...
+	Relative bool
+	// Location is the display time zone (nil = local time)
+	Location *time.Location
+	// Now is the reference time for relative dates (zero = time.Now)
+	Now time.Time
+}
+
+// Format formats t in the style's time zone.
+func (s DateStyle) Format(t time.Time) string {
+	t = t.In(s.location())
+	if s.Relative {
+		now := s.Now
+		if now.IsZero() {
+			now = time.Now()
+		}
+		if rel := RelativeTime(t, now.In(s.location())); rel != "" {
+			return rel
+		}
+		if s.Layout == "" {
+			if t.Year() == now.In(s.location()).Year() {
+				return t.Format("Jan 2")
+			}
+			return t.Format("2006-01-02")
+		}
+	}
+	return t.Format(s.layout())
+}
+
+// FormatString formats an RFC 3339 timestamp such as a JMAP receivedAt.
+// Values that don't parse are returned unchanged.
+func (s DateStyle) FormatString(dateStr string) string {
+	t, err := time.Parse(time.RFC3339, dateStr)
+	if err != nil {
+		return dateStr
+	}
+	return s.Format(t)
+}
+
+func (s DateStyle) layout() string {
+	if s.Layout == "" {
+		return DefaultDateLayout
+	}
+	return s.Layout
+}
+
+func (s DateStyle) location() *time.Location {
+	if s.Location == nil {
+		return time.Local
+	}
+	return s.Location
+}
+
+// RelativeTime describes t relative to now, both in the same time zone:
+// "just now", "5m ago", "3h ago" (same day), "yesterday", "4d ago" (within
+// a week), and the future equivalents "in 5m", "in 3h", "tomorrow", "in 4d".
+// Returns "" for dates a week or more away.
+func RelativeTime(t, now time.Time) string {
+	d := now.Sub(t)
+	future := d < 0
+	if future {
+		d = -d
+	}
+	ago := func(n int, unit string) string {
+		if future {
+			return fmt.Sprintf("in %d%s", n, unit)
+		}
+		return fmt.Sprintf("%d%s ago", n, unit)
+	}
+
+	if d < time.Minute {
+		return "just now"
+	}
+	if d < time.Hour {
+		return ago(int(d/time.Minute), "m")
+	}
+
+	days := calendarDays(t, now)
+	if future {
+		days = -days
+	}
+	switch {
+	case days == 0:
+		return ago(int(d/time.Hour), "h")
+	case days == 1 && future:
+		return "tomorrow"
+	case days == 1:
+		return "yesterday"
+	case days < 7:
+		return ago(days, "d")
+	}
+	return ""
+}
+
+// calendarDays returns how many calendar days t is before now, counted on
+// dates so that daylight saving changes don't matter.
+func calendarDays(t, now time.Time) int {
+	ty, tm, td := t.Date()
+	ny, nm, nd := now.Date()
+	a := time.Date(ty, tm, td, 0, 0, 0, 0, time.UTC)
+	b := time.Date(ny, nm, nd, 0, 0, 0, 0, time.UTC)
+	return int(b.Sub(a).Hours() / 24)
+}
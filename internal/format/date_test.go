@@ -0,0 +1,67 @@
+package format
+
+import (
+	"testing"
+	"time"
+)
+
+func TestRelativeTime(t *testing.T) {
+	now := time.Date(2025, 6, 15, 14, 30, 0, 0, time.UTC)
+
+	tests := []struct {
+		name string
+		t    time.Time
+		want string
+	}{
+		{"just now", now.Add(-30 * time.Second), "just now"},
+		{"future seconds", now.Add(10 * time.Second), "just now"},
+		{"one minute", now.Add(-time.Minute), "1m ago"},
+		{"minutes", now.Add(-59 * time.Minute), "59m ago"},
+		{"hours same day", now.Add(-3 * time.Hour), "3h ago"},
+		{"early morning", time.Date(2025, 6, 15, 0, 0, 0, 0, time.UTC), "14h ago"},
+		{"late yesterday", time.Date(2025, 6, 14, 23, 59, 0, 0, time.UTC), "yesterday"},
+		{"days", now.AddDate(0, 0, -6), "6d ago"},
+		{"a week", now.AddDate(0, 0, -7), ""},
+		{"in minutes", now.Add(5 * time.Minute), "in 5m"},
+		{"in hours", now.Add(2 * time.Hour), "in 2h"},
+		{"tomorrow", time.Date(2025, 6, 16, 0, 30, 0, 0, time.UTC), "tomorrow"},
+		{"in days", now.AddDate(0, 0, 3), "in 3d"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := RelativeTime(tt.t, now); got != tt.want {
+				t.Errorf("RelativeTime() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDateStyle(t *testing.T) {
+	now := time.Date(2025, 6, 15, 14, 30, 0, 0, time.UTC)
+	tokyo := time.FixedZone("JST", 9*60*60)
+
+	tests := []struct {
+		name  string
+		style DateStyle
+		in    string
+		want  string
+	}{
+		{"default layout", DateStyle{Location: time.UTC}, "2025-06-15T10:00:00Z", "2025-06-15 10:00"},
+		{"time zone", DateStyle{Location: tokyo}, "2025-06-15T20:00:00Z", "2025-06-16 05:00"},
+		{"custom layout", DateStyle{Layout: "02 Jan 15:04", Location: time.UTC}, "2025-06-15T10:00:00Z", "15 Jun 10:00"},
+		{"relative", DateStyle{Relative: true, Location: time.UTC, Now: now}, "2025-06-15T11:30:00Z", "3h ago"},
+		{"relative this year", DateStyle{Relative: true, Location: time.UTC, Now: now}, "2025-02-01T10:00:00Z", "Feb 1"},
+		{"relative over a year", DateStyle{Relative: true, Location: time.UTC, Now: now}, "2024-06-14T10:00:00Z", "2024-06-14"},
+		{"relative with layout", DateStyle{Relative: true, Layout: "02/01/2006", Location: time.UTC, Now: now}, "2024-06-14T10:00:00Z", "14/06/2024"},
+		{"unparseable", DateStyle{}, "not a date", "not a date"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.style.FormatString(tt.in); got != tt.want {
+				t.Errorf("FormatString(%q) = %q, want %q", tt.in, got, tt.want)
+			}
+		})
+	}
+}
@@ -3,7 +3,6 @@ package format
 import (
 	"fmt"
 	"strings"
-	"time"
 
 	"github.com/salmonumbrella/fastmail-cli/internal/jmap"
 )
@@ -22,11 +21,3 @@ func FormatEmailAddressList(addrs []jmap.EmailAddress) string {
 	}
 	return strings.Join(parts, ", ")
 }
-
-func FormatEmailDate(dateStr string) string {
-	t, err := time.Parse(time.RFC3339, dateStr)
-	if err != nil {
-		return dateStr
-	}
-	return t.Format("2006-01-02 15:04")
-}
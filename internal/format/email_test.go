@@ -26,14 +26,3 @@ func TestFormatEmailAddressList(t *testing.T) {
 		})
 	}
 }
-
-func TestFormatEmailDate(t *testing.T) {
-	if got := FormatEmailDate("2024-01-15T14:30:00Z"); got != "2024-01-15 14:30" {
-		t.Fatalf("FormatEmailDate() = %q, want %q", got, "2024-01-15 14:30")
-	}
-
-	invalid := "not-a-date"
-	if got := FormatEmailDate(invalid); got != invalid {
-		t.Fatalf("FormatEmailDate(invalid) = %q, want %q", got, invalid)
-	}
-}
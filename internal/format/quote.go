@@ -0,0 +1,70 @@
+package format
+
+import (
+	"regexp"
+	"strings"
+)
+
+// quoteAttributionRE matches the line a mail client puts above a quoted
+// reply, e.g. "On Mon, Jan 15, 2024 at 10:00 AM Alice <a@example.com> wrote:",
+// in the common locales, and Outlook's "Original Message" separator.
+var quoteAttributionRE = regexp.MustCompile(`(?i)^(` +
+	`on\s.+\swrote\s?:` + // English
+	`|le\s.+\sa\s[ée]crit\s?:` + // French
+	`|am\s.+\sschrieb.*:` + // German
+	`|el\s.+\sescribi[óo]\s?:` + // Spanish
+	`|il\s.+\sha\sscritto\s?:` + // Italian
+	`|op\s.+\sschreef.*:` + // Dutch
+	`|em\s.+\sescreveu\s?:` + // Portuguese
+	`|-{2,}\s*original message\s*-{2,}` + // Outlook
+	`)$`)
+
+// StripQuotedText removes the quoted history from a plain-text reply: the
+// first attribution line ("On ... wrote:" and its translations, possibly
+// wrapped over two lines, or Outlook's "Original Message" separator) and
+// everything after it, then any trailing block of ">" lines. Quotes
+// interleaved with the reply are kept. A body without quotes, or one that
+// would be left empty, is returned unchanged.
+func StripQuotedText(body string) string {
+	lines := strings.Split(strings.ReplaceAll(body, "\r\n", "\n"), "\n")
+
+	end := len(lines)
+	for i, line := range lines {
+		line = strings.TrimSpace(line)
+		if line == "" || isQuotedLine(line) {
+			continue
+		}
+		if quoteAttributionRE.MatchString(line) {
+			end = i
+			break
+		}
+		// Long attributions are often wrapped: "On ... Alice <a@example.com>\nwrote:"
+		if i+1 < len(lines) && quoteAttributionRE.MatchString(line+" "+strings.TrimSpace(lines[i+1])) {
+			end = i
+			break
+		}
+	}
+	stripped := end < len(lines)
+	lines = lines[:end]
+
+	// Drop the trailing quoted block and the blank lines around it
+	keep := len(lines)
+	for keep > 0 {
+		last := strings.TrimSpace(lines[keep-1])
+		if last != "" && !isQuotedLine(last) {
+			break
+		}
+		stripped = stripped || last != ""
+		keep--
+	}
+
+	if !stripped || keep == 0 {
+		return body
+	}
+	return strings.Join(lines[:keep], "\n")
+}
+
+// isQuotedLine reports whether a trimmed line is quoted (">", ">>", "> >"...).
+func isQuotedLine(line string) bool {
+	return strings.HasPrefix(line, ">")
+}
@@ -0,0 +1,56 @@
+package format
+
+import "testing"
+
+func TestStripQuotedText(t *testing.T) {
+	cases := []struct {
+		name string
+		in   string
+		want string
+	}{
+		{"no quotes", "Hi Bob,\n\nSounds good.\n", "Hi Bob,\n\nSounds good.\n"},
+		{
+			"english attribution",
+			"Sounds good.\n\nOn Mon, Jan 15, 2024 at 10:00 AM Alice <alice@example.com> wrote:\n> Lunch?\n",
+			"Sounds good.",
+		},
+		{
+			"wrapped attribution",
+			"Sounds good.\n\nOn Mon, Jan 15, 2024 at 10:00 AM Alice Example <alice@example.com>\nwrote:\n\n> Lunch?",
+			"Sounds good.",
+		},
+		{
+			"nested quotes",
+			"Yes.\n\n> Are you sure?\n>\n>> I think so.\n>> > really\n",
+			"Yes.",
+		},
+		{
+			"attribution drops everything after",
+			"Done.\n\nOn Tue, Bob wrote:\n> Done?\n\nOn Mon, Alice wrote:\n>> Can you?\n-- \nBob\n",
+			"Done.",
+		},
+		{"french", "D'accord.\n\nLe lun. 15 janv. 2024 à 10:00, Alice <alice@example.com> a écrit :\n> Déjeuner ?", "D'accord."},
+		{"german", "Gut.\n\nAm Mo., 15. Jan. 2024 um 10:00 Uhr schrieb Alice <alice@example.com>:\n> Mittagessen?", "Gut."},
+		{"spanish", "Vale.\n\nEl lun, 15 ene 2024 a las 10:00, Alice (<alice@example.com>) escribió:\n> ¿Comemos?", "Vale."},
+		{"italian", "Va bene.\n\nIl giorno lun 15 gen 2024 alle ore 10:00 Alice <alice@example.com> ha scritto:\n> Pranzo?", "Va bene."},
+		{"dutch", "Prima.\n\nOp ma 15 jan. 2024 om 10:00 schreef Alice <alice@example.com>:\n> Lunch?", "Prima."},
+		{"portuguese", "Certo.\n\nEm seg., 15 de jan. de 2024 às 10:00, Alice <alice@example.com> escreveu:\n> Almoço?", "Certo."},
+		{"outlook separator", "OK.\n\n-----Original Message-----\nFrom: Alice\nSubject: Lunch", "OK."},
+		{"crlf", "Sure.\r\n\r\nOn Mon, Alice wrote:\r\n> Lunch?\r\n", "Sure."},
+		{
+			"interleaved quotes kept",
+			"> First question?\nAnswer one.\n\n> Second question?\nAnswer two.\n",
+			"> First question?\nAnswer one.\n\n> Second question?\nAnswer two.\n",
+		},
+		{"attribution-like sentence kept", "On Monday she wrote: the draft is ready.", "On Monday she wrote: the draft is ready."},
+		{"only quotes kept", "> all quoted\n> text", "> all quoted\n> text"},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := StripQuotedText(tt.in); got != tt.want {
+				t.Fatalf("StripQuotedText(%q) = %q, want %q", tt.in, got, tt.want)
+			}
+		})
+	}
+}
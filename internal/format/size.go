@@ -3,6 +3,8 @@ package format
 import (
 	"fmt"
 	"math"
+	"strconv"
+	"strings"
 )
 
 // FormatBytes converts bytes to human-readable format (KB, MB, GB, TB).
@@ -26,3 +28,38 @@ func FormatBytes(bytes int64) string {
 
 	return fmt.Sprintf("%.1f %s", value, units[exp])
 }
+
+// ParseBytes parses a human-readable size such as "500", "10KB", "1.5 MB", or "2G"
+// into bytes. Units are binary (1 KB = 1024 bytes), matching FormatBytes.
+func ParseBytes(s string) (int64, error) {
+	raw := strings.ToUpper(strings.TrimSpace(s))
+	if raw == "" {
+		return 0, fmt.Errorf("empty size")
+	}
+
+	multipliers := []struct {
+		suffix string
+		value  float64
+	}{
+		{"TB", 1 << 40}, {"GB", 1 << 30}, {"MB", 1 << 20}, {"KB", 1 << 10},
+		{"T", 1 << 40}, {"G", 1 << 30}, {"M", 1 << 20}, {"K", 1 << 10},
+		{"B", 1},
+	}
+
+	multiplier := 1.0
+	number := raw
+	for _, m := range multipliers {
+		if strings.HasSuffix(raw, m.suffix) {
+			multiplier = m.value
+			number = strings.TrimSpace(strings.TrimSuffix(raw, m.suffix))
+			break
+		}
+	}
+
+	value, err := strconv.ParseFloat(number, 64)
+	if err != nil || value < 0 || math.IsInf(value, 0) || math.IsNaN(value) {
+		return 0, fmt.Errorf("invalid size %q", s)
+	}
+
+	return int64(value * multiplier), nil
+}
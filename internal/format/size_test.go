@@ -25,3 +25,38 @@ func TestFormatBytes(t *testing.T) {
 		})
 	}
 }
+
+func TestParseBytes(t *testing.T) {
+	cases := []struct {
+		in      string
+		want    int64
+		wantErr bool
+	}{
+		{in: "500", want: 500},
+		{in: "500B", want: 500},
+		{in: "10KB", want: 10 * 1024},
+		{in: "1.5 MB", want: 1536 * 1024},
+		{in: "2g", want: 2 * 1024 * 1024 * 1024},
+		{in: "", wantErr: true},
+		{in: "lots", wantErr: true},
+		{in: "-1MB", wantErr: true},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.in, func(t *testing.T) {
+			got, err := ParseBytes(tt.in)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("ParseBytes(%q) expected error, got %d", tt.in, got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseBytes(%q) error = %v", tt.in, err)
+			}
+			if got != tt.want {
+				t.Fatalf("ParseBytes(%q) = %d, want %d", tt.in, got, tt.want)
+			}
+		})
+	}
+}
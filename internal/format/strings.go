@@ -1,8 +1,13 @@
 package format
 
+// Truncate shortens s to maxLen bytes, ending in "..." when truncated.
+// A maxLen of 0 or less disables truncation.
 func Truncate(s string, maxLen int) string {
-	if len(s) <= maxLen {
+	if maxLen <= 0 || len(s) <= maxLen {
 		return s
 	}
+	if maxLen <= 3 {
+		return s[:maxLen]
+	}
 	return s[:maxLen-3] + "..."
 }
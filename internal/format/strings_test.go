@@ -13,6 +13,8 @@ func TestTruncate(t *testing.T) {
 		{"exact", "hello", 5, "hello"},
 		{"long", "hello", 4, "h..."},
 		{"longer", "abcdefghij", 6, "abc..."},
+		{"zero disables", "abcdefghij", 0, "abcdefghij"},
+		{"tiny", "abcdefghij", 2, "ab"},
 	}
 
 	for _, tt := range cases {
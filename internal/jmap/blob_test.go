@@ -3,11 +3,15 @@ package jmap
 import (
 	"bytes"
 	"context"
+	"errors"
 	"io"
 	"net/http"
 	"net/http/httptest"
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
+	"time"
 )
 
 func TestUploadBlob(t *testing.T) {
@@ -207,3 +211,211 @@ func TestDownloadBlob(t *testing.T) {
 		})
 	}
 }
+
+func TestDownloadBlobRange(t *testing.T) {
+	const content = "0123456789"
+
+	tests := []struct {
+		name        string
+		honorRange  bool
+		offset      int64
+		wantRange   string
+		wantContent string
+	}{
+		{name: "server honors range", honorRange: true, offset: 4, wantRange: "bytes=4-", wantContent: "456789"},
+		{name: "server ignores range", honorRange: false, offset: 4, wantRange: "bytes=4-", wantContent: "456789"},
+		{name: "zero offset sends no range", honorRange: true, offset: 0, wantRange: "", wantContent: content},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var gotRange string
+			downloadServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				gotRange = r.Header.Get("Range")
+				if tt.honorRange && gotRange != "" {
+					w.WriteHeader(http.StatusPartialContent)
+					_, _ = w.Write([]byte(content[tt.offset:]))
+					return
+				}
+				_, _ = w.Write([]byte(content))
+			}))
+			defer downloadServer.Close()
+
+			sessionServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_, _ = w.Write([]byte(`{
+					"apiUrl": "` + downloadServer.URL + `",
+					"downloadUrl": "` + downloadServer.URL + `/{accountId}/{blobId}/{name}?type={type}",
+					"accounts": {"acc123": {}}
+				}`))
+			}))
+			defer sessionServer.Close()
+
+			client := NewClientWithBaseURL("test-token", sessionServer.URL)
+
+			reader, err := client.DownloadBlobRange(context.Background(), "Gblob", tt.offset)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			defer reader.Close()
+
+			got, err := io.ReadAll(reader)
+			if err != nil {
+				t.Fatalf("read: %v", err)
+			}
+			if string(got) != tt.wantContent {
+				t.Errorf("content = %q, want %q", got, tt.wantContent)
+			}
+			if gotRange != tt.wantRange {
+				t.Errorf("Range header = %q, want %q", gotRange, tt.wantRange)
+			}
+		})
+	}
+}
+
+func TestDownloadBlob_RetriesBriefNotFound(t *testing.T) {
+	tests := []struct {
+		name         string
+		notFound     int
+		wantErr      bool
+		wantAttempts int
+	}{
+		{name: "404 then 200", notFound: 1, wantAttempts: 2},
+		{name: "404 at the cap", notFound: downloadNotFoundRetries, wantAttempts: downloadNotFoundRetries + 1},
+		{name: "persistent 404", notFound: 10, wantErr: true, wantAttempts: downloadNotFoundRetries + 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var attempts int
+			downloadServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				attempts++
+				if attempts <= tt.notFound {
+					http.NotFound(w, r)
+					return
+				}
+				_, _ = w.Write([]byte("payload"))
+			}))
+			defer downloadServer.Close()
+
+			sessionServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_, _ = w.Write([]byte(`{
+					"apiUrl": "` + downloadServer.URL + `",
+					"downloadUrl": "` + downloadServer.URL + `/{accountId}/{blobId}/{name}?type={type}",
+					"accounts": {"acc123": {}}
+				}`))
+			}))
+			defer sessionServer.Close()
+
+			client := NewClientWithBaseURL("test-token", sessionServer.URL)
+			client.SetRetryConfig(RetryConfig{MaxRetries: 5, InitialDelay: time.Millisecond, MaxDelay: time.Millisecond})
+
+			reader, err := client.DownloadBlob(context.Background(), "Gblob")
+			if attempts != tt.wantAttempts {
+				t.Errorf("attempts = %d, want %d", attempts, tt.wantAttempts)
+			}
+			if tt.wantErr {
+				if err == nil {
+					reader.Close()
+					t.Fatal("expected error for persistent 404")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("DownloadBlob: %v", err)
+			}
+			defer reader.Close()
+			if got, _ := io.ReadAll(reader); string(got) != "payload" {
+				t.Errorf("content = %q, want payload", got)
+			}
+		})
+	}
+}
+
+func TestDownloadBlobRange_RangeNotSatisfiable(t *testing.T) {
+	var attempts int
+	downloadServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		w.WriteHeader(http.StatusRequestedRangeNotSatisfiable)
+	}))
+	defer downloadServer.Close()
+
+	sessionServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{
+			"apiUrl": "` + downloadServer.URL + `",
+			"downloadUrl": "` + downloadServer.URL + `/{accountId}/{blobId}/{name}?type={type}",
+			"accounts": {"acc123": {}}
+		}`))
+	}))
+	defer sessionServer.Close()
+
+	client := NewClientWithBaseURL("test-token", sessionServer.URL)
+	client.SetRetryConfig(RetryConfig{MaxRetries: 3, InitialDelay: time.Millisecond, MaxDelay: time.Millisecond})
+
+	_, err := client.DownloadBlobRange(context.Background(), "Gblob", 100)
+	if !errors.Is(err, ErrRangeNotSatisfiable) {
+		t.Fatalf("error = %v, want ErrRangeNotSatisfiable", err)
+	}
+	if !strings.Contains(err.Error(), "offset 100") {
+		t.Errorf("error = %q, want the offset", err)
+	}
+	if attempts != 1 {
+		t.Errorf("attempts = %d, want 1 (416 is not retried)", attempts)
+	}
+}
+
+func TestUploadBlobFromFile_ReopensFileOnRetry(t *testing.T) {
+	content := []byte("streamed file content")
+	path := filepath.Join(t.TempDir(), "doc.txt")
+	if err := os.WriteFile(path, content, 0o600); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+
+	var attempts int
+	uploadServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		if r.ContentLength != int64(len(content)) {
+			t.Errorf("attempt %d: Content-Length = %d, want %d", attempts, r.ContentLength, len(content))
+		}
+		body, _ := io.ReadAll(r.Body)
+		if !bytes.Equal(body, content) {
+			t.Errorf("attempt %d: body = %q, want %q", attempts, body, content)
+		}
+		if attempts == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(`{"accountId":"acc123","blobId":"blob-file","type":"text/plain","size":21}`))
+	}))
+	defer uploadServer.Close()
+
+	sessionServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{
+			"apiUrl": "` + uploadServer.URL + `",
+			"uploadUrl": "` + uploadServer.URL + `/{accountId}/",
+			"accounts": {"acc123": {}}
+		}`))
+	}))
+	defer sessionServer.Close()
+
+	client := NewClientWithBaseURL("test-token", sessionServer.URL)
+	client.SetRetryConfig(RetryConfig{MaxRetries: 2, InitialDelay: time.Millisecond, MaxDelay: time.Millisecond})
+
+	result, err := client.UploadBlobFromFile(context.Background(), path, "text/plain")
+	if err != nil {
+		t.Fatalf("UploadBlobFromFile: %v", err)
+	}
+	if result.BlobID != "blob-file" {
+		t.Errorf("blobId = %q, want blob-file", result.BlobID)
+	}
+	if attempts != 2 {
+		t.Errorf("attempts = %d, want 2", attempts)
+	}
+}
+
+func TestUploadBlobFromFile_RejectsDirectory(t *testing.T) {
+	client := NewClientWithBaseURL("test-token", "http://127.0.0.1:0")
+	if _, err := client.UploadBlobFromFile(context.Background(), t.TempDir(), "text/plain"); err == nil {
+		t.Fatal("expected error for directory")
+	}
+}
@@ -3,6 +3,8 @@ package jmap
 import (
 	"context"
 	"fmt"
+	"slices"
+	"strings"
 	"time"
 )
 
@@ -19,6 +21,7 @@ type Calendar struct {
 // CalendarEvent represents a JMAP calendar event
 type CalendarEvent struct {
 	ID           string          `json:"id"`
+	UID          string          `json:"uid,omitempty"`
 	CalendarID   string          `json:"calendarId"`
 	Title        string          `json:"title"`
 	Description  string          `json:"description,omitempty"`
@@ -38,16 +41,108 @@ type CalendarEvent struct {
 type RecurrenceRule struct {
 	Frequency string `json:"frequency"` // daily, weekly, monthly, yearly
 	Interval  int    `json:"interval,omitempty"`
+	ByDay     []NDay `json:"byDay,omitempty"`
 	Until     string `json:"until,omitempty"`
 	Count     int    `json:"count,omitempty"`
 }
 
+// RecurrenceFrequencies are the frequencies NewRecurrenceRule accepts.
+var RecurrenceFrequencies = []string{"daily", "weekly", "monthly", "yearly"}
+
+// NewRecurrenceRule builds the rule for an event repeating at frequency
+// (daily, weekly, monthly or yearly) every interval periods (0 means 1),
+// ending after count occurrences or at until, whichever is set. until is a
+// local date-time, as JSCalendar expects.
+func NewRecurrenceRule(frequency string, interval, count int, until time.Time) (*RecurrenceRule, error) {
+	frequency = strings.ToLower(frequency)
+	if !slices.Contains(RecurrenceFrequencies, frequency) {
+		return nil, &ValidationError{Field: "frequency", Message: fmt.Sprintf("must be one of %s", strings.Join(RecurrenceFrequencies, ", "))}
+	}
+	if interval < 0 {
+		return nil, &ValidationError{Field: "interval", Message: "must be positive"}
+	}
+	if count < 0 {
+		return nil, &ValidationError{Field: "count", Message: "must be positive"}
+	}
+	if count > 0 && !until.IsZero() {
+		return nil, &ValidationError{Field: "count", Message: "cannot be combined with until"}
+	}
+
+	rule := &RecurrenceRule{Frequency: frequency, Count: count}
+	if interval > 1 {
+		rule.Interval = interval
+	}
+	if !until.IsZero() {
+		rule.Until = until.Format("2006-01-02T15:04:05")
+	}
+	return rule, nil
+}
+
+// NDay is a weekday in a recurrence rule, optionally restricted to its nth
+// occurrence within the period (negative counts from the end).
+type NDay struct {
+	Day         string `json:"day"` // mo, tu, we, th, fr, sa, su
+	NthOfPeriod int    `json:"nthOfPeriod,omitempty"`
+}
+
 // Alert represents a calendar event alert/reminder
 type Alert struct {
 	Trigger string `json:"trigger"` // e.g., "-PT15M" (15 min before)
 	Action  string `json:"action"`  // display, email
 }
 
+// Alert actions: AlertDisplay shows a notification, AlertEmail sends one.
+const (
+	AlertDisplay = "display"
+	AlertEmail   = "email"
+)
+
+// NewAlert builds an alert firing offset relative to the event start
+// (negative is before the start) with the given action.
+func NewAlert(offset time.Duration, action string) Alert {
+	return Alert{Trigger: AlertTrigger(offset), Action: action}
+}
+
+// AlertTrigger formats offset as an ISO 8601 duration, e.g. -15m as
+// "-PT15M" and 26h as "P1DT2H". Sub-second precision is dropped.
+func AlertTrigger(offset time.Duration) string {
+	var b strings.Builder
+	if offset < 0 {
+		b.WriteString("-")
+		offset = -offset
+	}
+	b.WriteString("P")
+
+	days := offset / (24 * time.Hour)
+	offset -= days * 24 * time.Hour
+	hours := offset / time.Hour
+	offset -= hours * time.Hour
+	minutes := offset / time.Minute
+	offset -= minutes * time.Minute
+	seconds := offset / time.Second
+
+	if days > 0 {
+		fmt.Fprintf(&b, "%dD", days)
+	}
+	if hours == 0 && minutes == 0 && seconds == 0 {
+		if days == 0 {
+			b.WriteString("T0S")
+		}
+		return b.String()
+	}
+	b.WriteString("T")
+	if hours > 0 {
+		fmt.Fprintf(&b, "%dH", hours)
+	}
+	if minutes > 0 {
+		fmt.Fprintf(&b, "%dM", minutes)
+	}
+	if seconds > 0 {
+		fmt.Fprintf(&b, "%dS", seconds)
+	}
+	return b.String()
+}
+
 // Participant represents an event participant
 type Participant struct {
 	Name   string `json:"name"`
@@ -240,7 +335,141 @@ func (c *Client) CreateEvent(ctx context.Context, event *CalendarEvent) (*Calend
 	return &created, nil
 }
 
-// UpdateEvent updates an existing calendar event
+// BusyPeriod is a span of time taken by an existing event.
+type BusyPeriod struct {
+	Start    time.Time `json:"start"`
+	End      time.Time `json:"end"`
+	EventID  string    `json:"eventId"`
+	Title    string    `json:"title"`
+	IsAllDay bool      `json:"isAllDay,omitempty"`
+}
+
+// Overlaps reports whether the period overlaps [start, end). Periods that
+// merely touch (one ends as the other starts) don't overlap.
+func (p BusyPeriod) Overlaps(start, end time.Time) bool {
+	return p.Start.Before(end) && start.Before(p.End)
+}
+
+// GetBusyPeriods returns the periods taken by non-cancelled events in the
+// visible calendars that overlap [from, to), sorted by start. All-day
+// events take their whole days in from's time zone.
+func (c *Client) GetBusyPeriods(ctx context.Context, from, to time.Time) ([]BusyPeriod, error) {
+	calendars, err := c.GetCalendars(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// All-day events are stored at midnight without a zone, so widen the
+	// query by a day on each side and filter precisely below
+	queryFrom, queryTo := from.AddDate(0, 0, -1), to.AddDate(0, 0, 1)
+
+	var periods []BusyPeriod
+	for _, cal := range calendars {
+		if !cal.IsVisible {
+			continue
+		}
+		events, err := c.GetEvents(ctx, cal.ID, queryFrom, queryTo, 0)
+		if err != nil {
+			return nil, fmt.Errorf("calendar %s: %w", cal.Name, err)
+		}
+		for _, ev := range events {
+			if ev.Status == "cancelled" {
+				continue
+			}
+			period := eventBusyPeriod(ev, from.Location())
+			if period.Overlaps(from, to) {
+				periods = append(periods, period)
+			}
+		}
+	}
+
+	slices.SortStableFunc(periods, func(a, b BusyPeriod) int {
+		return a.Start.Compare(b.Start)
+	})
+	return periods, nil
+}
+
+// eventBusyPeriod returns the time ev takes. An all-day event covers its
+// dates from midnight to midnight in loc, and at least one day.
+func eventBusyPeriod(ev CalendarEvent, loc *time.Location) BusyPeriod {
+	period := BusyPeriod{
+		Start:    ev.Start,
+		End:      ev.End,
+		EventID:  ev.ID,
+		Title:    ev.Title,
+		IsAllDay: ev.IsAllDay,
+	}
+	if ev.IsAllDay {
+		period.Start = time.Date(ev.Start.Year(), ev.Start.Month(), ev.Start.Day(), 0, 0, 0, 0, loc)
+		period.End = time.Date(ev.End.Year(), ev.End.Month(), ev.End.Day(), 0, 0, 0, 0, loc)
+		if !period.End.After(period.Start) {
+			period.End = period.Start.AddDate(0, 0, 1)
+		}
+	}
+	return period
+}
+
+// MergeParticipants returns participants with add appended (as needs-action)
+// and remove dropped. Emails compare case-insensitively; adding an existing
+// participant or removing an absent one is a no-op. changed reports whether
+// the result differs from participants.
+func MergeParticipants(participants []Participant, add, remove []string) (merged []Participant, changed bool) {
+	removeSet := make(map[string]bool, len(remove))
+	for _, email := range remove {
+		removeSet[strings.ToLower(strings.TrimSpace(email))] = true
+	}
+
+	merged = make([]Participant, 0, len(participants)+len(add))
+	present := make(map[string]bool, len(participants)+len(add))
+	for _, p := range participants {
+		key := strings.ToLower(p.Email)
+		if removeSet[key] {
+			changed = true
+			continue
+		}
+		present[key] = true
+		merged = append(merged, p)
+	}
+
+	for _, email := range add {
+		email = strings.TrimSpace(email)
+		key := strings.ToLower(email)
+		if present[key] || removeSet[key] {
+			continue
+		}
+		present[key] = true
+		merged = append(merged, Participant{Email: email, Status: "needs-action"})
+		changed = true
+	}
+
+	return merged, changed
+}
+
+// SetParticipantStatus returns a copy of participants with the status of
+// every participant whose email is one of emails (case-insensitively) set
+// to status. It returns ErrNotParticipant when no participant matches.
+func SetParticipantStatus(participants []Participant, emails []string, status string) ([]Participant, error) {
+	mine := make(map[string]bool, len(emails))
+	for _, email := range emails {
+		mine[strings.ToLower(strings.TrimSpace(email))] = true
+	}
+
+	updated := slices.Clone(participants)
+	found := false
+	for i, p := range updated {
+		if mine[strings.ToLower(p.Email)] {
+			updated[i].Status = status
+			found = true
+		}
+	}
+	if !found {
+		return nil, ErrNotParticipant
+	}
+	return updated, nil
+}
+
+// UpdateEvent updates an existing calendar event. When the update changes
+// participants, the server is asked to send invitations/cancellations.
 func (c *Client) UpdateEvent(ctx context.Context, id string, updates map[string]interface{}) (*CalendarEvent, error) {
 	session, err := c.GetSession(ctx)
 	if err != nil {
@@ -252,15 +481,20 @@ func (c *Client) UpdateEvent(ctx context.Context, id string, updates map[string]
 		return nil, ErrCalendarsNotEnabled
 	}
 
+	args := map[string]any{
+		"accountId": session.AccountID,
+		"update": map[string]any{
+			id: updates,
+		},
+	}
+	if _, ok := updates["participants"]; ok {
+		args["sendSchedulingMessages"] = true
+	}
+
 	req := &Request{
 		Using: []string{"urn:ietf:params:jmap:core", calendarsCapability},
 		MethodCalls: []MethodCall{
-			{"CalendarEvent/set", map[string]any{
-				"accountId": session.AccountID,
-				"update": map[string]any{
-					id: updates,
-				},
-			}, "0"},
+			{"CalendarEvent/set", args, "0"},
 		},
 	}
 
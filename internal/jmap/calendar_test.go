@@ -2,10 +2,15 @@ package jmap
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 	"time"
+
+	"github.com/salmonumbrella/fastmail-cli/internal/testutil"
 )
 
 func TestGetCalendars(t *testing.T) {
@@ -174,3 +179,226 @@ func TestCalendarsNotEnabled(t *testing.T) {
 		t.Errorf("DeleteEvent() error = %v, want %v", err, ErrCalendarsNotEnabled)
 	}
 }
+
+func TestMergeParticipants(t *testing.T) {
+	existing := []Participant{
+		{Name: "Alice", Email: "alice@example.com", Status: "accepted"},
+		{Email: "bob@example.com", Status: "needs-action"},
+	}
+
+	merged, changed := MergeParticipants(existing, []string{"carol@example.com", "ALICE@example.com"}, []string{"Bob@Example.com"})
+	if !changed {
+		t.Fatal("expected changed = true")
+	}
+	if len(merged) != 2 {
+		t.Fatalf("got %d participants, want 2: %+v", len(merged), merged)
+	}
+	if merged[0].Status != "accepted" {
+		t.Errorf("existing participant status lost: %+v", merged[0])
+	}
+	if merged[1].Email != "carol@example.com" || merged[1].Status != "needs-action" {
+		t.Errorf("unexpected added participant: %+v", merged[1])
+	}
+
+	if _, changed := MergeParticipants(existing, []string{"alice@example.com"}, []string{"nobody@example.com"}); changed {
+		t.Error("no-op merge reported changed")
+	}
+}
+
+func TestUpdateEvent_ParticipantsSendSchedulingMessages(t *testing.T) {
+	var gotArgs map[string]any
+	apiServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			MethodCalls [][]json.RawMessage `json:"methodCalls"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Fatalf("decode request: %v", err)
+		}
+		if err := json.Unmarshal(req.MethodCalls[0][1], &gotArgs); err != nil {
+			t.Fatalf("decode args: %v", err)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"methodResponses": [["CalendarEvent/set", {"updated": {"event1": {"id": "event1"}}}, "0"]]}`))
+	}))
+	defer apiServer.Close()
+
+	sessionServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{
+			"apiUrl": "` + apiServer.URL + `",
+			"accounts": {"acc123": {}},
+			"primaryAccounts": {"urn:ietf:params:jmap:calendars": "acc123"},
+			"capabilities": {"urn:ietf:params:jmap:core": {}, "urn:ietf:params:jmap:calendars": {}}
+		}`))
+	}))
+	defer sessionServer.Close()
+
+	client := NewClient("test-token")
+	client.baseURL = sessionServer.URL
+
+	if _, err := client.UpdateEvent(context.Background(), "event1", map[string]interface{}{"title": "x"}); err != nil {
+		t.Fatalf("UpdateEvent() error = %v", err)
+	}
+	if _, ok := gotArgs["sendSchedulingMessages"]; ok {
+		t.Error("sendSchedulingMessages set for non-participant update")
+	}
+
+	participants := []Participant{{Email: "carol@example.com", Status: "needs-action"}}
+	if _, err := client.UpdateEvent(context.Background(), "event1", map[string]interface{}{"participants": participants}); err != nil {
+		t.Fatalf("UpdateEvent() error = %v", err)
+	}
+	if gotArgs["sendSchedulingMessages"] != true {
+		t.Errorf("sendSchedulingMessages = %v, want true", gotArgs["sendSchedulingMessages"])
+	}
+}
+
+func TestNewRecurrenceRule(t *testing.T) {
+	until := time.Date(2026, 3, 31, 23, 59, 59, 0, time.UTC)
+
+	tests := []struct {
+		name      string
+		frequency string
+		interval  int
+		count     int
+		until     time.Time
+		want      string
+		wantErr   bool
+	}{
+		{name: "weekly", frequency: "weekly", want: `{"frequency":"weekly"}`},
+		{name: "every 2 weeks, 10 times", frequency: "Weekly", interval: 2, count: 10, want: `{"frequency":"weekly","interval":2,"count":10}`},
+		{name: "monthly until", frequency: "monthly", until: until, want: `{"frequency":"monthly","until":"2026-03-31T23:59:59"}`},
+		{name: "interval 1 omitted", frequency: "daily", interval: 1, want: `{"frequency":"daily"}`},
+		{name: "count and until", frequency: "daily", count: 3, until: until, wantErr: true},
+		{name: "unknown frequency", frequency: "hourly", wantErr: true},
+		{name: "negative interval", frequency: "daily", interval: -1, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rule, err := NewRecurrenceRule(tt.frequency, tt.interval, tt.count, tt.until)
+			if tt.wantErr {
+				if !IsValidationError(err) {
+					t.Fatalf("expected validation error, got %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			got, _ := json.Marshal(rule)
+			if string(got) != tt.want {
+				t.Errorf("got %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCreateEvent_SendsRecurrenceRule(t *testing.T) {
+	jm := testutil.NewJMAPMock()
+	defer jm.Close()
+	jm.AddCapability(calendarsCapability)
+	jm.QueueResponse(`{"methodResponses": [["CalendarEvent/set", {"created": {"new-event": {"id": "ev1"}}}, "0"]]}`)
+
+	rule, err := NewRecurrenceRule("weekly", 2, 5, time.Time{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	client := NewClientWithBaseURL("test-token", jm.URL())
+	_, err = client.CreateEvent(context.Background(), &CalendarEvent{
+		CalendarID: "cal1",
+		Title:      "Standup",
+		Start:      time.Date(2026, 1, 5, 9, 0, 0, 0, time.UTC),
+		End:        time.Date(2026, 1, 5, 9, 15, 0, 0, time.UTC),
+		Recurrence: rule,
+	})
+	if err != nil {
+		t.Fatalf("CreateEvent() error = %v", err)
+	}
+
+	jm.AssertRequestBody(t, func(body any) bool {
+		raw, _ := json.Marshal(body)
+		return strings.Contains(string(raw), `"recurrenceRule":{"count":5,"frequency":"weekly","interval":2}`)
+	})
+}
+
+func TestAlertTrigger(t *testing.T) {
+	tests := []struct {
+		offset time.Duration
+		want   string
+	}{
+		{-15 * time.Minute, "-PT15M"},
+		{-time.Hour, "-PT1H"},
+		{-90 * time.Minute, "-PT1H30M"},
+		{-24 * time.Hour, "-P1D"},
+		{26 * time.Hour, "P1DT2H"},
+		{30 * time.Second, "PT30S"},
+		{0, "PT0S"},
+	}
+
+	for _, tt := range tests {
+		if got := AlertTrigger(tt.offset); got != tt.want {
+			t.Errorf("AlertTrigger(%v) = %q, want %q", tt.offset, got, tt.want)
+		}
+	}
+}
+
+func TestGetBusyPeriods(t *testing.T) {
+	jm := testutil.NewJMAPMock()
+	defer jm.Close()
+	jm.AddCapability(calendarsCapability)
+	jm.QueueMethodResponses(testutil.MethodResponse{Name: "Calendar/get", Args: map[string]any{"list": []any{
+		map[string]any{"id": "cal1", "name": "Personal", "isVisible": true},
+		map[string]any{"id": "cal2", "name": "Hidden", "isVisible": false},
+	}}})
+	jm.QueueMethodResponses(
+		testutil.MethodResponse{Name: "CalendarEvent/query", Args: map[string]any{"ids": []any{}}},
+		testutil.MethodResponse{Name: "CalendarEvent/get", Args: map[string]any{"list": []any{
+			map[string]any{"id": "e1", "title": "Standup", "start": "2026-01-05T09:00:00Z", "end": "2026-01-05T09:30:00Z", "status": "confirmed"},
+			map[string]any{"id": "e2", "title": "Before", "start": "2026-01-05T08:00:00Z", "end": "2026-01-05T09:00:00Z", "status": "confirmed"},
+			map[string]any{"id": "e3", "title": "Cancelled", "start": "2026-01-05T09:00:00Z", "end": "2026-01-05T10:00:00Z", "status": "cancelled"},
+			map[string]any{"id": "e4", "title": "Holiday", "start": "2026-01-05T00:00:00Z", "end": "2026-01-05T00:00:00Z", "isAllDay": true},
+			map[string]any{"id": "e5", "title": "Yesterday", "start": "2026-01-04T00:00:00Z", "end": "2026-01-05T00:00:00Z", "isAllDay": true},
+		}}},
+	)
+
+	client := NewClientWithBaseURL("test-token", jm.URL())
+	from := time.Date(2026, 1, 5, 9, 0, 0, 0, time.UTC)
+	periods, err := client.GetBusyPeriods(context.Background(), from, from.Add(time.Hour))
+	if err != nil {
+		t.Fatalf("GetBusyPeriods() error = %v", err)
+	}
+
+	var titles []string
+	for _, p := range periods {
+		titles = append(titles, p.Title)
+	}
+	if got := strings.Join(titles, ","); got != "Holiday,Standup" {
+		t.Errorf("busy titles = %q, want Holiday,Standup", got)
+	}
+	if len(periods) > 0 && !periods[0].End.Equal(time.Date(2026, 1, 6, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("all-day period end = %v, want end of day", periods[0].End)
+	}
+	jm.AssertConsumed(t)
+}
+
+func TestSetParticipantStatus(t *testing.T) {
+	participants := []Participant{
+		{Name: "Organizer", Email: "boss@example.com", Status: "accepted"},
+		{Name: "Me", Email: "Me@Example.com", Status: "needs-action"},
+	}
+
+	updated, err := SetParticipantStatus(participants, []string{"other@example.com", "me@example.com"}, "declined")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if updated[1].Status != "declined" || updated[0].Status != "accepted" {
+		t.Errorf("updated = %+v, want only Me declined", updated)
+	}
+	if participants[1].Status != "needs-action" {
+		t.Error("SetParticipantStatus modified its input")
+	}
+
+	if _, err := SetParticipantStatus(participants, []string{"stranger@example.com"}, "accepted"); !errors.Is(err, ErrNotParticipant) {
+		t.Errorf("expected ErrNotParticipant, got %v", err)
+	}
+}
@@ -392,3 +392,47 @@ func TestCircuitBreaker_MultipleOpenClose(t *testing.T) {
 		cb.recordSuccess()
 	}
 }
+
+// TestClient_CircuitBreakerStateAndReset tests the exported breaker controls
+func TestClient_CircuitBreakerStateAndReset(t *testing.T) {
+	client := NewClient("test-token")
+	client.SetCircuitBreakerConfig(2, time.Hour)
+
+	if open, failures, _ := client.CircuitBreakerState(); open || failures != 0 {
+		t.Fatalf("initial state open=%v failures=%d, want closed with 0", open, failures)
+	}
+
+	client.circuitBreaker.recordFailure()
+	client.circuitBreaker.recordFailure()
+
+	open, failures, lastFailure := client.CircuitBreakerState()
+	if !open || failures != 2 || lastFailure.IsZero() {
+		t.Fatalf("state after threshold open=%v failures=%d last=%v, want open with 2", open, failures, lastFailure)
+	}
+
+	client.ResetCircuitBreaker()
+	if open, failures, lastFailure := client.CircuitBreakerState(); open || failures != 0 || !lastFailure.IsZero() {
+		t.Errorf("state after reset open=%v failures=%d last=%v, want closed and cleared", open, failures, lastFailure)
+	}
+}
+
+// TestClient_SetCircuitBreakerConfig tests custom and default configuration
+func TestClient_SetCircuitBreakerConfig(t *testing.T) {
+	client := NewClient("test-token")
+
+	client.SetCircuitBreakerConfig(1, 10*time.Millisecond)
+	client.circuitBreaker.recordFailure()
+	if open, _, _ := client.CircuitBreakerState(); !open {
+		t.Fatal("circuit should open after 1 failure with threshold 1")
+	}
+
+	time.Sleep(20 * time.Millisecond)
+	if open, _, _ := client.CircuitBreakerState(); open {
+		t.Error("circuit should be closed after resetAfter elapsed")
+	}
+
+	client.SetCircuitBreakerConfig(0, 0)
+	if client.circuitBreaker.threshold != DefaultCircuitBreakerThreshold || client.circuitBreaker.resetAfter != DefaultCircuitBreakerResetAfter {
+		t.Errorf("zero config = (%d, %v), want defaults", client.circuitBreaker.threshold, client.circuitBreaker.resetAfter)
+	}
+}
@@ -9,6 +9,7 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"os"
 	"sort"
 	"strings"
 	"sync"
@@ -25,19 +26,44 @@ const (
 	// SessionPath is the path to the JMAP session endpoint
 	SessionPath = "/jmap/session"
 
+	// DefaultUserAgent is the User-Agent sent when none is set with SetUserAgent
+	DefaultUserAgent = "fastmail-cli"
+
 	// Default retry configuration values (shared with transport)
 	DefaultMaxRetries   = transport.DefaultMaxRetries
 	DefaultInitialDelay = transport.DefaultInitialDelay
 	DefaultMaxDelay     = transport.DefaultMaxDelay
 
+	// DefaultRequestTimeout bounds a JMAP API or session call, including retries.
+	DefaultRequestTimeout = 30 * time.Second
+	// DefaultTransferTimeout bounds a blob upload or download, including retries.
+	DefaultTransferTimeout = 5 * time.Minute
+
 	// MaxUploadSize is the maximum size for blob uploads (50MB)
 	MaxUploadSize = 50 * 1024 * 1024
 
+	// downloadNotFoundRetries is how many times a download answered with 404
+	// is retried, since a just-uploaded blob can take a moment to appear.
+	downloadNotFoundRetries = 2
+
 	// Default circuit breaker configuration values
 	DefaultCircuitBreakerThreshold  = 5
 	DefaultCircuitBreakerResetAfter = 30 * time.Second
 )
 
+// TargetCapabilities lists the JMAP capabilities the client may request.
+// Which ones a session actually grants depends on the API token's scopes.
+var TargetCapabilities = []string{
+	"urn:ietf:params:jmap:core",
+	"urn:ietf:params:jmap:mail",
+	"urn:ietf:params:jmap:submission",
+	"urn:ietf:params:jmap:vacationresponse",
+	"urn:ietf:params:jmap:quota",
+	contactsCapability,
+	calendarsCapability,
+	maskedEmailNamespace,
+}
+
 // RetryConfig configures retry behavior for JMAP requests.
 type RetryConfig = transport.RetryConfig
 
@@ -94,6 +120,36 @@ func (cb *circuitBreaker) recordFailure() {
 	cb.lastFailure = time.Now()
 }
 
+// state reports whether the circuit is open without resetting it.
+func (cb *circuitBreaker) state() (open bool, failures int, lastFailure time.Time) {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+	open = cb.failures >= cb.threshold && time.Since(cb.lastFailure) <= cb.resetAfter
+	return open, cb.failures, cb.lastFailure
+}
+
+// reset closes the circuit and clears the failure history.
+func (cb *circuitBreaker) reset() {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+	cb.failures = 0
+	cb.lastFailure = time.Time{}
+}
+
+// configure sets the threshold and reset duration (non-positive values use defaults).
+func (cb *circuitBreaker) configure(threshold int, resetAfter time.Duration) {
+	if threshold <= 0 {
+		threshold = DefaultCircuitBreakerThreshold
+	}
+	if resetAfter <= 0 {
+		resetAfter = DefaultCircuitBreakerResetAfter
+	}
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+	cb.threshold = threshold
+	cb.resetAfter = resetAfter
+}
+
 // Session represents a JMAP session with API endpoints and account information
 type Session struct {
 	APIUrl       string         `json:"apiUrl"`
@@ -101,6 +157,96 @@ type Session struct {
 	Capabilities map[string]any `json:"capabilities"`
 	DownloadURL  string         `json:"downloadUrl"`
 	UploadURL    string         `json:"uploadUrl"`
+	// Accounts lists every account in the session, sorted by ID
+	Accounts []AccountInfo `json:"accounts,omitempty"`
+}
+
+// Capability URIs of the optional features commands check up front with
+// RequireCapability.
+const (
+	CapabilityMaskedEmail = maskedEmailNamespace
+	CapabilityCalendars   = calendarsCapability
+	CapabilityContacts    = contactsCapability
+	CapabilityVacation    = "urn:ietf:params:jmap:vacationresponse"
+)
+
+// capabilityErrors are the not-enabled errors RequireCapability returns.
+var capabilityErrors = map[string]error{
+	CapabilityMaskedEmail: ErrMaskedEmailNotEnabled,
+	CapabilityCalendars:   ErrCalendarsNotEnabled,
+	CapabilityContacts:    ErrContactsNotEnabled,
+	CapabilityVacation:    ErrVacationNotEnabled,
+}
+
+// capabilityNames are short names for the well-known capability URIs.
+var capabilityNames = map[string]string{
+	"urn:ietf:params:jmap:core":       "core",
+	"urn:ietf:params:jmap:mail":       "mail",
+	"urn:ietf:params:jmap:submission": "submission",
+	CapabilityVacation:                "vacation",
+	"urn:ietf:params:jmap:quota":      "quota",
+	contactsCapability:                "contacts",
+	calendarsCapability:               "calendars",
+	maskedEmailNamespace:              "masked-email",
+}
+
+// CapabilityURIs returns the URIs of the capabilities the session grants,
+// sorted.
+func (s *Session) CapabilityURIs() []string {
+	uris := make([]string, 0, len(s.Capabilities))
+	for uri := range s.Capabilities {
+		uris = append(uris, uri)
+	}
+	sort.Strings(uris)
+	return uris
+}
+
+// HasCapability reports whether the session grants the capability URI.
+func (s *Session) HasCapability(uri string) bool {
+	_, ok := s.Capabilities[uri]
+	return ok
+}
+
+// HasCapability reports whether the session grants the capability URI.
+func (c *Client) HasCapability(ctx context.Context, uri string) (bool, error) {
+	session, err := c.GetSession(ctx)
+	if err != nil {
+		return false, err
+	}
+	return session.HasCapability(uri), nil
+}
+
+// RequireCapability returns the feature's not-enabled error (such as
+// ErrCalendarsNotEnabled) if the session doesn't grant the capability URI.
+func (c *Client) RequireCapability(ctx context.Context, uri string) error {
+	ok, err := c.HasCapability(ctx, uri)
+	if err != nil || ok {
+		return err
+	}
+	if sentinel, known := capabilityErrors[uri]; known {
+		return sentinel
+	}
+	return fmt.Errorf("%s not enabled for this account", uri)
+}
+
+// CapabilityName returns a short name for a capability URI, such as "mail"
+// or "masked-email", or the URI itself if it isn't a well-known one.
+func CapabilityName(uri string) string {
+	if name, ok := capabilityNames[uri]; ok {
+		return name
+	}
+	return uri
+}
+
+// AccountInfo describes one account the token can access.
+type AccountInfo struct {
+	ID         string `json:"id"`
+	Name       string `json:"name"`
+	IsPersonal bool   `json:"isPersonal"`
+	IsReadOnly bool   `json:"isReadOnly"`
+	// Capabilities maps each capability URI the account supports to its
+	// account-level settings
+	Capabilities map[string]any `json:"accountCapabilities"`
 }
 
 // Request represents a JMAP request
@@ -132,18 +278,35 @@ type Client struct {
 	http           *http.Client
 	retry          RetryConfig
 	circuitBreaker *circuitBreaker
+
+	requestTimeout  time.Duration
+	transferTimeout time.Duration
+
+	logger func(RequestEvent)
+	dryRun func(*Request)
+
+	// accountID selects a session account; empty means the first by ID
+	accountID string
+
+	// sessionCacheDir enables the on-disk session cache when non-empty
+	sessionCacheDir string
+
+	userAgent string
 }
 
 // Compile-time interface compliance checks
 var _ EmailService = (*Client)(nil)
 var _ MaskedEmailService = (*Client)(nil)
 var _ VacationService = (*Client)(nil)
+var _ CalendarService = (*Client)(nil)
 var _ QuotaService = (*Client)(nil)
 
 // newSecureHTTPClient creates an HTTP client with secure TLS configuration.
+// It has no overall timeout of its own: deadlines are set per call on the
+// request context (see SetRequestTimeout) so that retries share one deadline
+// and blob transfers can run longer than API calls.
 func newSecureHTTPClient() *http.Client {
 	return &http.Client{
-		Timeout: 30 * time.Second,
 		Transport: &http.Transport{
 			TLSClientConfig: &tls.Config{
 				MinVersion: tls.VersionTLS12,
@@ -161,6 +324,10 @@ func NewClient(token string) *Client {
 		http:           newSecureHTTPClient(),
 		retry:          DefaultRetryConfig(),
 		circuitBreaker: newCircuitBreaker(),
+		userAgent:      DefaultUserAgent,
+
+		requestTimeout:  DefaultRequestTimeout,
+		transferTimeout: DefaultTransferTimeout,
 	}
 }
 
@@ -173,6 +340,10 @@ func NewClientWithBaseURL(token, baseURL string) *Client {
 		http:           newSecureHTTPClient(),
 		retry:          DefaultRetryConfig(),
 		circuitBreaker: newCircuitBreaker(),
+		userAgent:      DefaultUserAgent,
+
+		requestTimeout:  DefaultRequestTimeout,
+		transferTimeout: DefaultTransferTimeout,
 	}
 }
 
@@ -181,6 +352,68 @@ func (c *Client) SetRetryConfig(cfg RetryConfig) {
 	c.retry = cfg
 }
 
+// CircuitBreakerState reports whether the circuit breaker is open (requests
+// fail fast with CircuitBreakerError), the consecutive failure count and when
+// the last failure happened.
+func (c *Client) CircuitBreakerState() (open bool, failures int, lastFailure time.Time) {
+	return c.circuitBreaker.state()
+}
+
+// SetUserAgent sets the User-Agent header sent with every request, including
+// session fetches and blob transfers. An empty string restores
+// DefaultUserAgent.
+func (c *Client) SetUserAgent(ua string) {
+	if ua == "" {
+		ua = DefaultUserAgent
+	}
+	c.userAgent = ua
+}
+
+// ResetCircuitBreaker closes the circuit breaker so the next request is attempted.
+func (c *Client) ResetCircuitBreaker() {
+	c.circuitBreaker.reset()
+}
+
+// SetCircuitBreakerConfig sets how many consecutive failures open the circuit
+// and how long it stays open (non-positive values use defaults).
+func (c *Client) SetCircuitBreakerConfig(threshold int, resetAfter time.Duration) {
+	c.circuitBreaker.configure(threshold, resetAfter)
+}
+
+// SetRequestTimeout sets one overall deadline for every API and session
+// call the client makes. Retries of a call share its deadline. Blob uploads
+// and downloads keep their own, longer deadline. A zero or negative
+// duration disables the deadline.
+func (c *Client) SetRequestTimeout(d time.Duration) {
+	c.requestTimeout = d
+}
+
+// withTimeout derives a context bounded by d, unless d is not positive.
+func withTimeout(ctx context.Context, d time.Duration) (context.Context, context.CancelFunc) {
+	if d <= 0 {
+		return context.WithCancel(ctx)
+	}
+	return context.WithTimeout(ctx, d)
+}
+
+// cancelOnClose releases a call's context when its response body is closed.
+type cancelOnClose struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+func (r *cancelOnClose) Close() error {
+	err := r.ReadCloser.Close()
+	r.cancel()
+	return err
+}
+
+// redactToken scrubs the API token from a response body before it is put in
+// an error, in case a misconfigured proxy echoes the request headers back.
+func (c *Client) redactToken(s string) string {
+	return transport.RedactSecrets(s, c.token)
+}
+
 // generateIdempotencyKey generates a random 16-byte hex string for idempotency
 func generateIdempotencyKey() string {
 	b := make([]byte, 16)
@@ -221,6 +454,14 @@ func (c *Client) GetSession(ctx context.Context) (*Session, error) {
 		return c.session, nil
 	}
 
+	// Another process may have fetched the session recently
+	if c.loadCachedSession() {
+		return c.session, nil
+	}
+
+	ctx, cancel := withTimeout(ctx, c.requestTimeout)
+	defer cancel()
+
 	// Build session URL
 	sessionURL := c.baseURL + SessionPath
 	reqFn := func(ctx context.Context) (*http.Request, error) {
@@ -229,12 +470,13 @@ func (c *Client) GetSession(ctx context.Context) (*Session, error) {
 			return nil, fmt.Errorf("creating session request: %w", err)
 		}
 		req.Header.Set("Authorization", "Bearer "+c.token)
+		req.Header.Set("User-Agent", c.userAgent)
 		req.Header.Set("Content-Type", "application/json")
 		req.Header.Set("X-Request-ID", uuid.New().String())
 		return req, nil
 	}
 
-	resp, err := transport.DoWithRetry(ctx, c.http, c.retry, reqFn, func(attempt int, resp *http.Response) (bool, error) {
+	resp, err := transport.DoWithRetry(ctx, c.tracedHTTP(RequestKindSession, nil), c.retry, reqFn, func(attempt int, resp *http.Response) (bool, error) {
 		if resp.StatusCode == http.StatusOK {
 			return false, nil
 		}
@@ -260,31 +502,44 @@ func (c *Client) GetSession(ctx context.Context) (*Session, error) {
 
 	if resp.StatusCode != http.StatusOK {
 		body, _ := io.ReadAll(resp.Body) //nolint:errcheck // best-effort read for error message
-		return nil, transport.NewHTTPError("session request", resp, body)
+		if resp.StatusCode == http.StatusUnauthorized {
+			// Already holding sessionMu, so clear inline rather than via ClearSession
+			c.session = nil
+			c.removeCachedSession()
+		}
+		return nil, c.responseError("session request", resp, body)
 	}
 
 	var sessionData struct {
-		APIUrl       string                    `json:"apiUrl"`
-		Accounts     map[string]map[string]any `json:"accounts"`
-		Capabilities map[string]any            `json:"capabilities"`
-		DownloadURL  string                    `json:"downloadUrl"`
-		UploadURL    string                    `json:"uploadUrl"`
+		APIUrl       string                 `json:"apiUrl"`
+		Accounts     map[string]AccountInfo `json:"accounts"`
+		Capabilities map[string]any         `json:"capabilities"`
+		DownloadURL  string                 `json:"downloadUrl"`
+		UploadURL    string                 `json:"uploadUrl"`
 	}
 
 	if err := json.NewDecoder(resp.Body).Decode(&sessionData); err != nil {
 		return nil, fmt.Errorf("decoding session response: %w", err)
 	}
 
-	// Extract the first account ID deterministically (Fastmail typically has one account)
-	accountIDs := make([]string, 0, len(sessionData.Accounts))
-	for id := range sessionData.Accounts {
-		accountIDs = append(accountIDs, id)
+	// List accounts deterministically (Fastmail typically has one account)
+	accounts := make([]AccountInfo, 0, len(sessionData.Accounts))
+	for id, account := range sessionData.Accounts {
+		account.ID = id
+		accounts = append(accounts, account)
 	}
-	if len(accountIDs) == 0 {
+	if len(accounts) == 0 {
 		return nil, ErrNoAccounts
 	}
-	sort.Strings(accountIDs)
-	accountID := accountIDs[0]
+	sort.Slice(accounts, func(i, j int) bool { return accounts[i].ID < accounts[j].ID })
+
+	accountID := accounts[0].ID
+	if c.accountID != "" {
+		if _, ok := sessionData.Accounts[c.accountID]; !ok {
+			return nil, fmt.Errorf("%w: %s", ErrAccountNotFound, c.accountID)
+		}
+		accountID = c.accountID
+	}
 
 	// Build and cache session
 	c.session = &Session{
@@ -293,10 +548,12 @@ func (c *Client) GetSession(ctx context.Context) (*Session, error) {
 		Capabilities: sessionData.Capabilities,
 		DownloadURL:  sessionData.DownloadURL,
 		UploadURL:    sessionData.UploadURL,
+		Accounts:     accounts,
 	}
 
 	// Record the time of successful session fetch
 	c.sessionFetch = time.Now()
+	c.saveCachedSession()
 
 	// Record success in circuit breaker
 	c.circuitBreaker.recordSuccess()
@@ -306,11 +563,20 @@ func (c *Client) GetSession(ctx context.Context) (*Session, error) {
 
 // MakeRequest executes a JMAP request and returns the response
 func (c *Client) MakeRequest(ctx context.Context, req *Request) (*Response, error) {
+	if c.dryRun != nil {
+		c.dryRun(req)
+		return dryRunResponse(req), nil
+	}
+
 	// Check circuit breaker
 	if c.circuitBreaker.isOpen() {
 		return nil, &CircuitBreakerError{}
 	}
 
+	// One deadline covers the session lookup and all retries
+	ctx, cancel := withTimeout(ctx, c.requestTimeout)
+	defer cancel()
+
 	// Ensure we have a session
 	session, err := c.GetSession(ctx)
 	if err != nil {
@@ -342,6 +608,7 @@ func (c *Client) MakeRequest(ctx context.Context, req *Request) (*Response, erro
 			return nil, fmt.Errorf("creating request: %w", reqErr)
 		}
 		httpReq.Header.Set("Authorization", "Bearer "+c.token)
+		httpReq.Header.Set("User-Agent", c.userAgent)
 		httpReq.Header.Set("Content-Type", "application/json")
 		httpReq.Header.Set("X-Request-ID", uuid.New().String())
 		if idempotencyKey != "" {
@@ -350,7 +617,7 @@ func (c *Client) MakeRequest(ctx context.Context, req *Request) (*Response, erro
 		return httpReq, nil
 	}
 
-	httpResp, err := transport.DoWithRetry(ctx, c.http, c.retry, reqFn, func(attempt int, resp *http.Response) (bool, error) {
+	httpResp, err := transport.DoWithRetry(ctx, c.tracedHTTP(RequestKindAPI, req), c.retry, reqFn, func(attempt int, resp *http.Response) (bool, error) {
 		if resp.StatusCode == http.StatusOK {
 			return false, nil
 		}
@@ -376,7 +643,11 @@ func (c *Client) MakeRequest(ctx context.Context, req *Request) (*Response, erro
 
 	if httpResp.StatusCode != http.StatusOK {
 		bodyBytes, _ := io.ReadAll(httpResp.Body) //nolint:errcheck // best-effort read for error message
-		return nil, transport.NewHTTPError("JMAP request", httpResp, bodyBytes)
+		if httpResp.StatusCode == http.StatusUnauthorized {
+			// The cached session may belong to a revoked token
+			c.ClearSession()
+		}
+		return nil, c.responseError("JMAP request", httpResp, bodyBytes)
 	}
 
 	var response Response
@@ -390,11 +661,45 @@ func (c *Client) MakeRequest(ctx context.Context, req *Request) (*Response, erro
 	return &response, nil
 }
 
-// ClearSession clears the cached session, forcing a new session fetch on next request
+// ListAccounts returns every account in the session, sorted by ID, with the
+// capabilities each one supports.
+func (c *Client) ListAccounts(ctx context.Context) ([]AccountInfo, error) {
+	session, err := c.GetSession(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return session.Accounts, nil
+}
+
+// SetAccountID makes the client target the given account instead of the
+// first one in the session. An empty id restores the default. The cached
+// session is dropped so the next call resolves the new account.
+func (c *Client) SetAccountID(id string) {
+	c.sessionMu.Lock()
+	defer c.sessionMu.Unlock()
+	c.accountID = id
+	c.session = nil
+}
+
+// responseError builds the error for a failed HTTP response, with the
+// token redacted from body. A 401 becomes an *AuthError classified from
+// the response (expired, revoked...) that wraps the HTTP error.
+func (c *Client) responseError(op string, resp *http.Response, body []byte) error {
+	body = []byte(c.redactToken(string(body)))
+	httpErr := transport.NewHTTPError(op, resp, body)
+	if resp.StatusCode == http.StatusUnauthorized {
+		return &AuthError{Message: classifyAuthFailure(resp.Header, body), Err: httpErr}
+	}
+	return httpErr
+}
+
+// ClearSession clears the cached session, in memory and on disk, forcing a
+// new session fetch on next request
 func (c *Client) ClearSession() {
 	c.sessionMu.Lock()
 	defer c.sessionMu.Unlock()
 	c.session = nil
+	c.removeCachedSession()
 }
 
 // SetSessionTTL configures the session cache time-to-live duration
@@ -411,6 +716,32 @@ func (c *Client) SetHTTPClient(httpClient *http.Client) {
 // The caller is responsible for closing the returned ReadCloser.
 // Download URL is a template per RFC 8620: {accountId}, {blobId}, {name}, {type} placeholders.
 func (c *Client) DownloadBlob(ctx context.Context, blobID string) (io.ReadCloser, error) {
+	return c.DownloadBlobRange(ctx, blobID, 0)
+}
+
+// DownloadBlobRange downloads a blob starting at byte offset, for resuming a
+// partial download. A non-zero offset is sent as a Range header and the server
+// is expected to answer 206 Partial Content. If it ignores the range and
+// returns the full blob (200), the first offset bytes are skipped so the
+// returned stream always begins at offset.
+// An offset past the end of the blob (416) returns ErrRangeNotSatisfiable.
+// A 404 is retried briefly, as a just-uploaded blob may not be served yet.
+// The transfer timeout covers the whole download, including reading the body.
+func (c *Client) DownloadBlobRange(ctx context.Context, blobID string, offset int64) (io.ReadCloser, error) {
+	if offset < 0 {
+		return nil, fmt.Errorf("invalid download offset %d", offset)
+	}
+
+	ctx, cancel := withTimeout(ctx, c.transferTimeout)
+	body, err := c.downloadBlobRange(ctx, blobID, offset)
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+	return &cancelOnClose{ReadCloser: body, cancel: cancel}, nil
+}
+
+func (c *Client) downloadBlobRange(ctx context.Context, blobID string, offset int64) (io.ReadCloser, error) {
 	// Ensure we have a session
 	session, err := c.GetSession(ctx)
 	if err != nil {
@@ -431,14 +762,21 @@ func (c *Client) DownloadBlob(ctx context.Context, blobID string) (io.ReadCloser
 			return nil, fmt.Errorf("creating download request: %w", reqErr)
 		}
 		req.Header.Set("Authorization", "Bearer "+c.token)
+		req.Header.Set("User-Agent", c.userAgent)
 		req.Header.Set("X-Request-ID", uuid.New().String())
+		if offset > 0 {
+			req.Header.Set("Range", fmt.Sprintf("bytes=%d-", offset))
+		}
 		return req, nil
 	}
 
-	resp, err := transport.DoWithRetry(ctx, c.http, c.retry, reqFn, func(_ int, resp *http.Response) (bool, error) {
-		if resp.StatusCode == http.StatusOK {
+	resp, err := transport.DoWithRetry(ctx, c.tracedHTTP(RequestKindDownload, nil), c.retry, reqFn, func(attempt int, resp *http.Response) (bool, error) {
+		if resp.StatusCode == http.StatusOK || resp.StatusCode == http.StatusPartialContent {
 			return false, nil
 		}
+		if resp.StatusCode == http.StatusNotFound {
+			return attempt < downloadNotFoundRetries, nil
+		}
 		if transport.IsRetriableStatus(resp.StatusCode) {
 			return true, nil
 		}
@@ -448,10 +786,27 @@ func (c *Client) DownloadBlob(ctx context.Context, blobID string) (io.ReadCloser
 		return nil, fmt.Errorf("downloading blob: %w", err)
 	}
 
-	if resp.StatusCode != http.StatusOK {
+	switch {
+	case resp.StatusCode == http.StatusPartialContent && offset > 0:
+		// Server honored the range.
+	case resp.StatusCode == http.StatusOK:
+		if offset > 0 {
+			// Range ignored - fall back to the full download and skip what we already have.
+			if _, err := io.CopyN(io.Discard, resp.Body, offset); err != nil {
+				_ = resp.Body.Close()
+				return nil, fmt.Errorf("skipping %d already-downloaded bytes: %w", offset, err)
+			}
+		}
+	case resp.StatusCode == http.StatusRequestedRangeNotSatisfiable:
+		_ = resp.Body.Close()
+		return nil, fmt.Errorf("%w (offset %d): the partial file may be stale, delete it and download again", ErrRangeNotSatisfiable, offset)
+	default:
 		body, _ := io.ReadAll(resp.Body) //nolint:errcheck // best-effort read for error message
 		_ = resp.Body.Close()
-		return nil, transport.NewHTTPError("download", resp, body)
+		if resp.StatusCode == http.StatusUnauthorized {
+			c.ClearSession()
+		}
+		return nil, c.responseError("download", resp, body)
 	}
 
 	// Success - return the body as a ReadCloser (caller closes it).
@@ -494,19 +849,74 @@ func (c *Client) UploadBlob(ctx context.Context, reader io.Reader, contentType s
 		return nil, fmt.Errorf("upload content size exceeds maximum allowed size of %d bytes (50MB)", MaxUploadSize)
 	}
 
+	return c.uploadBlob(ctx, uploadURL, contentType, int64(len(content)), func() (io.ReadCloser, error) {
+		return io.NopCloser(bytes.NewReader(content)), nil
+	})
+}
+
+// UploadBlobFromFile uploads the file at path without buffering it in memory.
+// The file is re-opened for each retry attempt and sent with a known
+// Content-Length.
+func (c *Client) UploadBlobFromFile(ctx context.Context, path, contentType string) (*UploadBlobResult, error) {
+	if contentType == "" {
+		return nil, fmt.Errorf("contentType is required")
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading upload file: %w", err)
+	}
+	if info.IsDir() {
+		return nil, fmt.Errorf("cannot upload directory: %s", path)
+	}
+	if info.Size() > MaxUploadSize {
+		return nil, fmt.Errorf("upload content size exceeds maximum allowed size of %d bytes (50MB)", MaxUploadSize)
+	}
+
+	session, err := c.GetSession(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("getting session: %w", err)
+	}
+
+	// Build upload URL by replacing {accountId} placeholder
+	uploadURL := strings.Replace(session.UploadURL, "{accountId}", session.AccountID, 1)
+
+	return c.uploadBlob(ctx, uploadURL, contentType, info.Size(), func() (io.ReadCloser, error) {
+		return os.Open(path)
+	})
+}
+
+// uploadBlob posts a blob of the given size. openBody is called once per
+// attempt so retries can re-send the content from the start.
+func (c *Client) uploadBlob(ctx context.Context, uploadURL, contentType string, size int64, openBody func() (io.ReadCloser, error)) (*UploadBlobResult, error) {
+	if c.dryRun != nil {
+		return &UploadBlobResult{BlobID: DryRunBlobID, Type: contentType, Size: size}, nil
+	}
+
+	ctx, cancel := withTimeout(ctx, c.transferTimeout)
+	defer cancel()
+
 	reqFn := func(ctx context.Context) (*http.Request, error) {
-		req, reqErr := http.NewRequestWithContext(ctx, http.MethodPost, uploadURL, bytes.NewReader(content))
+		body, openErr := openBody()
+		if openErr != nil {
+			return nil, fmt.Errorf("opening upload content: %w", openErr)
+		}
+
+		req, reqErr := http.NewRequestWithContext(ctx, http.MethodPost, uploadURL, body)
 		if reqErr != nil {
+			_ = body.Close()
 			return nil, fmt.Errorf("creating upload request: %w", reqErr)
 		}
+		req.ContentLength = size
 
 		req.Header.Set("Authorization", "Bearer "+c.token)
+		req.Header.Set("User-Agent", c.userAgent)
 		req.Header.Set("Content-Type", contentType)
 		req.Header.Set("X-Request-ID", uuid.New().String())
 		return req, nil
 	}
 
-	resp, err := transport.DoWithRetry(ctx, c.http, c.retry, reqFn, func(_ int, resp *http.Response) (bool, error) {
+	resp, err := transport.DoWithRetry(ctx, c.tracedHTTP(RequestKindUpload, nil), c.retry, reqFn, func(_ int, resp *http.Response) (bool, error) {
 		if resp.StatusCode == http.StatusCreated || resp.StatusCode == http.StatusOK {
 			return false, nil
 		}
@@ -522,7 +932,10 @@ func (c *Client) UploadBlob(ctx context.Context, reader io.Reader, contentType s
 
 	if resp.StatusCode != http.StatusCreated && resp.StatusCode != http.StatusOK {
 		body, _ := io.ReadAll(resp.Body) //nolint:errcheck // best-effort read for error message
-		return nil, transport.NewHTTPError("upload", resp, body)
+		if resp.StatusCode == http.StatusUnauthorized {
+			c.ClearSession()
+		}
+		return nil, c.responseError("upload", resp, body)
 	}
 
 	var result UploadBlobResult
@@ -0,0 +1,369 @@
+package jmap
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/salmonumbrella/fastmail-cli/internal/testutil"
+)
+
+func TestSetRequestTimeout_RetriesShareDeadline(t *testing.T) {
+	var attempts int32
+	apiServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&attempts, 1)
+		time.Sleep(30 * time.Millisecond)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer apiServer.Close()
+
+	sessionServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"apiUrl": "` + apiServer.URL + `", "accounts": {"acc123": {}}}`))
+	}))
+	defer sessionServer.Close()
+
+	client := NewClientWithBaseURL("test-token", sessionServer.URL)
+	client.SetRetryConfig(RetryConfig{MaxRetries: 50, InitialDelay: 20 * time.Millisecond, MaxDelay: 20 * time.Millisecond})
+	client.SetRequestTimeout(200 * time.Millisecond)
+
+	start := time.Now()
+	_, err := client.MakeRequest(context.Background(), &Request{
+		Using:       []string{"urn:ietf:params:jmap:core"},
+		MethodCalls: []MethodCall{{"Core/echo", map[string]any{}, "0"}},
+	})
+	elapsed := time.Since(start)
+
+	if err == nil {
+		t.Fatal("expected error when deadline expires")
+	}
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("error = %v, want deadline exceeded", err)
+	}
+	if elapsed > time.Second {
+		t.Errorf("MakeRequest took %v; retries did not share the 200ms deadline", elapsed)
+	}
+	if n := atomic.LoadInt32(&attempts); n >= 50 {
+		t.Errorf("attempts = %d, expected deadline to stop retries early", n)
+	}
+}
+
+func TestSetRequestTimeout_LeavesTransferTimeout(t *testing.T) {
+	client := NewClient("test-token")
+	client.SetRequestTimeout(5 * time.Second)
+
+	if client.requestTimeout != 5*time.Second {
+		t.Errorf("requestTimeout = %v, want 5s", client.requestTimeout)
+	}
+	if client.transferTimeout != DefaultTransferTimeout {
+		t.Errorf("transferTimeout = %v, want %v", client.transferTimeout, DefaultTransferTimeout)
+	}
+}
+
+func TestDownloadBlob_BodyOutlivesCall(t *testing.T) {
+	downloadServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("payload"))
+	}))
+	defer downloadServer.Close()
+
+	sessionServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{
+			"apiUrl": "` + downloadServer.URL + `",
+			"downloadUrl": "` + downloadServer.URL + `/{accountId}/{blobId}/{name}?type={type}",
+			"accounts": {"acc123": {}}
+		}`))
+	}))
+	defer sessionServer.Close()
+
+	client := NewClientWithBaseURL("test-token", sessionServer.URL)
+
+	reader, err := client.DownloadBlob(context.Background(), "Gblob")
+	if err != nil {
+		t.Fatalf("DownloadBlob: %v", err)
+	}
+	defer reader.Close()
+
+	got, err := io.ReadAll(reader)
+	if err != nil {
+		t.Fatalf("reading body after DownloadBlob returned: %v", err)
+	}
+	if string(got) != "payload" {
+		t.Errorf("body = %q, want payload", got)
+	}
+}
+
+func TestUserAgent_SentOnAllRequests(t *testing.T) {
+	var agents []string
+	apiServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		agents = append(agents, r.URL.Path+" "+r.UserAgent())
+		if r.Method == http.MethodGet {
+			_, _ = w.Write([]byte("payload"))
+			return
+		}
+		_, _ = w.Write([]byte(`{"methodResponses": [["Core/echo", {}, "c0"]]}`))
+	}))
+	defer apiServer.Close()
+
+	sessionServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		agents = append(agents, "session "+r.UserAgent())
+		_, _ = w.Write([]byte(`{
+			"apiUrl": "` + apiServer.URL + `/api",
+			"downloadUrl": "` + apiServer.URL + `/download/{blobId}",
+			"accounts": {"acc123": {}}
+		}`))
+	}))
+	defer sessionServer.Close()
+
+	client := NewClientWithBaseURL("test-token", sessionServer.URL)
+	client.SetUserAgent("my-script/1.0")
+
+	if _, err := client.MakeRequest(context.Background(), &Request{
+		Using:       []string{"urn:ietf:params:jmap:core"},
+		MethodCalls: []MethodCall{{"Core/echo", map[string]any{}, "c0"}},
+	}); err != nil {
+		t.Fatalf("MakeRequest: %v", err)
+	}
+	reader, err := client.DownloadBlob(context.Background(), "Gblob")
+	if err != nil {
+		t.Fatalf("DownloadBlob: %v", err)
+	}
+	reader.Close()
+
+	want := []string{"session my-script/1.0", "/api my-script/1.0", "/download/Gblob my-script/1.0"}
+	if strings.Join(agents, "|") != strings.Join(want, "|") {
+		t.Errorf("requests = %q, want %q", agents, want)
+	}
+
+	client.SetUserAgent("")
+	if client.userAgent != DefaultUserAgent {
+		t.Errorf("userAgent after reset = %q, want %q", client.userAgent, DefaultUserAgent)
+	}
+}
+
+func TestMakeRequest_RetryAfterHTTPDate(t *testing.T) {
+	retryAt := time.Now().Add(20 * time.Second).UTC()
+	apiServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Retry-After", retryAt.Format(http.TimeFormat))
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer apiServer.Close()
+
+	sessionServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"apiUrl": "` + apiServer.URL + `", "accounts": {"acc123": {}}}`))
+	}))
+	defer sessionServer.Close()
+
+	client := NewClientWithBaseURL("test-token", sessionServer.URL)
+	client.SetRetryConfig(RetryConfig{MaxRetries: 0, InitialDelay: time.Millisecond, MaxDelay: time.Minute})
+
+	_, err := client.MakeRequest(context.Background(), &Request{
+		Using:       []string{"urn:ietf:params:jmap:core"},
+		MethodCalls: []MethodCall{{"Core/echo", map[string]any{}, "0"}},
+	})
+
+	var rle *RateLimitError
+	if !errors.As(err, &rle) {
+		t.Fatalf("error = %v, want RateLimitError", err)
+	}
+	// HTTP-dates have one-second resolution, so allow for truncation and test latency.
+	if rle.RetryAfter < 18*time.Second || rle.RetryAfter > 20*time.Second {
+		t.Errorf("RetryAfter = %v, want ~20s from the HTTP-date", rle.RetryAfter)
+	}
+}
+
+func TestErrorBodies_RedactToken(t *testing.T) {
+	const token = "super-secret-token"
+
+	// echo mimics a misconfigured proxy that reflects request headers in its error body.
+	echo := func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte("bad request; Authorization: " + r.Header.Get("Authorization")))
+	}
+
+	apiServer := httptest.NewServer(http.HandlerFunc(echo))
+	defer apiServer.Close()
+
+	sessionOK := true
+	sessionServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !sessionOK {
+			echo(w, r)
+			return
+		}
+		_, _ = w.Write([]byte(`{
+			"apiUrl": "` + apiServer.URL + `",
+			"downloadUrl": "` + apiServer.URL + `/download/{accountId}/{blobId}/{name}?type={type}",
+			"uploadUrl": "` + apiServer.URL + `/upload/{accountId}/",
+			"accounts": {"acc123": {}}
+		}`))
+	}))
+	defer sessionServer.Close()
+
+	client := NewClientWithBaseURL(token, sessionServer.URL)
+	client.SetRetryConfig(RetryConfig{MaxRetries: 0, InitialDelay: time.Millisecond, MaxDelay: time.Millisecond})
+	ctx := context.Background()
+
+	checks := map[string]func() error{
+		"MakeRequest": func() error {
+			_, err := client.MakeRequest(ctx, &Request{
+				Using:       []string{"urn:ietf:params:jmap:core"},
+				MethodCalls: []MethodCall{{"Core/echo", map[string]any{}, "0"}},
+			})
+			return err
+		},
+		"DownloadBlob": func() error {
+			_, err := client.DownloadBlob(ctx, "blob1")
+			return err
+		},
+		"UploadBlob": func() error {
+			_, err := client.UploadBlob(ctx, strings.NewReader("data"), "text/plain")
+			return err
+		},
+	}
+	for name, call := range checks {
+		err := call()
+		if err == nil {
+			t.Fatalf("%s: expected error", name)
+		}
+		if strings.Contains(err.Error(), token) {
+			t.Errorf("%s error leaks token: %v", name, err)
+		}
+		if !strings.Contains(err.Error(), "Bearer ***") {
+			t.Errorf("%s error = %v, want redacted bearer", name, err)
+		}
+	}
+
+	sessionOK = false
+	client.ClearSession()
+	_, err := client.GetSession(ctx)
+	if err == nil {
+		t.Fatal("GetSession: expected error")
+	}
+	if strings.Contains(err.Error(), token) || !strings.Contains(err.Error(), "Bearer ***") {
+		t.Errorf("GetSession error = %v, want token redacted", err)
+	}
+}
+
+func TestListAccountsAndSetAccountID(t *testing.T) {
+	sessionServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{
+			"apiUrl": "https://api.example.com/jmap/api/",
+			"accounts": {
+				"u2": {"name": "shared@example.com", "isPersonal": false, "isReadOnly": true,
+					"accountCapabilities": {"urn:ietf:params:jmap:mail": {}}},
+				"u1": {"name": "me@example.com", "isPersonal": true,
+					"accountCapabilities": {"urn:ietf:params:jmap:mail": {}, "urn:ietf:params:jmap:calendars": {}}}
+			}
+		}`))
+	}))
+	defer sessionServer.Close()
+
+	client := NewClientWithBaseURL("test-token", sessionServer.URL)
+	ctx := context.Background()
+
+	accounts, err := client.ListAccounts(ctx)
+	if err != nil {
+		t.Fatalf("ListAccounts() error = %v", err)
+	}
+	if len(accounts) != 2 || accounts[0].ID != "u1" || accounts[1].ID != "u2" {
+		t.Fatalf("accounts = %+v, want u1, u2 sorted", accounts)
+	}
+	if accounts[0].Name != "me@example.com" || !accounts[0].IsPersonal {
+		t.Errorf("accounts[0] = %+v", accounts[0])
+	}
+	if _, ok := accounts[0].Capabilities["urn:ietf:params:jmap:calendars"]; !ok {
+		t.Errorf("accounts[0] capabilities = %v, want calendars", accounts[0].Capabilities)
+	}
+	if !accounts[1].IsReadOnly || len(accounts[1].Capabilities) != 1 {
+		t.Errorf("accounts[1] = %+v", accounts[1])
+	}
+
+	session, err := client.GetSession(ctx)
+	if err != nil {
+		t.Fatalf("GetSession() error = %v", err)
+	}
+	if session.AccountID != "u1" {
+		t.Errorf("default AccountID = %q, want first account u1", session.AccountID)
+	}
+
+	client.SetAccountID("u2")
+	session, err = client.GetSession(ctx)
+	if err != nil {
+		t.Fatalf("GetSession() after SetAccountID error = %v", err)
+	}
+	if session.AccountID != "u2" {
+		t.Errorf("AccountID = %q, want u2", session.AccountID)
+	}
+
+	client.SetAccountID("missing")
+	if _, err := client.GetSession(ctx); !errors.Is(err, ErrAccountNotFound) {
+		t.Errorf("GetSession() with unknown account error = %v, want ErrAccountNotFound", err)
+	}
+}
+
+func TestSessionCapabilities(t *testing.T) {
+	jm := testutil.NewJMAPMock()
+	defer jm.Close()
+	jm.AddCapability(contactsCapability)
+	jm.AddCapability(maskedEmailNamespace)
+
+	client := NewClientWithBaseURL("test-token", jm.URL())
+	session, err := client.GetSession(context.Background())
+	if err != nil {
+		t.Fatalf("GetSession() error = %v", err)
+	}
+
+	want := []string{
+		maskedEmailNamespace,
+		"urn:ietf:params:jmap:contacts",
+		"urn:ietf:params:jmap:core",
+		"urn:ietf:params:jmap:mail",
+		"urn:ietf:params:jmap:submission",
+	}
+	if got := session.CapabilityURIs(); !reflect.DeepEqual(got, want) {
+		t.Errorf("CapabilityURIs() = %v, want %v", got, want)
+	}
+	if !session.HasCapability(contactsCapability) || session.HasCapability(calendarsCapability) {
+		t.Errorf("HasCapability() wrong for contacts/calendars")
+	}
+	if got := CapabilityName(maskedEmailNamespace); got != "masked-email" {
+		t.Errorf("CapabilityName(masked email) = %q", got)
+	}
+	if got := CapabilityName("urn:example:custom"); got != "urn:example:custom" {
+		t.Errorf("CapabilityName(unknown) = %q, want the URI", got)
+	}
+}
+
+func TestRequireCapability(t *testing.T) {
+	jm := testutil.NewJMAPMock()
+	defer jm.Close()
+	jm.AddCapability(CapabilityCalendars)
+
+	client := NewClientWithBaseURL("test-token", jm.URL())
+	ctx := context.Background()
+
+	if ok, err := client.HasCapability(ctx, CapabilityCalendars); err != nil || !ok {
+		t.Errorf("HasCapability(calendars) = %v, %v, want true", ok, err)
+	}
+	if err := client.RequireCapability(ctx, CapabilityCalendars); err != nil {
+		t.Errorf("RequireCapability(calendars) = %v, want nil", err)
+	}
+
+	for uri, want := range map[string]error{
+		CapabilityMaskedEmail: ErrMaskedEmailNotEnabled,
+		CapabilityVacation:    ErrVacationNotEnabled,
+		CapabilityContacts:    ErrContactsNotEnabled,
+	} {
+		if err := client.RequireCapability(ctx, uri); !errors.Is(err, want) {
+			t.Errorf("RequireCapability(%s) = %v, want %v", uri, err, want)
+		}
+	}
+	if err := client.RequireCapability(ctx, "urn:example:other"); err == nil {
+		t.Error("RequireCapability(unknown) = nil, want an error")
+	}
+}
@@ -0,0 +1,215 @@
+package jmap
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// ContactGroup is a ContactCard of kind "group" (RFC 9553). Members are
+// referenced by the uid of each member card.
+type ContactGroup struct {
+	ID      string          `json:"id"`
+	UID     string          `json:"uid,omitempty"`
+	Name    string          `json:"name"`
+	Members map[string]bool `json:"members,omitempty"`
+}
+
+// MemberUIDs returns the group's member uids in sorted order.
+func (g *ContactGroup) MemberUIDs() []string {
+	uids := make([]string, 0, len(g.Members))
+	for uid, ok := range g.Members {
+		if ok {
+			uids = append(uids, uid)
+		}
+	}
+	sort.Strings(uids)
+	return uids
+}
+
+// GetContactGroups retrieves all contact groups
+func (c *Client) GetContactGroups(ctx context.Context) ([]ContactGroup, error) {
+	session, err := c.GetSession(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// Check if contacts capability is available
+	if _, ok := session.Capabilities[contactsCapability]; !ok {
+		return nil, ErrContactsNotEnabled
+	}
+
+	req := &Request{
+		Using: []string{"urn:ietf:params:jmap:core", contactsCapability},
+		MethodCalls: []MethodCall{
+			{"ContactCard/query", map[string]any{
+				"accountId": session.AccountID,
+				"filter":    map[string]any{"kind": "group"},
+			}, "0"},
+			{"ContactCard/get", map[string]any{
+				"accountId": session.AccountID,
+				"#ids": map[string]any{
+					"resultOf": "0",
+					"name":     "ContactCard/query",
+					"path":     "/ids",
+				},
+				"properties": []string{"id", "uid", "name", "members"},
+			}, "1"},
+		},
+	}
+
+	resp, err := c.MakeRequest(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := decodeMethodResponse[struct {
+		List []ContactGroup `json:"list"`
+	}](resp, 1)
+	if err != nil {
+		return nil, err
+	}
+
+	return result.List, nil
+}
+
+// CreateContactGroup creates a new, empty contact group
+func (c *Client) CreateContactGroup(ctx context.Context, name string) (*ContactGroup, error) {
+	if strings.TrimSpace(name) == "" {
+		return nil, &ValidationError{Field: "name", Message: "group name is required"}
+	}
+
+	session, err := c.GetSession(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// Check if contacts capability is available
+	if _, ok := session.Capabilities[contactsCapability]; !ok {
+		return nil, ErrContactsNotEnabled
+	}
+
+	req := &Request{
+		Using: []string{"urn:ietf:params:jmap:core", contactsCapability},
+		MethodCalls: []MethodCall{
+			{"ContactCard/set", map[string]any{
+				"accountId": session.AccountID,
+				"create": map[string]any{
+					"new-group": map[string]any{
+						"kind":    "group",
+						"name":    name,
+						"members": map[string]bool{},
+					},
+				},
+			}, "0"},
+		},
+	}
+
+	resp, err := c.MakeRequest(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := decodeMethodResponse[struct {
+		Created    map[string]ContactGroup `json:"created"`
+		NotCreated map[string]any          `json:"notCreated"`
+	}](resp, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	if errInfo, exists := result.NotCreated["new-group"]; exists {
+		return nil, fmt.Errorf("failed to create contact group: %s", setErrorMessage(errInfo))
+	}
+
+	created, ok := result.Created["new-group"]
+	if !ok {
+		return nil, fmt.Errorf("contact group creation failed")
+	}
+	if created.Name == "" {
+		created.Name = name
+	}
+
+	return &created, nil
+}
+
+// AddContactToGroup adds a contact to a group
+func (c *Client) AddContactToGroup(ctx context.Context, groupID, contactID string) error {
+	return c.setContactGroupMembership(ctx, groupID, contactID, true)
+}
+
+// RemoveContactFromGroup removes a contact from a group
+func (c *Client) RemoveContactFromGroup(ctx context.Context, groupID, contactID string) error {
+	return c.setContactGroupMembership(ctx, groupID, contactID, false)
+}
+
+// setContactGroupMembership patches members/<uid> on the group card.
+func (c *Client) setContactGroupMembership(ctx context.Context, groupID, contactID string, member bool) error {
+	contact, err := c.GetContactByID(ctx, contactID)
+	if err != nil {
+		return err
+	}
+	if contact.UID == "" {
+		return fmt.Errorf("contact %s has no uid; cannot reference it from a group", contactID)
+	}
+
+	session, err := c.GetSession(ctx)
+	if err != nil {
+		return err
+	}
+
+	var memberValue any
+	if member {
+		memberValue = true
+	} else {
+		memberValue = nil // null in JMAP removes the member
+	}
+
+	req := &Request{
+		Using: []string{"urn:ietf:params:jmap:core", contactsCapability},
+		MethodCalls: []MethodCall{
+			{"ContactCard/set", map[string]any{
+				"accountId": session.AccountID,
+				"update": map[string]any{
+					groupID: map[string]any{
+						"members/" + escapeJSONPointer(contact.UID): memberValue,
+					},
+				},
+			}, "0"},
+		},
+	}
+
+	resp, err := c.MakeRequest(ctx, req)
+	if err != nil {
+		return err
+	}
+
+	result, err := decodeMethodResponse[struct {
+		Updated    map[string]any `json:"updated"`
+		NotUpdated map[string]any `json:"notUpdated"`
+	}](resp, 0)
+	if err != nil {
+		return err
+	}
+
+	if errInfo, exists := result.NotUpdated[groupID]; exists {
+		if m, ok := errInfo.(map[string]any); ok && getString(m, "type") == "notFound" {
+			return fmt.Errorf("%w: %s", ErrContactGroupNotFound, groupID)
+		}
+		return fmt.Errorf("failed to update contact group: %s", setErrorMessage(errInfo))
+	}
+
+	if _, ok := result.Updated[groupID]; !ok {
+		return fmt.Errorf("contact group update failed")
+	}
+
+	return nil
+}
+
+// escapeJSONPointer escapes a value for use as a JSON Pointer segment (RFC 6901)
+// in JMAP patch paths.
+func escapeJSONPointer(s string) string {
+	s = strings.ReplaceAll(s, "~", "~0")
+	return strings.ReplaceAll(s, "/", "~1")
+}
@@ -0,0 +1,185 @@
+package jmap
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+// newContactGroupServer returns a client whose API answers each method with
+// the given handler, keyed by method name.
+func newContactGroupServer(t *testing.T, handlers map[string]func(args map[string]any) map[string]any) *Client {
+	t.Helper()
+
+	apiServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			MethodCalls [][]json.RawMessage `json:"methodCalls"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Fatalf("failed to decode request: %v", err)
+		}
+
+		responses := []any{}
+		for _, call := range req.MethodCalls {
+			var method, callID string
+			var args map[string]any
+			_ = json.Unmarshal(call[0], &method)
+			_ = json.Unmarshal(call[1], &args)
+			_ = json.Unmarshal(call[2], &callID)
+
+			handler, ok := handlers[method]
+			if !ok {
+				t.Fatalf("unexpected method %s", method)
+			}
+			responses = append(responses, []any{method, handler(args), callID})
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]any{"methodResponses": responses})
+	}))
+	t.Cleanup(apiServer.Close)
+
+	sessionServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{
+			"apiUrl": "` + apiServer.URL + `",
+			"accounts": {"acc123": {}},
+			"capabilities": {
+				"urn:ietf:params:jmap:core": {},
+				"urn:ietf:params:jmap:contacts": {}
+			}
+		}`))
+	}))
+	t.Cleanup(sessionServer.Close)
+
+	client := NewClient("test-token")
+	client.baseURL = sessionServer.URL
+	return client
+}
+
+func TestGetContactGroups(t *testing.T) {
+	var queryFilter map[string]any
+	client := newContactGroupServer(t, map[string]func(map[string]any) map[string]any{
+		"ContactCard/query": func(args map[string]any) map[string]any {
+			queryFilter, _ = args["filter"].(map[string]any)
+			return map[string]any{"ids": []string{"g1"}}
+		},
+		"ContactCard/get": func(args map[string]any) map[string]any {
+			return map[string]any{"list": []any{map[string]any{
+				"id":      "g1",
+				"uid":     "urn:uuid:g1",
+				"name":    "Friends",
+				"members": map[string]any{"urn:uuid:b": true, "urn:uuid:a": true},
+			}}}
+		},
+	})
+
+	groups, err := client.GetContactGroups(context.Background())
+	if err != nil {
+		t.Fatalf("GetContactGroups() error = %v", err)
+	}
+
+	if queryFilter["kind"] != "group" {
+		t.Errorf("query filter = %v, want kind=group", queryFilter)
+	}
+	if len(groups) != 1 || groups[0].Name != "Friends" {
+		t.Fatalf("groups = %+v", groups)
+	}
+	if got, want := groups[0].MemberUIDs(), []string{"urn:uuid:a", "urn:uuid:b"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("MemberUIDs() = %v, want %v", got, want)
+	}
+}
+
+func TestCreateContactGroup(t *testing.T) {
+	var created map[string]any
+	client := newContactGroupServer(t, map[string]func(map[string]any) map[string]any{
+		"ContactCard/set": func(args map[string]any) map[string]any {
+			create := args["create"].(map[string]any)
+			for key, obj := range create {
+				created = obj.(map[string]any)
+				return map[string]any{"created": map[string]any{key: map[string]any{"id": "g9"}}}
+			}
+			return nil
+		},
+	})
+
+	group, err := client.CreateContactGroup(context.Background(), "Book club")
+	if err != nil {
+		t.Fatalf("CreateContactGroup() error = %v", err)
+	}
+
+	if created["kind"] != "group" || created["name"] != "Book club" {
+		t.Errorf("create payload = %v", created)
+	}
+	if group.ID != "g9" || group.Name != "Book club" {
+		t.Errorf("group = %+v", group)
+	}
+
+	if _, err := client.CreateContactGroup(context.Background(), " "); !IsValidationError(err) {
+		t.Errorf("empty name error = %v, want validation error", err)
+	}
+}
+
+func TestContactGroupMembership(t *testing.T) {
+	tests := []struct {
+		name   string
+		add    bool
+		expect any
+	}{
+		{name: "add", add: true, expect: true},
+		{name: "remove", add: false, expect: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var patch map[string]any
+			client := newContactGroupServer(t, map[string]func(map[string]any) map[string]any{
+				"ContactCard/get": func(args map[string]any) map[string]any {
+					return map[string]any{"list": []any{map[string]any{"id": "c1", "uid": "urn:uuid:a/b~c"}}}
+				},
+				"ContactCard/set": func(args map[string]any) map[string]any {
+					patch = args["update"].(map[string]any)["g1"].(map[string]any)
+					return map[string]any{"updated": map[string]any{"g1": nil}}
+				},
+			})
+
+			var err error
+			if tt.add {
+				err = client.AddContactToGroup(context.Background(), "g1", "c1")
+			} else {
+				err = client.RemoveContactFromGroup(context.Background(), "g1", "c1")
+			}
+			if err != nil {
+				t.Fatalf("membership change error = %v", err)
+			}
+
+			value, ok := patch["members/urn:uuid:a~1b~0c"]
+			if !ok {
+				t.Fatalf("patch = %v, want escaped members path", patch)
+			}
+			if value != tt.expect {
+				t.Errorf("member value = %v, want %v", value, tt.expect)
+			}
+		})
+	}
+}
+
+func TestAddContactToGroup_GroupNotFound(t *testing.T) {
+	client := newContactGroupServer(t, map[string]func(map[string]any) map[string]any{
+		"ContactCard/get": func(args map[string]any) map[string]any {
+			return map[string]any{"list": []any{map[string]any{"id": "c1", "uid": "urn:uuid:a"}}}
+		},
+		"ContactCard/set": func(args map[string]any) map[string]any {
+			return map[string]any{"notUpdated": map[string]any{"missing": map[string]any{"type": "notFound"}}}
+		},
+	})
+
+	err := client.AddContactToGroup(context.Background(), "missing", "c1")
+	if !errors.Is(err, ErrContactGroupNotFound) {
+		t.Errorf("error = %v, want ErrContactGroupNotFound", err)
+	}
+}
@@ -3,16 +3,21 @@ package jmap
 import (
 	"context"
 	"fmt"
+	"strings"
 	"time"
+
+	"github.com/salmonumbrella/fastmail-cli/internal/validation"
 )
 
 // Contact represents a JMAP contact (RFC 9610)
 type Contact struct {
 	ID          string           `json:"id"`
+	UID         string           `json:"uid,omitempty"`
 	Name        string           `json:"name"`
 	Emails      []ContactEmail   `json:"emails,omitempty"`
 	Phones      []ContactPhone   `json:"phones,omitempty"`
 	Addresses   []ContactAddress `json:"addresses,omitempty"`
+	Online      []ContactOnline  `json:"online,omitempty"`
 	Company     string           `json:"company,omitempty"`
 	JobTitle    string           `json:"jobTitle,omitempty"`
 	Notes       string           `json:"notes,omitempty"`
@@ -43,6 +48,13 @@ type ContactAddress struct {
 	Country    string `json:"country,omitempty"`
 }
 
+// ContactOnline represents an online resource (website, profile, username) for a contact
+type ContactOnline struct {
+	Type  string `json:"type"` // uri, username, other
+	Value string `json:"value"`
+	Label string `json:"label,omitempty"`
+}
+
 // AddressBook represents a JMAP address book
 type AddressBook struct {
 	ID           string `json:"id"`
@@ -186,6 +198,97 @@ func (c *Client) GetContactByID(ctx context.Context, id string) (*Contact, error
 	return &result.List[0], nil
 }
 
+// GetContactByEmail returns the first contact with the given email address.
+// Returns ErrContactNotFound if no contact has it.
+func (c *Client) GetContactByEmail(ctx context.Context, email string) (*Contact, error) {
+	session, err := c.GetSession(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// Check if contacts capability is available
+	if _, ok := session.Capabilities[contactsCapability]; !ok {
+		return nil, ErrContactsNotEnabled
+	}
+
+	req := &Request{
+		Using: []string{"urn:ietf:params:jmap:core", contactsCapability},
+		MethodCalls: []MethodCall{
+			{"ContactCard/query", map[string]any{
+				"accountId": session.AccountID,
+				"filter": map[string]any{
+					"email": email,
+				},
+				"limit": 1,
+			}, "0"},
+			{"ContactCard/get", map[string]any{
+				"accountId": session.AccountID,
+				"#ids": map[string]any{
+					"resultOf": "0",
+					"name":     "ContactCard/query",
+					"path":     "/ids",
+				},
+			}, "1"},
+		},
+	}
+
+	resp, err := c.MakeRequest(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := decodeMethodResponse[struct {
+		List []Contact `json:"list"`
+	}](resp, 1)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(result.List) == 0 {
+		return nil, fmt.Errorf("%w: %s", ErrContactNotFound, email)
+	}
+
+	return &result.List[0], nil
+}
+
+// contactCreatePayload builds the ContactCard/set create object for a contact.
+// Server-set properties (id, updated) are omitted.
+func contactCreatePayload(contact *Contact) map[string]any {
+	payload := map[string]any{
+		"name": contact.Name,
+	}
+
+	if len(contact.Emails) > 0 {
+		payload["emails"] = contact.Emails
+	}
+	if len(contact.Phones) > 0 {
+		payload["phones"] = contact.Phones
+	}
+	if len(contact.Addresses) > 0 {
+		payload["addresses"] = contact.Addresses
+	}
+	if len(contact.Online) > 0 {
+		payload["online"] = contact.Online
+	}
+	if contact.Company != "" {
+		payload["company"] = contact.Company
+	}
+	if contact.JobTitle != "" {
+		payload["jobTitle"] = contact.JobTitle
+	}
+	if contact.Notes != "" {
+		payload["notes"] = contact.Notes
+	}
+	if contact.Birthday != "" {
+		payload["birthday"] = contact.Birthday
+	}
+	if contact.Anniversary != "" {
+		payload["anniversary"] = contact.Anniversary
+	}
+
+	return payload
+}
+
 // CreateContact creates a new contact
 func (c *Client) CreateContact(ctx context.Context, contact *Contact) (*Contact, error) {
 	session, err := c.GetSession(ctx)
@@ -204,7 +307,7 @@ func (c *Client) CreateContact(ctx context.Context, contact *Contact) (*Contact,
 			{"ContactCard/set", map[string]any{
 				"accountId": session.AccountID,
 				"create": map[string]any{
-					"new-contact": contact,
+					"new-contact": contactCreatePayload(contact),
 				},
 			}, "0"},
 		},
@@ -230,7 +333,9 @@ func (c *Client) CreateContact(ctx context.Context, contact *Contact) (*Contact,
 	return &created, nil
 }
 
-// UpdateContact updates an existing contact
+// UpdateContact updates an existing contact.
+// Values in updates may be nested structures such as []ContactPhone,
+// []ContactAddress, or []ContactOnline; they replace the existing property.
 func (c *Client) UpdateContact(ctx context.Context, id string, updates map[string]interface{}) (*Contact, error) {
 	session, err := c.GetSession(ctx)
 	if err != nil {
@@ -359,3 +464,36 @@ func (c *Client) SearchContacts(ctx context.Context, query string, limit int) ([
 
 	return result.List, nil
 }
+
+// ResolveRecipient turns a recipient into addresses. A valid email address is
+// returned as is; anything else is searched for in contacts and every address
+// of the matching contacts is returned, without duplicates. An empty result
+// means no contact matched.
+func (c *Client) ResolveRecipient(ctx context.Context, nameOrEmail string) ([]EmailAddress, error) {
+	nameOrEmail = strings.TrimSpace(nameOrEmail)
+	if nameOrEmail == "" {
+		return nil, &ValidationError{Field: "recipient", Message: "cannot be empty"}
+	}
+	if validation.IsValidEmail(nameOrEmail) {
+		return []EmailAddress{{Email: nameOrEmail}}, nil
+	}
+
+	contacts, err := c.SearchContacts(ctx, nameOrEmail, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	var addrs []EmailAddress
+	seen := make(map[string]bool)
+	for _, contact := range contacts {
+		for _, e := range contact.Emails {
+			key := strings.ToLower(e.Value)
+			if seen[key] || !validation.IsValidEmail(e.Value) {
+				continue
+			}
+			seen[key] = true
+			addrs = append(addrs, EmailAddress{Name: contact.Name, Email: e.Value})
+		}
+	}
+	return addrs, nil
+}
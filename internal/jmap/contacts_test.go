@@ -3,8 +3,10 @@ package jmap
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"net/http"
 	"net/http/httptest"
+	"reflect"
 	"testing"
 	"time"
 )
@@ -761,6 +763,56 @@ func TestSearchContacts(t *testing.T) {
 	}
 }
 
+func TestResolveRecipient(t *testing.T) {
+	var queries int
+	apiServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		queries++
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{
+			"methodResponses": [
+				["ContactCard/query", {"accountId": "acc123", "ids": ["c1", "c2"]}, "0"],
+				["ContactCard/get", {"accountId": "acc123", "list": [
+					{"id": "c1", "name": "Jane Smith", "emails": [
+						{"type": "work", "value": "jane@example.com"},
+						{"type": "home", "value": "not-an-address"}
+					]},
+					{"id": "c2", "name": "Jane S.", "emails": [{"type": "other", "value": "JANE@example.com"}]}
+				]}, "1"]
+			]
+		}`))
+	}))
+	defer apiServer.Close()
+
+	sessionServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{
+			"apiUrl": "` + apiServer.URL + `",
+			"accounts": {"acc123": {}},
+			"primaryAccounts": {"urn:ietf:params:jmap:mail": "acc123"},
+			"capabilities": {"urn:ietf:params:jmap:core": {}, "urn:ietf:params:jmap:contacts": {}}
+		}`))
+	}))
+	defer sessionServer.Close()
+
+	client := NewClientWithBaseURL("test-token", sessionServer.URL)
+
+	addrs, err := client.ResolveRecipient(context.Background(), "bob@example.com")
+	if err != nil || len(addrs) != 1 || addrs[0].Email != "bob@example.com" {
+		t.Fatalf("email input: got %v, %v", addrs, err)
+	}
+	if queries != 0 {
+		t.Errorf("expected no contact search for an email address, got %d", queries)
+	}
+
+	addrs, err = client.ResolveRecipient(context.Background(), "jane")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(addrs) != 1 || addrs[0].Email != "jane@example.com" || addrs[0].Name != "Jane Smith" {
+		t.Errorf("got %v, want one address jane@example.com for Jane Smith", addrs)
+	}
+}
+
 // Helper function to check if a string contains another string
 func contains(s, substr string) bool {
 	return len(s) >= len(substr) && (s == substr || len(substr) == 0 ||
@@ -778,3 +830,230 @@ func findSubstring(s, substr string) bool {
 
 // Suppress unused warning for time import
 var _ = time.Now
+
+// newContactStoreServer returns a client backed by a fake server that stores
+// created contacts and applies updates, so tests can round-trip contacts.
+func newContactStoreServer(t *testing.T) (*Client, map[string]map[string]any) {
+	t.Helper()
+
+	store := map[string]map[string]any{}
+
+	apiServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			MethodCalls [][]json.RawMessage `json:"methodCalls"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Fatalf("failed to decode request: %v", err)
+		}
+
+		var method string
+		_ = json.Unmarshal(req.MethodCalls[0][0], &method)
+		var args map[string]any
+		_ = json.Unmarshal(req.MethodCalls[0][1], &args)
+
+		var result map[string]any
+		switch method {
+		case "ContactCard/set":
+			result = map[string]any{}
+			if create, ok := args["create"].(map[string]any); ok {
+				created := map[string]any{}
+				for key, obj := range create {
+					card := obj.(map[string]any)
+					card["id"] = "c1"
+					card["updated"] = "2024-01-01T00:00:00Z"
+					store["c1"] = card
+					created[key] = card
+				}
+				result["created"] = created
+			}
+			if update, ok := args["update"].(map[string]any); ok {
+				updated := map[string]any{}
+				for id, patch := range update {
+					for k, v := range patch.(map[string]any) {
+						store[id][k] = v
+					}
+					updated[id] = store[id]
+				}
+				result["updated"] = updated
+			}
+		case "ContactCard/get":
+			list := []any{}
+			for _, id := range args["ids"].([]any) {
+				if card, ok := store[id.(string)]; ok {
+					list = append(list, card)
+				}
+			}
+			result = map[string]any{"list": list}
+		default:
+			t.Fatalf("unexpected method %s", method)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"methodResponses": []any{[]any{method, result, "0"}},
+		})
+	}))
+	t.Cleanup(apiServer.Close)
+
+	sessionServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{
+			"apiUrl": "` + apiServer.URL + `",
+			"accounts": {"acc123": {}},
+			"capabilities": {
+				"urn:ietf:params:jmap:core": {},
+				"urn:ietf:params:jmap:contacts": {}
+			}
+		}`))
+	}))
+	t.Cleanup(sessionServer.Close)
+
+	client := NewClient("test-token")
+	client.baseURL = sessionServer.URL
+	return client, store
+}
+
+func TestCreateContact_RoundTripsFullFields(t *testing.T) {
+	client, store := newContactStoreServer(t)
+
+	contact := &Contact{
+		Name:      "Jane Smith",
+		Emails:    []ContactEmail{{Type: "work", Value: "jane@example.com"}},
+		Phones:    []ContactPhone{{Type: "mobile", Value: "+1-555-1234"}},
+		Addresses: []ContactAddress{{Type: "home", Street: "1 Main St", City: "Springfield", Country: "US"}},
+		Online:    []ContactOnline{{Type: "uri", Value: "https://example.com"}},
+		Notes:     "Met at conference",
+		Birthday:  "1990-04-01",
+	}
+
+	created, err := client.CreateContact(context.Background(), contact)
+	if err != nil {
+		t.Fatalf("CreateContact() error = %v", err)
+	}
+
+	if _, exists := store["c1"]["updated"]; !exists {
+		t.Fatal("expected server to set updated")
+	}
+
+	got, err := client.GetContactByID(context.Background(), created.ID)
+	if err != nil {
+		t.Fatalf("GetContactByID() error = %v", err)
+	}
+
+	contact.ID = "c1"
+	contact.Updated = time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	if !reflect.DeepEqual(got, contact) {
+		t.Errorf("round-tripped contact = %+v, want %+v", got, contact)
+	}
+}
+
+func TestCreateContact_OmitsServerSetProperties(t *testing.T) {
+	payload := contactCreatePayload(&Contact{ID: "ignored", Name: "Jane"})
+
+	if _, exists := payload["id"]; exists {
+		t.Error("payload should not include id")
+	}
+	if _, exists := payload["updated"]; exists {
+		t.Error("payload should not include updated")
+	}
+	if payload["name"] != "Jane" {
+		t.Errorf("payload name = %v, want Jane", payload["name"])
+	}
+}
+
+func TestUpdateContact_SetsNestedStructures(t *testing.T) {
+	client, _ := newContactStoreServer(t)
+
+	created, err := client.CreateContact(context.Background(), &Contact{Name: "Jane Smith"})
+	if err != nil {
+		t.Fatalf("CreateContact() error = %v", err)
+	}
+
+	addresses := []ContactAddress{{Type: "work", City: "Berlin", Country: "DE"}}
+	online := []ContactOnline{{Type: "uri", Value: "https://example.org"}}
+	phones := []ContactPhone{{Type: "work", Value: "+49-30-1234"}}
+
+	_, err = client.UpdateContact(context.Background(), created.ID, map[string]interface{}{
+		"addresses": addresses,
+		"online":    online,
+		"phones":    phones,
+		"birthday":  "1985-12-24",
+	})
+	if err != nil {
+		t.Fatalf("UpdateContact() error = %v", err)
+	}
+
+	got, err := client.GetContactByID(context.Background(), created.ID)
+	if err != nil {
+		t.Fatalf("GetContactByID() error = %v", err)
+	}
+
+	if !reflect.DeepEqual(got.Addresses, addresses) {
+		t.Errorf("Addresses = %+v, want %+v", got.Addresses, addresses)
+	}
+	if !reflect.DeepEqual(got.Online, online) {
+		t.Errorf("Online = %+v, want %+v", got.Online, online)
+	}
+	if !reflect.DeepEqual(got.Phones, phones) {
+		t.Errorf("Phones = %+v, want %+v", got.Phones, phones)
+	}
+	if got.Birthday != "1985-12-24" {
+		t.Errorf("Birthday = %q, want 1985-12-24", got.Birthday)
+	}
+}
+
+func TestGetContactByEmail(t *testing.T) {
+	var filter map[string]any
+	found := true
+
+	apiServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			MethodCalls [][]json.RawMessage `json:"methodCalls"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&req)
+		var args struct {
+			Filter map[string]any `json:"filter"`
+		}
+		_ = json.Unmarshal(req.MethodCalls[0][1], &args)
+		filter = args.Filter
+
+		list := `[]`
+		if found {
+			list = `[{"id": "c1", "name": "Alice", "emails": [{"type": "work", "value": "alice@example.com"}]}]`
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"methodResponses": [
+			["ContactCard/query", {"ids": []}, "0"],
+			["ContactCard/get", {"list": ` + list + `}, "1"]
+		]}`))
+	}))
+	defer apiServer.Close()
+
+	sessionServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{
+			"apiUrl": "` + apiServer.URL + `",
+			"accounts": {"acc123": {}},
+			"primaryAccounts": {"urn:ietf:params:jmap:mail": "acc123"},
+			"capabilities": {"urn:ietf:params:jmap:core": {}, "urn:ietf:params:jmap:contacts": {}}
+		}`))
+	}))
+	defer sessionServer.Close()
+
+	client := NewClientWithBaseURL("test-token", sessionServer.URL)
+
+	contact, err := client.GetContactByEmail(context.Background(), "alice@example.com")
+	if err != nil {
+		t.Fatalf("GetContactByEmail() error = %v", err)
+	}
+	if contact.ID != "c1" {
+		t.Errorf("contact = %+v, want c1", contact)
+	}
+	if filter["email"] != "alice@example.com" {
+		t.Errorf("filter = %v, want email filter", filter)
+	}
+
+	found = false
+	if _, err := client.GetContactByEmail(context.Background(), "nobody@example.com"); !errors.Is(err, ErrContactNotFound) {
+		t.Errorf("missing contact error = %v, want ErrContactNotFound", err)
+	}
+}
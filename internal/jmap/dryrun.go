@@ -0,0 +1,43 @@
+package jmap
+
+import "strings"
+
+// DryRunBlobID is the blob ID returned for uploads skipped in dry-run mode.
+const DryRunBlobID = "dry-run-blob"
+
+// SetDryRun puts the client in request-capture mode: MakeRequest passes each
+// request to capture and returns an empty success response for every method
+// call instead of sending it. Blob uploads are skipped too and return
+// DryRunBlobID. The session is still fetched so that requests carry the real
+// account ID, and blob downloads, which change nothing, are still made.
+// Pass nil to leave dry-run mode.
+func (c *Client) SetDryRun(capture func(req *Request)) {
+	c.dryRun = capture
+}
+
+// dryRunResponse answers every method call in req with an empty result
+// shaped like the method's real response, so callers parse "nothing found"
+// rather than a malformed response.
+func dryRunResponse(req *Request) *Response {
+	resp := &Response{MethodResponses: make([]MethodResponse, len(req.MethodCalls))}
+	for i, call := range req.MethodCalls {
+		name, _ := call[0].(string)
+		resp.MethodResponses[i] = MethodResponse{name, emptyMethodResult(name), call[2]}
+	}
+	return resp
+}
+
+func emptyMethodResult(method string) map[string]any {
+	switch {
+	case strings.HasSuffix(method, "/get"):
+		return map[string]any{"list": []any{}, "notFound": []any{}}
+	case strings.HasSuffix(method, "/query"):
+		return map[string]any{"ids": []any{}, "total": 0}
+	case strings.HasSuffix(method, "/set"):
+		return map[string]any{"created": map[string]any{}, "updated": map[string]any{}, "destroyed": []any{}}
+	case strings.HasSuffix(method, "/changes"):
+		return map[string]any{"created": []any{}, "updated": []any{}, "destroyed": []any{}}
+	default:
+		return map[string]any{}
+	}
+}
@@ -0,0 +1,117 @@
+package jmap
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+)
+
+func TestSetDryRun_CapturesWithoutSending(t *testing.T) {
+	var apiCalls int32
+	apiServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&apiCalls, 1)
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer apiServer.Close()
+
+	sessionServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{
+			"apiUrl": "` + apiServer.URL + `",
+			"accounts": {"acc123": {}},
+			"primaryAccounts": {"urn:ietf:params:jmap:mail": "acc123"}
+		}`))
+	}))
+	defer sessionServer.Close()
+
+	var captured []*Request
+	client := NewClientWithBaseURL("test-token", sessionServer.URL)
+	client.SetDryRun(func(req *Request) { captured = append(captured, req) })
+
+	emails, err := client.GetEmails(context.Background(), "inbox-id", 5)
+	if err != nil {
+		t.Fatalf("GetEmails() error = %v", err)
+	}
+	if len(emails) != 0 {
+		t.Errorf("got %d emails, want none in dry-run", len(emails))
+	}
+
+	result, err := client.MoveEmails(context.Background(), []string{"e1"}, "archive-id")
+	if err != nil {
+		t.Fatalf("MoveEmails() error = %v", err)
+	}
+	if len(result.Succeeded) != 0 {
+		t.Errorf("MoveEmails succeeded = %v, want none in dry-run", result.Succeeded)
+	}
+
+	if n := atomic.LoadInt32(&apiCalls); n != 0 {
+		t.Errorf("API server received %d requests, want 0", n)
+	}
+	if len(captured) != 2 {
+		t.Fatalf("captured %d requests, want 2", len(captured))
+	}
+	query := captured[0].MethodCalls[0]
+	args := query[1].(map[string]any)
+	if query[0] != "Email/query" || args["accountId"] != "acc123" || args["limit"] != 5 {
+		t.Errorf("captured query = %v", query)
+	}
+	if captured[1].MethodCalls[0][0] != "Email/set" {
+		t.Errorf("captured write = %v, want Email/set", captured[1].MethodCalls[0])
+	}
+}
+
+func TestDryRunResponse_ShapesResults(t *testing.T) {
+	resp := dryRunResponse(&Request{MethodCalls: []MethodCall{
+		{"Email/query", map[string]any{}, "q"},
+		{"Email/get", map[string]any{}, "g"},
+		{"Core/echo", map[string]any{}, "e"},
+	}})
+
+	if len(resp.MethodResponses) != 3 {
+		t.Fatalf("got %d responses, want 3", len(resp.MethodResponses))
+	}
+	if resp.MethodResponses[0][0] != "Email/query" || resp.MethodResponses[0][2] != "q" {
+		t.Errorf("response[0] = %v, want Email/query echoed with call ID", resp.MethodResponses[0])
+	}
+	if _, ok := resp.MethodResponses[0][1].(map[string]any)["ids"]; !ok {
+		t.Error("query response missing ids")
+	}
+	if emails, err := parseEmailList(resp.MethodResponses[1]); err != nil || len(emails) != 0 {
+		t.Errorf("parseEmailList(get response) = %v, %v; want empty list", emails, err)
+	}
+}
+
+func TestSetDryRun_SkipsUploads(t *testing.T) {
+	var uploads int32
+	uploadServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&uploads, 1)
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer uploadServer.Close()
+
+	sessionServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{
+			"apiUrl": "` + uploadServer.URL + `",
+			"uploadUrl": "` + uploadServer.URL + `/upload/{accountId}/",
+			"accounts": {"acc123": {}},
+			"primaryAccounts": {"urn:ietf:params:jmap:mail": "acc123"}
+		}`))
+	}))
+	defer sessionServer.Close()
+
+	client := NewClientWithBaseURL("test-token", sessionServer.URL)
+	client.SetDryRun(func(*Request) {})
+
+	result, err := client.UploadBlob(context.Background(), strings.NewReader("hello"), "text/plain")
+	if err != nil {
+		t.Fatalf("UploadBlob() error = %v", err)
+	}
+	if result.BlobID != DryRunBlobID || result.Size != 5 {
+		t.Errorf("result = %+v", result)
+	}
+	if n := atomic.LoadInt32(&uploads); n != 0 {
+		t.Errorf("upload server received %d requests, want 0", n)
+	}
+}
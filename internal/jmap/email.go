@@ -16,6 +16,7 @@ type Mailbox struct {
 	ID            string `json:"id"`
 	Name          string `json:"name"`
 	Role          string `json:"role,omitempty"`
+	ParentID      string `json:"parentId,omitempty"`
 	TotalEmails   int    `json:"totalEmails"`
 	UnreadEmails  int    `json:"unreadEmails"`
 	TotalThreads  int    `json:"totalThreads,omitempty"`
@@ -30,23 +31,27 @@ type EmailAddress struct {
 
 // Email represents a JMAP email.
 type Email struct {
-	ID            string               `json:"id"`
-	ThreadID      string               `json:"threadId,omitempty"`
-	Subject       string               `json:"subject"`
-	From          []EmailAddress       `json:"from,omitempty"`
-	To            []EmailAddress       `json:"to,omitempty"`
-	CC            []EmailAddress       `json:"cc,omitempty"`
-	BCC           []EmailAddress       `json:"bcc,omitempty"`
-	ReplyTo       []EmailAddress       `json:"replyTo,omitempty"`
-	ReceivedAt    string               `json:"receivedAt"`
-	Preview       string               `json:"preview,omitempty"`
-	HasAttachment bool                 `json:"hasAttachment"`
-	Keywords      map[string]bool      `json:"keywords,omitempty"`
-	MailboxIDs    map[string]bool      `json:"mailboxIds,omitempty"`
-	BodyValues    map[string]BodyValue `json:"bodyValues,omitempty"`
-	TextBody      []BodyPart           `json:"textBody,omitempty"`
-	HTMLBody      []BodyPart           `json:"htmlBody,omitempty"`
-	Attachments   []Attachment         `json:"attachments,omitempty"`
+	ID         string         `json:"id"`
+	ThreadID   string         `json:"threadId,omitempty"`
+	Subject    string         `json:"subject"`
+	From       []EmailAddress `json:"from,omitempty"`
+	To         []EmailAddress `json:"to,omitempty"`
+	CC         []EmailAddress `json:"cc,omitempty"`
+	BCC        []EmailAddress `json:"bcc,omitempty"`
+	ReplyTo    []EmailAddress `json:"replyTo,omitempty"`
+	ReceivedAt string         `json:"receivedAt"`
+	Preview    string         `json:"preview,omitempty"`
+	// PreviewTruncated is set by ListEmailsWithPreview when the body was cut at the byte cap
+	PreviewTruncated bool `json:"previewTruncated,omitempty"`
+	HasAttachment    bool `json:"hasAttachment"`
+	// Size is the raw message size in bytes (RFC 8621 size property)
+	Size        int64                `json:"size,omitempty"`
+	Keywords    map[string]bool      `json:"keywords,omitempty"`
+	MailboxIDs  map[string]bool      `json:"mailboxIds,omitempty"`
+	BodyValues  map[string]BodyValue `json:"bodyValues,omitempty"`
+	TextBody    []BodyPart           `json:"textBody,omitempty"`
+	HTMLBody    []BodyPart           `json:"htmlBody,omitempty"`
+	Attachments []Attachment         `json:"attachments,omitempty"`
 	// Headers for threading replies
 	MessageID  []string `json:"messageId,omitempty"`
 	InReplyTo  []string `json:"inReplyTo,omitempty"`
@@ -55,7 +60,8 @@ type Email struct {
 
 // BodyValue represents email body content.
 type BodyValue struct {
-	Value string `json:"value"`
+	Value       string `json:"value"`
+	IsTruncated bool   `json:"isTruncated,omitempty"` // value was cut at maxBodyValueBytes
 }
 
 // BodyPart represents a body part reference.
@@ -66,20 +72,44 @@ type BodyPart struct {
 
 // Attachment represents an email attachment.
 type Attachment struct {
-	PartID string `json:"partId"`
-	BlobID string `json:"blobId"`
-	Name   string `json:"name"`
-	Type   string `json:"type"`
-	Size   int64  `json:"size"`
+	PartID      string `json:"partId"`
+	BlobID      string `json:"blobId"`
+	Name        string `json:"name"`
+	Type        string `json:"type"`
+	Size        int64  `json:"size"`
+	ContentID   string `json:"cid,omitempty"`
+	Disposition string `json:"disposition,omitempty"`
+}
+
+// IsInline reports whether the attachment is an image embedded in the HTML
+// body (referenced by Content-ID) rather than a file the sender attached.
+func (a Attachment) IsInline() bool {
+	return a.ContentID != "" && strings.HasPrefix(strings.ToLower(a.Type), "image/")
+}
+
+// parseAttachment converts a JMAP EmailBodyPart into an Attachment.
+func parseAttachment(att map[string]any) Attachment {
+	return Attachment{
+		PartID:      getString(att, "partId"),
+		BlobID:      getString(att, "blobId"),
+		Name:        getString(att, "name"),
+		Type:        getString(att, "type"),
+		Size:        getInt64(att, "size"),
+		ContentID:   strings.Trim(getString(att, "cid"), "<>"),
+		Disposition: getString(att, "disposition"),
+	}
 }
 
 // Identity represents a sending identity.
 type Identity struct {
-	ID        string `json:"id"`
-	Name      string `json:"name,omitempty"`
-	Email     string `json:"email"`
-	MayDelete bool   `json:"mayDelete"`
-	IsDefault bool   `json:"isDefault,omitempty"` // CLI preference, not JMAP property
+	ID            string         `json:"id"`
+	Name          string         `json:"name,omitempty"`
+	Email         string         `json:"email"`
+	ReplyTo       []EmailAddress `json:"replyTo,omitempty"`
+	TextSignature string         `json:"textSignature,omitempty"`
+	HTMLSignature string         `json:"htmlSignature,omitempty"`
+	MayDelete     bool           `json:"mayDelete"`
+	IsDefault     bool           `json:"isDefault,omitempty"` // CLI preference, not JMAP property
 }
 
 // AttachmentOpts represents an attachment to include when sending an email.
@@ -87,6 +117,33 @@ type AttachmentOpts struct {
 	BlobID string // Required: blob ID from UploadBlob
 	Name   string // Required: filename to display
 	Type   string // Required: MIME type (e.g., "application/pdf")
+	// Disposition is "attachment" (default when empty) or "inline". Inline
+	// parts get CID as Content-ID, so HTML can use <img src="cid:...">.
+	Disposition string
+	// CID is the Content-ID of an inline part; it defaults to Name
+	CID string
+}
+
+// attachmentBodyParts builds the Email/set attachments for opts.
+func attachmentBodyParts(opts []AttachmentOpts) []map[string]any {
+	parts := make([]map[string]any, len(opts))
+	for i, att := range opts {
+		part := map[string]any{
+			"blobId":      att.BlobID,
+			"name":        att.Name,
+			"type":        att.Type,
+			"disposition": "attachment",
+		}
+		if att.Disposition == "inline" {
+			part["disposition"] = "inline"
+			part["cid"] = att.CID
+			if att.CID == "" {
+				part["cid"] = att.Name
+			}
+		}
+		parts[i] = part
+	}
+	return parts
 }
 
 // SendEmailOpts contains options for sending an email.
@@ -104,6 +161,56 @@ type SendEmailOpts struct {
 	References []string
 	// Attachments to include (requires uploading blobs first via UploadBlob)
 	Attachments []AttachmentOpts
+	// Signature appends the sending identity's signatures to the bodies
+	// (masked email senders have none)
+	Signature bool
+	// Headers are extra header fields such as X-Priority, sent as
+	// header:<name>:asText properties (see CustomHeaderProperties)
+	Headers map[string]string
+}
+
+// reservedHeaders are header fields set from SendEmailOpts or by the server,
+// which Headers may not override.
+var reservedHeaders = map[string]bool{
+	"from": true, "sender": true, "reply-to": true, "to": true, "cc": true, "bcc": true,
+	"subject": true, "date": true, "message-id": true, "in-reply-to": true, "references": true,
+	"mime-version": true,
+}
+
+// ValidateHeader checks that name is a valid header field name the caller
+// may set and that neither name nor value could inject further headers.
+// From, To, Date, Message-ID and the other fields set from SendEmailOpts or
+// by the server, as well as Content-* fields, are rejected.
+func ValidateHeader(name, value string) error {
+	if name == "" {
+		return &ValidationError{Field: "header", Message: "name is required"}
+	}
+	for _, r := range name {
+		if r < 33 || r > 126 || r == ':' {
+			return &ValidationError{Field: "header", Message: fmt.Sprintf("invalid header name %q", name)}
+		}
+	}
+	lower := strings.ToLower(name)
+	if reservedHeaders[lower] || strings.HasPrefix(lower, "content-") {
+		return &ValidationError{Field: "header", Message: fmt.Sprintf("%s cannot be set as a custom header", name)}
+	}
+	if strings.ContainsAny(value, "\r\n") {
+		return &ValidationError{Field: "header", Message: fmt.Sprintf("%s value must not contain line breaks", name)}
+	}
+	return nil
+}
+
+// CustomHeaderProperties validates headers and returns them as Email/set
+// header:<name>:asText properties.
+func CustomHeaderProperties(headers map[string]string) (map[string]string, error) {
+	props := make(map[string]string, len(headers))
+	for name, value := range headers {
+		if err := ValidateHeader(name, value); err != nil {
+			return nil, err
+		}
+		props["header:"+name+":asText"] = value
+	}
+	return props, nil
 }
 
 // GetMailboxes retrieves all mailboxes for the account.
@@ -147,6 +254,7 @@ func (c *Client) GetMailboxes(ctx context.Context) ([]Mailbox, error) {
 			ID:            getString(mb, "id"),
 			Name:          getString(mb, "name"),
 			Role:          getString(mb, "role"),
+			ParentID:      getString(mb, "parentId"),
 			TotalEmails:   getInt(mb, "totalEmails"),
 			UnreadEmails:  getInt(mb, "unreadEmails"),
 			TotalThreads:  getInt(mb, "totalThreads"),
@@ -158,7 +266,9 @@ func (c *Client) GetMailboxes(ctx context.Context) ([]Mailbox, error) {
 	return mailboxes, nil
 }
 
-// GetMailboxByName finds a mailbox by name (case-insensitive).
+// GetMailboxByName finds a mailbox by role or name (case-insensitive).
+// A role match (e.g., "inbox", "sent", "archive") wins over a mailbox that is
+// merely named that way, whatever order the server lists them in.
 // Returns ErrMailboxNotFound if no mailbox matches the given name or role.
 func (c *Client) GetMailboxByName(ctx context.Context, name string) (*Mailbox, error) {
 	mailboxes, err := c.GetMailboxes(ctx)
@@ -166,13 +276,13 @@ func (c *Client) GetMailboxByName(ctx context.Context, name string) (*Mailbox, e
 		return nil, err
 	}
 
-	nameLower := strings.ToLower(name)
 	for i := range mailboxes {
-		if strings.ToLower(mailboxes[i].Name) == nameLower {
+		if strings.EqualFold(mailboxes[i].Role, name) {
 			return &mailboxes[i], nil
 		}
-		// Also check role (e.g., "inbox", "sent", "drafts", "trash")
-		if strings.ToLower(mailboxes[i].Role) == nameLower {
+	}
+	for i := range mailboxes {
+		if strings.EqualFold(mailboxes[i].Name, name) {
 			return &mailboxes[i], nil
 		}
 	}
@@ -180,14 +290,44 @@ func (c *Client) GetMailboxByName(ctx context.Context, name string) (*Mailbox, e
 	return nil, fmt.Errorf("%w: %s", ErrMailboxNotFound, name)
 }
 
+// GetMailboxByRole finds the mailbox with the given JMAP role (e.g. "trash", "junk").
+// Returns ErrMailboxNotFound if no mailbox has that role.
+func (c *Client) GetMailboxByRole(ctx context.Context, role string) (*Mailbox, error) {
+	mailboxes, err := c.GetMailboxes(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range mailboxes {
+		if strings.EqualFold(mailboxes[i].Role, role) {
+			return &mailboxes[i], nil
+		}
+	}
+
+	return nil, fmt.Errorf("%w: role %s", ErrMailboxNotFound, role)
+}
+
+// MailboxRolePrefix makes ResolveMailboxID match by role only, e.g. "role:archive".
+const MailboxRolePrefix = "role:"
+
 // ResolveMailboxID takes either a mailbox ID or name and returns the ID.
-// It first tries to match by name/role, then validates if it's a valid mailbox ID.
+// It first tries to match by role, then name, then validates if it's a valid
+// mailbox ID. With a "role:" prefix only the role is matched, so a folder that
+// is merely named like the role is never picked.
 // Returns ErrMailboxNotFound if the identifier doesn't match any mailbox.
 func (c *Client) ResolveMailboxID(ctx context.Context, idOrName string) (string, error) {
 	if idOrName == "" {
 		return "", fmt.Errorf("mailbox identifier cannot be empty")
 	}
 
+	if len(idOrName) > len(MailboxRolePrefix) && strings.EqualFold(idOrName[:len(MailboxRolePrefix)], MailboxRolePrefix) {
+		mb, err := c.GetMailboxByRole(ctx, idOrName[len(MailboxRolePrefix):])
+		if err != nil {
+			return "", err
+		}
+		return mb.ID, nil
+	}
+
 	// Try name/role lookup first
 	mb, err := c.GetMailboxByName(ctx, idOrName)
 	if err == nil {
@@ -216,6 +356,77 @@ func (c *Client) ResolveMailboxID(ctx context.Context, idOrName string) (string,
 
 // GetEmails retrieves emails from a mailbox.
 func (c *Client) GetEmails(ctx context.Context, mailboxID string, limit int) ([]Email, error) {
+	return c.getEmails(ctx, mailboxID, limit, EmailListOpts{})
+}
+
+// EmailListOpts selects optional, heavier properties fetched by ListEmails.
+type EmailListOpts struct {
+	// PreviewBytes builds previews from the first N bytes of each text body
+	// instead of the server's short default preview (0 = server preview)
+	PreviewBytes int
+	// Attachments fetches each email's attachment list
+	Attachments bool
+	// FlaggedFirst sorts flagged emails ahead of the rest, each group in Sort order
+	FlaggedFirst bool
+	// Sort orders the emails (nil = newest first); see ParseEmailSort
+	Sort []SortComparator
+}
+
+// ListEmails retrieves emails from a mailbox, fetching the optional
+// properties selected by opts.
+func (c *Client) ListEmails(ctx context.Context, mailboxID string, limit int, opts EmailListOpts) ([]Email, error) {
+	if opts.PreviewBytes < 0 {
+		return nil, &ValidationError{Field: "previewBytes", Message: "must not be negative"}
+	}
+
+	emails, err := c.getEmails(ctx, mailboxID, limit, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	if opts.PreviewBytes > 0 {
+		for i := range emails {
+			if preview, truncated, ok := textBodyPreview(&emails[i]); ok {
+				emails[i].Preview = preview
+				emails[i].PreviewTruncated = truncated
+			}
+		}
+	}
+
+	return emails, nil
+}
+
+// ListEmailsWithPreview retrieves emails from a mailbox with previews built
+// from the first maxBodyValueBytes of each text body, instead of the server's
+// short default preview. PreviewTruncated reports whether the body was cut.
+func (c *Client) ListEmailsWithPreview(ctx context.Context, mailboxID string, limit, maxBodyValueBytes int) ([]Email, error) {
+	if maxBodyValueBytes <= 0 {
+		return nil, &ValidationError{Field: "maxBodyValueBytes", Message: "must be positive"}
+	}
+	return c.ListEmails(ctx, mailboxID, limit, EmailListOpts{PreviewBytes: maxBodyValueBytes})
+}
+
+// textBodyPreview joins an email's fetched text body values into a single
+// whitespace-normalized line. ok is false when no text body was fetched.
+func textBodyPreview(email *Email) (preview string, truncated, ok bool) {
+	var parts []string
+	for _, part := range email.TextBody {
+		bv, exists := email.BodyValues[part.PartID]
+		if !exists {
+			continue
+		}
+		parts = append(parts, bv.Value)
+		truncated = truncated || bv.IsTruncated
+	}
+	if len(parts) == 0 {
+		return "", false, false
+	}
+	return strings.Join(strings.Fields(strings.Join(parts, " ")), " "), truncated, true
+}
+
+// getEmails lists emails in a mailbox. When opts.PreviewBytes is positive the
+// text body values are fetched, capped at that many bytes each.
+func (c *Client) getEmails(ctx context.Context, mailboxID string, limit int, opts EmailListOpts) ([]Email, error) {
 	session, err := c.GetSession(ctx)
 	if err != nil {
 		return nil, err
@@ -226,20 +437,31 @@ func (c *Client) GetEmails(ctx context.Context, mailboxID string, limit int) ([]
 		filter["inMailbox"] = mailboxID
 	}
 
+	properties := []string{"id", "subject", "from", "to", "receivedAt", "size", "preview", "hasAttachment", "keywords", "threadId"}
+	getArgs := map[string]any{
+		"accountId": session.AccountID,
+		"#ids":      map[string]any{"resultOf": "query", "name": "Email/query", "path": "/ids"},
+	}
+	if opts.PreviewBytes > 0 {
+		properties = append(properties, "textBody", "bodyValues")
+		getArgs["fetchTextBodyValues"] = true
+		getArgs["maxBodyValueBytes"] = opts.PreviewBytes
+	}
+	if opts.Attachments {
+		properties = append(properties, "attachments")
+	}
+	getArgs["properties"] = properties
+
 	req := &Request{
 		Using: []string{"urn:ietf:params:jmap:core", "urn:ietf:params:jmap:mail"},
 		MethodCalls: []MethodCall{
 			{"Email/query", map[string]any{
 				"accountId": session.AccountID,
 				"filter":    filter,
-				"sort":      []map[string]any{{"property": "receivedAt", "isAscending": false}},
+				"sort":      emailListSort(opts),
 				"limit":     limit,
 			}, "query"},
-			{"Email/get", map[string]any{
-				"accountId":  session.AccountID,
-				"#ids":       map[string]any{"resultOf": "query", "name": "Email/query", "path": "/ids"},
-				"properties": []string{"id", "subject", "from", "to", "receivedAt", "preview", "hasAttachment", "keywords", "threadId"},
-			}, "emails"},
+			{"Email/get", getArgs, "emails"},
 		},
 	}
 
@@ -251,6 +473,42 @@ func (c *Client) GetEmails(ctx context.Context, mailboxID string, limit int) ([]
 	return parseEmailList(resp.MethodResponses[1])
 }
 
+// emailListSort builds the Email/query sort for ListEmails: opts.Sort
+// (default newest first), optionally preceded by flagged emails.
+func emailListSort(opts EmailListOpts) []map[string]any {
+	var sort []map[string]any
+	if opts.FlaggedFirst {
+		sort = append(sort, keywordSort("$flagged", false))
+	}
+	return append(sort, emailQuerySort(opts.Sort)...)
+}
+
+// keywordSort returns an Email/query hasKeyword comparator (RFC 8621 4.4.2).
+// With isAscending false, emails that have the keyword sort first.
+func keywordSort(keyword string, isAscending bool) map[string]any {
+	return map[string]any{"property": "hasKeyword", "keyword": keyword, "isAscending": isAscending}
+}
+
+// emailDetailProperties are the Email properties fetched for full email
+// details (email get).
+var emailDetailProperties = []string{
+	"id", "subject", "from", "to", "cc", "bcc", "replyTo", "receivedAt",
+	"textBody", "htmlBody", "attachments", "bodyValues", "keywords", "threadId",
+	"messageId", "inReplyTo", "references",
+}
+
+// emailDetailGetArgs returns Email/get arguments fetching full details of ids.
+func emailDetailGetArgs(accountID string, ids []string) map[string]any {
+	return map[string]any{
+		"accountId":           accountID,
+		"ids":                 ids,
+		"properties":          emailDetailProperties,
+		"bodyProperties":      []string{"partId", "blobId", "type", "size", "name", "disposition", "cid"},
+		"fetchTextBodyValues": true,
+		"fetchHTMLBodyValues": true,
+	}
+}
+
 // GetEmailByID retrieves a specific email by ID.
 func (c *Client) GetEmailByID(ctx context.Context, id string) (*Email, error) {
 	session, err := c.GetSession(ctx)
@@ -261,18 +519,7 @@ func (c *Client) GetEmailByID(ctx context.Context, id string) (*Email, error) {
 	req := &Request{
 		Using: []string{"urn:ietf:params:jmap:core", "urn:ietf:params:jmap:mail"},
 		MethodCalls: []MethodCall{
-			{"Email/get", map[string]any{
-				"accountId": session.AccountID,
-				"ids":       []string{id},
-				"properties": []string{
-					"id", "subject", "from", "to", "cc", "bcc", "replyTo", "receivedAt",
-					"textBody", "htmlBody", "attachments", "bodyValues", "keywords", "threadId",
-					"messageId", "inReplyTo", "references",
-				},
-				"bodyProperties":      []string{"partId", "blobId", "type", "size"},
-				"fetchTextBodyValues": true,
-				"fetchHTMLBodyValues": true,
-			}, "email"},
+			{"Email/get", emailDetailGetArgs(session.AccountID, []string{id}), "email"},
 		},
 	}
 
@@ -304,6 +551,69 @@ func (c *Client) GetEmailByID(ctx context.Context, id string) (*Email, error) {
 	return parseEmail(emailData), nil
 }
 
+// GetEmailsByIDs retrieves several emails with full details in a single
+// Email/get. Found emails are returned in the order of ids (duplicates
+// collapsed); IDs the server reports as notFound are returned separately
+// rather than as an error.
+func (c *Client) GetEmailsByIDs(ctx context.Context, ids []string) ([]Email, []string, error) {
+	// Deduplicate IDs
+	seen := make(map[string]bool)
+	uniqueIDs := make([]string, 0, len(ids))
+	for _, id := range ids {
+		if id != "" && !seen[id] {
+			seen[id] = true
+			uniqueIDs = append(uniqueIDs, id)
+		}
+	}
+	if len(uniqueIDs) == 0 {
+		return []Email{}, []string{}, nil
+	}
+
+	session, err := c.GetSession(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	req := &Request{
+		Using: []string{"urn:ietf:params:jmap:core", "urn:ietf:params:jmap:mail"},
+		MethodCalls: []MethodCall{
+			{"Email/get", emailDetailGetArgs(session.AccountID, uniqueIDs), "emails"},
+		},
+	}
+
+	resp, err := c.MakeRequest(ctx, req)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	list, err := parseEmailList(resp.MethodResponses[0])
+	if err != nil {
+		return nil, nil, err
+	}
+	byID := make(map[string]Email, len(list))
+	for _, email := range list {
+		byID[email.ID] = email
+	}
+
+	result, _ := resp.MethodResponses[0][1].(map[string]any)
+	notFound := []string{}
+	if nf, ok := result["notFound"].([]any); ok {
+		for _, id := range nf {
+			if s, ok := id.(string); ok {
+				notFound = append(notFound, s)
+			}
+		}
+	}
+
+	emails := make([]Email, 0, len(list))
+	for _, id := range uniqueIDs {
+		if email, ok := byID[id]; ok {
+			emails = append(emails, email)
+		}
+	}
+	return emails, notFound, nil
+}
+
 // EmailSearchFilter contains JMAP filter options for email search.
 type EmailSearchFilter struct {
 	Text   string // Full-text search query
@@ -328,9 +638,26 @@ func (f *EmailSearchFilter) ToJMAPFilter() map[string]any {
 
 // SearchEmails searches for emails matching a filter.
 func (c *Client) SearchEmails(ctx context.Context, searchFilter *EmailSearchFilter, limit int) ([]Email, error) {
+	emails, _, err := c.SearchEmailsWithOpts(ctx, searchFilter, limit, EmailSearchOpts{})
+	return emails, err
+}
+
+// EmailSearchOpts selects optional data fetched by SearchEmailsWithOpts.
+type EmailSearchOpts struct {
+	// Snippets also fetches highlighted search snippets
+	Snippets bool
+	// Attachments fetches each email's attachment list
+	Attachments bool
+	// Sort orders the results (nil = newest first); see ParseEmailSort
+	Sort []SortComparator
+}
+
+// SearchEmailsWithOpts searches for emails matching a filter, fetching the
+// optional data selected by opts. Snippets are nil unless opts.Snippets is set.
+func (c *Client) SearchEmailsWithOpts(ctx context.Context, searchFilter *EmailSearchFilter, limit int, opts EmailSearchOpts) ([]Email, []SearchSnippet, error) {
 	session, err := c.GetSession(ctx)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	filter := map[string]any{}
@@ -338,29 +665,93 @@ func (c *Client) SearchEmails(ctx context.Context, searchFilter *EmailSearchFilt
 		filter = searchFilter.ToJMAPFilter()
 	}
 
+	properties := []string{"id", "subject", "from", "to", "cc", "receivedAt", "size", "preview", "hasAttachment", "keywords", "threadId"}
+	if opts.Attachments {
+		properties = append(properties, "attachments")
+	}
+
 	req := &Request{
 		Using: []string{"urn:ietf:params:jmap:core", "urn:ietf:params:jmap:mail"},
 		MethodCalls: []MethodCall{
 			{"Email/query", map[string]any{
 				"accountId": session.AccountID,
 				"filter":    filter,
-				"sort":      []map[string]any{{"property": "receivedAt", "isAscending": false}},
+				"sort":      emailQuerySort(opts.Sort),
 				"limit":     limit,
 			}, "query"},
 			{"Email/get", map[string]any{
 				"accountId":  session.AccountID,
 				"#ids":       map[string]any{"resultOf": "query", "name": "Email/query", "path": "/ids"},
-				"properties": []string{"id", "subject", "from", "to", "cc", "receivedAt", "preview", "hasAttachment", "keywords", "threadId"},
+				"properties": properties,
 			}, "emails"},
 		},
 	}
+	if opts.Snippets {
+		req.MethodCalls = append(req.MethodCalls, MethodCall{"SearchSnippet/get", map[string]any{
+			"accountId": session.AccountID,
+			"filter":    filter,
+			"#emailIds": map[string]any{"resultOf": "query", "name": "Email/query", "path": "/ids"},
+		}, "snippets"})
+	}
 
 	resp, err := c.MakeRequest(ctx, req)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	emails, err := parseEmailList(resp.MethodResponses[1])
+	if err != nil {
+		return nil, nil, err
+	}
+	if !opts.Snippets {
+		return emails, nil, nil
+	}
+
+	snippets, err := parseSearchSnippets(resp.MethodResponses[2])
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return emails, snippets, nil
+}
+
+// GetEmailBodies fetches the text and HTML body values of the given emails,
+// each value capped at maxBodyValueBytes. The returned emails carry only ID,
+// TextBody, HTMLBody and BodyValues.
+func (c *Client) GetEmailBodies(ctx context.Context, ids []string, maxBodyValueBytes int) ([]Email, error) {
+	if maxBodyValueBytes <= 0 {
+		return nil, &ValidationError{Field: "maxBodyValueBytes", Message: "must be positive"}
+	}
+	if len(ids) == 0 {
+		return []Email{}, nil
+	}
+
+	session, err := c.GetSession(ctx)
 	if err != nil {
 		return nil, err
 	}
 
-	return parseEmailList(resp.MethodResponses[1])
+	req := &Request{
+		Using: []string{"urn:ietf:params:jmap:core", "urn:ietf:params:jmap:mail"},
+		MethodCalls: []MethodCall{
+			{"Email/get", map[string]any{
+				"accountId":           session.AccountID,
+				"ids":                 ids,
+				"properties":          []string{"id", "textBody", "htmlBody", "bodyValues"},
+				"bodyProperties":      []string{"partId", "type"},
+				"fetchTextBodyValues": true,
+				"fetchHTMLBodyValues": true,
+				"maxBodyValueBytes":   maxBodyValueBytes,
+			}, "bodies"},
+		},
+	}
+
+	resp, err := c.MakeRequest(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return parseEmailList(resp.MethodResponses[0])
 }
 
 // GetDrafts retrieves all draft emails.
@@ -461,11 +852,11 @@ func (c *Client) UpdateDraft(ctx context.Context, draftID string, opts SendEmail
 	if opts.TextBody != "" || opts.HTMLBody != "" {
 		bodyValues := map[string]map[string]string{}
 		if opts.TextBody != "" {
-			update["textBody"] = []map[string]string{{"partId": "text", "type": "text/plain"}}
+			update["textBody"] = bodyPart("text", "text/plain")
 			bodyValues["text"] = map[string]string{"value": opts.TextBody}
 		}
 		if opts.HTMLBody != "" {
-			update["htmlBody"] = []map[string]string{{"partId": "html", "type": "text/html"}}
+			update["htmlBody"] = bodyPart("html", "text/html")
 			bodyValues["html"] = map[string]string{"value": opts.HTMLBody}
 		}
 		update["bodyValues"] = bodyValues
@@ -606,6 +997,20 @@ func (c *Client) CreateReplyDraft(ctx context.Context, replyToID string, opts Se
 		return "", fmt.Errorf("failed to fetch original email: %w", err)
 	}
 
+	opts, err = c.ReplyOpts(ctx, original, opts, false)
+	if err != nil {
+		return "", err
+	}
+	return c.SaveDraft(ctx, opts)
+}
+
+// ReplyOpts fills in opts as a reply to original: threading headers, To
+// from the sender (Reply-To first) unless set, a "Re:" subject unless set,
+// and when From is unset the masked address the original was sent to.
+// With all (reply-all) the original To and CC are added as well, without
+// the account's own addresses (identities, wildcards included, and From)
+// or duplicates.
+func (c *Client) ReplyOpts(ctx context.Context, original *Email, opts SendEmailOpts, all bool) (SendEmailOpts, error) {
 	// Set up threading: InReplyTo = original's MessageID
 	if len(original.MessageID) > 0 {
 		opts.InReplyTo = original.MessageID
@@ -656,7 +1061,83 @@ func (c *Client) CreateReplyDraft(ctx context.Context, replyToID string, opts Se
 		}
 	}
 
-	return c.SaveDraft(ctx, opts)
+	if all {
+		identities, err := c.GetIdentities(ctx)
+		if err != nil {
+			return opts, fmt.Errorf("failed to fetch identities: %w", err)
+		}
+		opts.To, opts.CC = replyAllRecipients(original, opts.To, opts.CC, ownAddressMatcher(identities, opts.From))
+	}
+
+	return opts, nil
+}
+
+// replyAllRecipients adds the original To to to and the original CC to cc,
+// dropping own addresses and case-insensitive duplicates across both
+// lists. If only CC recipients remain, the first becomes the To.
+func replyAllRecipients(original *Email, to, cc []string, own func(string) bool) ([]string, []string) {
+	seen := make(map[string]bool)
+	add := func(list []string, addr string) []string {
+		key := strings.ToLower(strings.TrimSpace(addr))
+		if key == "" || seen[key] || own(key) {
+			return list
+		}
+		seen[key] = true
+		return append(list, addr)
+	}
+
+	var outTo, outCC []string
+	for _, addr := range to {
+		outTo = add(outTo, addr)
+	}
+	for _, addr := range original.To {
+		outTo = add(outTo, addr.Email)
+	}
+	for _, addr := range cc {
+		outCC = add(outCC, addr)
+	}
+	for _, addr := range original.CC {
+		outCC = add(outCC, addr.Email)
+	}
+
+	if len(outTo) == 0 && len(outCC) > 0 {
+		outTo, outCC = outCC[:1], outCC[1:]
+	}
+	return outTo, outCC
+}
+
+// ownAddressMatcher reports whether an address belongs to the account: one
+// of the identities (a "*@example.com" identity matches the whole domain)
+// or one of extra.
+func ownAddressMatcher(identities []Identity, extra ...string) func(string) bool {
+	own := make(map[string]bool)
+	var domains []string
+	for _, id := range identities {
+		addr := strings.ToLower(id.Email)
+		if domain, ok := strings.CutPrefix(addr, "*@"); ok {
+			domains = append(domains, domain)
+			continue
+		}
+		own[addr] = true
+	}
+	for _, addr := range extra {
+		if addr != "" {
+			own[strings.ToLower(addr)] = true
+		}
+	}
+
+	return func(addr string) bool {
+		addr = strings.ToLower(strings.TrimSpace(addr))
+		if own[addr] {
+			return true
+		}
+		for _, domain := range domains {
+			if strings.HasSuffix(addr, "@"+domain) {
+				return true
+			}
+		}
+		return false
+	}
 }
 
 // findMaskedEmailRecipient checks if any recipient address in the email is a masked email.
@@ -696,6 +1177,11 @@ func (c *Client) findMaskedEmailRecipient(ctx context.Context, email *Email) str
 
 // SaveDraft saves an email as a draft without sending it.
 func (c *Client) SaveDraft(ctx context.Context, opts SendEmailOpts) (string, error) {
+	headerProps, err := CustomHeaderProperties(opts.Headers)
+	if err != nil {
+		return "", err
+	}
+
 	session, err := c.GetSession(ctx)
 	if err != nil {
 		return "", err
@@ -780,27 +1266,18 @@ func (c *Client) SaveDraft(ctx context.Context, opts SendEmailOpts) (string, err
 	// Add body
 	bodyValues := make(map[string]map[string]string)
 	if opts.TextBody != "" {
-		emailObj["textBody"] = []map[string]string{{"partId": "text", "type": "text/plain"}}
+		emailObj["textBody"] = bodyPart("text", "text/plain")
 		bodyValues["text"] = map[string]string{"value": opts.TextBody}
 	}
 	if opts.HTMLBody != "" {
-		emailObj["htmlBody"] = []map[string]string{{"partId": "html", "type": "text/html"}}
+		emailObj["htmlBody"] = bodyPart("html", "text/html")
 		bodyValues["html"] = map[string]string{"value": opts.HTMLBody}
 	}
 	emailObj["bodyValues"] = bodyValues
 
 	// Add attachments if provided
 	if len(opts.Attachments) > 0 {
-		attachments := make([]map[string]any, len(opts.Attachments))
-		for i, att := range opts.Attachments {
-			attachments[i] = map[string]any{
-				"blobId":      att.BlobID,
-				"name":        att.Name,
-				"type":        att.Type,
-				"disposition": "attachment",
-			}
-		}
-		emailObj["attachments"] = attachments
+		emailObj["attachments"] = attachmentBodyParts(opts.Attachments)
 	}
 
 	// Add threading headers for replies
@@ -810,6 +1287,9 @@ func (c *Client) SaveDraft(ctx context.Context, opts SendEmailOpts) (string, err
 	if len(opts.References) > 0 {
 		emailObj["references"] = opts.References
 	}
+	for prop, value := range headerProps {
+		emailObj[prop] = value
+	}
 
 	// Create draft (no EmailSubmission - just save)
 	req := &Request{
@@ -851,21 +1331,51 @@ func (c *Client) SaveDraft(ctx context.Context, opts SendEmailOpts) (string, err
 	return "", fmt.Errorf("draft created but ID not returned")
 }
 
-// SendEmail sends an email.
+// SendResult contains the IDs produced by sending an email.
+type SendResult struct {
+	DraftID      string `json:"draftId"`      // ID of the draft created for submission
+	EmailID      string `json:"emailId"`      // ID of the sent email (the submitted draft)
+	SubmissionID string `json:"submissionId"` // ID of the EmailSubmission
+}
+
+// bodyPart returns a single-part body reference for Email/set.
+// Body values are Go (UTF-8) strings, so the charset is always declared as utf-8;
+// the server then picks a suitable transfer encoding for non-ASCII content.
+// Subjects and other headers are passed as-is: JMAP servers apply RFC 2047
+// encoding themselves.
+func bodyPart(partID, mimeType string) []map[string]string {
+	return []map[string]string{{"partId": partID, "type": mimeType, "charset": "utf-8"}}
+}
+
+// SendEmail sends an email and returns the submission ID.
 func (c *Client) SendEmail(ctx context.Context, opts SendEmailOpts) (string, error) {
-	session, err := c.GetSession(ctx)
+	result, err := c.SendEmailResult(ctx, opts)
 	if err != nil {
 		return "", err
 	}
+	return result.SubmissionID, nil
+}
+
+// SendEmailResult sends an email and returns the draft, email, and submission IDs.
+func (c *Client) SendEmailResult(ctx context.Context, opts SendEmailOpts) (*SendResult, error) {
+	headerProps, err := CustomHeaderProperties(opts.Headers)
+	if err != nil {
+		return nil, err
+	}
+
+	session, err := c.GetSession(ctx)
+	if err != nil {
+		return nil, err
+	}
 
 	// Get identities for authorization
 	identities, err := c.GetIdentities(ctx)
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 
 	if len(identities) == 0 {
-		return "", ErrNoIdentities
+		return nil, ErrNoIdentities
 	}
 
 	// Find default identity (for authorization when sending from masked email)
@@ -892,11 +1402,13 @@ func (c *Client) SendEmail(ctx context.Context, opts SendEmailOpts) (string, err
 	var envelopeFromEmail string
 	var isMaskedEmail bool
 	var tempIdentityID string
+	var fromIdentity *Identity
 
 	if opts.From != "" {
 		// Check if From matches an identity
 		for i := range identities {
 			if strings.EqualFold(identities[i].Email, opts.From) {
+				fromIdentity = &identities[i]
 				authIdentityID = identities[i].ID
 				authIdentityEmail = identities[i].Email
 				sendFromEmail = identities[i].Email
@@ -939,7 +1451,7 @@ func (c *Client) SendEmail(ctx context.Context, opts SendEmailOpts) (string, err
 			for i, id := range identities {
 				availableIdentities[i] = id.Email
 			}
-			return "", &InvalidFromAddressError{
+			return nil, &InvalidFromAddressError{
 				AttemptedAddress:    opts.From,
 				AvailableIdentities: availableIdentities,
 				IsMaskedEmail:       false,
@@ -947,12 +1459,18 @@ func (c *Client) SendEmail(ctx context.Context, opts SendEmailOpts) (string, err
 		}
 	} else {
 		// No From specified, use default identity
+		fromIdentity = defaultIdentity
 		authIdentityID = defaultIdentity.ID
 		authIdentityEmail = defaultIdentity.Email
 		sendFromEmail = defaultIdentity.Email
 		envelopeFromEmail = defaultIdentity.Email
 	}
 
+	if opts.Signature && fromIdentity != nil {
+		opts.TextBody = appendTextSignature(opts.TextBody, fromIdentity.TextSignature)
+		opts.HTMLBody = appendHTMLSignature(opts.HTMLBody, fromIdentity.HTMLSignature)
+	}
+
 	if tempIdentityID != "" {
 		defer func() {
 			if delErr := c.deleteIdentity(ctx, tempIdentityID); delErr != nil {
@@ -964,7 +1482,7 @@ func (c *Client) SendEmail(ctx context.Context, opts SendEmailOpts) (string, err
 	// Get mailboxes
 	mailboxes, err := c.GetMailboxes(ctx)
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 
 	var draftsMailbox, sentMailbox *Mailbox
@@ -978,15 +1496,15 @@ func (c *Client) SendEmail(ctx context.Context, opts SendEmailOpts) (string, err
 	}
 
 	if draftsMailbox == nil {
-		return "", ErrNoDraftsMailbox
+		return nil, ErrNoDraftsMailbox
 	}
 	if sentMailbox == nil {
-		return "", ErrNoSentMailbox
+		return nil, ErrNoSentMailbox
 	}
 
 	// Ensure we have at least one body type
 	if opts.TextBody == "" && opts.HTMLBody == "" {
-		return "", ErrNoBody
+		return nil, ErrNoBody
 	}
 
 	// Build email object
@@ -1033,27 +1551,29 @@ func (c *Client) SendEmail(ctx context.Context, opts SendEmailOpts) (string, err
 	// Add body
 	bodyValues := make(map[string]map[string]string)
 	if opts.TextBody != "" {
-		emailObj["textBody"] = []map[string]string{{"partId": "text", "type": "text/plain"}}
+		emailObj["textBody"] = bodyPart("text", "text/plain")
 		bodyValues["text"] = map[string]string{"value": opts.TextBody}
 	}
 	if opts.HTMLBody != "" {
-		emailObj["htmlBody"] = []map[string]string{{"partId": "html", "type": "text/html"}}
+		emailObj["htmlBody"] = bodyPart("html", "text/html")
 		bodyValues["html"] = map[string]string{"value": opts.HTMLBody}
 	}
 	emailObj["bodyValues"] = bodyValues
 
 	// Add attachments if provided
 	if len(opts.Attachments) > 0 {
-		attachments := make([]map[string]any, len(opts.Attachments))
-		for i, att := range opts.Attachments {
-			attachments[i] = map[string]any{
-				"blobId":      att.BlobID,
-				"name":        att.Name,
-				"type":        att.Type,
-				"disposition": "attachment",
-			}
-		}
-		emailObj["attachments"] = attachments
+		emailObj["attachments"] = attachmentBodyParts(opts.Attachments)
+	}
+
+	// Add threading headers for replies
+	if len(opts.InReplyTo) > 0 {
+		emailObj["inReplyTo"] = opts.InReplyTo
+	}
+	if len(opts.References) > 0 {
+		emailObj["references"] = opts.References
+	}
+	for prop, value := range headerProps {
+		emailObj[prop] = value
 	}
 
 	// Build submission object
@@ -1105,30 +1625,40 @@ func (c *Client) SendEmail(ctx context.Context, opts SendEmailOpts) (string, err
 
 	resp, err := c.MakeRequest(ctx, req)
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 
 	// Check email creation
 	emailResult, ok := resp.MethodResponses[0][1].(map[string]any)
 	if !ok {
-		return "", fmt.Errorf("unexpected response format")
+		return nil, fmt.Errorf("unexpected response format")
 	}
 
 	if notCreated, notCreatedOK := emailResult["notCreated"].(map[string]any); notCreatedOK {
 		if errInfo, exists := notCreated["draft"]; exists {
-			return "", fmt.Errorf("failed to create email: %v", errInfo)
+			return nil, fmt.Errorf("failed to create email: %v", errInfo)
+		}
+	}
+
+	sendResult := &SendResult{SubmissionID: "unknown"}
+
+	// Extract draft ID
+	if created, createdOK := emailResult["created"].(map[string]any); createdOK {
+		if draft, draftOK := created["draft"].(map[string]any); draftOK {
+			sendResult.DraftID = getString(draft, "id")
+			sendResult.EmailID = sendResult.DraftID
 		}
 	}
 
 	// Check email submission
 	submissionResult, ok := resp.MethodResponses[1][1].(map[string]any)
 	if !ok {
-		return "", fmt.Errorf("unexpected response format")
+		return nil, fmt.Errorf("unexpected response format")
 	}
 
 	if notCreated, ok := submissionResult["notCreated"].(map[string]any); ok {
 		if errInfo, exists := notCreated["submission"]; exists {
-			return "", fmt.Errorf("failed to submit email: %v", errInfo)
+			return nil, fmt.Errorf("failed to submit email: %v", errInfo)
 		}
 	}
 
@@ -1136,12 +1666,16 @@ func (c *Client) SendEmail(ctx context.Context, opts SendEmailOpts) (string, err
 	if created, ok := submissionResult["created"].(map[string]any); ok {
 		if submission, ok := created["submission"].(map[string]any); ok {
 			if id, ok := submission["id"].(string); ok {
-				return id, nil
+				sendResult.SubmissionID = id
+			}
+			// Prefer the server-reported email ID when the submission includes it
+			if emailID := getString(submission, "emailId"); emailID != "" {
+				sendResult.EmailID = emailID
 			}
 		}
 	}
 
-	return "unknown", nil
+	return sendResult, nil
 }
 
 // BulkResult contains the result of a bulk operation.
@@ -1239,75 +1773,190 @@ func (c *Client) DeleteEmails(ctx context.Context, ids []string) (*BulkResult, e
 		}
 	}
 
-	if trashMailbox == nil {
-		return nil, ErrNoTrashMailbox
+	if trashMailbox == nil {
+		return nil, ErrNoTrashMailbox
+	}
+
+	// Build updates map for all IDs
+	updates := make(map[string]any)
+	for _, id := range ids {
+		updates[id] = map[string]any{
+			"mailboxIds": map[string]bool{trashMailbox.ID: true},
+		}
+	}
+
+	req := &Request{
+		Using: []string{"urn:ietf:params:jmap:core", "urn:ietf:params:jmap:mail"},
+		MethodCalls: []MethodCall{
+			{"Email/set", map[string]any{
+				"accountId": session.AccountID,
+				"update":    updates,
+			}, "moveToTrash"},
+		},
+	}
+
+	resp, err := c.MakeRequest(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	result, ok := resp.MethodResponses[0][1].(map[string]any)
+	if !ok {
+		return nil, fmt.Errorf("unexpected response format")
+	}
+
+	// Parse succeeded and failed IDs
+	succeeded, failed := parseBulkUpdateResult(result)
+
+	return &BulkResult{
+		Succeeded: succeeded,
+		Failed:    failed,
+	}, nil
+}
+
+// parseBulkUpdateResult extracts succeeded and failed IDs from an Email/set update response.
+func parseBulkUpdateResult(result map[string]any) ([]string, map[string]string) {
+	succeeded := []string{}
+	failed := make(map[string]string)
+
+	// Extract succeeded updates
+	if updated, ok := result["updated"].(map[string]any); ok {
+		for id := range updated {
+			succeeded = append(succeeded, id)
+		}
+	}
+
+	// Extract failed updates
+	if notUpdated, ok := result["notUpdated"].(map[string]any); ok {
+		for id, errInfo := range notUpdated {
+			failed[id] = setErrorMessage(errInfo)
+		}
+	}
+
+	return succeeded, failed
+}
+
+// setErrorMessage formats a JMAP SetError as "type: description".
+func setErrorMessage(errInfo any) string {
+	errMsg := "unknown error"
+	if errMap, ok := errInfo.(map[string]any); ok {
+		errType := getString(errMap, "type")
+		errDesc := getString(errMap, "description")
+		if errType != "" && errDesc != "" {
+			errMsg = errType + ": " + errDesc
+		} else if errType != "" {
+			errMsg = errType
+		} else if errDesc != "" {
+			errMsg = errDesc
+		}
+	}
+	return errMsg
+}
+
+// emptyMailboxPageSize bounds both the Email/query page size and the number of
+// IDs destroyed per Email/set call when emptying a mailbox.
+const emptyMailboxPageSize = 256
+
+// EmptyMailbox permanently destroys every email in the given mailbox.
+// Email IDs are collected by paging through Email/query first, then destroyed in
+// batches so large mailboxes (thousands of messages) stay within server limits.
+// Returns a BulkResult containing IDs that were destroyed and those that failed.
+func (c *Client) EmptyMailbox(ctx context.Context, mailboxID string) (*BulkResult, error) {
+	if mailboxID == "" {
+		return nil, fmt.Errorf("mailbox ID is required")
+	}
+
+	session, err := c.GetSession(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// Collect all IDs before destroying anything so paging positions stay stable.
+	var ids []string
+	for position := 0; ; position += emptyMailboxPageSize {
+		req := &Request{
+			Using: []string{"urn:ietf:params:jmap:core", "urn:ietf:params:jmap:mail"},
+			MethodCalls: []MethodCall{
+				{"Email/query", map[string]any{
+					"accountId": session.AccountID,
+					"filter":    map[string]any{"inMailbox": mailboxID},
+					"position":  position,
+					"limit":     emptyMailboxPageSize,
+				}, "query"},
+			},
+		}
+
+		resp, reqErr := c.MakeRequest(ctx, req)
+		if reqErr != nil {
+			return nil, reqErr
+		}
+
+		result, ok := resp.MethodResponses[0][1].(map[string]any)
+		if !ok {
+			return nil, fmt.Errorf("unexpected response format")
+		}
+
+		page, ok := result["ids"].([]any)
+		if !ok {
+			return nil, fmt.Errorf("unexpected ids format")
+		}
+		ids = append(ids, parseStringArray(page)...)
+
+		if len(page) < emptyMailboxPageSize {
+			break
+		}
+	}
+
+	bulk := &BulkResult{
+		Succeeded: []string{},
+		Failed:    map[string]string{},
 	}
 
-	// Build updates map for all IDs
-	updates := make(map[string]any)
-	for _, id := range ids {
-		updates[id] = map[string]any{
-			"mailboxIds": map[string]bool{trashMailbox.ID: true},
+	for start := 0; start < len(ids); start += emptyMailboxPageSize {
+		end := min(start+emptyMailboxPageSize, len(ids))
+
+		req := &Request{
+			Using: []string{"urn:ietf:params:jmap:core", "urn:ietf:params:jmap:mail"},
+			MethodCalls: []MethodCall{
+				{"Email/set", map[string]any{
+					"accountId": session.AccountID,
+					"destroy":   ids[start:end],
+				}, "destroyEmails"},
+			},
 		}
-	}
 
-	req := &Request{
-		Using: []string{"urn:ietf:params:jmap:core", "urn:ietf:params:jmap:mail"},
-		MethodCalls: []MethodCall{
-			{"Email/set", map[string]any{
-				"accountId": session.AccountID,
-				"update":    updates,
-			}, "moveToTrash"},
-		},
-	}
+		resp, reqErr := c.MakeRequest(ctx, req)
+		if reqErr != nil {
+			return bulk, reqErr
+		}
 
-	resp, err := c.MakeRequest(ctx, req)
-	if err != nil {
-		return nil, err
-	}
+		result, ok := resp.MethodResponses[0][1].(map[string]any)
+		if !ok {
+			return bulk, fmt.Errorf("unexpected response format")
+		}
 
-	result, ok := resp.MethodResponses[0][1].(map[string]any)
-	if !ok {
-		return nil, fmt.Errorf("unexpected response format")
+		succeeded, failed := parseBulkDestroyResult(result)
+		bulk.Succeeded = append(bulk.Succeeded, succeeded...)
+		for id, msg := range failed {
+			bulk.Failed[id] = msg
+		}
 	}
 
-	// Parse succeeded and failed IDs
-	succeeded, failed := parseBulkUpdateResult(result)
-
-	return &BulkResult{
-		Succeeded: succeeded,
-		Failed:    failed,
-	}, nil
+	return bulk, nil
 }
 
-// parseBulkUpdateResult extracts succeeded and failed IDs from an Email/set update response.
-func parseBulkUpdateResult(result map[string]any) ([]string, map[string]string) {
+// parseBulkDestroyResult extracts destroyed and failed IDs from an Email/set destroy response.
+func parseBulkDestroyResult(result map[string]any) ([]string, map[string]string) {
 	succeeded := []string{}
 	failed := make(map[string]string)
 
-	// Extract succeeded updates
-	if updated, ok := result["updated"].(map[string]any); ok {
-		for id := range updated {
-			succeeded = append(succeeded, id)
-		}
+	if destroyed, ok := result["destroyed"].([]any); ok {
+		succeeded = append(succeeded, parseStringArray(destroyed)...)
 	}
 
-	// Extract failed updates
-	if notUpdated, ok := result["notUpdated"].(map[string]any); ok {
-		for id, errInfo := range notUpdated {
-			errMsg := "unknown error"
-			if errMap, ok := errInfo.(map[string]any); ok {
-				errType := getString(errMap, "type")
-				errDesc := getString(errMap, "description")
-				if errType != "" && errDesc != "" {
-					errMsg = errType + ": " + errDesc
-				} else if errType != "" {
-					errMsg = errType
-				} else if errDesc != "" {
-					errMsg = errDesc
-				}
-			}
-			failed[id] = errMsg
+	if notDestroyed, ok := result["notDestroyed"].(map[string]any); ok {
+		for id, errInfo := range notDestroyed {
+			failed[id] = setErrorMessage(errInfo)
 		}
 	}
 
@@ -1527,6 +2176,64 @@ func (c *Client) MarkEmailsRead(ctx context.Context, ids []string, read bool) (*
 
 // GetThread retrieves all emails in a thread.
 func (c *Client) GetThread(ctx context.Context, threadID string) ([]Email, error) {
+	return c.getThread(ctx, threadID, false)
+}
+
+// MarkThreadRead marks every email in a thread as read or unread with one
+// Email/set. threadID may also be the ID of any email in the thread.
+func (c *Client) MarkThreadRead(ctx context.Context, threadID string, read bool) (*BulkResult, error) {
+	ids, err := c.threadEmailIDs(ctx, threadID)
+	if err != nil {
+		return nil, err
+	}
+	return c.MarkEmailsRead(ctx, ids, read)
+}
+
+// MoveThread moves every email in a thread to a mailbox with one Email/set.
+// threadID may also be the ID of any email in the thread.
+func (c *Client) MoveThread(ctx context.Context, threadID, targetMailboxID string) (*BulkResult, error) {
+	if targetMailboxID == "" {
+		return nil, fmt.Errorf("target mailbox ID is required")
+	}
+	ids, err := c.threadEmailIDs(ctx, threadID)
+	if err != nil {
+		return nil, err
+	}
+	return c.MoveEmails(ctx, ids, targetMailboxID)
+}
+
+// FlagThread flags or unflags every email in a thread with one Email/set.
+// threadID may also be the ID of any email in the thread.
+func (c *Client) FlagThread(ctx context.Context, threadID string, flagged bool) (*BulkResult, error) {
+	ids, err := c.threadEmailIDs(ctx, threadID)
+	if err != nil {
+		return nil, err
+	}
+	return c.UpdateEmails(ctx, ids, NewEmailPatch().Flag(flagged))
+}
+
+// threadEmailIDs returns the IDs of all emails in a thread.
+func (c *Client) threadEmailIDs(ctx context.Context, threadID string) ([]string, error) {
+	if threadID == "" {
+		return nil, fmt.Errorf("thread ID is required")
+	}
+	emails, err := c.GetThread(ctx, threadID)
+	if err != nil {
+		return nil, err
+	}
+	ids := make([]string, 0, len(emails))
+	for _, email := range emails {
+		ids = append(ids, email.ID)
+	}
+	return ids, nil
+}
+
+// GetThreadWithBodies retrieves all emails in a thread, including their text and HTML bodies.
+func (c *Client) GetThreadWithBodies(ctx context.Context, threadID string) ([]Email, error) {
+	return c.getThread(ctx, threadID, true)
+}
+
+func (c *Client) getThread(ctx context.Context, threadID string, withBodies bool) ([]Email, error) {
 	session, err := c.GetSession(ctx)
 	if err != nil {
 		return nil, err
@@ -1561,6 +2268,21 @@ func (c *Client) GetThread(ctx context.Context, threadID string) ([]Email, error
 	}
 
 	// Get thread with all emails
+	emailArgs := map[string]any{
+		"accountId":  session.AccountID,
+		"#ids":       map[string]any{"resultOf": "getThread", "name": "Thread/get", "path": "/list/*/emailIds"},
+		"properties": []string{"id", "subject", "from", "to", "cc", "receivedAt", "preview", "hasAttachment", "keywords", "threadId"},
+	}
+	if withBodies {
+		emailArgs["properties"] = []string{
+			"id", "subject", "from", "to", "cc", "receivedAt", "preview", "hasAttachment", "keywords", "threadId",
+			"textBody", "htmlBody", "bodyValues",
+		}
+		emailArgs["bodyProperties"] = []string{"partId", "blobId", "type", "size"}
+		emailArgs["fetchTextBodyValues"] = true
+		emailArgs["fetchHTMLBodyValues"] = true
+	}
+
 	req := &Request{
 		Using: []string{"urn:ietf:params:jmap:core", "urn:ietf:params:jmap:mail"},
 		MethodCalls: []MethodCall{
@@ -1568,11 +2290,7 @@ func (c *Client) GetThread(ctx context.Context, threadID string) ([]Email, error
 				"accountId": session.AccountID,
 				"ids":       []string{actualThreadID},
 			}, "getThread"},
-			{"Email/get", map[string]any{
-				"accountId":  session.AccountID,
-				"#ids":       map[string]any{"resultOf": "getThread", "name": "Thread/get", "path": "/list/*/emailIds"},
-				"properties": []string{"id", "subject", "from", "to", "cc", "receivedAt", "preview", "hasAttachment", "keywords", "threadId"},
-			}, "emails"},
+			{"Email/get", emailArgs, "emails"},
 		},
 	}
 
@@ -1644,14 +2362,7 @@ func (c *Client) GetEmailAttachments(ctx context.Context, id string) ([]Attachme
 			continue
 		}
 
-		attachment := Attachment{
-			PartID: getString(att, "partId"),
-			BlobID: getString(att, "blobId"),
-			Name:   getString(att, "name"),
-			Type:   getString(att, "type"),
-			Size:   getInt64(att, "size"),
-		}
-		result_attachments = append(result_attachments, attachment)
+		result_attachments = append(result_attachments, parseAttachment(att))
 	}
 
 	return result_attachments, nil
@@ -1744,9 +2455,16 @@ func (c *Client) deleteIdentity(ctx context.Context, id string) error {
 
 // GetIdentities retrieves sending identities for the account.
 func (c *Client) GetIdentities(ctx context.Context) ([]Identity, error) {
+	identities, _, err := c.GetIdentitiesWithState(ctx)
+	return identities, err
+}
+
+// GetIdentitiesWithState retrieves sending identities along with the
+// Identity state string, which can be passed to GetIdentityChanges later.
+func (c *Client) GetIdentitiesWithState(ctx context.Context) ([]Identity, string, error) {
 	session, err := c.GetSession(ctx)
 	if err != nil {
-		return nil, err
+		return nil, "", err
 	}
 
 	req := &Request{
@@ -1760,36 +2478,20 @@ func (c *Client) GetIdentities(ctx context.Context) ([]Identity, error) {
 
 	resp, err := c.MakeRequest(ctx, req)
 	if err != nil {
-		return nil, err
+		return nil, "", err
 	}
 
 	result, ok := resp.MethodResponses[0][1].(map[string]any)
 	if !ok {
-		return nil, fmt.Errorf("unexpected response format")
+		return nil, "", fmt.Errorf("unexpected response format")
 	}
 
 	list, ok := result["list"].([]any)
 	if !ok {
-		return nil, fmt.Errorf("unexpected list format")
-	}
-
-	identities := make([]Identity, 0, len(list))
-	for _, item := range list {
-		id, ok := item.(map[string]any)
-		if !ok {
-			continue
-		}
-
-		identity := Identity{
-			ID:        getString(id, "id"),
-			Name:      getString(id, "name"),
-			Email:     getString(id, "email"),
-			MayDelete: getBool(id, "mayDelete"),
-		}
-		identities = append(identities, identity)
+		return nil, "", fmt.Errorf("unexpected list format")
 	}
 
-	return identities, nil
+	return parseIdentityList(list), getString(result, "state"), nil
 }
 
 func (c *Client) getDefaultIdentity(ctx context.Context) (*Identity, error) {
@@ -1843,6 +2545,7 @@ func parseEmail(data map[string]any) *Email {
 		ReceivedAt:    getString(data, "receivedAt"),
 		Preview:       getString(data, "preview"),
 		HasAttachment: getBool(data, "hasAttachment"),
+		Size:          getInt64(data, "size"),
 	}
 
 	// Parse addresses
@@ -1898,8 +2601,10 @@ func parseEmail(data map[string]any) *Email {
 		email.BodyValues = make(map[string]BodyValue)
 		for k, v := range bodyValues {
 			if bv, ok := v.(map[string]any); ok {
+				isTruncated, _ := bv["isTruncated"].(bool)
 				email.BodyValues[k] = BodyValue{
-					Value: getString(bv, "value"),
+					Value:       getString(bv, "value"),
+					IsTruncated: isTruncated,
 				}
 			}
 		}
@@ -1918,13 +2623,7 @@ func parseEmail(data map[string]any) *Email {
 		email.Attachments = make([]Attachment, 0, len(attachments))
 		for _, item := range attachments {
 			if att, ok := item.(map[string]any); ok {
-				email.Attachments = append(email.Attachments, Attachment{
-					PartID: getString(att, "partId"),
-					BlobID: getString(att, "blobId"),
-					Name:   getString(att, "name"),
-					Type:   getString(att, "type"),
-					Size:   getInt64(att, "size"),
-				})
+				email.Attachments = append(email.Attachments, parseAttachment(att))
 			}
 		}
 	}
@@ -1977,54 +2676,7 @@ type SearchSnippet struct {
 
 // SearchEmailsWithSnippets searches for emails and returns highlighted snippets.
 func (c *Client) SearchEmailsWithSnippets(ctx context.Context, searchFilter *EmailSearchFilter, limit int) ([]Email, []SearchSnippet, error) {
-	session, err := c.GetSession(ctx)
-	if err != nil {
-		return nil, nil, err
-	}
-
-	filter := map[string]any{}
-	if searchFilter != nil {
-		filter = searchFilter.ToJMAPFilter()
-	}
-
-	req := &Request{
-		Using: []string{"urn:ietf:params:jmap:core", "urn:ietf:params:jmap:mail"},
-		MethodCalls: []MethodCall{
-			{"Email/query", map[string]any{
-				"accountId": session.AccountID,
-				"filter":    filter,
-				"sort":      []map[string]any{{"property": "receivedAt", "isAscending": false}},
-				"limit":     limit,
-			}, "query"},
-			{"Email/get", map[string]any{
-				"accountId":  session.AccountID,
-				"#ids":       map[string]any{"resultOf": "query", "name": "Email/query", "path": "/ids"},
-				"properties": []string{"id", "subject", "from", "to", "cc", "receivedAt", "preview", "hasAttachment", "keywords", "threadId"},
-			}, "emails"},
-			{"SearchSnippet/get", map[string]any{
-				"accountId": session.AccountID,
-				"filter":    filter,
-				"#emailIds": map[string]any{"resultOf": "query", "name": "Email/query", "path": "/ids"},
-			}, "snippets"},
-		},
-	}
-
-	resp, err := c.MakeRequest(ctx, req)
-	if err != nil {
-		return nil, nil, err
-	}
-
-	emails, err := parseEmailList(resp.MethodResponses[1])
-	if err != nil {
-		return nil, nil, err
-	}
-
-	snippets, err := parseSearchSnippets(resp.MethodResponses[2])
-	if err != nil {
-		return nil, nil, err
-	}
-
-	return emails, snippets, nil
+	return c.SearchEmailsWithOpts(ctx, searchFilter, limit, EmailSearchOpts{Snippets: true})
 }
 
 func parseSearchSnippets(methodResp MethodResponse) ([]SearchSnippet, error) {
@@ -2199,11 +2851,104 @@ func (c *Client) RenameMailbox(ctx context.Context, id, newName string) error {
 	return nil
 }
 
+// MoveMailbox re-parents a mailbox under newParentID, or promotes it to the
+// top level when newParentID is empty. Moving a mailbox under itself or one of
+// its descendants returns a ValidationError.
+func (c *Client) MoveMailbox(ctx context.Context, id, newParentID string) error {
+	if id == "" {
+		return &ValidationError{Field: "id", Message: "mailbox ID is required"}
+	}
+
+	var parent any // JMAP uses null for a top-level mailbox
+	if newParentID != "" {
+		mailboxes, err := c.GetMailboxes(ctx)
+		if err != nil {
+			return err
+		}
+		if err := checkMailboxMove(mailboxes, id, newParentID); err != nil {
+			return err
+		}
+		parent = newParentID
+	}
+
+	session, err := c.GetSession(ctx)
+	if err != nil {
+		return err
+	}
+
+	req := &Request{
+		Using: []string{"urn:ietf:params:jmap:core", "urn:ietf:params:jmap:mail"},
+		MethodCalls: []MethodCall{
+			{"Mailbox/set", map[string]any{
+				"accountId": session.AccountID,
+				"update": map[string]any{
+					id: map[string]any{
+						"parentId": parent,
+					},
+				},
+			}, "moveMailbox"},
+		},
+	}
+
+	resp, err := c.MakeRequest(ctx, req)
+	if err != nil {
+		return err
+	}
+
+	result, ok := resp.MethodResponses[0][1].(map[string]any)
+	if !ok {
+		return fmt.Errorf("unexpected response format")
+	}
+
+	if notUpdated, ok := result["notUpdated"].(map[string]any); ok {
+		if errInfo, exists := notUpdated[id]; exists {
+			return fmt.Errorf("failed to move mailbox: %v", errInfo)
+		}
+	}
+
+	return nil
+}
+
+// checkMailboxMove walks the parent chain from newParentID to the top level
+// and rejects the move if it passes through id, which would create a cycle.
+func checkMailboxMove(mailboxes []Mailbox, id, newParentID string) error {
+	parents := make(map[string]string, len(mailboxes))
+	for _, mb := range mailboxes {
+		parents[mb.ID] = mb.ParentID
+	}
+	if _, ok := parents[newParentID]; !ok {
+		return fmt.Errorf("%w: %s", ErrMailboxNotFound, newParentID)
+	}
+
+	// The step limit guards against a server reporting a cycle already
+	for cur, steps := newParentID, 0; cur != "" && steps <= len(mailboxes); cur, steps = parents[cur], steps+1 {
+		if cur == id {
+			return &ValidationError{Field: "parent", Message: "cannot move a mailbox under itself or one of its subfolders"}
+		}
+	}
+	return nil
+}
+
 // ForwardEmailOpts contains options for forwarding an email.
 type ForwardEmailOpts struct {
-	To   []string // Required: recipient addresses
-	From string   // Optional: override sender (default: auto-detect masked email)
-	Body string   // Optional: message to prepend to forwarded content
+	To                []string // Required: recipient addresses
+	From              string   // Optional: override sender (default: auto-detect masked email)
+	Body              string   // Optional: message to prepend to forwarded content
+	NoAttachments     bool     // Optional: drop all original attachments
+	MaxAttachmentSize int64    // Optional: drop original attachments larger than this many bytes (0 = no limit)
+}
+
+// FilterForwardAttachments splits the original email's attachments into those
+// re-attached to the forward and those dropped by NoAttachments or MaxAttachmentSize.
+func FilterForwardAttachments(attachments []Attachment, opts ForwardEmailOpts) (kept, dropped []Attachment) {
+	for _, att := range attachments {
+		if opts.NoAttachments || (opts.MaxAttachmentSize > 0 && att.Size > opts.MaxAttachmentSize) {
+			dropped = append(dropped, att)
+			continue
+		}
+		kept = append(kept, att)
+	}
+	return kept, dropped
 }
 
 // ForwardFromSource indicates how the From address was chosen for a forward.
@@ -2253,7 +2998,7 @@ func (c *Client) ResolveForwardFrom(ctx context.Context, original *Email, opts F
 
 // ForwardEmail forwards an email to new recipients.
 // Automatically uses the masked email address if the original was received on one.
-// Includes all original attachments.
+// Includes the original attachments, except those dropped by FilterForwardAttachments.
 func (c *Client) ForwardEmail(ctx context.Context, original *Email, opts ForwardEmailOpts) (string, error) {
 	if len(opts.To) == 0 {
 		return "", fmt.Errorf("at least one recipient is required")
@@ -2279,8 +3024,9 @@ func (c *Client) ForwardEmail(ctx context.Context, original *Email, opts Forward
 	textBody, htmlBody := buildForwardBody(original, opts.Body)
 
 	// Prepare attachments (reuse existing blob IDs)
+	kept, _ := FilterForwardAttachments(original.Attachments, opts)
 	var attachments []AttachmentOpts
-	for _, att := range original.Attachments {
+	for _, att := range kept {
 		attachments = append(attachments, AttachmentOpts{
 			BlobID: att.BlobID,
 			Name:   att.Name,
@@ -2331,25 +3077,8 @@ func buildForwardBody(original *Email, prependBody string) (textBody, htmlBody s
 	forwardHeader += "\n"
 
 	// Get original body content
-	var originalTextBody string
-	if len(original.TextBody) > 0 && len(original.BodyValues) > 0 {
-		for _, part := range original.TextBody {
-			if body, ok := original.BodyValues[part.PartID]; ok {
-				originalTextBody = body.Value
-				break
-			}
-		}
-	}
-
-	var originalHTMLBody string
-	if len(original.HTMLBody) > 0 && len(original.BodyValues) > 0 {
-		for _, part := range original.HTMLBody {
-			if body, ok := original.BodyValues[part.PartID]; ok {
-				originalHTMLBody = body.Value
-				break
-			}
-		}
-	}
+	originalTextBody := firstBodyValue(original.TextBody, original.BodyValues)
+	originalHTMLBody := firstBodyValue(original.HTMLBody, original.BodyValues)
 
 	// Build text body
 	if prependBody != "" {
@@ -2377,6 +3106,104 @@ func buildForwardBody(original *Email, prependBody string) (textBody, htmlBody s
 	return textBody, htmlBody
 }
 
+// firstBodyValue returns the fetched value of the first body part that has
+// one, or "" when none was fetched.
+func firstBodyValue(parts []BodyPart, values map[string]BodyValue) string {
+	for _, part := range parts {
+		if body, ok := values[part.PartID]; ok {
+			return body.Value
+		}
+	}
+	return ""
+}
+
+// BuildResendOpts reconstructs SendEmailOpts from a previously sent email:
+// its sender, recipients, subject, bodies, threading headers and attachments
+// (by existing blob ID, so nothing is re-uploaded, keeping their names and
+// inline parts' Content-IDs). Non-empty fields of
+// overrides take precedence; setting any of To, CC or BCC replaces all of
+// the original recipients.
+func BuildResendOpts(original *Email, overrides SendEmailOpts) (SendEmailOpts, error) {
+	opts := SendEmailOpts{
+		Subject:    original.Subject,
+		TextBody:   firstBodyValue(original.TextBody, original.BodyValues),
+		HTMLBody:   firstBodyValue(original.HTMLBody, original.BodyValues),
+		InReplyTo:  original.InReplyTo,
+		References: original.References,
+	}
+	if opts.TextBody == "" && opts.HTMLBody == "" {
+		return SendEmailOpts{}, fmt.Errorf("email %s has no text or HTML body to resend: %w", original.ID, ErrNoBody)
+	}
+
+	if len(original.From) > 0 {
+		opts.From = original.From[0].Email
+	}
+	if overrides.From != "" {
+		opts.From = overrides.From
+	}
+	if overrides.Subject != "" {
+		opts.Subject = overrides.Subject
+	}
+
+	if len(overrides.To) > 0 || len(overrides.CC) > 0 || len(overrides.BCC) > 0 {
+		opts.To, opts.CC, opts.BCC = overrides.To, overrides.CC, overrides.BCC
+	} else {
+		opts.To = addressEmails(original.To)
+		opts.CC = addressEmails(original.CC)
+		opts.BCC = addressEmails(original.BCC)
+	}
+	if len(opts.To) == 0 && len(opts.CC) == 0 && len(opts.BCC) == 0 {
+		return SendEmailOpts{}, fmt.Errorf("email %s has no recipients; pass new recipients to resend it", original.ID)
+	}
+
+	for _, att := range original.Attachments {
+		attOpts := AttachmentOpts{
+			BlobID: att.BlobID,
+			Name:   att.Name,
+			Type:   att.Type,
+		}
+		if att.Disposition == "inline" || att.IsInline() {
+			attOpts.Disposition = "inline"
+			attOpts.CID = att.ContentID
+		}
+		opts.Attachments = append(opts.Attachments, attOpts)
+	}
+
+	return opts, nil
+}
+
+// ResendEmail fetches a previously sent email and submits it again as a new
+// message built by BuildResendOpts. It returns the submission ID and the
+// options that were sent.
+func (c *Client) ResendEmail(ctx context.Context, emailID string, overrides SendEmailOpts) (string, SendEmailOpts, error) {
+	original, err := c.GetEmailByID(ctx, emailID)
+	if err != nil {
+		return "", SendEmailOpts{}, err
+	}
+
+	opts, err := BuildResendOpts(original, overrides)
+	if err != nil {
+		return "", SendEmailOpts{}, err
+	}
+
+	submissionID, err := c.SendEmail(ctx, opts)
+	if err != nil {
+		return "", SendEmailOpts{}, err
+	}
+	return submissionID, opts, nil
+}
+
+func addressEmails(addrs []EmailAddress) []string {
+	if len(addrs) == 0 {
+		return nil
+	}
+	emails := make([]string, len(addrs))
+	for i, addr := range addrs {
+		emails[i] = addr.Email
+	}
+	return emails
+}
+
 // formatAddressList formats a list of email addresses for display.
 func formatAddressList(addrs []EmailAddress) string {
 	if len(addrs) == 0 {
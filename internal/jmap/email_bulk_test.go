@@ -2,10 +2,14 @@ package jmap
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"reflect"
 	"testing"
+
+	"github.com/salmonumbrella/fastmail-cli/internal/testutil"
 )
 
 func TestDeleteEmails_Multiple(t *testing.T) {
@@ -41,32 +45,13 @@ func TestDeleteEmails_Multiple(t *testing.T) {
 		]
 	}`
 
-	var requestCount int
-	apiServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		w.Header().Set("Content-Type", "application/json")
-		requestCount++
-		if requestCount == 1 {
-			// First request: GetMailboxes
-			_, _ = w.Write([]byte(mailboxesResponse))
-		} else {
-			// Second request: Email/set
-			_, _ = w.Write([]byte(response))
-		}
-	}))
-	defer apiServer.Close()
-
-	sessionServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		_, _ = w.Write([]byte(`{
-			"apiUrl": "` + apiServer.URL + `",
-			"uploadUrl": "` + apiServer.URL + `/{accountId}/",
-			"downloadUrl": "` + apiServer.URL + `",
-			"accounts": {"acc123": {}},
-			"primaryAccounts": {"urn:ietf:params:jmap:mail": "acc123"}
-		}`))
-	}))
-	defer sessionServer.Close()
+	jm := testutil.NewJMAPMock()
+	defer jm.Close()
+	jm.QueueResponse(mailboxesResponse) // GetMailboxes
+	jm.QueueResponse(response)          // Email/set
+	defer jm.AssertConsumed(t)
 
-	client := NewClientWithBaseURL("test-token", sessionServer.URL)
+	client := NewClientWithBaseURL("test-token", jm.URL())
 
 	ids := []string{"email1", "email2", "email3"}
 	result, err := client.DeleteEmails(context.Background(), ids)
@@ -135,30 +120,13 @@ func TestDeleteEmails_PartialFailure(t *testing.T) {
 		]
 	}`
 
-	var requestCount int
-	apiServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		w.Header().Set("Content-Type", "application/json")
-		requestCount++
-		if requestCount == 1 {
-			_, _ = w.Write([]byte(mailboxesResponse))
-		} else {
-			_, _ = w.Write([]byte(response))
-		}
-	}))
-	defer apiServer.Close()
+	jm := testutil.NewJMAPMock()
+	defer jm.Close()
+	jm.QueueResponse(mailboxesResponse) // GetMailboxes
+	jm.QueueResponse(response)          // Email/set
+	defer jm.AssertConsumed(t)
 
-	sessionServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		_, _ = w.Write([]byte(`{
-			"apiUrl": "` + apiServer.URL + `",
-			"uploadUrl": "` + apiServer.URL + `/{accountId}/",
-			"downloadUrl": "` + apiServer.URL + `",
-			"accounts": {"acc123": {}},
-			"primaryAccounts": {"urn:ietf:params:jmap:mail": "acc123"}
-		}`))
-	}))
-	defer sessionServer.Close()
-
-	client := NewClientWithBaseURL("test-token", sessionServer.URL)
+	client := NewClientWithBaseURL("test-token", jm.URL())
 
 	ids := []string{"email1", "email2", "email3"}
 	result, err := client.DeleteEmails(context.Background(), ids)
@@ -326,30 +294,13 @@ func TestDeleteEmails_AllFailed(t *testing.T) {
 		]
 	}`
 
-	var requestCount int
-	apiServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		w.Header().Set("Content-Type", "application/json")
-		requestCount++
-		if requestCount == 1 {
-			_, _ = w.Write([]byte(mailboxesResponse))
-		} else {
-			_, _ = w.Write([]byte(response))
-		}
-	}))
-	defer apiServer.Close()
+	jm := testutil.NewJMAPMock()
+	defer jm.Close()
+	jm.QueueResponse(mailboxesResponse) // GetMailboxes
+	jm.QueueResponse(response)          // Email/set
+	defer jm.AssertConsumed(t)
 
-	sessionServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		_, _ = w.Write([]byte(`{
-			"apiUrl": "` + apiServer.URL + `",
-			"uploadUrl": "` + apiServer.URL + `/{accountId}/",
-			"downloadUrl": "` + apiServer.URL + `",
-			"accounts": {"acc123": {}},
-			"primaryAccounts": {"urn:ietf:params:jmap:mail": "acc123"}
-		}`))
-	}))
-	defer sessionServer.Close()
-
-	client := NewClientWithBaseURL("test-token", sessionServer.URL)
+	client := NewClientWithBaseURL("test-token", jm.URL())
 
 	ids := []string{"email1", "email2"}
 	result, err := client.DeleteEmails(context.Background(), ids)
@@ -914,3 +865,192 @@ func TestBulkResult_Empty(t *testing.T) {
 		t.Errorf("Zero value BulkResult.Failed should be nil, got %v", zeroResult.Failed)
 	}
 }
+
+func TestEmptyMailbox_PagesThroughQuery(t *testing.T) {
+	// First page is full, so the client must request a second page.
+	firstPage := make([]string, emptyMailboxPageSize)
+	for i := range firstPage {
+		firstPage[i] = fmt.Sprintf("email%d", i)
+	}
+	secondPage := []string{"last1", "last2"}
+
+	var destroyCalls [][]string
+	var positions []float64
+	apiServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		var req Request
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Fatalf("decode request: %v", err)
+		}
+		method := req.MethodCalls[0][0].(string)
+		args := req.MethodCalls[0][1].(map[string]any)
+
+		switch method {
+		case "Email/query":
+			position := args["position"].(float64)
+			positions = append(positions, position)
+			ids := firstPage
+			if position > 0 {
+				ids = secondPage
+			}
+			_ = json.NewEncoder(w).Encode(map[string]any{
+				"methodResponses": []any{[]any{"Email/query", map[string]any{"ids": ids}, "query"}},
+			})
+		case "Email/set":
+			var ids []string
+			for _, id := range args["destroy"].([]any) {
+				ids = append(ids, id.(string))
+			}
+			destroyCalls = append(destroyCalls, ids)
+			result := map[string]any{"destroyed": ids}
+			if ids[len(ids)-1] == "last2" {
+				result["destroyed"] = ids[:len(ids)-1]
+				result["notDestroyed"] = map[string]any{
+					"last2": map[string]any{"type": "notFound"},
+				}
+			}
+			_ = json.NewEncoder(w).Encode(map[string]any{
+				"methodResponses": []any{[]any{"Email/set", result, "destroyEmails"}},
+			})
+		default:
+			t.Fatalf("unexpected method %s", method)
+		}
+	}))
+	defer apiServer.Close()
+
+	sessionServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{
+			"apiUrl": "` + apiServer.URL + `",
+			"accounts": {"acc123": {}}
+		}`))
+	}))
+	defer sessionServer.Close()
+
+	client := NewClientWithBaseURL("test-token", sessionServer.URL)
+
+	result, err := client.EmptyMailbox(context.Background(), "trash-123")
+	if err != nil {
+		t.Fatalf("EmptyMailbox() unexpected error: %v", err)
+	}
+
+	if !reflect.DeepEqual(positions, []float64{0, emptyMailboxPageSize}) {
+		t.Errorf("EmptyMailbox() query positions = %v, want [0 %d]", positions, emptyMailboxPageSize)
+	}
+	if len(destroyCalls) != 2 {
+		t.Fatalf("EmptyMailbox() destroy calls = %d, want 2", len(destroyCalls))
+	}
+	if len(destroyCalls[0]) != emptyMailboxPageSize || len(destroyCalls[1]) != 2 {
+		t.Errorf("EmptyMailbox() destroy batch sizes = %d, %d", len(destroyCalls[0]), len(destroyCalls[1]))
+	}
+	if len(result.Succeeded) != emptyMailboxPageSize+1 {
+		t.Errorf("EmptyMailbox() succeeded count = %d, want %d", len(result.Succeeded), emptyMailboxPageSize+1)
+	}
+	if result.Failed["last2"] != "notFound" {
+		t.Errorf("EmptyMailbox() failed = %v, want last2: notFound", result.Failed)
+	}
+}
+
+func TestEmptyMailbox_EmptyMailboxID(t *testing.T) {
+	client := NewClient("test-token")
+	if _, err := client.EmptyMailbox(context.Background(), ""); err == nil {
+		t.Error("EmptyMailbox() expected error for empty mailbox ID")
+	}
+}
+
+// newThreadBulkTestClient serves a two-email thread and records the
+// Email/set update map.
+func newThreadBulkTestClient(t *testing.T, update *map[string]any) *Client {
+	t.Helper()
+	apiServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req Request
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Errorf("decode request: %v", err)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		switch req.MethodCalls[0][0] {
+		case "Email/get":
+			_, _ = w.Write([]byte(`{"methodResponses": [["Email/get", {"list": []}, "checkEmail"]]}`))
+		case "Thread/get":
+			_, _ = w.Write([]byte(`{"methodResponses": [
+				["Thread/get", {"list": [{"id": "T1", "emailIds": ["e1", "e2"]}]}, "getThread"],
+				["Email/get", {"list": [{"id": "e1", "threadId": "T1"}, {"id": "e2", "threadId": "T1"}]}, "emails"]
+			]}`))
+		case "Email/set":
+			args := req.MethodCalls[0][1].(map[string]any)
+			*update = args["update"].(map[string]any)
+			_, _ = w.Write([]byte(`{"methodResponses": [["Email/set", {"updated": {"e1": null, "e2": null}}, "0"]]}`))
+		default:
+			t.Errorf("unexpected method %v", req.MethodCalls[0][0])
+		}
+	}))
+	t.Cleanup(apiServer.Close)
+
+	sessionServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"apiUrl": "` + apiServer.URL + `", "accounts": {"acc123": {}}}`))
+	}))
+	t.Cleanup(sessionServer.Close)
+
+	return NewClientWithBaseURL("test-token", sessionServer.URL)
+}
+
+func TestMarkThreadRead(t *testing.T) {
+	var update map[string]any
+	client := newThreadBulkTestClient(t, &update)
+
+	result, err := client.MarkThreadRead(context.Background(), "T1", false)
+	if err != nil {
+		t.Fatalf("MarkThreadRead() error = %v", err)
+	}
+	if len(result.Succeeded) != 2 {
+		t.Errorf("Succeeded = %v, want both thread emails", result.Succeeded)
+	}
+	want := map[string]any{
+		"e1": map[string]any{"keywords/$seen": nil},
+		"e2": map[string]any{"keywords/$seen": nil},
+	}
+	if !reflect.DeepEqual(update, want) {
+		t.Errorf("update = %v, want %v", update, want)
+	}
+}
+
+func TestMoveThread(t *testing.T) {
+	var update map[string]any
+	client := newThreadBulkTestClient(t, &update)
+
+	result, err := client.MoveThread(context.Background(), "T1", "archive")
+	if err != nil {
+		t.Fatalf("MoveThread() error = %v", err)
+	}
+	if len(result.Succeeded) != 2 || len(update) != 2 {
+		t.Errorf("Succeeded = %v, update = %v", result.Succeeded, update)
+	}
+	patch, _ := update["e1"].(map[string]any)
+	if mailboxes, _ := patch["mailboxIds"].(map[string]any); mailboxes["archive"] != true {
+		t.Errorf("e1 patch = %v, want mailboxIds {archive: true}", patch)
+	}
+
+	if _, err := client.MoveThread(context.Background(), "T1", ""); err == nil {
+		t.Error("MoveThread() with empty mailbox: error = nil")
+	}
+}
+
+func TestFlagThread(t *testing.T) {
+	var update map[string]any
+	client := newThreadBulkTestClient(t, &update)
+
+	result, err := client.FlagThread(context.Background(), "T1", true)
+	if err != nil {
+		t.Fatalf("FlagThread() error = %v", err)
+	}
+	if len(result.Succeeded) != 2 {
+		t.Errorf("Succeeded = %v, want both thread emails", result.Succeeded)
+	}
+	want := map[string]any{
+		"e1": map[string]any{"keywords/$flagged": true},
+		"e2": map[string]any{"keywords/$flagged": true},
+	}
+	if !reflect.DeepEqual(update, want) {
+		t.Errorf("update = %v, want %v", update, want)
+	}
+}
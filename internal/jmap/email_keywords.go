@@ -0,0 +1,99 @@
+package jmap
+
+import (
+	"context"
+	"sort"
+	"strings"
+)
+
+// keywordScanPageSize is how many emails CountKeywords fetches per request.
+const keywordScanPageSize = 256
+
+// KeywordCount is a keyword and the number of scanned emails that have it.
+type KeywordCount struct {
+	Keyword string
+	Count   int
+}
+
+// IsSystemKeyword reports whether keyword is a system or registered keyword
+// such as $seen or $flagged (RFC 8621 4.1.1), as opposed to a user label.
+func IsSystemKeyword(keyword string) bool {
+	return strings.HasPrefix(keyword, "$")
+}
+
+// CountKeywords tallies the keywords on up to limit emails in a mailbox
+// (newest first; empty mailboxID means all mail). It returns the counts,
+// most used first and then by name, and how many emails were scanned.
+func (c *Client) CountKeywords(ctx context.Context, mailboxID string, limit int) ([]KeywordCount, int, error) {
+	if limit <= 0 {
+		return nil, 0, &ValidationError{Field: "limit", Message: "must be positive"}
+	}
+
+	session, err := c.GetSession(ctx)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	filter := map[string]any{}
+	if mailboxID != "" {
+		filter["inMailbox"] = mailboxID
+	}
+
+	counts := make(map[string]int)
+	scanned := 0
+	for scanned < limit {
+		pageSize := min(keywordScanPageSize, limit-scanned)
+		req := &Request{
+			Using: []string{"urn:ietf:params:jmap:core", "urn:ietf:params:jmap:mail"},
+			MethodCalls: []MethodCall{
+				{"Email/query", map[string]any{
+					"accountId": session.AccountID,
+					"filter":    filter,
+					"sort":      emailQuerySort(nil),
+					"position":  scanned,
+					"limit":     pageSize,
+				}, "query"},
+				{"Email/get", map[string]any{
+					"accountId":  session.AccountID,
+					"#ids":       map[string]any{"resultOf": "query", "name": "Email/query", "path": "/ids"},
+					"properties": []string{"id", "keywords"},
+				}, "emails"},
+			},
+		}
+
+		resp, err := c.MakeRequest(ctx, req)
+		if err != nil {
+			return nil, 0, err
+		}
+
+		emails, err := parseEmailList(resp.MethodResponses[1])
+		if err != nil {
+			return nil, 0, err
+		}
+		for _, email := range emails {
+			for keyword, set := range email.Keywords {
+				if set {
+					counts[keyword]++
+				}
+			}
+		}
+		scanned += len(emails)
+
+		if len(emails) < pageSize {
+			break
+		}
+	}
+
+	result := make([]KeywordCount, 0, len(counts))
+	for keyword, count := range counts {
+		result = append(result, KeywordCount{Keyword: keyword, Count: count})
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Count != result[j].Count {
+			return result[i].Count > result[j].Count
+		}
+		return result[i].Keyword < result[j].Keyword
+	})
+
+	return result, scanned, nil
+}
@@ -0,0 +1,93 @@
+package jmap
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestCountKeywords_PagesAndTallies(t *testing.T) {
+	var positions []int
+
+	apiServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			MethodCalls [][]json.RawMessage `json:"methodCalls"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Fatalf("failed to decode request: %v", err)
+		}
+		var args struct {
+			Filter   map[string]any `json:"filter"`
+			Position int            `json:"position"`
+			Limit    int            `json:"limit"`
+		}
+		_ = json.Unmarshal(req.MethodCalls[0][1], &args)
+		if args.Filter["inMailbox"] != "mb-inbox" {
+			t.Errorf("filter = %v, want inMailbox mb-inbox", args.Filter)
+		}
+		positions = append(positions, args.Position)
+
+		// The first page is full; the second has two emails left.
+		n := keywordScanPageSize
+		if args.Position > 0 {
+			n = 2
+		}
+		list := make([]string, n)
+		for i := range list {
+			keywords := `{"$seen": true}`
+			if args.Position > 0 {
+				keywords = `{"$seen": true, "receipts": true, "work": true}`
+			} else if i < 3 {
+				keywords = `{"work": true}`
+			}
+			list[i] = fmt.Sprintf(`{"id": "e%d-%d", "keywords": %s}`, args.Position, i, keywords)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = fmt.Fprintf(w, `{"methodResponses": [
+			["Email/query", {"ids": []}, "query"],
+			["Email/get", {"list": [%s]}, "emails"]
+		]}`, strings.Join(list, ","))
+	}))
+	defer apiServer.Close()
+
+	sessionServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{
+			"apiUrl": "` + apiServer.URL + `",
+			"accounts": {"acc123": {}},
+			"primaryAccounts": {"urn:ietf:params:jmap:mail": "acc123"}
+		}`))
+	}))
+	defer sessionServer.Close()
+
+	client := NewClientWithBaseURL("test-token", sessionServer.URL)
+
+	counts, scanned, err := client.CountKeywords(context.Background(), "mb-inbox", 1000)
+	if err != nil {
+		t.Fatalf("CountKeywords() error = %v", err)
+	}
+
+	if scanned != keywordScanPageSize+2 {
+		t.Errorf("scanned = %d, want %d", scanned, keywordScanPageSize+2)
+	}
+	if !reflect.DeepEqual(positions, []int{0, keywordScanPageSize}) {
+		t.Errorf("positions = %v", positions)
+	}
+	want := []KeywordCount{
+		{Keyword: "$seen", Count: keywordScanPageSize - 3 + 2},
+		{Keyword: "work", Count: 5},
+		{Keyword: "receipts", Count: 2},
+	}
+	if !reflect.DeepEqual(counts, want) {
+		t.Errorf("counts = %v, want %v", counts, want)
+	}
+
+	if _, _, err := client.CountKeywords(context.Background(), "", 0); !IsValidationError(err) {
+		t.Errorf("CountKeywords(limit 0) error = %v, want validation error", err)
+	}
+}
@@ -0,0 +1,95 @@
+package jmap
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// SortComparator is one Email/query sort comparator (RFC 8621 4.4.2).
+type SortComparator struct {
+	Property    string // receivedAt, size, from, subject, hasKeyword, ...
+	IsAscending bool
+	Keyword     string // only for hasKeyword and related properties
+}
+
+func (s SortComparator) toJMAP() map[string]any {
+	m := map[string]any{"property": s.Property, "isAscending": s.IsAscending}
+	if s.Keyword != "" {
+		m["keyword"] = s.Keyword
+	}
+	return m
+}
+
+// defaultEmailSort is newest first.
+var defaultEmailSort = []SortComparator{{Property: "receivedAt", IsAscending: false}}
+
+// emailSortKeys maps --sort names to a property and its default direction:
+// dates and sizes descending (newest, biggest first), text ascending.
+var emailSortKeys = map[string]SortComparator{
+	"date":    {Property: "receivedAt", IsAscending: false},
+	"size":    {Property: "size", IsAscending: false},
+	"from":    {Property: "from", IsAscending: true},
+	"subject": {Property: "subject", IsAscending: true},
+}
+
+// EmailSortNames lists the names accepted by ParseEmailSort.
+func EmailSortNames() []string {
+	return []string{"date", "date-asc", "size", "size-asc", "from", "from-desc", "subject", "subject-desc"}
+}
+
+// ParseEmailSort parses a sort name such as "date", "date-asc", "size" or
+// "from" into comparators. Without a -asc/-desc suffix, dates and sizes sort
+// descending and from/subject ascending. Sorts other than by date are
+// followed by newest first as a tiebreaker. An empty name is the default,
+// newest first.
+func ParseEmailSort(name string) ([]SortComparator, error) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	if name == "" {
+		return defaultEmailSort, nil
+	}
+
+	key, dir := name, ""
+	if k, ok := strings.CutSuffix(name, "-asc"); ok {
+		key, dir = k, "asc"
+	} else if k, ok := strings.CutSuffix(name, "-desc"); ok {
+		key, dir = k, "desc"
+	}
+	comparator, ok := emailSortKeys[key]
+	if !ok {
+		return nil, &ValidationError{
+			Field:   "sort",
+			Message: fmt.Sprintf("unknown sort %q (valid: %s)", name, strings.Join(EmailSortNames(), ", ")),
+		}
+	}
+	switch dir {
+	case "asc":
+		comparator.IsAscending = true
+	case "desc":
+		comparator.IsAscending = false
+	}
+
+	if comparator.Property == "receivedAt" {
+		return []SortComparator{comparator}, nil
+	}
+	return append([]SortComparator{comparator}, defaultEmailSort...), nil
+}
+
+// GetEmailsSorted retrieves emails from a mailbox in the given order
+// (nil = newest first).
+func (c *Client) GetEmailsSorted(ctx context.Context, mailboxID string, limit int, sort []SortComparator) ([]Email, error) {
+	return c.ListEmails(ctx, mailboxID, limit, EmailListOpts{Sort: sort})
+}
+
+// emailQuerySort converts comparators to the Email/query sort argument,
+// falling back to newest first.
+func emailQuerySort(sort []SortComparator) []map[string]any {
+	if len(sort) == 0 {
+		sort = defaultEmailSort
+	}
+	out := make([]map[string]any, len(sort))
+	for i, s := range sort {
+		out[i] = s.toJMAP()
+	}
+	return out
+}
@@ -0,0 +1,56 @@
+package jmap
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseEmailSort(t *testing.T) {
+	newest := SortComparator{Property: "receivedAt", IsAscending: false}
+	tests := []struct {
+		name string
+		want []SortComparator
+	}{
+		{"", []SortComparator{newest}},
+		{"date", []SortComparator{newest}},
+		{"date-desc", []SortComparator{newest}},
+		{"date-asc", []SortComparator{{Property: "receivedAt", IsAscending: true}}},
+		{"size", []SortComparator{{Property: "size", IsAscending: false}, newest}},
+		{"Size-Asc", []SortComparator{{Property: "size", IsAscending: true}, newest}},
+		{"from", []SortComparator{{Property: "from", IsAscending: true}, newest}},
+		{"subject-desc", []SortComparator{{Property: "subject", IsAscending: false}, newest}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseEmailSort(tt.name)
+			if err != nil {
+				t.Fatalf("ParseEmailSort(%q) error = %v", tt.name, err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseEmailSort(%q) = %v, want %v", tt.name, got, tt.want)
+			}
+		})
+	}
+
+	for _, bad := range []string{"cc", "date-up", "-asc", "size-"} {
+		if _, err := ParseEmailSort(bad); !IsValidationError(err) {
+			t.Errorf("ParseEmailSort(%q) error = %v, want validation error", bad, err)
+		}
+	}
+}
+
+func TestEmailListSort_Custom(t *testing.T) {
+	sort, err := ParseEmailSort("size")
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := emailListSort(EmailListOpts{FlaggedFirst: true, Sort: sort})
+	want := []map[string]any{
+		{"property": "hasKeyword", "keyword": "$flagged", "isAscending": false},
+		{"property": "size", "isAscending": false},
+		{"property": "receivedAt", "isAscending": false},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("sort = %v, want %v", got, want)
+	}
+}
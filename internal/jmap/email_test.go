@@ -3,12 +3,16 @@ package jmap
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"io"
 	"net/http"
 	"net/http/httptest"
 	"reflect"
 	"strings"
 	"testing"
+	"time"
+
+	"github.com/salmonumbrella/fastmail-cli/internal/testutil"
 )
 
 func TestParseAddresses(t *testing.T) {
@@ -483,6 +487,34 @@ func TestParseEmail(t *testing.T) {
 	}
 }
 
+func TestParseAttachment(t *testing.T) {
+	att := parseAttachment(map[string]any{
+		"partId":      "3",
+		"blobId":      "B1",
+		"name":        "logo.png",
+		"type":        "image/png",
+		"size":        float64(42),
+		"cid":         "<logo@example.com>",
+		"disposition": "inline",
+	})
+	if att.ContentID != "logo@example.com" {
+		t.Errorf("ContentID = %q, want angle brackets stripped", att.ContentID)
+	}
+	if att.Disposition != "inline" || att.Size != 42 {
+		t.Errorf("unexpected attachment: %+v", att)
+	}
+	if !att.IsInline() {
+		t.Error("image with cid should be inline")
+	}
+
+	if (Attachment{Type: "application/pdf", ContentID: "x"}).IsInline() {
+		t.Error("non-image with cid should not be inline")
+	}
+	if (Attachment{Type: "image/png"}).IsInline() {
+		t.Error("image without cid should not be inline")
+	}
+}
+
 func TestParseEmailList(t *testing.T) {
 	tests := []struct {
 		name        string
@@ -672,6 +704,22 @@ func TestSendEmailOptsWithAttachments(t *testing.T) {
 	}
 }
 
+func TestAttachmentBodyParts(t *testing.T) {
+	got := attachmentBodyParts([]AttachmentOpts{
+		{BlobID: "blob-123", Name: "document.pdf", Type: "application/pdf"},
+		{BlobID: "blob-456", Name: "logo.png", Type: "image/png", Disposition: "inline"},
+		{BlobID: "blob-789", Name: "header.png", Type: "image/png", Disposition: "inline", CID: "header@receipt"},
+	})
+	want := []map[string]any{
+		{"blobId": "blob-123", "name": "document.pdf", "type": "application/pdf", "disposition": "attachment"},
+		{"blobId": "blob-456", "name": "logo.png", "type": "image/png", "disposition": "inline", "cid": "logo.png"},
+		{"blobId": "blob-789", "name": "header.png", "type": "image/png", "disposition": "inline", "cid": "header@receipt"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("attachmentBodyParts() = %v, want %v", got, want)
+	}
+}
+
 func TestParseSearchSnippets(t *testing.T) {
 	tests := []struct {
 		name        string
@@ -1950,3 +1998,825 @@ func TestSendDraft(t *testing.T) {
 		})
 	}
 }
+
+func TestSendEmailResult(t *testing.T) {
+	apiResponses := []string{
+		`{
+			"methodResponses": [
+				["Identity/get", {
+					"accountId": "acc123",
+					"list": [{"id": "identity1", "email": "test@example.com", "mayDelete": false}]
+				}, "getIdentities"]
+			]
+		}`,
+		`{
+			"methodResponses": [
+				["Mailbox/get", {
+					"accountId": "acc123",
+					"list": [
+						{"id": "sent1", "name": "Sent", "role": "sent"},
+						{"id": "drafts1", "name": "Drafts", "role": "drafts"}
+					]
+				}, "getMailboxes"]
+			]
+		}`,
+		`{
+			"methodResponses": [
+				["Email/set", {
+					"accountId": "acc123",
+					"created": {"draft": {"id": "email123", "blobId": "blob1", "threadId": "thread1"}}
+				}, "createEmail"],
+				["EmailSubmission/set", {
+					"accountId": "acc123",
+					"created": {"submission": {"id": "submission123"}}
+				}, "submitEmail"]
+			]
+		}`,
+	}
+
+	callIndex := 0
+	apiServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if callIndex < len(apiResponses) {
+			_, _ = w.Write([]byte(apiResponses[callIndex]))
+			callIndex++
+			return
+		}
+		_, _ = w.Write([]byte(`{"methodResponses": []}`))
+	}))
+	defer apiServer.Close()
+
+	sessionServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{
+			"apiUrl": "` + apiServer.URL + `",
+			"uploadUrl": "` + apiServer.URL + `/{accountId}/",
+			"downloadUrl": "` + apiServer.URL + `",
+			"accounts": {"acc123": {}},
+			"primaryAccounts": {"urn:ietf:params:jmap:mail": "acc123"}
+		}`))
+	}))
+	defer sessionServer.Close()
+
+	client := NewClientWithBaseURL("test-token", sessionServer.URL)
+
+	result, err := client.SendEmailResult(context.Background(), SendEmailOpts{
+		To:       []string{"recipient@example.com"},
+		Subject:  "Hello",
+		TextBody: "Body",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if result.DraftID != "email123" {
+		t.Errorf("DraftID = %q, want %q", result.DraftID, "email123")
+	}
+	if result.EmailID != "email123" {
+		t.Errorf("EmailID = %q, want %q", result.EmailID, "email123")
+	}
+	if result.SubmissionID != "submission123" {
+		t.Errorf("SubmissionID = %q, want %q", result.SubmissionID, "submission123")
+	}
+}
+
+func TestFilterForwardAttachments(t *testing.T) {
+	attachments := []Attachment{
+		{BlobID: "small", Name: "notes.txt", Size: 1024},
+		{BlobID: "large", Name: "video.mp4", Size: 50 * 1024 * 1024},
+	}
+
+	tests := []struct {
+		name        string
+		opts        ForwardEmailOpts
+		wantKept    []string
+		wantDropped []string
+	}{
+		{
+			name:     "default keeps all",
+			opts:     ForwardEmailOpts{},
+			wantKept: []string{"small", "large"},
+		},
+		{
+			name:        "no attachments drops all",
+			opts:        ForwardEmailOpts{NoAttachments: true},
+			wantDropped: []string{"small", "large"},
+		},
+		{
+			name:        "max size drops large",
+			opts:        ForwardEmailOpts{MaxAttachmentSize: 10 * 1024 * 1024},
+			wantKept:    []string{"small"},
+			wantDropped: []string{"large"},
+		},
+	}
+
+	blobIDs := func(atts []Attachment) []string {
+		var ids []string
+		for _, att := range atts {
+			ids = append(ids, att.BlobID)
+		}
+		return ids
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			kept, dropped := FilterForwardAttachments(attachments, tt.opts)
+			if got := blobIDs(kept); !reflect.DeepEqual(got, tt.wantKept) {
+				t.Errorf("kept = %v, want %v", got, tt.wantKept)
+			}
+			if got := blobIDs(dropped); !reflect.DeepEqual(got, tt.wantDropped) {
+				t.Errorf("dropped = %v, want %v", got, tt.wantDropped)
+			}
+		})
+	}
+}
+
+// newEmailCreateCaptureClient returns a client whose fake server answers identity and
+// mailbox lookups and records the "draft" object sent in Email/set create.
+func newEmailCreateCaptureClient(t *testing.T, captured *map[string]any) *Client {
+	t.Helper()
+
+	apiServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		var req Request
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Fatalf("failed to decode request: %v", err)
+		}
+
+		switch req.MethodCalls[0][0] {
+		case "Identity/get":
+			_, _ = w.Write([]byte(`{"methodResponses": [["Identity/get", {"list": [{"id": "identity1", "email": "me@example.com", "mayDelete": false}]}, "getIdentities"]]}`))
+		case "Mailbox/get":
+			_, _ = w.Write([]byte(`{"methodResponses": [["Mailbox/get", {"list": [
+				{"id": "drafts1", "name": "Drafts", "role": "drafts"},
+				{"id": "sent1", "name": "Sent", "role": "sent"}
+			]}, "getMailboxes"]]}`))
+		case "Email/set":
+			args := req.MethodCalls[0][1].(map[string]any)
+			*captured = args["create"].(map[string]any)["draft"].(map[string]any)
+			_, _ = w.Write([]byte(`{"methodResponses": [
+				["Email/set", {"created": {"draft": {"id": "email1"}}}, "createEmail"],
+				["EmailSubmission/set", {"created": {"submission": {"id": "sub1"}}}, "submitEmail"]
+			]}`))
+		default:
+			t.Fatalf("unexpected method %v", req.MethodCalls[0][0])
+		}
+	}))
+	t.Cleanup(apiServer.Close)
+
+	sessionServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"apiUrl": "` + apiServer.URL + `", "accounts": {"acc123": {}}}`))
+	}))
+	t.Cleanup(sessionServer.Close)
+
+	return NewClientWithBaseURL("test-token", sessionServer.URL)
+}
+
+func assertUTF8EmailObject(t *testing.T, emailObj map[string]any, subject, textBody, htmlBody string) {
+	t.Helper()
+
+	if emailObj["subject"] != subject {
+		t.Errorf("subject = %q, want %q passed through unchanged", emailObj["subject"], subject)
+	}
+
+	for _, tc := range []struct {
+		key, partID, mimeType, value string
+	}{
+		{"textBody", "text", "text/plain", textBody},
+		{"htmlBody", "html", "text/html", htmlBody},
+	} {
+		parts, ok := emailObj[tc.key].([]any)
+		if !ok || len(parts) != 1 {
+			t.Fatalf("%s = %v, want one part", tc.key, emailObj[tc.key])
+		}
+		part := parts[0].(map[string]any)
+		if part["type"] != tc.mimeType || part["charset"] != "utf-8" || part["partId"] != tc.partID {
+			t.Errorf("%s part = %v, want type %s with charset utf-8", tc.key, part, tc.mimeType)
+		}
+
+		bodyValues := emailObj["bodyValues"].(map[string]any)
+		if got := bodyValues[tc.partID].(map[string]any)["value"]; got != tc.value {
+			t.Errorf("bodyValues[%s] = %q, want %q", tc.partID, got, tc.value)
+		}
+	}
+}
+
+func TestSendEmail_NonASCIIDeclaresUTF8(t *testing.T) {
+	var emailObj map[string]any
+	client := newEmailCreateCaptureClient(t, &emailObj)
+
+	subject := "Café réunion 🎉"
+	textBody := "Voilà le résumé — à bientôt 👋"
+	htmlBody := "<p>Voilà le <b>résumé</b> 👋</p>"
+
+	_, err := client.SendEmail(context.Background(), SendEmailOpts{
+		To:       []string{"recipient@example.com"},
+		Subject:  subject,
+		TextBody: textBody,
+		HTMLBody: htmlBody,
+	})
+	if err != nil {
+		t.Fatalf("SendEmail() error = %v", err)
+	}
+
+	assertUTF8EmailObject(t, emailObj, subject, textBody, htmlBody)
+}
+
+func TestSaveDraft_NonASCIIDeclaresUTF8(t *testing.T) {
+	var emailObj map[string]any
+	client := newEmailCreateCaptureClient(t, &emailObj)
+
+	subject := "Grüße aus München ☕"
+	textBody := "Schöne Grüße, Jürgen ☕"
+	htmlBody := "<p>Schöne Grüße ☕</p>"
+
+	_, err := client.SaveDraft(context.Background(), SendEmailOpts{
+		To:       []string{"recipient@example.com"},
+		Subject:  subject,
+		TextBody: textBody,
+		HTMLBody: htmlBody,
+	})
+	if err != nil {
+		t.Fatalf("SaveDraft() error = %v", err)
+	}
+
+	assertUTF8EmailObject(t, emailObj, subject, textBody, htmlBody)
+}
+
+func TestListEmailsWithPreview(t *testing.T) {
+	var getArgs map[string]any
+
+	apiServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			MethodCalls [][]json.RawMessage `json:"methodCalls"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Fatalf("failed to decode request: %v", err)
+		}
+		_ = json.Unmarshal(req.MethodCalls[1][1], &getArgs)
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{
+			"methodResponses": [
+				["Email/query", {"ids": ["e1", "e2"]}, "query"],
+				["Email/get", {"list": [
+					{
+						"id": "e1", "subject": "Long", "preview": "short",
+						"textBody": [{"partId": "1", "type": "text/plain"}],
+						"bodyValues": {"1": {"value": "First line\n\nsecond   line", "isTruncated": true}}
+					},
+					{"id": "e2", "subject": "No body", "preview": "server preview"}
+				]}, "emails"]
+			]
+		}`))
+	}))
+	defer apiServer.Close()
+
+	sessionServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{
+			"apiUrl": "` + apiServer.URL + `",
+			"accounts": {"acc123": {}},
+			"primaryAccounts": {"urn:ietf:params:jmap:mail": "acc123"}
+		}`))
+	}))
+	defer sessionServer.Close()
+
+	client := NewClientWithBaseURL("test-token", sessionServer.URL)
+	emails, err := client.ListEmailsWithPreview(context.Background(), "", 10, 512)
+	if err != nil {
+		t.Fatalf("ListEmailsWithPreview() error = %v", err)
+	}
+
+	if getArgs["maxBodyValueBytes"] != float64(512) || getArgs["fetchTextBodyValues"] != true {
+		t.Errorf("Email/get args = %v, want maxBodyValueBytes=512 and fetchTextBodyValues", getArgs)
+	}
+
+	if len(emails) != 2 {
+		t.Fatalf("got %d emails, want 2", len(emails))
+	}
+	if emails[0].Preview != "First line second line" || !emails[0].PreviewTruncated {
+		t.Errorf("email[0] preview = %q truncated=%v", emails[0].Preview, emails[0].PreviewTruncated)
+	}
+	if !emails[0].BodyValues["1"].IsTruncated {
+		t.Error("BodyValue.IsTruncated not parsed")
+	}
+	if emails[1].Preview != "server preview" || emails[1].PreviewTruncated {
+		t.Errorf("email[1] preview = %q truncated=%v, want server preview kept", emails[1].Preview, emails[1].PreviewTruncated)
+	}
+
+	if _, err := client.ListEmailsWithPreview(context.Background(), "", 10, 0); !IsValidationError(err) {
+		t.Errorf("ListEmailsWithPreview(0) error = %v, want validation error", err)
+	}
+}
+
+func TestListAndSearchEmails_Attachments(t *testing.T) {
+	var calls [][]json.RawMessage
+
+	apiServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			MethodCalls [][]json.RawMessage `json:"methodCalls"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Fatalf("failed to decode request: %v", err)
+		}
+		calls = req.MethodCalls
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{
+			"methodResponses": [
+				["Email/query", {"ids": ["e1", "e2"]}, "query"],
+				["Email/get", {"list": [
+					{
+						"id": "e1", "subject": "Photos", "hasAttachment": true, "size": 4096,
+						"attachments": [
+							{"partId": "2", "blobId": "b1", "name": "a.jpg", "type": "image/jpeg", "size": 100},
+							{"partId": "3", "blobId": "b2", "name": "b.jpg", "type": "image/jpeg", "size": 200},
+							{"partId": "4", "blobId": "b3", "name": "c.pdf", "type": "application/pdf", "size": 300}
+						]
+					},
+					{"id": "e2", "subject": "Plain", "hasAttachment": false, "attachments": []}
+				]}, "emails"]
+			]
+		}`))
+	}))
+	defer apiServer.Close()
+
+	sessionServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{
+			"apiUrl": "` + apiServer.URL + `",
+			"accounts": {"acc123": {}},
+			"primaryAccounts": {"urn:ietf:params:jmap:mail": "acc123"}
+		}`))
+	}))
+	defer sessionServer.Close()
+
+	client := NewClientWithBaseURL("test-token", sessionServer.URL)
+
+	requestedProperties := func() []string {
+		var args struct {
+			Properties []string `json:"properties"`
+		}
+		_ = json.Unmarshal(calls[1][1], &args)
+		return args.Properties
+	}
+	hasProperty := func(props []string, name string) bool {
+		for _, p := range props {
+			if p == name {
+				return true
+			}
+		}
+		return false
+	}
+	checkEmails := func(name string, emails []Email) {
+		t.Helper()
+		if len(emails) != 2 {
+			t.Fatalf("%s: got %d emails, want 2", name, len(emails))
+		}
+		if len(emails[0].Attachments) != 3 || emails[0].Attachments[2].Name != "c.pdf" {
+			t.Errorf("%s: email[0] attachments = %+v, want 3", name, emails[0].Attachments)
+		}
+		if len(emails[1].Attachments) != 0 {
+			t.Errorf("%s: email[1] attachments = %+v, want none", name, emails[1].Attachments)
+		}
+	}
+
+	emails, err := client.ListEmails(context.Background(), "", 10, EmailListOpts{Attachments: true})
+	if err != nil {
+		t.Fatalf("ListEmails() error = %v", err)
+	}
+	if !hasProperty(requestedProperties(), "attachments") || !hasProperty(requestedProperties(), "size") {
+		t.Errorf("ListEmails properties = %v, want attachments and size", requestedProperties())
+	}
+	if emails[0].Size != 4096 {
+		t.Errorf("ListEmails size = %d, want 4096", emails[0].Size)
+	}
+	checkEmails("ListEmails", emails)
+
+	emails, snippets, err := client.SearchEmailsWithOpts(context.Background(), &EmailSearchFilter{Text: "x"}, 10, EmailSearchOpts{Attachments: true})
+	if err != nil {
+		t.Fatalf("SearchEmailsWithOpts() error = %v", err)
+	}
+	if !hasProperty(requestedProperties(), "attachments") || !hasProperty(requestedProperties(), "size") {
+		t.Errorf("SearchEmailsWithOpts properties = %v, want attachments and size", requestedProperties())
+	}
+	if len(calls) != 2 || snippets != nil {
+		t.Errorf("SearchEmailsWithOpts made %d calls, snippets = %v; want no SearchSnippet/get", len(calls), snippets)
+	}
+	checkEmails("SearchEmailsWithOpts", emails)
+
+	if _, err := client.GetEmails(context.Background(), "", 10); err != nil {
+		t.Fatalf("GetEmails() error = %v", err)
+	}
+	if hasProperty(requestedProperties(), "attachments") {
+		t.Errorf("GetEmails properties = %v, should not request attachments", requestedProperties())
+	}
+	if !hasProperty(requestedProperties(), "size") {
+		t.Errorf("GetEmails properties = %v, want size", requestedProperties())
+	}
+}
+
+func TestEmailListSort(t *testing.T) {
+	got := emailListSort(EmailListOpts{})
+	want := []map[string]any{{"property": "receivedAt", "isAscending": false}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("default sort = %v, want %v", got, want)
+	}
+
+	got = emailListSort(EmailListOpts{FlaggedFirst: true})
+	want = []map[string]any{
+		{"property": "hasKeyword", "keyword": "$flagged", "isAscending": false},
+		{"property": "receivedAt", "isAscending": false},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("flagged-first sort = %v, want %v", got, want)
+	}
+}
+
+func TestListEmails_FlaggedFirstSendsKeywordSort(t *testing.T) {
+	var captured *Request
+	client := NewClient("test-token")
+	client.session = &Session{AccountID: "acc123"}
+	client.sessionFetch = time.Now()
+	client.SetDryRun(func(req *Request) { captured = req })
+
+	if _, err := client.ListEmails(context.Background(), "", 10, EmailListOpts{FlaggedFirst: true}); err != nil {
+		t.Fatalf("ListEmails() error = %v", err)
+	}
+
+	data, err := json.Marshal(captured.MethodCalls[0][1])
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+	var args struct {
+		Sort []map[string]any `json:"sort"`
+	}
+	if err := json.Unmarshal(data, &args); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	if len(args.Sort) != 2 || args.Sort[0]["property"] != "hasKeyword" || args.Sort[0]["keyword"] != "$flagged" || args.Sort[0]["isAscending"] != false {
+		t.Errorf("Email/query sort = %v, want hasKeyword $flagged first", args.Sort)
+	}
+	if args.Sort[1]["property"] != "receivedAt" {
+		t.Errorf("second sort = %v, want receivedAt", args.Sort[1])
+	}
+}
+
+func TestGetEmailBodies(t *testing.T) {
+	var getArgs map[string]any
+
+	apiServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			MethodCalls [][]json.RawMessage `json:"methodCalls"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Fatalf("failed to decode request: %v", err)
+		}
+		_ = json.Unmarshal(req.MethodCalls[0][1], &getArgs)
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{
+			"methodResponses": [
+				["Email/get", {"list": [{
+					"id": "e1",
+					"textBody": [{"partId": "1", "type": "text/plain"}],
+					"htmlBody": [{"partId": "2", "type": "text/html"}],
+					"bodyValues": {
+						"1": {"value": "plain"},
+						"2": {"value": "<p>html</p>", "isTruncated": true}
+					}
+				}]}, "bodies"]
+			]
+		}`))
+	}))
+	defer apiServer.Close()
+
+	sessionServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{
+			"apiUrl": "` + apiServer.URL + `",
+			"accounts": {"acc123": {}},
+			"primaryAccounts": {"urn:ietf:params:jmap:mail": "acc123"}
+		}`))
+	}))
+	defer sessionServer.Close()
+
+	client := NewClientWithBaseURL("test-token", sessionServer.URL)
+	emails, err := client.GetEmailBodies(context.Background(), []string{"e1"}, 1024)
+	if err != nil {
+		t.Fatalf("GetEmailBodies() error = %v", err)
+	}
+
+	if getArgs["maxBodyValueBytes"] != float64(1024) || getArgs["fetchTextBodyValues"] != true || getArgs["fetchHTMLBodyValues"] != true {
+		t.Errorf("Email/get args = %v", getArgs)
+	}
+	if len(emails) != 1 || emails[0].BodyValues["1"].Value != "plain" || !emails[0].BodyValues["2"].IsTruncated {
+		t.Errorf("unexpected emails: %+v", emails)
+	}
+
+	if _, err := client.GetEmailBodies(context.Background(), []string{"e1"}, 0); !IsValidationError(err) {
+		t.Errorf("zero cap error = %v, want validation error", err)
+	}
+}
+
+func TestGetEmailsByIDs(t *testing.T) {
+	var getArgs map[string]any
+
+	apiServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			MethodCalls [][]json.RawMessage `json:"methodCalls"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Fatalf("failed to decode request: %v", err)
+		}
+		_ = json.Unmarshal(req.MethodCalls[0][1], &getArgs)
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{
+			"methodResponses": [
+				["Email/get", {
+					"list": [
+						{"id": "e3", "subject": "Third", "receivedAt": "2025-01-03T00:00:00Z"},
+						{"id": "e1", "subject": "First", "receivedAt": "2025-01-01T00:00:00Z"}
+					],
+					"notFound": ["missing"]
+				}, "emails"]
+			]
+		}`))
+	}))
+	defer apiServer.Close()
+
+	sessionServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{
+			"apiUrl": "` + apiServer.URL + `",
+			"accounts": {"acc123": {}},
+			"primaryAccounts": {"urn:ietf:params:jmap:mail": "acc123"}
+		}`))
+	}))
+	defer sessionServer.Close()
+
+	client := NewClientWithBaseURL("test-token", sessionServer.URL)
+	emails, notFound, err := client.GetEmailsByIDs(context.Background(), []string{"e1", "missing", "e3", "e1"})
+	if err != nil {
+		t.Fatalf("GetEmailsByIDs() error = %v", err)
+	}
+
+	if ids, _ := getArgs["ids"].([]any); len(ids) != 3 {
+		t.Errorf("Email/get ids = %v, want 3 unique IDs", getArgs["ids"])
+	}
+	if len(emails) != 2 || emails[0].ID != "e1" || emails[1].ID != "e3" {
+		t.Errorf("emails = %+v, want e1, e3 in request order", emails)
+	}
+	if len(notFound) != 1 || notFound[0] != "missing" {
+		t.Errorf("notFound = %v, want [missing]", notFound)
+	}
+
+	emails, notFound, err = client.GetEmailsByIDs(context.Background(), nil)
+	if err != nil || len(emails) != 0 || len(notFound) != 0 {
+		t.Errorf("empty input = %v, %v, %v", emails, notFound, err)
+	}
+}
+
+func TestBuildResendOpts(t *testing.T) {
+	original := &Email{
+		ID:         "E1",
+		Subject:    "Invoice",
+		From:       []EmailAddress{{Name: "Me", Email: "me@example.com"}},
+		To:         []EmailAddress{{Email: "a@example.com"}, {Email: "b@example.com"}},
+		CC:         []EmailAddress{{Email: "c@example.com"}},
+		TextBody:   []BodyPart{{PartID: "1", Type: "text/plain"}},
+		HTMLBody:   []BodyPart{{PartID: "2", Type: "text/html"}},
+		BodyValues: map[string]BodyValue{"1": {Value: "plain"}, "2": {Value: "<p>html</p>"}},
+		References: []string{"<root@example.com>"},
+		Attachments: []Attachment{
+			{BlobID: "B1", Name: "invoice.pdf", Type: "application/pdf"},
+			{BlobID: "B2", Name: "terms.pdf", Type: "application/pdf"},
+			{BlobID: "B3", Name: "logo.png", Type: "image/png", ContentID: "logo@shop", Disposition: "inline"},
+		},
+	}
+
+	opts, err := BuildResendOpts(original, SendEmailOpts{})
+	if err != nil {
+		t.Fatalf("BuildResendOpts() error = %v", err)
+	}
+	if opts.From != "me@example.com" || opts.Subject != "Invoice" {
+		t.Errorf("from/subject = %q/%q", opts.From, opts.Subject)
+	}
+	if !reflect.DeepEqual(opts.To, []string{"a@example.com", "b@example.com"}) || !reflect.DeepEqual(opts.CC, []string{"c@example.com"}) {
+		t.Errorf("recipients = %v / %v", opts.To, opts.CC)
+	}
+	if opts.TextBody != "plain" || opts.HTMLBody != "<p>html</p>" {
+		t.Errorf("bodies = %q / %q", opts.TextBody, opts.HTMLBody)
+	}
+	wantAtt := []AttachmentOpts{
+		{BlobID: "B1", Name: "invoice.pdf", Type: "application/pdf"},
+		{BlobID: "B2", Name: "terms.pdf", Type: "application/pdf"},
+		{BlobID: "B3", Name: "logo.png", Type: "image/png", Disposition: "inline", CID: "logo@shop"},
+	}
+	if !reflect.DeepEqual(opts.Attachments, wantAtt) {
+		t.Errorf("attachments = %+v, want original blobs", opts.Attachments)
+	}
+	if !reflect.DeepEqual(opts.References, original.References) {
+		t.Errorf("references = %v", opts.References)
+	}
+
+	opts, err = BuildResendOpts(original, SendEmailOpts{To: []string{"z@example.com"}, From: "alt@example.com"})
+	if err != nil {
+		t.Fatalf("BuildResendOpts(overrides) error = %v", err)
+	}
+	if !reflect.DeepEqual(opts.To, []string{"z@example.com"}) || opts.CC != nil || opts.From != "alt@example.com" {
+		t.Errorf("overridden opts = to %v cc %v from %q; want only the new recipient", opts.To, opts.CC, opts.From)
+	}
+
+	noBody := *original
+	noBody.BodyValues = nil
+	if _, err := BuildResendOpts(&noBody, SendEmailOpts{}); !errors.Is(err, ErrNoBody) {
+		t.Errorf("missing bodies error = %v, want ErrNoBody", err)
+	}
+
+	htmlOnly := *original
+	htmlOnly.BodyValues = map[string]BodyValue{"2": {Value: "<p>html</p>"}}
+	if opts, err := BuildResendOpts(&htmlOnly, SendEmailOpts{}); err != nil || opts.TextBody != "" || opts.HTMLBody == "" {
+		t.Errorf("html-only resend = %+v, %v; want HTML body kept", opts, err)
+	}
+}
+
+func TestReplyAllRecipients(t *testing.T) {
+	identities := []Identity{
+		{Email: "me@example.com"},
+		{Email: "*@mydomain.com"},
+	}
+	own := ownAddressMatcher(identities, "alias123@fastmail.com")
+
+	original := &Email{
+		From: []EmailAddress{{Email: "alice@example.com"}},
+		To: []EmailAddress{
+			{Email: "Me@Example.com"},
+			{Email: "bob@example.com"},
+			{Email: "anything@mydomain.com"},
+		},
+		CC: []EmailAddress{
+			{Email: "carol@example.com"},
+			{Email: "ALICE@example.com"},
+			{Email: "alias123@fastmail.com"},
+		},
+	}
+
+	to, cc := replyAllRecipients(original, []string{"alice@example.com"}, []string{"dave@example.com"}, own)
+	if want := []string{"alice@example.com", "bob@example.com"}; !reflect.DeepEqual(to, want) {
+		t.Errorf("to = %v, want %v", to, want)
+	}
+	if want := []string{"dave@example.com", "carol@example.com"}; !reflect.DeepEqual(cc, want) {
+		t.Errorf("cc = %v, want %v", cc, want)
+	}
+
+	// Replying to your own message: only others remain, and a CC-only
+	// result is promoted to To.
+	sent := &Email{
+		From: []EmailAddress{{Email: "me@example.com"}},
+		CC:   []EmailAddress{{Email: "carol@example.com"}, {Email: "erin@example.com"}},
+	}
+	to, cc = replyAllRecipients(sent, []string{"me@example.com"}, nil, own)
+	if want := []string{"carol@example.com"}; !reflect.DeepEqual(to, want) {
+		t.Errorf("own message: to = %v, want %v", to, want)
+	}
+	if want := []string{"erin@example.com"}; !reflect.DeepEqual(cc, want) {
+		t.Errorf("own message: cc = %v, want %v", cc, want)
+	}
+}
+
+func TestSendEmailResult_ReplySendsThreadingHeaders(t *testing.T) {
+	var emailObj map[string]any
+	client := newEmailCreateCaptureClient(t, &emailObj)
+
+	original := &Email{
+		Subject:    "Plans",
+		From:       []EmailAddress{{Email: "alice@example.com"}},
+		To:         []EmailAddress{{Email: "me@example.com"}},
+		MessageID:  []string{"m1@example.com"},
+		References: []string{"m0@example.com"},
+	}
+	opts, err := client.ReplyOpts(context.Background(), original, SendEmailOpts{From: "me@example.com", TextBody: "Sounds good"}, false)
+	if err != nil {
+		t.Fatalf("ReplyOpts() error = %v", err)
+	}
+	if _, err := client.SendEmailResult(context.Background(), opts); err != nil {
+		t.Fatalf("SendEmailResult() error = %v", err)
+	}
+
+	if got, want := emailObj["inReplyTo"], []any{"m1@example.com"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("inReplyTo = %v, want %v", got, want)
+	}
+	if got, want := emailObj["references"], []any{"m0@example.com", "m1@example.com"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("references = %v, want %v", got, want)
+	}
+}
+
+func TestSendEmail_SendsThreadingHeaders(t *testing.T) {
+	jm := testutil.NewJMAPMock()
+	defer jm.Close()
+	jm.QueueResponse(`{"methodResponses": [["Identity/get", {"list": [{"id": "identity1", "email": "me@example.com", "mayDelete": false}]}, "getIdentities"]]}`)
+	jm.QueueResponse(`{"methodResponses": [["Mailbox/get", {"list": [{"id": "drafts1", "role": "drafts"}, {"id": "sent1", "role": "sent"}]}, "getMailboxes"]]}`)
+	jm.QueueResponse(`{"methodResponses": [
+		["Email/set", {"created": {"draft": {"id": "email1"}}}, "createEmail"],
+		["EmailSubmission/set", {"created": {"submission": {"id": "sub1"}}}, "submitEmail"]
+	]}`)
+
+	client := NewClientWithBaseURL("test-token", jm.URL())
+	_, err := client.SendEmail(context.Background(), SendEmailOpts{
+		To:         []string{"alice@example.com"},
+		Subject:    "Re: Plans",
+		TextBody:   "Sounds good",
+		InReplyTo:  []string{"<m1@example.com>"},
+		References: []string{"<m0@example.com>", "<m1@example.com>"},
+	})
+	if err != nil {
+		t.Fatalf("SendEmail() error = %v", err)
+	}
+
+	jm.AssertConsumed(t)
+	jm.AssertRequestBody(t, func(body any) bool {
+		var req Request
+		raw, _ := json.Marshal(body)
+		if json.Unmarshal(raw, &req) != nil || len(req.MethodCalls) == 0 || req.MethodCalls[0][0] != "Email/set" {
+			return false
+		}
+		args, _ := req.MethodCalls[0][1].(map[string]any)
+		create, _ := args["create"].(map[string]any)
+		draft, _ := create["draft"].(map[string]any)
+		return reflect.DeepEqual(draft["inReplyTo"], []any{"<m1@example.com>"}) &&
+			reflect.DeepEqual(draft["references"], []any{"<m0@example.com>", "<m1@example.com>"})
+	})
+}
+
+func TestSendEmail_CustomHeaders(t *testing.T) {
+	var emailObj map[string]any
+	client := newEmailCreateCaptureClient(t, &emailObj)
+
+	_, err := client.SendEmail(context.Background(), SendEmailOpts{
+		To:       []string{"recipient@example.com"},
+		Subject:  "Status",
+		TextBody: "All good",
+		Headers: map[string]string{
+			"X-Priority":     "1",
+			"Auto-Submitted": "auto-generated",
+		},
+	})
+	if err != nil {
+		t.Fatalf("SendEmail() error = %v", err)
+	}
+
+	if got := emailObj["header:X-Priority:asText"]; got != "1" {
+		t.Errorf("header:X-Priority:asText = %v, want 1", got)
+	}
+	if got := emailObj["header:Auto-Submitted:asText"]; got != "auto-generated" {
+		t.Errorf("header:Auto-Submitted:asText = %v, want auto-generated", got)
+	}
+}
+
+func TestSaveDraft_CustomHeaders(t *testing.T) {
+	var emailObj map[string]any
+	client := newEmailCreateCaptureClient(t, &emailObj)
+
+	_, err := client.SaveDraft(context.Background(), SendEmailOpts{
+		To:       []string{"recipient@example.com"},
+		Subject:  "Newsletter",
+		TextBody: "Hello",
+		Headers:  map[string]string{"List-Unsubscribe": "<mailto:unsub@example.com>"},
+	})
+	if err != nil {
+		t.Fatalf("SaveDraft() error = %v", err)
+	}
+
+	if got := emailObj["header:List-Unsubscribe:asText"]; got != "<mailto:unsub@example.com>" {
+		t.Errorf("header:List-Unsubscribe:asText = %v", got)
+	}
+}
+
+func TestSendEmail_RejectsInvalidHeaders(t *testing.T) {
+	tests := []struct {
+		name, header, value string
+	}{
+		{"server controlled", "Message-ID", "<x@example.com>"},
+		{"address field", "from", "evil@example.com"},
+		{"date", "Date", "Mon, 1 Jan 2024 00:00:00 +0000"},
+		{"content type", "Content-Type", "text/html"},
+		{"newline in value", "X-Foo", "bar\r\nBcc: evil@example.com"},
+		{"colon in name", "X-Foo:", "bar"},
+		{"space in name", "X Foo", "bar"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// No server: validation must fail before any request
+			client := NewClientWithBaseURL("test-token", "http://127.0.0.1:0")
+			_, err := client.SendEmail(context.Background(), SendEmailOpts{
+				To:       []string{"recipient@example.com"},
+				Subject:  "Hi",
+				TextBody: "Hi",
+				Headers:  map[string]string{tt.header: tt.value},
+			})
+			var vErr *ValidationError
+			if !errors.As(err, &vErr) {
+				t.Fatalf("SendEmail() error = %v, want ValidationError", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,185 @@
+package jmap
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// EmailPatch is a JMAP Email/set update patch. Build one with NewEmailPatch
+// to combine a move with keyword changes in a single request.
+type EmailPatch map[string]any
+
+// NewEmailPatch returns an empty patch.
+func NewEmailPatch() EmailPatch {
+	return EmailPatch{}
+}
+
+// MoveTo replaces the email's mailboxes with the given mailbox.
+func (p EmailPatch) MoveTo(mailboxID string) EmailPatch {
+	p["mailboxIds"] = map[string]bool{mailboxID: true}
+	return p
+}
+
+// SetKeyword sets or clears a single keyword without touching the others.
+func (p EmailPatch) SetKeyword(keyword string, set bool) EmailPatch {
+	if set {
+		p["keywords/"+keyword] = true
+	} else {
+		p["keywords/"+keyword] = nil // null in JMAP removes the keyword
+	}
+	return p
+}
+
+// Flag sets or clears the $flagged keyword.
+func (p EmailPatch) Flag(flagged bool) EmailPatch {
+	return p.SetKeyword("$flagged", flagged)
+}
+
+// Read sets or clears the $seen keyword.
+func (p EmailPatch) Read(read bool) EmailPatch {
+	return p.SetKeyword("$seen", read)
+}
+
+// ValidateEmailPatch checks that a patch only touches mailboxIds and keywords,
+// which are the mutable Email properties (RFC 8621 section 4.6).
+func ValidateEmailPatch(patch map[string]any) error {
+	if len(patch) == 0 {
+		return &ValidationError{Field: "patch", Message: "patch is empty"}
+	}
+
+	keys := make([]string, 0, len(patch))
+	for key := range patch {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		value := patch[key]
+		switch {
+		case key == "mailboxIds" || key == "keywords":
+			if !isBoolSet(value) {
+				return &ValidationError{Field: key, Message: "must be a map of string to true"}
+			}
+			if key == "mailboxIds" && isEmptySet(value) {
+				return &ValidationError{Field: key, Message: "an email must belong to at least one mailbox"}
+			}
+		case strings.HasPrefix(key, "mailboxIds/") || strings.HasPrefix(key, "keywords/"):
+			name := key[strings.Index(key, "/")+1:]
+			if name == "" || strings.ContainsAny(name, "/ \t\r\n") {
+				return &ValidationError{Field: key, Message: "invalid patch path"}
+			}
+			if value != nil && value != true {
+				return &ValidationError{Field: key, Message: "value must be true or null"}
+			}
+		default:
+			return &ValidationError{Field: key, Message: "only mailboxIds and keywords can be updated"}
+		}
+	}
+
+	return nil
+}
+
+// isBoolSet reports whether v is a JMAP set: a map whose values are all true.
+func isBoolSet(v any) bool {
+	switch set := v.(type) {
+	case map[string]bool:
+		for _, ok := range set {
+			if !ok {
+				return false
+			}
+		}
+		return true
+	case map[string]any:
+		for _, ok := range set {
+			if ok != true {
+				return false
+			}
+		}
+		return true
+	}
+	return false
+}
+
+func isEmptySet(v any) bool {
+	switch set := v.(type) {
+	case map[string]bool:
+		return len(set) == 0
+	case map[string]any:
+		return len(set) == 0
+	}
+	return false
+}
+
+// UpdateEmail applies an arbitrary Email/set update patch to one email.
+// The patch is validated with ValidateEmailPatch before it is sent.
+func (c *Client) UpdateEmail(ctx context.Context, id string, patch map[string]any) error {
+	if id == "" {
+		return &ValidationError{Field: "id", Message: "email ID is required"}
+	}
+
+	results, err := c.UpdateEmails(ctx, []string{id}, patch)
+	if err != nil {
+		return err
+	}
+
+	if msg, exists := results.Failed[id]; exists {
+		return fmt.Errorf("failed to update email %s: %s", id, msg)
+	}
+
+	return nil
+}
+
+// UpdateEmails applies the same update patch to multiple emails in a single
+// JMAP request. Returns a BulkResult containing IDs that succeeded and failed.
+func (c *Client) UpdateEmails(ctx context.Context, ids []string, patch map[string]any) (*BulkResult, error) {
+	if err := ValidateEmailPatch(patch); err != nil {
+		return nil, err
+	}
+
+	// Handle empty/nil input
+	if len(ids) == 0 {
+		return &BulkResult{
+			Succeeded: []string{},
+			Failed:    map[string]string{},
+		}, nil
+	}
+
+	session, err := c.GetSession(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	updates := make(map[string]any)
+	for _, id := range ids {
+		updates[id] = patch
+	}
+
+	req := &Request{
+		Using: []string{"urn:ietf:params:jmap:core", "urn:ietf:params:jmap:mail"},
+		MethodCalls: []MethodCall{
+			{"Email/set", map[string]any{
+				"accountId": session.AccountID,
+				"update":    updates,
+			}, "updateEmails"},
+		},
+	}
+
+	resp, err := c.MakeRequest(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	result, ok := resp.MethodResponses[0][1].(map[string]any)
+	if !ok {
+		return nil, fmt.Errorf("unexpected response format")
+	}
+
+	succeeded, failed := parseBulkUpdateResult(result)
+
+	return &BulkResult{
+		Succeeded: succeeded,
+		Failed:    failed,
+	}, nil
+}
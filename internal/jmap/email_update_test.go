@@ -0,0 +1,136 @@
+package jmap
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestEmailPatch_Builder(t *testing.T) {
+	patch := NewEmailPatch().MoveTo("mb-archive").Flag(true).Read(false)
+
+	mailboxIDs, ok := patch["mailboxIds"].(map[string]bool)
+	if !ok || !mailboxIDs["mb-archive"] || len(mailboxIDs) != 1 {
+		t.Errorf("mailboxIds = %v, want {mb-archive: true}", patch["mailboxIds"])
+	}
+	if patch["keywords/$flagged"] != true {
+		t.Errorf("keywords/$flagged = %v, want true", patch["keywords/$flagged"])
+	}
+	if v, exists := patch["keywords/$seen"]; !exists || v != nil {
+		t.Errorf("keywords/$seen = %v (exists=%v), want nil", v, exists)
+	}
+	if err := ValidateEmailPatch(patch); err != nil {
+		t.Errorf("ValidateEmailPatch() error = %v", err)
+	}
+}
+
+func TestValidateEmailPatch(t *testing.T) {
+	tests := []struct {
+		name    string
+		patch   map[string]any
+		wantErr bool
+	}{
+		{name: "empty", patch: map[string]any{}, wantErr: true},
+		{name: "keyword set", patch: map[string]any{"keywords/$seen": true}},
+		{name: "keyword clear", patch: map[string]any{"keywords/$seen": nil}},
+		{name: "mailbox add", patch: map[string]any{"mailboxIds/mb1": true}},
+		{name: "full keywords", patch: map[string]any{"keywords": map[string]any{"$seen": true}}},
+		{name: "keyword false", patch: map[string]any{"keywords/$seen": false}, wantErr: true},
+		{name: "empty keyword", patch: map[string]any{"keywords/": true}, wantErr: true},
+		{name: "nested path", patch: map[string]any{"keywords/a/b": true}, wantErr: true},
+		{name: "immutable property", patch: map[string]any{"subject": "hi"}, wantErr: true},
+		{name: "no mailboxes", patch: map[string]any{"mailboxIds": map[string]bool{}}, wantErr: true},
+		{name: "mailboxIds not a set", patch: map[string]any{"mailboxIds": "mb1"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateEmailPatch(tt.patch)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ValidateEmailPatch() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil && !IsValidationError(err) {
+				t.Errorf("error = %T, want *ValidationError", err)
+			}
+		})
+	}
+}
+
+func TestUpdateEmails_SendsCombinedPatch(t *testing.T) {
+	var update map[string]map[string]any
+
+	apiServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			MethodCalls [][]json.RawMessage `json:"methodCalls"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Fatalf("failed to decode request: %v", err)
+		}
+		var args struct {
+			Update map[string]map[string]any `json:"update"`
+		}
+		_ = json.Unmarshal(req.MethodCalls[0][1], &args)
+		update = args.Update
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{
+			"methodResponses": [
+				["Email/set", {
+					"accountId": "acc123",
+					"updated": {"email1": null},
+					"notUpdated": {"email2": {"type": "notFound", "description": "no such email"}}
+				}, "updateEmails"]
+			]
+		}`))
+	}))
+	defer apiServer.Close()
+
+	sessionServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{
+			"apiUrl": "` + apiServer.URL + `",
+			"accounts": {"acc123": {}},
+			"primaryAccounts": {"urn:ietf:params:jmap:mail": "acc123"}
+		}`))
+	}))
+	defer sessionServer.Close()
+
+	client := NewClientWithBaseURL("test-token", sessionServer.URL)
+
+	patch := NewEmailPatch().MoveTo("mb-archive").Flag(true).Read(true)
+	result, err := client.UpdateEmails(context.Background(), []string{"email1", "email2"}, patch)
+	if err != nil {
+		t.Fatalf("UpdateEmails() error = %v", err)
+	}
+
+	for _, id := range []string{"email1", "email2"} {
+		got := update[id]
+		if got["keywords/$flagged"] != true || got["keywords/$seen"] != true {
+			t.Errorf("update[%s] = %v, want flagged and seen", id, got)
+		}
+		if mb, _ := got["mailboxIds"].(map[string]any); mb["mb-archive"] != true {
+			t.Errorf("update[%s].mailboxIds = %v, want mb-archive", id, got["mailboxIds"])
+		}
+	}
+
+	if len(result.Succeeded) != 1 || result.Succeeded[0] != "email1" {
+		t.Errorf("Succeeded = %v, want [email1]", result.Succeeded)
+	}
+	if _, ok := result.Failed["email2"]; !ok {
+		t.Errorf("Failed = %v, want email2", result.Failed)
+	}
+
+	if err := client.UpdateEmail(context.Background(), "email2", patch); err == nil {
+		t.Error("UpdateEmail() expected error for notUpdated email")
+	}
+}
+
+func TestUpdateEmails_RejectsInvalidPatchWithoutRequest(t *testing.T) {
+	client := NewClientWithBaseURL("test-token", "http://127.0.0.1:0")
+
+	_, err := client.UpdateEmails(context.Background(), []string{"email1"}, map[string]any{"subject": "x"})
+	if !IsValidationError(err) {
+		t.Fatalf("UpdateEmails() error = %v, want validation error", err)
+	}
+}
@@ -1,8 +1,11 @@
 package jmap
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
+	"net/http"
+	"strings"
 	"time"
 )
 
@@ -11,12 +14,18 @@ var (
 	// ErrNoAccounts indicates no accounts were found in session
 	ErrNoAccounts = errors.New("no accounts found in session")
 
+	// ErrAccountNotFound indicates the account ID selected with SetAccountID is not in the session
+	ErrAccountNotFound = errors.New("account not found in session")
+
 	// ErrEmailNotFound indicates the requested email was not found
 	ErrEmailNotFound = errors.New("email not found")
 
 	// ErrContactNotFound indicates the requested contact was not found
 	ErrContactNotFound = errors.New("contact not found")
 
+	// ErrContactGroupNotFound indicates the requested contact group was not found
+	ErrContactGroupNotFound = errors.New("contact group not found")
+
 	// ErrThreadNotFound indicates the requested thread was not found
 	ErrThreadNotFound = errors.New("thread not found")
 
@@ -26,15 +35,32 @@ var (
 	// ErrContactsNotEnabled indicates contacts API is not available
 	ErrContactsNotEnabled = errors.New("contacts API not enabled for this account")
 
+	// ErrMaskedEmailNotEnabled indicates the masked email API is not available
+	ErrMaskedEmailNotEnabled = errors.New("masked email API not enabled for this account (the API token needs the Masked Email scope)")
+
 	// ErrCalendarsNotEnabled indicates calendars API is not available
 	ErrCalendarsNotEnabled = errors.New("calendars API not enabled for this account")
 
+	// ErrVacationNotEnabled indicates the vacation response API is not available
+	ErrVacationNotEnabled = errors.New("vacation response API not enabled for this account")
+
 	// ErrEventNotFound indicates the requested calendar event was not found
 	ErrEventNotFound = errors.New("calendar event not found")
 
+	// ErrNotParticipant indicates none of the user's addresses is a
+	// participant of the event
+	ErrNotParticipant = errors.New("not a participant of this event")
+
 	// ErrNoIdentities indicates no sending identities were found
 	ErrNoIdentities = errors.New("no sending identities found")
 
+	// ErrIdentityNotFound indicates the requested identity does not exist
+	ErrIdentityNotFound = errors.New("identity not found")
+
+	// ErrIdentityNotDeletable indicates the server forbids deleting an
+	// identity (mayDelete is false), as for the account's primary address
+	ErrIdentityNotDeletable = errors.New("identity cannot be deleted (it is the account's primary identity)")
+
 	// ErrInvalidFromAddress indicates the from address is not verified
 	ErrInvalidFromAddress = errors.New("from address not verified for sending")
 
@@ -52,6 +78,9 @@ var (
 
 	// ErrQuotaNotEnabled indicates quota API is not available
 	ErrQuotaNotEnabled = errors.New("quota API not enabled for this account")
+
+	// ErrRangeNotSatisfiable indicates a resumed download's offset is past the end of the blob (HTTP 416)
+	ErrRangeNotSatisfiable = errors.New("download offset is past the end of the blob")
 )
 
 // Typed errors for specific error conditions
@@ -85,15 +114,84 @@ func (e *CircuitBreakerError) Error() string {
 	return "circuit breaker open: service temporarily unavailable"
 }
 
+// Classified 401 reasons used as AuthError.Message
+const (
+	AuthTokenExpired   = "token expired"
+	AuthTokenRevoked   = "token revoked"
+	AuthTokenMalformed = "token malformed"
+	AuthTokenInvalid   = "token invalid"
+)
+
 // AuthError represents an authentication error
 type AuthError struct {
 	Message string
+	Err     error // underlying HTTP error, if any
 }
 
 func (e *AuthError) Error() string {
 	return fmt.Sprintf("authentication error: %s", e.Message)
 }
 
+// Unwrap returns the underlying error for errors.Is and errors.As compatibility.
+func (e *AuthError) Unwrap() error {
+	return e.Err
+}
+
+// classifyAuthFailure inspects a 401 response's WWW-Authenticate header and
+// body for the server's error detail and returns a short reason such as
+// AuthTokenExpired. Falls back to "unauthorized" with any detail found.
+func classifyAuthFailure(header http.Header, body []byte) string {
+	detail := authFailureDetail(body)
+	text := strings.ToLower(header.Get("WWW-Authenticate") + " " + detail + " " + string(body))
+
+	switch {
+	case authFailureType(body) == "notFound":
+		return AuthTokenInvalid
+	case strings.Contains(text, "expired"):
+		return AuthTokenExpired
+	case strings.Contains(text, "revoked"):
+		return AuthTokenRevoked
+	case strings.Contains(text, "malformed"), strings.Contains(text, "could not parse"),
+		strings.Contains(text, "invalid format"), strings.Contains(text, "invalid_request"):
+		return AuthTokenMalformed
+	case strings.Contains(text, "invalid_token"), strings.Contains(text, "invalid token"),
+		strings.Contains(text, "unknown token"):
+		return AuthTokenInvalid
+	}
+
+	if detail != "" {
+		return "unauthorized: " + detail
+	}
+	return "unauthorized"
+}
+
+// authFailureDetail extracts a human-readable detail from a JSON error body
+// (RFC 7807 problem details or a simple {"error": "..."} object).
+func authFailureDetail(body []byte) string {
+	var problem map[string]any
+	if err := json.Unmarshal(body, &problem); err != nil {
+		return ""
+	}
+	for _, key := range []string{"detail", "description", "error_description", "message", "title", "error"} {
+		if v, ok := problem[key].(string); ok && strings.TrimSpace(v) != "" {
+			return strings.TrimSpace(v)
+		}
+	}
+	return ""
+}
+
+// authFailureType returns the JMAP error type of a problem details body,
+// without the urn:ietf:params:jmap:error: prefix, e.g. "notFound".
+func authFailureType(body []byte) string {
+	var problem struct {
+		Type string `json:"type"`
+	}
+	if err := json.Unmarshal(body, &problem); err != nil {
+		return ""
+	}
+	return strings.TrimPrefix(problem.Type, "urn:ietf:params:jmap:error:")
+}
+
 // Helper functions for type checking errors
 
 // IsValidationError checks if an error is a ValidationError
@@ -178,6 +276,7 @@ func IsNotFoundError(err error) bool {
 	// Also check sentinel errors
 	return errors.Is(err, ErrEmailNotFound) ||
 		errors.Is(err, ErrContactNotFound) ||
+		errors.Is(err, ErrContactGroupNotFound) ||
 		errors.Is(err, ErrThreadNotFound) ||
 		errors.Is(err, ErrMailboxNotFound) ||
 		errors.Is(err, ErrEventNotFound)
@@ -1,11 +1,17 @@
 package jmap
 
 import (
+	"context"
 	"errors"
 	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"strings"
 	"testing"
 	"time"
+
+	"github.com/salmonumbrella/fastmail-cli/internal/testutil"
+	"github.com/salmonumbrella/fastmail-cli/internal/transport"
 )
 
 // TestValidationError_Error tests the Error() method
@@ -803,3 +809,121 @@ func TestInvalidFromAddressError_TypedNil(t *testing.T) {
 		t.Error("IsInvalidFromAddressError(typed nil) = false, want true (expected errors.As behavior)")
 	}
 }
+
+// TestGetSession_Classifies401 tests that session 401 bodies are classified into AuthError
+func TestGetSession_Classifies401(t *testing.T) {
+	tests := []struct {
+		name        string
+		wwwAuth     string
+		body        string
+		wantMessage string
+	}{
+		{
+			name:        "expired problem detail",
+			body:        `{"type":"about:blank","title":"Unauthorized","detail":"The access token has expired"}`,
+			wantMessage: AuthTokenExpired,
+		},
+		{
+			name:        "revoked",
+			body:        `{"error":"token revoked by user"}`,
+			wantMessage: AuthTokenRevoked,
+		},
+		{
+			name:        "malformed",
+			body:        `Authorization header is malformed`,
+			wantMessage: AuthTokenMalformed,
+		},
+		{
+			name:        "invalid token via WWW-Authenticate",
+			wwwAuth:     `Bearer realm="jmap", error="invalid_token"`,
+			wantMessage: AuthTokenInvalid,
+		},
+		{
+			name:        "expired via WWW-Authenticate description",
+			wwwAuth:     `Bearer error="invalid_token", error_description="token expired"`,
+			wantMessage: AuthTokenExpired,
+		},
+		{
+			name:        "JMAP notFound problem type",
+			body:        `{"type":"urn:ietf:params:jmap:error:notFound","status":401}`,
+			wantMessage: AuthTokenInvalid,
+		},
+		{
+			name:        "not found in free text is not a token error",
+			body:        `{"message":"Account not found"}`,
+			wantMessage: "unauthorized: Account not found",
+		},
+		{
+			name:        "unknown detail",
+			body:        `{"message":"Access denied"}`,
+			wantMessage: "unauthorized: Access denied",
+		},
+		{
+			name:        "empty body",
+			wantMessage: "unauthorized",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if tt.wwwAuth != "" {
+					w.Header().Set("WWW-Authenticate", tt.wwwAuth)
+				}
+				w.WriteHeader(http.StatusUnauthorized)
+				_, _ = w.Write([]byte(tt.body))
+			}))
+			defer server.Close()
+
+			client := NewClientWithBaseURL("test-token", server.URL)
+			_, err := client.GetSession(context.Background())
+
+			var ae *AuthError
+			if !errors.As(err, &ae) {
+				t.Fatalf("GetSession() error = %v, want *AuthError", err)
+			}
+			if ae.Message != tt.wantMessage {
+				t.Errorf("AuthError.Message = %q, want %q", ae.Message, tt.wantMessage)
+			}
+			if !transport.IsUnauthorized(err) {
+				t.Error("transport.IsUnauthorized() = false, want true for wrapped 401")
+			}
+		})
+	}
+}
+
+// TestMakeRequest_401IsAuthErrorAndClearsSession tests that a token revoked
+// after the session was fetched surfaces as *AuthError and forces a new
+// session fetch on the next call
+func TestMakeRequest_401IsAuthErrorAndClearsSession(t *testing.T) {
+	jm := testutil.NewJMAPMock()
+	defer jm.Close()
+	jm.HandleSequence(http.MethodPost, testutil.JMAPAPIPath, testutil.Response{
+		Status: http.StatusUnauthorized,
+		Body:   map[string]any{"detail": "The access token has been revoked"},
+	})
+
+	client := NewClientWithBaseURL("test-token", jm.URL())
+	if _, err := client.GetSession(context.Background()); err != nil {
+		t.Fatalf("GetSession() error = %v", err)
+	}
+
+	_, err := client.GetMailboxes(context.Background())
+	var ae *AuthError
+	if !errors.As(err, &ae) {
+		t.Fatalf("GetMailboxes() error = %v, want *AuthError", err)
+	}
+	if ae.Message != AuthTokenRevoked {
+		t.Errorf("AuthError.Message = %q, want %q", ae.Message, AuthTokenRevoked)
+	}
+	if !transport.IsUnauthorized(err) {
+		t.Error("transport.IsUnauthorized() = false, want true for wrapped 401")
+	}
+
+	client.sessionMu.RLock()
+	cleared := client.session == nil
+	client.sessionMu.RUnlock()
+	if !cleared {
+		t.Error("session still cached after 401")
+	}
+}
@@ -0,0 +1,501 @@
+package jmap
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+const submissionCapability = "urn:ietf:params:jmap:submission"
+
+// IdentityOpts contains options for creating an identity.
+type IdentityOpts struct {
+	Email         string   // Required: address to send from (immutable once created)
+	Name          string   // Optional: display name
+	ReplyTo       []string // Optional: Reply-To addresses
+	TextSignature string   // Optional: plain-text signature
+	HTMLSignature string   // Optional: HTML signature
+}
+
+// UpdateIdentityOpts contains the identity properties to change. The email
+// address of an identity cannot be changed.
+type UpdateIdentityOpts struct {
+	Name          *string  // nil = don't change
+	ReplyTo       []string // nil = don't change, empty = clear
+	TextSignature *string  // nil = don't change
+	HTMLSignature *string  // nil = don't change
+}
+
+// parseIdentity converts an Identity/get or Identity/set object.
+func parseIdentity(m map[string]any) Identity {
+	identity := Identity{
+		ID:            getString(m, "id"),
+		Name:          getString(m, "name"),
+		Email:         getString(m, "email"),
+		TextSignature: getString(m, "textSignature"),
+		HTMLSignature: getString(m, "htmlSignature"),
+		MayDelete:     getBool(m, "mayDelete"),
+	}
+	if replyTo, ok := m["replyTo"].([]any); ok {
+		identity.ReplyTo = parseAddresses(replyTo)
+	}
+	return identity
+}
+
+// parseIdentityList converts the list of an Identity/get response.
+func parseIdentityList(list []any) []Identity {
+	identities := make([]Identity, 0, len(list))
+	for _, item := range list {
+		if m, ok := item.(map[string]any); ok {
+			identities = append(identities, parseIdentity(m))
+		}
+	}
+	return identities
+}
+
+// identityAddresses builds a JMAP EmailAddress[] (null when empty).
+func identityAddresses(addrs []string) any {
+	if len(addrs) == 0 {
+		return nil
+	}
+	list := make([]map[string]any, 0, len(addrs))
+	for _, addr := range addrs {
+		list = append(list, map[string]any{"email": addr})
+	}
+	return list
+}
+
+// CreateIdentity adds a sending identity (alias) to the account.
+func (c *Client) CreateIdentity(ctx context.Context, opts IdentityOpts) (*Identity, error) {
+	email := strings.TrimSpace(opts.Email)
+	if email == "" {
+		return nil, &ValidationError{Field: "email", Message: "identity email is required"}
+	}
+
+	session, err := c.GetSession(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	identityObj := map[string]any{
+		"email": email,
+	}
+	if opts.Name != "" {
+		identityObj["name"] = opts.Name
+	}
+	if len(opts.ReplyTo) > 0 {
+		identityObj["replyTo"] = identityAddresses(opts.ReplyTo)
+	}
+	if opts.TextSignature != "" {
+		identityObj["textSignature"] = opts.TextSignature
+	}
+	if opts.HTMLSignature != "" {
+		identityObj["htmlSignature"] = opts.HTMLSignature
+	}
+
+	req := &Request{
+		Using: []string{"urn:ietf:params:jmap:core", submissionCapability},
+		MethodCalls: []MethodCall{
+			{"Identity/set", map[string]any{
+				"accountId": session.AccountID,
+				"create":    map[string]any{"new": identityObj},
+			}, "createIdentity"},
+		},
+	}
+
+	resp, err := c.MakeRequest(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	result, ok := resp.MethodResponses[0][1].(map[string]any)
+	if !ok {
+		return nil, fmt.Errorf("unexpected response format")
+	}
+
+	if notCreated, ok := result["notCreated"].(map[string]any); ok {
+		if errInfo, exists := notCreated["new"]; exists {
+			return nil, fmt.Errorf("failed to create identity: %s", setErrorMessage(errInfo))
+		}
+	}
+
+	created, ok := result["created"].(map[string]any)
+	if !ok {
+		return nil, fmt.Errorf("identity created but ID not returned")
+	}
+	obj, ok := created["new"].(map[string]any)
+	if !ok || getString(obj, "id") == "" {
+		return nil, fmt.Errorf("identity created but ID not returned")
+	}
+
+	// The server only returns properties it set or changed; fill in the rest
+	// from what was sent
+	identity := parseIdentity(obj)
+	identity.Email = email
+	if identity.Name == "" {
+		identity.Name = opts.Name
+	}
+	if identity.ReplyTo == nil {
+		for _, addr := range opts.ReplyTo {
+			identity.ReplyTo = append(identity.ReplyTo, EmailAddress{Email: addr})
+		}
+	}
+	if identity.TextSignature == "" {
+		identity.TextSignature = opts.TextSignature
+	}
+	if identity.HTMLSignature == "" {
+		identity.HTMLSignature = opts.HTMLSignature
+	}
+	if _, ok := obj["mayDelete"]; !ok {
+		identity.MayDelete = true
+	}
+
+	return &identity, nil
+}
+
+// UpdateIdentity changes the name, Reply-To or signatures of an identity.
+func (c *Client) UpdateIdentity(ctx context.Context, id string, opts UpdateIdentityOpts) error {
+	if id == "" {
+		return &ValidationError{Field: "id", Message: "identity ID is required"}
+	}
+
+	update := map[string]any{}
+	if opts.Name != nil {
+		update["name"] = *opts.Name
+	}
+	if opts.ReplyTo != nil {
+		update["replyTo"] = identityAddresses(opts.ReplyTo)
+	}
+	if opts.TextSignature != nil {
+		update["textSignature"] = *opts.TextSignature
+	}
+	if opts.HTMLSignature != nil {
+		update["htmlSignature"] = *opts.HTMLSignature
+	}
+	if len(update) == 0 {
+		return &ValidationError{Message: "nothing to update"}
+	}
+
+	session, err := c.GetSession(ctx)
+	if err != nil {
+		return err
+	}
+
+	req := &Request{
+		Using: []string{"urn:ietf:params:jmap:core", submissionCapability},
+		MethodCalls: []MethodCall{
+			{"Identity/set", map[string]any{
+				"accountId": session.AccountID,
+				"update":    map[string]any{id: update},
+			}, "updateIdentity"},
+		},
+	}
+
+	resp, err := c.MakeRequest(ctx, req)
+	if err != nil {
+		return err
+	}
+
+	result, ok := resp.MethodResponses[0][1].(map[string]any)
+	if !ok {
+		return fmt.Errorf("unexpected response format")
+	}
+
+	if notUpdated, ok := result["notUpdated"].(map[string]any); ok {
+		if errInfo, exists := notUpdated[id]; exists {
+			if m, ok := errInfo.(map[string]any); ok && getString(m, "type") == "notFound" {
+				return fmt.Errorf("%w: %s", ErrIdentityNotFound, id)
+			}
+			return fmt.Errorf("failed to update identity: %s", setErrorMessage(errInfo))
+		}
+	}
+
+	return nil
+}
+
+// DeleteIdentity removes a sending identity, as returned by GetIdentities.
+// Identities the server marks with mayDelete=false (the account's primary
+// address) are refused before any change is sent.
+func (c *Client) DeleteIdentity(ctx context.Context, target Identity) error {
+	id := target.ID
+	if id == "" {
+		return &ValidationError{Field: "id", Message: "identity ID is required"}
+	}
+	if !target.MayDelete {
+		return fmt.Errorf("%w: %s", ErrIdentityNotDeletable, target.Email)
+	}
+
+	session, err := c.GetSession(ctx)
+	if err != nil {
+		return err
+	}
+
+	req := &Request{
+		Using: []string{"urn:ietf:params:jmap:core", submissionCapability},
+		MethodCalls: []MethodCall{
+			{"Identity/set", map[string]any{
+				"accountId": session.AccountID,
+				"destroy":   []string{id},
+			}, "deleteIdentity"},
+		},
+	}
+
+	resp, err := c.MakeRequest(ctx, req)
+	if err != nil {
+		return err
+	}
+
+	result, ok := resp.MethodResponses[0][1].(map[string]any)
+	if !ok {
+		return fmt.Errorf("unexpected response format")
+	}
+
+	if notDestroyed, ok := result["notDestroyed"].(map[string]any); ok {
+		if errInfo, exists := notDestroyed[id]; exists {
+			if m, ok := errInfo.(map[string]any); ok {
+				switch getString(m, "type") {
+				case "forbidden":
+					return fmt.Errorf("%w: %s", ErrIdentityNotDeletable, target.Email)
+				case "notFound":
+					return fmt.Errorf("%w: %s", ErrIdentityNotFound, id)
+				}
+			}
+			return fmt.Errorf("failed to delete identity: %s", setErrorMessage(errInfo))
+		}
+	}
+
+	return nil
+}
+
+// signatureDelimiter is the conventional "dash dash space" line that
+// separates a signature from the message body.
+const signatureDelimiter = "-- \n"
+
+// appendTextSignature appends sig to a plain-text body below the signature
+// delimiter. Empty bodies and bodies already ending with sig are unchanged.
+func appendTextSignature(body, sig string) string {
+	sig = strings.TrimSpace(sig)
+	if body == "" || sig == "" || strings.HasSuffix(strings.TrimSpace(body), sig) {
+		return body
+	}
+	if !strings.HasPrefix(sig, "--") {
+		sig = signatureDelimiter + sig
+	}
+	return strings.TrimRight(body, "\r\n") + "\n\n" + sig + "\n"
+}
+
+// appendHTMLSignature appends sig to an HTML body, inside </body> when the
+// body has one. Empty bodies and bodies already containing sig at the end
+// are unchanged.
+func appendHTMLSignature(body, sig string) string {
+	sig = strings.TrimSpace(sig)
+	if body == "" || sig == "" {
+		return body
+	}
+
+	content := strings.TrimSpace(body)
+	closing := ""
+	if i := strings.LastIndex(strings.ToLower(content), "</body>"); i >= 0 {
+		content, closing = strings.TrimSpace(content[:i]), content[i:]
+	}
+	if strings.HasSuffix(content, sig) {
+		return body
+	}
+
+	return content + "\n<div>-- <br>\n" + sig + "\n</div>" + closing
+}
+
+// IdentityChanges lists the identities created, updated and destroyed since
+// a previous Identity state.
+type IdentityChanges struct {
+	OldState  string     `json:"oldState"`
+	NewState  string     `json:"newState"`
+	Created   []Identity `json:"created"`
+	Updated   []Identity `json:"updated"`
+	Destroyed []string   `json:"destroyed"`
+	// Full is set when the server could no longer calculate changes from
+	// the old state; Created then holds every current identity.
+	Full bool `json:"full,omitempty"`
+}
+
+// maxIdentityChangePages bounds how many Identity/changes round trips one
+// GetIdentityChanges call makes while the server reports more changes.
+const maxIdentityChangePages = 50
+
+// GetIdentityChanges returns the identities that changed since sinceState,
+// a state from GetIdentitiesWithState or a previous call. When the server
+// answers cannotCalculateChanges (the state is too old), every identity is
+// returned as created and Full is set.
+func (c *Client) GetIdentityChanges(ctx context.Context, sinceState string) (*IdentityChanges, error) {
+	if sinceState == "" {
+		return nil, &ValidationError{Field: "sinceState", Message: "a state from a previous identity listing is required"}
+	}
+
+	changes := &IdentityChanges{
+		OldState:  sinceState,
+		Created:   []Identity{},
+		Updated:   []Identity{},
+		Destroyed: []string{},
+	}
+	state := sinceState
+
+	for page := 0; ; page++ {
+		if page == maxIdentityChangePages {
+			return nil, fmt.Errorf("identity changes did not settle after %d requests", maxIdentityChangePages)
+		}
+
+		delta, err := c.identityChangesPage(ctx, state)
+		if err != nil {
+			var jmapErr *JMAPError
+			if errors.As(err, &jmapErr) && jmapErr.Type == "cannotCalculateChanges" {
+				return c.allIdentitiesAsChanges(ctx, sinceState)
+			}
+			return nil, err
+		}
+
+		changes.merge(delta)
+		state = delta.NewState
+		if !delta.hasMore {
+			break
+		}
+	}
+
+	changes.NewState = state
+	return changes, nil
+}
+
+// identityChangesPage is one Identity/changes response with the created and
+// updated identities fetched in the same request.
+type identityChangesPage struct {
+	IdentityChanges
+	hasMore bool
+}
+
+func (c *Client) identityChangesPage(ctx context.Context, sinceState string) (*identityChangesPage, error) {
+	session, err := c.GetSession(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	getChanged := func(path string) map[string]any {
+		return map[string]any{
+			"accountId": session.AccountID,
+			"#ids": map[string]any{
+				"resultOf": "changes",
+				"name":     "Identity/changes",
+				"path":     path,
+			},
+		}
+	}
+
+	req := &Request{
+		Using: []string{"urn:ietf:params:jmap:core", submissionCapability},
+		MethodCalls: []MethodCall{
+			{"Identity/changes", map[string]any{
+				"accountId":  session.AccountID,
+				"sinceState": sinceState,
+			}, "changes"},
+			{"Identity/get", getChanged("/created"), "created"},
+			{"Identity/get", getChanged("/updated"), "updated"},
+		},
+	}
+
+	resp, err := c.MakeRequest(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	delta, err := decodeMethodResponse[struct {
+		OldState       string   `json:"oldState"`
+		NewState       string   `json:"newState"`
+		HasMoreChanges bool     `json:"hasMoreChanges"`
+		Destroyed      []string `json:"destroyed"`
+	}](resp, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	page := &identityChangesPage{hasMore: delta.HasMoreChanges}
+	page.OldState = delta.OldState
+	page.NewState = delta.NewState
+	page.Destroyed = delta.Destroyed
+
+	for i, dst := range []*[]Identity{&page.Created, &page.Updated} {
+		if len(resp.MethodResponses) <= i+1 {
+			break
+		}
+		if result, ok := resp.MethodResponses[i+1][1].(map[string]any); ok {
+			if list, ok := result["list"].([]any); ok {
+				*dst = parseIdentityList(list)
+			}
+		}
+	}
+
+	return page, nil
+}
+
+// merge folds a later page into c so each identity is reported once: an
+// identity created and then updated stays created, and one created and then
+// destroyed disappears.
+func (c *IdentityChanges) merge(page *identityChangesPage) {
+	created := make(map[string]int, len(c.Created))
+	for i, identity := range c.Created {
+		created[identity.ID] = i
+	}
+
+	c.Created = append(c.Created, page.Created...)
+	for _, identity := range page.Updated {
+		if i, ok := created[identity.ID]; ok {
+			c.Created[i] = identity
+			continue
+		}
+		c.Updated = replaceIdentity(c.Updated, identity)
+	}
+
+	for _, id := range page.Destroyed {
+		if _, ok := created[id]; ok {
+			c.Created = removeIdentity(c.Created, id)
+			continue
+		}
+		c.Updated = removeIdentity(c.Updated, id)
+		c.Destroyed = append(c.Destroyed, id)
+	}
+}
+
+func replaceIdentity(list []Identity, identity Identity) []Identity {
+	for i := range list {
+		if list[i].ID == identity.ID {
+			list[i] = identity
+			return list
+		}
+	}
+	return append(list, identity)
+}
+
+func removeIdentity(list []Identity, id string) []Identity {
+	kept := list[:0]
+	for _, identity := range list {
+		if identity.ID != id {
+			kept = append(kept, identity)
+		}
+	}
+	return kept
+}
+
+// allIdentitiesAsChanges is the fallback when the state is too old to
+// compute a delta from.
+func (c *Client) allIdentitiesAsChanges(ctx context.Context, sinceState string) (*IdentityChanges, error) {
+	identities, state, err := c.GetIdentitiesWithState(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &IdentityChanges{
+		OldState:  sinceState,
+		NewState:  state,
+		Created:   identities,
+		Updated:   []Identity{},
+		Destroyed: []string{},
+		Full:      true,
+	}, nil
+}
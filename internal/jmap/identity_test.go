@@ -0,0 +1,385 @@
+package jmap
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+// newIdentityTestClient serves Identity/get with the given identities and
+// answers Identity/set with setResult, recording each Identity/set call.
+func newIdentityTestClient(t *testing.T, identities string, setResult string, setCalls *[]map[string]any) *Client {
+	t.Helper()
+	apiServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req Request
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Errorf("decode request: %v", err)
+		}
+		name, _ := req.MethodCalls[0][0].(string)
+		w.Header().Set("Content-Type", "application/json")
+		switch name {
+		case "Identity/get":
+			_, _ = w.Write([]byte(`{"methodResponses": [["Identity/get", {"list": ` + identities + `}, "identities"]]}`))
+		case "Identity/set":
+			args, _ := req.MethodCalls[0][1].(map[string]any)
+			*setCalls = append(*setCalls, args)
+			_, _ = w.Write([]byte(`{"methodResponses": [["Identity/set", ` + setResult + `, "0"]]}`))
+		default:
+			t.Errorf("unexpected method %s", name)
+		}
+	}))
+	t.Cleanup(apiServer.Close)
+
+	client := NewClientWithBaseURL("test-token", "http://unused")
+	client.session = &Session{AccountID: "acc123", APIUrl: apiServer.URL}
+	client.sessionFetch = time.Now()
+	return client
+}
+
+func TestCreateIdentity(t *testing.T) {
+	var calls []map[string]any
+	client := newIdentityTestClient(t, `[]`,
+		`{"created": {"new": {"id": "id-new", "mayDelete": true}}}`, &calls)
+
+	identity, err := client.CreateIdentity(context.Background(), IdentityOpts{
+		Email:         "sales@example.com",
+		Name:          "Sales",
+		ReplyTo:       []string{"team@example.com"},
+		TextSignature: "-- Sales",
+	})
+	if err != nil {
+		t.Fatalf("CreateIdentity() error = %v", err)
+	}
+	if identity.ID != "id-new" || identity.Email != "sales@example.com" || identity.Name != "Sales" || !identity.MayDelete {
+		t.Errorf("identity = %+v", identity)
+	}
+	if len(identity.ReplyTo) != 1 || identity.ReplyTo[0].Email != "team@example.com" {
+		t.Errorf("ReplyTo = %+v", identity.ReplyTo)
+	}
+
+	created := calls[0]["create"].(map[string]any)["new"].(map[string]any)
+	if created["email"] != "sales@example.com" || created["name"] != "Sales" || created["textSignature"] != "-- Sales" {
+		t.Errorf("create object = %v", created)
+	}
+	if _, ok := created["htmlSignature"]; ok {
+		t.Errorf("unset htmlSignature was sent: %v", created)
+	}
+	replyTo, _ := created["replyTo"].([]any)
+	if len(replyTo) != 1 || replyTo[0].(map[string]any)["email"] != "team@example.com" {
+		t.Errorf("replyTo = %v", created["replyTo"])
+	}
+}
+
+func TestCreateIdentity_Errors(t *testing.T) {
+	client := NewClientWithBaseURL("test-token", "http://unused")
+	var ve *ValidationError
+	if _, err := client.CreateIdentity(context.Background(), IdentityOpts{Email: " "}); !errors.As(err, &ve) {
+		t.Errorf("empty email error = %v, want ValidationError", err)
+	}
+
+	var calls []map[string]any
+	client = newIdentityTestClient(t, `[]`,
+		`{"notCreated": {"new": {"type": "forbiddenFrom", "description": "not your address"}}}`, &calls)
+	_, err := client.CreateIdentity(context.Background(), IdentityOpts{Email: "ceo@other.com"})
+	if err == nil || err.Error() != "failed to create identity: forbiddenFrom: not your address" {
+		t.Errorf("error = %v", err)
+	}
+}
+
+func TestUpdateIdentity(t *testing.T) {
+	var calls []map[string]any
+	client := newIdentityTestClient(t, `[]`, `{"updated": {"id1": null}}`, &calls)
+
+	name := "New Name"
+	empty := ""
+	err := client.UpdateIdentity(context.Background(), "id1", UpdateIdentityOpts{
+		Name:          &name,
+		ReplyTo:       []string{},
+		HTMLSignature: &empty,
+	})
+	if err != nil {
+		t.Fatalf("UpdateIdentity() error = %v", err)
+	}
+
+	patch := calls[0]["update"].(map[string]any)["id1"].(map[string]any)
+	if patch["name"] != "New Name" || patch["htmlSignature"] != "" {
+		t.Errorf("patch = %v", patch)
+	}
+	if v, ok := patch["replyTo"]; !ok || v != nil {
+		t.Errorf("replyTo = %v (present %v), want null to clear", v, ok)
+	}
+	if _, ok := patch["textSignature"]; ok {
+		t.Errorf("unchanged textSignature was sent: %v", patch)
+	}
+
+	var ve *ValidationError
+	if err := client.UpdateIdentity(context.Background(), "id1", UpdateIdentityOpts{}); !errors.As(err, &ve) {
+		t.Errorf("empty update error = %v, want ValidationError", err)
+	}
+}
+
+func TestUpdateIdentity_NotFound(t *testing.T) {
+	var calls []map[string]any
+	client := newIdentityTestClient(t, `[]`, `{"notUpdated": {"gone": {"type": "notFound"}}}`, &calls)
+
+	name := "x"
+	err := client.UpdateIdentity(context.Background(), "gone", UpdateIdentityOpts{Name: &name})
+	if !errors.Is(err, ErrIdentityNotFound) {
+		t.Errorf("error = %v, want ErrIdentityNotFound", err)
+	}
+}
+
+func TestDeleteIdentity(t *testing.T) {
+	primary := Identity{ID: "primary", Email: "me@example.com"}
+	alias := Identity{ID: "alias", Email: "alias@example.com", MayDelete: true}
+
+	t.Run("deletes alias", func(t *testing.T) {
+		var calls []map[string]any
+		client := newIdentityTestClient(t, `[]`, `{"destroyed": ["alias"]}`, &calls)
+		if err := client.DeleteIdentity(context.Background(), alias); err != nil {
+			t.Fatalf("DeleteIdentity() error = %v", err)
+		}
+		destroy, _ := calls[0]["destroy"].([]any)
+		if len(destroy) != 1 || destroy[0] != "alias" {
+			t.Errorf("destroy = %v", calls[0]["destroy"])
+		}
+	})
+
+	t.Run("refuses primary", func(t *testing.T) {
+		var calls []map[string]any
+		client := newIdentityTestClient(t, `[]`, `{}`, &calls)
+		err := client.DeleteIdentity(context.Background(), primary)
+		if !errors.Is(err, ErrIdentityNotDeletable) {
+			t.Errorf("error = %v, want ErrIdentityNotDeletable", err)
+		}
+		if len(calls) != 0 {
+			t.Errorf("Identity/set was sent for the primary identity")
+		}
+	})
+
+	t.Run("already gone", func(t *testing.T) {
+		var calls []map[string]any
+		client := newIdentityTestClient(t, `[]`, `{"notDestroyed": {"alias": {"type": "notFound"}}}`, &calls)
+		if err := client.DeleteIdentity(context.Background(), alias); !errors.Is(err, ErrIdentityNotFound) {
+			t.Errorf("error = %v, want ErrIdentityNotFound", err)
+		}
+	})
+}
+
+func TestGetIdentities_ParsesSignatures(t *testing.T) {
+	var calls []map[string]any
+	client := newIdentityTestClient(t, `[{
+		"id": "id1", "name": "Me", "email": "me@example.com", "mayDelete": false,
+		"replyTo": [{"name": "Team", "email": "team@example.com"}],
+		"textSignature": "-- Me", "htmlSignature": "<p>Me</p>"
+	}]`, `{}`, &calls)
+
+	identities, err := client.GetIdentities(context.Background())
+	if err != nil {
+		t.Fatalf("GetIdentities() error = %v", err)
+	}
+	got := identities[0]
+	if got.TextSignature != "-- Me" || got.HTMLSignature != "<p>Me</p>" {
+		t.Errorf("signatures = %q, %q", got.TextSignature, got.HTMLSignature)
+	}
+	if len(got.ReplyTo) != 1 || got.ReplyTo[0].Email != "team@example.com" || got.ReplyTo[0].Name != "Team" {
+		t.Errorf("ReplyTo = %+v", got.ReplyTo)
+	}
+}
+
+func TestAppendTextSignature(t *testing.T) {
+	tests := []struct {
+		name, body, sig, want string
+	}{
+		{"appends below delimiter", "Hi\n", "Ann\nAcme", "Hi\n\n-- \nAnn\nAcme\n"},
+		{"keeps existing delimiter", "Hi", "-- \nAnn", "Hi\n\n-- \nAnn\n"},
+		{"no double append", "Hi\n\n-- \nAnn\n", "Ann", "Hi\n\n-- \nAnn\n"},
+		{"empty signature", "Hi", "  ", "Hi"},
+		{"empty body", "", "Ann", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := appendTextSignature(tt.body, tt.sig); got != tt.want {
+				t.Errorf("appendTextSignature() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAppendHTMLSignature(t *testing.T) {
+	tests := []struct {
+		name, body, sig, want string
+	}{
+		{"appends", "<p>Hi</p>", "<b>Ann</b>", "<p>Hi</p>\n<div>-- <br>\n<b>Ann</b>\n</div>"},
+		{"inside body tag", "<html><body><p>Hi</p></body></html>", "<b>Ann</b>",
+			"<html><body><p>Hi</p>\n<div>-- <br>\n<b>Ann</b>\n</div></body></html>"},
+		{"no double append", "<p>Hi</p><b>Ann</b>\n", "<b>Ann</b>", "<p>Hi</p><b>Ann</b>\n"},
+		{"empty signature", "<p>Hi</p>", "", "<p>Hi</p>"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := appendHTMLSignature(tt.body, tt.sig); got != tt.want {
+				t.Errorf("appendHTMLSignature() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSendEmail_AppendsIdentitySignature(t *testing.T) {
+	var bodyValues map[string]any
+	apiServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req Request
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Errorf("decode request: %v", err)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		switch req.MethodCalls[0][0] {
+		case "Identity/get":
+			_, _ = w.Write([]byte(`{"methodResponses": [["Identity/get", {"list": [
+				{"id": "identity1", "email": "me@example.com", "mayDelete": false,
+				 "textSignature": "Me", "htmlSignature": "<i>Me</i>"}
+			]}, "identities"]]}`))
+		case "Mailbox/get":
+			_, _ = w.Write([]byte(`{"methodResponses": [["Mailbox/get", {"list": [
+				{"id": "drafts1", "role": "drafts"}, {"id": "sent1", "role": "sent"}
+			]}, "mailboxes"]]}`))
+		case "Email/set":
+			args := req.MethodCalls[0][1].(map[string]any)
+			draft := args["create"].(map[string]any)["draft"].(map[string]any)
+			bodyValues = draft["bodyValues"].(map[string]any)
+			_, _ = w.Write([]byte(`{"methodResponses": [
+				["Email/set", {"created": {"draft": {"id": "email1"}}}, "createEmail"],
+				["EmailSubmission/set", {"created": {"submission": {"id": "sub1"}}}, "submitEmail"]
+			]}`))
+		}
+	}))
+	defer apiServer.Close()
+
+	client := NewClientWithBaseURL("test-token", "http://unused")
+	client.session = &Session{AccountID: "acc123", APIUrl: apiServer.URL}
+	client.sessionFetch = time.Now()
+
+	opts := SendEmailOpts{
+		To:        []string{"you@example.com"},
+		Subject:   "Hi",
+		TextBody:  "Hello",
+		HTMLBody:  "<p>Hello</p>",
+		Signature: true,
+	}
+	if _, err := client.SendEmailResult(context.Background(), opts); err != nil {
+		t.Fatalf("SendEmailResult() error = %v", err)
+	}
+	text := bodyValues["text"].(map[string]any)["value"]
+	html := bodyValues["html"].(map[string]any)["value"]
+	if text != "Hello\n\n-- \nMe\n" {
+		t.Errorf("text body = %q", text)
+	}
+	if html != "<p>Hello</p>\n<div>-- <br>\n<i>Me</i>\n</div>" {
+		t.Errorf("html body = %q", html)
+	}
+
+	opts.Signature = false
+	if _, err := client.SendEmailResult(context.Background(), opts); err != nil {
+		t.Fatalf("SendEmailResult() error = %v", err)
+	}
+	if text := bodyValues["text"].(map[string]any)["value"]; text != "Hello" {
+		t.Errorf("text body without signature = %q", text)
+	}
+}
+
+// newIdentityChangesClient answers each Identity/changes request with the
+// page keyed by its sinceState, and Identity/get with identities.
+func newIdentityChangesClient(t *testing.T, pages map[string]string, identities string) *Client {
+	t.Helper()
+	apiServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req Request
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Errorf("decode request: %v", err)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		args, _ := req.MethodCalls[0][1].(map[string]any)
+		switch req.MethodCalls[0][0] {
+		case "Identity/changes":
+			since, _ := args["sinceState"].(string)
+			page, ok := pages[since]
+			if !ok {
+				t.Errorf("unexpected sinceState %q", since)
+			}
+			_, _ = w.Write([]byte(page))
+		case "Identity/get":
+			_, _ = w.Write([]byte(`{"methodResponses": [["Identity/get", {"state": "s-now", "list": ` + identities + `}, "identities"]]}`))
+		}
+	}))
+	t.Cleanup(apiServer.Close)
+
+	client := NewClientWithBaseURL("test-token", "http://unused")
+	client.session = &Session{AccountID: "acc123", APIUrl: apiServer.URL}
+	client.sessionFetch = time.Now()
+	return client
+}
+
+func TestGetIdentityChanges(t *testing.T) {
+	pages := map[string]string{
+		"s1": `{"methodResponses": [
+			["Identity/changes", {"oldState": "s1", "newState": "s2", "hasMoreChanges": true,
+				"created": ["a", "b"], "updated": ["old"], "destroyed": []}, "changes"],
+			["Identity/get", {"list": [{"id": "a", "email": "a@example.com"}, {"id": "b", "email": "b@example.com"}]}, "created"],
+			["Identity/get", {"list": [{"id": "old", "email": "old@example.com", "name": "Old"}]}, "updated"]
+		]}`,
+		"s2": `{"methodResponses": [
+			["Identity/changes", {"oldState": "s2", "newState": "s3", "hasMoreChanges": false,
+				"created": [], "updated": ["a"], "destroyed": ["b", "gone"]}, "changes"],
+			["Identity/get", {"list": []}, "created"],
+			["Identity/get", {"list": [{"id": "a", "email": "a@example.com", "name": "Renamed"}]}, "updated"]
+		]}`,
+	}
+	client := newIdentityChangesClient(t, pages, `[]`)
+
+	changes, err := client.GetIdentityChanges(context.Background(), "s1")
+	if err != nil {
+		t.Fatalf("GetIdentityChanges() error = %v", err)
+	}
+
+	if changes.OldState != "s1" || changes.NewState != "s3" || changes.Full {
+		t.Errorf("states = %q -> %q (full %v), want s1 -> s3", changes.OldState, changes.NewState, changes.Full)
+	}
+	if len(changes.Created) != 1 || changes.Created[0].ID != "a" || changes.Created[0].Name != "Renamed" {
+		t.Errorf("Created = %+v, want only a with its latest name", changes.Created)
+	}
+	if len(changes.Updated) != 1 || changes.Updated[0].ID != "old" {
+		t.Errorf("Updated = %+v, want old", changes.Updated)
+	}
+	if len(changes.Destroyed) != 1 || changes.Destroyed[0] != "gone" {
+		t.Errorf("Destroyed = %v, want gone (b was created and destroyed in the window)", changes.Destroyed)
+	}
+}
+
+func TestGetIdentityChanges_StateTooOld(t *testing.T) {
+	pages := map[string]string{
+		"ancient": `{"methodResponses": [["error", {"type": "cannotCalculateChanges"}, "changes"]]}`,
+	}
+	client := newIdentityChangesClient(t, pages, `[{"id": "p", "email": "me@example.com"}]`)
+
+	changes, err := client.GetIdentityChanges(context.Background(), "ancient")
+	if err != nil {
+		t.Fatalf("GetIdentityChanges() error = %v", err)
+	}
+	if !changes.Full || changes.NewState != "s-now" {
+		t.Errorf("Full = %v, NewState = %q, want full listing at s-now", changes.Full, changes.NewState)
+	}
+	if len(changes.Created) != 1 || changes.Created[0].ID != "p" {
+		t.Errorf("Created = %+v, want every identity", changes.Created)
+	}
+}
+
+func TestGetIdentityChanges_RequiresState(t *testing.T) {
+	client := NewClientWithBaseURL("test-token", "http://unused")
+	var ve *ValidationError
+	if _, err := client.GetIdentityChanges(context.Background(), ""); !errors.As(err, &ve) {
+		t.Errorf("error = %v, want ValidationError", err)
+	}
+}
@@ -21,6 +21,9 @@ type EmailService interface {
 	// GetEmailByID retrieves a specific email by ID with full details
 	GetEmailByID(ctx context.Context, id string) (*Email, error)
 
+	// GetEmailsByIDs retrieves several emails with full details, plus the IDs that were not found
+	GetEmailsByIDs(ctx context.Context, ids []string) ([]Email, []string, error)
+
 	// UpdateDraft updates an existing draft
 	UpdateDraft(ctx context.Context, draftID string, opts SendEmailOpts) error
 
@@ -30,15 +33,24 @@ type EmailService interface {
 	// SendEmail sends an email with the provided options
 	SendEmail(ctx context.Context, opts SendEmailOpts) (string, error)
 
+	// SendEmailResult sends an email and returns the draft, email, and submission IDs
+	SendEmailResult(ctx context.Context, opts SendEmailOpts) (*SendResult, error)
+
 	// DeleteEmail moves an email to trash
 	DeleteEmail(ctx context.Context, id string) error
 
 	// MoveEmail moves an email to a target mailbox
 	MoveEmail(ctx context.Context, id, targetMailboxID string) error
 
+	// MoveEmails moves several emails to a target mailbox in one request
+	MoveEmails(ctx context.Context, ids []string, targetMailboxID string) (*BulkResult, error)
+
 	// MarkEmailRead marks an email as read or unread
 	MarkEmailRead(ctx context.Context, id string, read bool) error
 
+	// UpdateEmail applies an Email/set update patch to an email
+	UpdateEmail(ctx context.Context, id string, patch map[string]any) error
+
 	// GetThread retrieves all emails in a thread
 	GetThread(ctx context.Context, threadID string) ([]Email, error)
 
@@ -51,9 +63,15 @@ type EmailService interface {
 	// DownloadBlob downloads a blob (attachment) by ID and returns a ReadCloser
 	DownloadBlob(ctx context.Context, blobID string) (io.ReadCloser, error)
 
+	// DownloadBlobRange downloads a blob starting at the given byte offset
+	DownloadBlobRange(ctx context.Context, blobID string, offset int64) (io.ReadCloser, error)
+
 	// UploadBlob uploads binary data and returns the blob ID
 	UploadBlob(ctx context.Context, reader io.Reader, contentType string) (*UploadBlobResult, error)
 
+	// UploadBlobFromFile uploads a file without buffering it in memory
+	UploadBlobFromFile(ctx context.Context, path, contentType string) (*UploadBlobResult, error)
+
 	// GetIdentities retrieves sending identities for the account
 	GetIdentities(ctx context.Context) ([]Identity, error)
 
@@ -72,6 +90,12 @@ type EmailService interface {
 	// RenameMailbox renames a mailbox
 	RenameMailbox(ctx context.Context, id, newName string) error
 
+	// MoveMailbox re-parents a mailbox (empty parent moves it to the top level)
+	MoveMailbox(ctx context.Context, id, newParentID string) error
+
+	// GetEmailBodies fetches capped text/HTML body values for the given emails
+	GetEmailBodies(ctx context.Context, ids []string, maxBodyValueBytes int) ([]Email, error)
+
 	// SearchEmailsWithSnippets searches with highlighted context
 	SearchEmailsWithSnippets(ctx context.Context, filter *EmailSearchFilter, limit int) ([]Email, []SearchSnippet, error)
 
@@ -94,6 +118,9 @@ type MaskedEmailService interface {
 	// GetMaskedEmailByEmail retrieves a specific masked email by its address
 	GetMaskedEmailByEmail(ctx context.Context, email string) (*MaskedEmail, error)
 
+	// GetMaskedEmailByID retrieves a specific masked email by its ID
+	GetMaskedEmailByID(ctx context.Context, id string) (*MaskedEmail, error)
+
 	// GetMaskedEmailsForDomain retrieves masked emails for a specific domain
 	GetMaskedEmailsForDomain(ctx context.Context, domain string) ([]MaskedEmail, error)
 
@@ -127,6 +154,9 @@ type ContactsService interface {
 	// GetContactByID retrieves a specific contact by ID
 	GetContactByID(ctx context.Context, id string) (*Contact, error)
 
+	// GetContactByEmail retrieves the first contact with the given email address
+	GetContactByEmail(ctx context.Context, email string) (*Contact, error)
+
 	// CreateContact creates a new contact
 	CreateContact(ctx context.Context, contact *Contact) (*Contact, error)
 
@@ -139,6 +169,9 @@ type ContactsService interface {
 	// SearchContacts searches for contacts matching a query string
 	SearchContacts(ctx context.Context, query string, limit int) ([]Contact, error)
 
+	// ResolveRecipient returns the address itself, or the addresses of contacts matching a name
+	ResolveRecipient(ctx context.Context, nameOrEmail string) ([]EmailAddress, error)
+
 	// GetAddressBooks retrieves all address books for the account
 	GetAddressBooks(ctx context.Context) ([]AddressBook, error)
 }
@@ -151,6 +184,9 @@ type CalendarService interface {
 	// GetEvents retrieves calendar events within a date range
 	GetEvents(ctx context.Context, calendarID string, from, to time.Time, limit int) ([]CalendarEvent, error)
 
+	// GetBusyPeriods retrieves the periods taken by events overlapping a range
+	GetBusyPeriods(ctx context.Context, from, to time.Time) ([]BusyPeriod, error)
+
 	// GetEventByID retrieves a specific calendar event by ID
 	GetEventByID(ctx context.Context, id string) (*CalendarEvent, error)
 
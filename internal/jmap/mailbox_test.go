@@ -2,6 +2,8 @@ package jmap
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -419,3 +421,177 @@ func TestRenameMailbox(t *testing.T) {
 		})
 	}
 }
+
+func TestResolveMailboxID_Role(t *testing.T) {
+	// A user folder literally named "Archive" is listed before the mailbox
+	// with the archive role, which has a different name.
+	apiServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"methodResponses": [["Mailbox/get", {"list": [
+			{"id": "user-archive", "name": "Archive"},
+			{"id": "role-archive", "name": "Archived Mail", "role": "archive"},
+			{"id": "inbox", "name": "Inbox", "role": "inbox"},
+			{"id": "junk-folder", "name": "junk"}
+		]}, "mailboxes"]]}`))
+	}))
+	defer apiServer.Close()
+
+	sessionServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{
+			"apiUrl": "` + apiServer.URL + `",
+			"accounts": {"acc123": {}},
+			"primaryAccounts": {"urn:ietf:params:jmap:mail": "acc123"}
+		}`))
+	}))
+	defer sessionServer.Close()
+
+	client := NewClientWithBaseURL("test-token", sessionServer.URL)
+
+	tests := []struct {
+		input   string
+		want    string
+		wantErr bool
+	}{
+		{input: "archive", want: "role-archive"},
+		{input: "Archive", want: "role-archive"},
+		{input: "role:archive", want: "role-archive"},
+		{input: "ROLE:Archive", want: "role-archive"},
+		{input: "Archived Mail", want: "role-archive"},
+		{input: "user-archive", want: "user-archive"},
+		{input: "junk", want: "junk-folder"},
+		{input: "role:junk", wantErr: true},
+		{input: "role:", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := client.ResolveMailboxID(context.Background(), tt.input)
+			if tt.wantErr {
+				if !errors.Is(err, ErrMailboxNotFound) {
+					t.Errorf("ResolveMailboxID(%q) error = %v, want ErrMailboxNotFound", tt.input, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ResolveMailboxID(%q) error = %v", tt.input, err)
+			}
+			if got != tt.want {
+				t.Errorf("ResolveMailboxID(%q) = %q, want %q", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCheckMailboxMove(t *testing.T) {
+	mailboxes := []Mailbox{
+		{ID: "work"},
+		{ID: "projects", ParentID: "work"},
+		{ID: "alpha", ParentID: "projects"},
+		{ID: "personal"},
+	}
+
+	tests := []struct {
+		name      string
+		id        string
+		parent    string
+		wantValid bool
+		wantErr   error
+	}{
+		{name: "sibling tree", id: "personal", parent: "alpha"},
+		{name: "up the tree", id: "alpha", parent: "work"},
+		{name: "under itself", id: "work", parent: "work", wantValid: true},
+		{name: "under child", id: "work", parent: "projects", wantValid: true},
+		{name: "under grandchild", id: "work", parent: "alpha", wantValid: true},
+		{name: "unknown parent", id: "work", parent: "missing", wantErr: ErrMailboxNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := checkMailboxMove(mailboxes, tt.id, tt.parent)
+			switch {
+			case tt.wantValid:
+				if !IsValidationError(err) {
+					t.Errorf("expected validation error, got %v", err)
+				}
+			case tt.wantErr != nil:
+				if !errors.Is(err, tt.wantErr) {
+					t.Errorf("expected %v, got %v", tt.wantErr, err)
+				}
+			case err != nil:
+				t.Errorf("unexpected error: %v", err)
+			}
+		})
+	}
+}
+
+func TestMoveMailbox(t *testing.T) {
+	tests := []struct {
+		name       string
+		id         string
+		parent     string
+		wantParent any
+		wantErr    bool
+	}{
+		{name: "under parent", id: "alpha", parent: "personal", wantParent: "personal"},
+		{name: "to top level", id: "alpha", parent: "", wantParent: nil},
+		{name: "cycle", id: "work", parent: "alpha", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var update map[string]any
+			apiServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				var req Request
+				if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+					t.Fatalf("decoding request: %v", err)
+				}
+				w.Header().Set("Content-Type", "application/json")
+				switch req.MethodCalls[0][0] {
+				case "Mailbox/get":
+					_, _ = w.Write([]byte(`{"methodResponses": [["Mailbox/get", {"list": [
+						{"id": "work", "name": "Work"},
+						{"id": "alpha", "name": "Alpha", "parentId": "work"},
+						{"id": "personal", "name": "Personal"}
+					]}, "mailboxes"]]}`))
+				case "Mailbox/set":
+					args, _ := req.MethodCalls[0][1].(map[string]any)
+					update, _ = args["update"].(map[string]any)
+					_, _ = w.Write([]byte(`{"methodResponses": [["Mailbox/set", {"updated": {"` + tt.id + `": {}}}, "moveMailbox"]]}`))
+				}
+			}))
+			defer apiServer.Close()
+
+			sessionServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_, _ = w.Write([]byte(`{
+					"apiUrl": "` + apiServer.URL + `",
+					"accounts": {"acc123": {}},
+					"primaryAccounts": {"urn:ietf:params:jmap:mail": "acc123"}
+				}`))
+			}))
+			defer sessionServer.Close()
+
+			client := NewClientWithBaseURL("test-token", sessionServer.URL)
+			err := client.MoveMailbox(context.Background(), tt.id, tt.parent)
+
+			if tt.wantErr {
+				if !IsValidationError(err) {
+					t.Errorf("expected validation error, got %v", err)
+				}
+				if update != nil {
+					t.Error("expected no Mailbox/set for a rejected move")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			patch, ok := update[tt.id].(map[string]any)
+			if !ok {
+				t.Fatalf("expected update for %s, got %v", tt.id, update)
+			}
+			if got, ok := patch["parentId"]; !ok || got != tt.wantParent {
+				t.Errorf("parentId: got %v, want %v", got, tt.wantParent)
+			}
+		})
+	}
+}
@@ -115,6 +115,30 @@ func (c *Client) GetMaskedEmailByEmail(ctx context.Context, email string) (*Mask
 	return nil, fmt.Errorf("masked email not found: %s", email)
 }
 
+// GetMaskedEmailByID retrieves a specific masked email by its ID
+func (c *Client) GetMaskedEmailByID(ctx context.Context, id string) (*MaskedEmail, error) {
+	aliases, err := c.GetMaskedEmails(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, alias := range aliases {
+		if alias.ID == id {
+			return &alias, nil
+		}
+	}
+
+	return nil, fmt.Errorf("masked email not found: %s", id)
+}
+
+// ResolveMaskedEmail looks up a masked email by address or, failing that, by ID
+func (c *Client) ResolveMaskedEmail(ctx context.Context, idOrEmail string) (*MaskedEmail, error) {
+	if LooksLikeEmail(idOrEmail) {
+		return c.GetMaskedEmailByEmail(ctx, idOrEmail)
+	}
+	return c.GetMaskedEmailByID(ctx, idOrEmail)
+}
+
 // GetMaskedEmailsForDomain retrieves masked emails for a specific domain
 func (c *Client) GetMaskedEmailsForDomain(ctx context.Context, domain string) ([]MaskedEmail, error) {
 	normalizedDomain, err := NormalizeDomain(domain)
@@ -210,6 +234,38 @@ func (c *Client) CreateMaskedEmail(ctx context.Context, domain, description stri
 	return &created, nil
 }
 
+// CreateMaskedEmailForRecipient creates an enabled masked email scoped to the
+// recipient's domain, for use as the From address of outbound mail.
+// New aliases start pending, so it is enabled immediately to allow sending.
+func (c *Client) CreateMaskedEmailForRecipient(ctx context.Context, recipient string) (*MaskedEmail, error) {
+	at := strings.LastIndex(recipient, "@")
+	if at < 0 || at == len(recipient)-1 {
+		return nil, &ValidationError{Field: "recipient", Message: "recipient must be an email address"}
+	}
+
+	session, err := c.GetSession(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := session.Capabilities[maskedEmailNamespace]; !ok {
+		return nil, ErrMaskedEmailNotEnabled
+	}
+
+	alias, err := c.CreateMaskedEmail(ctx, recipient[at+1:], "Sent to "+recipient)
+	if err != nil {
+		return nil, err
+	}
+
+	if alias.State != MaskedEmailEnabled {
+		if err := c.UpdateMaskedEmailState(ctx, alias.ID, MaskedEmailEnabled); err != nil {
+			return nil, fmt.Errorf("enabling masked email %s: %w", alias.Email, err)
+		}
+		alias.State = MaskedEmailEnabled
+	}
+
+	return alias, nil
+}
+
 // UpdateMaskedEmailState updates the state of a masked email
 func (c *Client) UpdateMaskedEmailState(ctx context.Context, id string, state MaskedEmailState) error {
 	session, err := c.GetSession(ctx)
@@ -0,0 +1,133 @@
+package jmap
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func newMaskedEmailListClient(t *testing.T) *Client {
+	t.Helper()
+
+	apiServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{
+			"methodResponses": [
+				["MaskedEmail/get", {
+					"accountId": "acc123",
+					"list": [
+						{"id": "masked-1", "email": "shop.1234@fastmail.com", "forDomain": "shop.example.com", "state": "enabled"},
+						{"id": "masked-2", "email": "news.5678@fastmail.com", "forDomain": "news.example.com", "state": "disabled"}
+					]
+				}, "0"]
+			]
+		}`))
+	}))
+	t.Cleanup(apiServer.Close)
+
+	sessionServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{
+			"apiUrl": "` + apiServer.URL + `",
+			"accounts": {"acc123": {}},
+			"primaryAccounts": {"urn:ietf:params:jmap:mail": "acc123"}
+		}`))
+	}))
+	t.Cleanup(sessionServer.Close)
+
+	return NewClientWithBaseURL("test-token", sessionServer.URL)
+}
+
+func TestResolveMaskedEmail(t *testing.T) {
+	client := newMaskedEmailListClient(t)
+
+	tests := []struct {
+		name    string
+		input   string
+		wantID  string
+		wantErr bool
+	}{
+		{name: "by email", input: "news.5678@fastmail.com", wantID: "masked-2"},
+		{name: "by id", input: "masked-1", wantID: "masked-1"},
+		{name: "unknown id", input: "masked-9", wantErr: true},
+		{name: "unknown email", input: "nobody@fastmail.com", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			alias, err := client.ResolveMaskedEmail(context.Background(), tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ResolveMaskedEmail(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+			}
+			if err == nil && alias.ID != tt.wantID {
+				t.Errorf("ResolveMaskedEmail(%q).ID = %q, want %q", tt.input, alias.ID, tt.wantID)
+			}
+		})
+	}
+}
+
+func TestCreateMaskedEmailForRecipient(t *testing.T) {
+	var methods []string
+	var createArgs map[string]any
+
+	apiServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			MethodCalls [][]json.RawMessage `json:"methodCalls"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Fatalf("failed to decode request: %v", err)
+		}
+		var args map[string]any
+		_ = json.Unmarshal(req.MethodCalls[0][1], &args)
+
+		w.Header().Set("Content-Type", "application/json")
+		if create, ok := args["create"].(map[string]any); ok {
+			methods = append(methods, "create")
+			createArgs, _ = create["new"].(map[string]any)
+			_, _ = w.Write([]byte(`{"methodResponses": [["MaskedEmail/set", {
+				"created": {"new": {"id": "masked-9", "email": "shop.9999@fastmail.com", "state": "pending"}}
+			}, "0"]]}`))
+			return
+		}
+		methods = append(methods, "update")
+		_, _ = w.Write([]byte(`{"methodResponses": [["MaskedEmail/set", {"updated": {"masked-9": null}}, "0"]]}`))
+	}))
+	defer apiServer.Close()
+
+	sessionServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{
+			"apiUrl": "` + apiServer.URL + `",
+			"accounts": {"acc123": {}},
+			"capabilities": {"https://www.fastmail.com/dev/maskedemail": {}}
+		}`))
+	}))
+	defer sessionServer.Close()
+
+	client := NewClientWithBaseURL("test-token", sessionServer.URL)
+	alias, err := client.CreateMaskedEmailForRecipient(context.Background(), "vendor@shop.example.com")
+	if err != nil {
+		t.Fatalf("CreateMaskedEmailForRecipient() error = %v", err)
+	}
+
+	if alias.Email != "shop.9999@fastmail.com" || alias.State != MaskedEmailEnabled {
+		t.Errorf("alias = %+v, want enabled shop.9999@fastmail.com", alias)
+	}
+	if createArgs["forDomain"] != "https://shop.example.com" {
+		t.Errorf("forDomain = %v, want https://shop.example.com", createArgs["forDomain"])
+	}
+	if len(methods) != 2 || methods[1] != "update" {
+		t.Errorf("methods = %v, want create then enable", methods)
+	}
+}
+
+func TestCreateMaskedEmailForRecipient_NotEnabled(t *testing.T) {
+	// newMaskedEmailListClient's session has no masked email capability
+	client := newMaskedEmailListClient(t)
+
+	_, err := client.CreateMaskedEmailForRecipient(context.Background(), "vendor@shop.example.com")
+	if !errors.Is(err, ErrMaskedEmailNotEnabled) {
+		t.Fatalf("error = %v, want ErrMaskedEmailNotEnabled", err)
+	}
+}
@@ -17,6 +17,14 @@ type Quota struct {
 	ResourceType string `json:"resourceType"` // octets, message count
 }
 
+// Percent returns usage as a percentage of the limit, or 0 for unlimited quotas.
+func (q Quota) Percent() float64 {
+	if q.Limit <= 0 {
+		return 0
+	}
+	return float64(q.Used) / float64(q.Limit) * 100
+}
+
 // GetQuotas retrieves all quotas for the account
 func (c *Client) GetQuotas(ctx context.Context) ([]Quota, error) {
 	session, err := c.GetSession(ctx)
@@ -199,3 +199,23 @@ func TestGetQuotas(t *testing.T) {
 		})
 	}
 }
+
+func TestQuotaPercent(t *testing.T) {
+	tests := []struct {
+		name  string
+		quota Quota
+		want  float64
+	}{
+		{"half", Quota{Used: 50, Limit: 100}, 50},
+		{"full", Quota{Used: 100, Limit: 100}, 100},
+		{"unlimited", Quota{Used: 500, Limit: 0}, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.quota.Percent(); got != tt.want {
+				t.Errorf("Percent() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,116 @@
+package jmap
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// sessionCacheFile is the on-disk layout of a cached session.
+type sessionCacheFile struct {
+	FetchedAt time.Time `json:"fetchedAt"`
+	Session   *Session  `json:"session"`
+}
+
+// SetSessionCacheDir enables on-disk session caching in dir so that separate
+// processes using the same token can skip the session request while the
+// cached copy is younger than the session TTL. Files are keyed by a hash of
+// the token and base URL and written with mode 0600. An empty dir disables
+// the cache.
+func (c *Client) SetSessionCacheDir(dir string) {
+	c.sessionMu.Lock()
+	defer c.sessionMu.Unlock()
+	c.sessionCacheDir = dir
+}
+
+// sessionCachePath returns the cache file for this client, or "" when disk
+// caching is off. The token itself never appears in the path.
+func (c *Client) sessionCachePath() string {
+	if c.sessionCacheDir == "" {
+		return ""
+	}
+	sum := sha256.Sum256([]byte(c.baseURL + "\x00" + c.token))
+	return filepath.Join(c.sessionCacheDir, "session-"+hex.EncodeToString(sum[:16])+".json")
+}
+
+// loadCachedSession fills the in-memory session from disk when a fresh copy
+// exists that can serve the selected account. Caller must hold sessionMu.
+func (c *Client) loadCachedSession() bool {
+	path := c.sessionCachePath()
+	if path == "" {
+		return false
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return false
+	}
+	var cached sessionCacheFile
+	if err := json.Unmarshal(data, &cached); err != nil || cached.Session == nil {
+		return false
+	}
+	if time.Since(cached.FetchedAt) >= c.sessionTTL || len(cached.Session.Accounts) == 0 {
+		return false
+	}
+
+	// The file is shared by every account selection, so resolve it again
+	session := *cached.Session
+	session.AccountID = session.Accounts[0].ID
+	if c.accountID != "" {
+		found := false
+		for _, account := range session.Accounts {
+			if account.ID == c.accountID {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+		session.AccountID = c.accountID
+	}
+
+	c.session = &session
+	c.sessionFetch = cached.FetchedAt
+	return true
+}
+
+// saveCachedSession writes the in-memory session to disk. Failures are
+// ignored: the cache only saves a round trip. Caller must hold sessionMu.
+func (c *Client) saveCachedSession() {
+	path := c.sessionCachePath()
+	if path == "" || c.session == nil {
+		return
+	}
+
+	data, err := json.Marshal(sessionCacheFile{FetchedAt: c.sessionFetch, Session: c.session})
+	if err != nil {
+		return
+	}
+	if err := os.MkdirAll(c.sessionCacheDir, 0o700); err != nil {
+		return
+	}
+
+	// Write to a temp file (created 0600) and rename so readers never see a
+	// partial file
+	tmp, err := os.CreateTemp(c.sessionCacheDir, "session-*.tmp")
+	if err != nil {
+		return
+	}
+	_, writeErr := tmp.Write(data)
+	closeErr := tmp.Close()
+	if writeErr != nil || closeErr != nil || os.Rename(tmp.Name(), path) != nil {
+		_ = os.Remove(tmp.Name())
+	}
+}
+
+// removeCachedSession deletes the on-disk session, if any. Caller must hold
+// sessionMu.
+func (c *Client) removeCachedSession() {
+	if path := c.sessionCachePath(); path != "" {
+		_ = os.Remove(path)
+	}
+}
@@ -0,0 +1,135 @@
+package jmap
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"runtime"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func newCountingSessionServer(t *testing.T, status *atomic.Int32, fetches *atomic.Int32) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fetches.Add(1)
+		if code := status.Load(); code != 0 {
+			w.WriteHeader(int(code))
+			return
+		}
+		_, _ = w.Write([]byte(`{
+			"apiUrl": "https://api.example.com/jmap/api/",
+			"accounts": {"u1": {"name": "me@example.com"}, "u2": {"name": "shared@example.com"}}
+		}`))
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestSessionCache_SharedAcrossClients(t *testing.T) {
+	var status, fetches atomic.Int32
+	server := newCountingSessionServer(t, &status, &fetches)
+	dir := t.TempDir()
+	ctx := context.Background()
+
+	first := NewClientWithBaseURL("test-token", server.URL)
+	first.SetSessionCacheDir(dir)
+	if _, err := first.GetSession(ctx); err != nil {
+		t.Fatalf("GetSession() error = %v", err)
+	}
+
+	path := first.sessionCachePath()
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatalf("cache file not written: %v", err)
+	}
+	if runtime.GOOS != "windows" && info.Mode().Perm() != 0o600 {
+		t.Errorf("cache file mode = %v, want 0600", info.Mode().Perm())
+	}
+
+	second := NewClientWithBaseURL("test-token", server.URL)
+	second.SetSessionCacheDir(dir)
+	second.SetAccountID("u2")
+	session, err := second.GetSession(ctx)
+	if err != nil {
+		t.Fatalf("GetSession() error = %v", err)
+	}
+	if fetches.Load() != 1 {
+		t.Errorf("session fetched %d times, want 1 (second client should use the disk cache)", fetches.Load())
+	}
+	if session.AccountID != "u2" {
+		t.Errorf("AccountID = %q, want u2 resolved from the cached accounts", session.AccountID)
+	}
+
+	// A different token never shares the file
+	other := NewClientWithBaseURL("other-token", server.URL)
+	other.SetSessionCacheDir(dir)
+	if other.sessionCachePath() == path {
+		t.Error("different tokens map to the same cache file")
+	}
+}
+
+func TestSessionCache_RespectsTTL(t *testing.T) {
+	var status, fetches atomic.Int32
+	server := newCountingSessionServer(t, &status, &fetches)
+	dir := t.TempDir()
+	ctx := context.Background()
+
+	first := NewClientWithBaseURL("test-token", server.URL)
+	first.SetSessionCacheDir(dir)
+	if _, err := first.GetSession(ctx); err != nil {
+		t.Fatalf("GetSession() error = %v", err)
+	}
+
+	second := NewClientWithBaseURL("test-token", server.URL)
+	second.SetSessionCacheDir(dir)
+	second.SetSessionTTL(time.Nanosecond)
+	if _, err := second.GetSession(ctx); err != nil {
+		t.Fatalf("GetSession() error = %v", err)
+	}
+	if fetches.Load() != 2 {
+		t.Errorf("session fetched %d times, want 2 (stale cache must be refetched)", fetches.Load())
+	}
+}
+
+func TestSessionCache_Invalidation(t *testing.T) {
+	var status, fetches atomic.Int32
+	server := newCountingSessionServer(t, &status, &fetches)
+	ctx := context.Background()
+
+	t.Run("ClearSession", func(t *testing.T) {
+		client := NewClientWithBaseURL("test-token", server.URL)
+		client.SetSessionCacheDir(t.TempDir())
+		if _, err := client.GetSession(ctx); err != nil {
+			t.Fatalf("GetSession() error = %v", err)
+		}
+		client.ClearSession()
+		if _, err := os.Stat(client.sessionCachePath()); !os.IsNotExist(err) {
+			t.Errorf("cache file still present after ClearSession (stat err = %v)", err)
+		}
+	})
+
+	t.Run("401", func(t *testing.T) {
+		dir := t.TempDir()
+		client := NewClientWithBaseURL("test-token", server.URL)
+		client.SetSessionCacheDir(dir)
+		if _, err := client.GetSession(ctx); err != nil {
+			t.Fatalf("GetSession() error = %v", err)
+		}
+
+		status.Store(http.StatusUnauthorized)
+		defer status.Store(0)
+
+		fresh := NewClientWithBaseURL("test-token", server.URL)
+		fresh.SetSessionCacheDir(dir)
+		fresh.SetSessionTTL(time.Nanosecond)
+		if _, err := fresh.GetSession(ctx); err == nil {
+			t.Fatal("GetSession() error = nil, want auth error")
+		}
+		if _, err := os.Stat(client.sessionCachePath()); !os.IsNotExist(err) {
+			t.Errorf("cache file still present after 401 (stat err = %v)", err)
+		}
+	})
+}
@@ -10,6 +10,7 @@ import (
 	"sort"
 
 	"github.com/google/uuid"
+	"github.com/salmonumbrella/fastmail-cli/internal/transport"
 )
 
 // SieveBlocks represents the Fastmail Sieve script blocks.
@@ -57,6 +58,12 @@ func NewSieveClientFromCredentials(token, cookie string) *SieveClient {
 	}
 }
 
+// redact scrubs the session token and cookie from a response body before it
+// is put in an error.
+func (c *SieveClient) redact(body []byte) string {
+	return transport.RedactSecrets(string(body), c.token, c.cookie)
+}
+
 func (c *SieveClient) getAccountID(ctx context.Context) (string, error) {
 	if c.accountID != "" {
 		return c.accountID, nil
@@ -77,7 +84,7 @@ func (c *SieveClient) getAccountID(ctx context.Context) (string, error) {
 
 	if resp.StatusCode != http.StatusOK {
 		body, _ := io.ReadAll(resp.Body)
-		return "", fmt.Errorf("session request failed: %s - %s", resp.Status, string(body))
+		return "", fmt.Errorf("session request failed: %s - %s", resp.Status, c.redact(body))
 	}
 
 	var session struct {
@@ -142,7 +149,7 @@ func (c *SieveClient) GetSieveBlocks(ctx context.Context) (*SieveBlocks, error)
 
 	if resp.StatusCode != http.StatusOK {
 		respBody, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("sieve request failed: %s - %s", resp.Status, string(respBody))
+		return nil, fmt.Errorf("sieve request failed: %s - %s", resp.Status, c.redact(respBody))
 	}
 
 	var result struct {
@@ -249,7 +256,7 @@ func (c *SieveClient) SetSieveBlocks(ctx context.Context, opts SetSieveBlocksOpt
 
 	if resp.StatusCode != http.StatusOK {
 		respBody, _ := io.ReadAll(resp.Body)
-		return fmt.Errorf("sieve update failed: %s - %s", resp.Status, string(respBody))
+		return fmt.Errorf("sieve update failed: %s - %s", resp.Status, c.redact(respBody))
 	}
 
 	var result struct {
@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 )
 
@@ -48,3 +49,27 @@ func TestGetSieveBlocks(t *testing.T) {
 		t.Errorf("SieveAtStart = %q, want %q", blocks.SieveAtStart, "# start")
 	}
 }
+
+func TestGetSieveBlocks_ErrorRedactsCredentials(t *testing.T) {
+	sessionServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"accounts": map[string]any{"acc123": map[string]any{}},
+		})
+	}))
+	defer sessionServer.Close()
+
+	apiServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte("echo: " + r.Header.Get("Authorization") + "; " + r.Header.Get("Cookie")))
+	}))
+	defer apiServer.Close()
+
+	client := NewSieveClient("test-token", "test-cookie", sessionServer.URL, apiServer.URL)
+	_, err := client.GetSieveBlocks(context.Background())
+	if err == nil {
+		t.Fatal("GetSieveBlocks() error = nil, want error")
+	}
+	if strings.Contains(err.Error(), "test-token") || strings.Contains(err.Error(), "test-cookie") {
+		t.Errorf("error leaks credentials: %v", err)
+	}
+}
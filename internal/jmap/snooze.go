@@ -0,0 +1,114 @@
+package jmap
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// SnoozedMailboxName is the mailbox snoozed emails are moved into.
+const SnoozedMailboxName = "Snoozed"
+
+// snoozedKeyword marks emails that were snoozed by the CLI.
+const snoozedKeyword = "$snoozed"
+
+// SnoozeEmail moves an email to the Snoozed mailbox (creating it if missing)
+// and sets the $snoozed keyword.
+//
+// JMAP has no standard snooze, so waking the email at the given time is the
+// caller's responsibility (see UnsnoozeEmail).
+func (c *Client) SnoozeEmail(ctx context.Context, id string, until time.Time) error {
+	if id == "" {
+		return &ValidationError{Field: "id", Message: "email ID is required"}
+	}
+	if !until.After(time.Now()) {
+		return &ValidationError{Field: "until", Message: "snooze time must be in the future"}
+	}
+
+	mailbox, err := c.ensureSnoozedMailbox(ctx)
+	if err != nil {
+		return err
+	}
+
+	return c.updateSnoozeState(ctx, id, mailbox.ID, true)
+}
+
+// UnsnoozeEmail moves a snoozed email back to the inbox and clears the
+// $snoozed keyword.
+func (c *Client) UnsnoozeEmail(ctx context.Context, id string) error {
+	if id == "" {
+		return &ValidationError{Field: "id", Message: "email ID is required"}
+	}
+
+	inbox, err := c.GetMailboxByRole(ctx, "inbox")
+	if err != nil {
+		return err
+	}
+
+	return c.updateSnoozeState(ctx, id, inbox.ID, false)
+}
+
+// ensureSnoozedMailbox returns the Snoozed mailbox, creating it if it doesn't exist.
+func (c *Client) ensureSnoozedMailbox(ctx context.Context) (*Mailbox, error) {
+	mailboxes, err := c.GetMailboxes(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range mailboxes {
+		if strings.EqualFold(mailboxes[i].Role, "snoozed") || strings.EqualFold(mailboxes[i].Name, SnoozedMailboxName) {
+			return &mailboxes[i], nil
+		}
+	}
+
+	return c.CreateMailbox(ctx, CreateMailboxOpts{Name: SnoozedMailboxName})
+}
+
+// updateSnoozeState moves an email to mailboxID and sets or clears the $snoozed keyword.
+func (c *Client) updateSnoozeState(ctx context.Context, id, mailboxID string, snoozed bool) error {
+	session, err := c.GetSession(ctx)
+	if err != nil {
+		return err
+	}
+
+	var keywordValue any
+	if snoozed {
+		keywordValue = true
+	} else {
+		keywordValue = nil // null in JMAP removes the keyword
+	}
+
+	req := &Request{
+		Using: []string{"urn:ietf:params:jmap:core", "urn:ietf:params:jmap:mail"},
+		MethodCalls: []MethodCall{
+			{"Email/set", map[string]any{
+				"accountId": session.AccountID,
+				"update": map[string]any{
+					id: map[string]any{
+						"mailboxIds":                 map[string]bool{mailboxID: true},
+						"keywords/" + snoozedKeyword: keywordValue,
+					},
+				},
+			}, "snoozeEmail"},
+		},
+	}
+
+	resp, err := c.MakeRequest(ctx, req)
+	if err != nil {
+		return err
+	}
+
+	result, ok := resp.MethodResponses[0][1].(map[string]any)
+	if !ok {
+		return fmt.Errorf("unexpected response format")
+	}
+
+	if notUpdated, ok := result["notUpdated"].(map[string]any); ok {
+		if errInfo, exists := notUpdated[id]; exists {
+			return fmt.Errorf("failed to update email %s: %s", id, setErrorMessage(errInfo))
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,122 @@
+package jmap
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func newSnoozeTestClient(t *testing.T, mailboxes string, updates *[]map[string]any, created *bool) *Client {
+	t.Helper()
+
+	apiServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		var req Request
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Fatalf("failed to decode request: %v", err)
+		}
+
+		call := req.MethodCalls[0]
+		args := call[1].(map[string]any)
+
+		switch call[0] {
+		case "Mailbox/get":
+			_, _ = w.Write([]byte(`{"methodResponses": [["Mailbox/get", {"list": ` + mailboxes + `}, "getMailboxes"]]}`))
+		case "Mailbox/set":
+			*created = true
+			_, _ = w.Write([]byte(`{"methodResponses": [["Mailbox/set", {"created": {"new": {"id": "snoozed-new"}}}, "createMailbox"]]}`))
+		case "Email/set":
+			for _, patch := range args["update"].(map[string]any) {
+				*updates = append(*updates, patch.(map[string]any))
+			}
+			_, _ = w.Write([]byte(`{"methodResponses": [["Email/set", {"updated": {"email1": null}}, "snoozeEmail"]]}`))
+		default:
+			t.Fatalf("unexpected method %v", call[0])
+		}
+	}))
+	t.Cleanup(apiServer.Close)
+
+	sessionServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{
+			"apiUrl": "` + apiServer.URL + `",
+			"accounts": {"acc123": {}}
+		}`))
+	}))
+	t.Cleanup(sessionServer.Close)
+
+	return NewClientWithBaseURL("test-token", sessionServer.URL)
+}
+
+func TestSnoozeEmail_CreatesMailboxAndSetsKeyword(t *testing.T) {
+	var updates []map[string]any
+	var created bool
+	client := newSnoozeTestClient(t, `[{"id": "inbox1", "name": "Inbox", "role": "inbox"}]`, &updates, &created)
+
+	if err := client.SnoozeEmail(context.Background(), "email1", time.Now().Add(time.Hour)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !created {
+		t.Fatal("expected Snoozed mailbox to be created")
+	}
+	if len(updates) != 1 {
+		t.Fatalf("expected 1 Email/set update, got %d", len(updates))
+	}
+	mailboxIDs := updates[0]["mailboxIds"].(map[string]any)
+	if mailboxIDs["snoozed-new"] != true {
+		t.Errorf("expected email moved to snoozed-new, got %v", mailboxIDs)
+	}
+	if updates[0]["keywords/$snoozed"] != true {
+		t.Errorf("expected keywords/$snoozed=true, got %v", updates[0]["keywords/$snoozed"])
+	}
+}
+
+func TestSnoozeEmail_ReusesExistingMailbox(t *testing.T) {
+	var updates []map[string]any
+	var created bool
+	client := newSnoozeTestClient(t, `[{"id": "snz1", "name": "Snoozed"}]`, &updates, &created)
+
+	if err := client.SnoozeEmail(context.Background(), "email1", time.Now().Add(time.Hour)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if created {
+		t.Error("expected existing Snoozed mailbox to be reused")
+	}
+	if len(updates) != 1 || updates[0]["mailboxIds"].(map[string]any)["snz1"] != true {
+		t.Errorf("expected email moved to snz1, got %v", updates)
+	}
+}
+
+func TestSnoozeEmail_RejectsPastTime(t *testing.T) {
+	client := NewClient("test-token")
+
+	err := client.SnoozeEmail(context.Background(), "email1", time.Now().Add(-time.Minute))
+	if !IsValidationError(err) {
+		t.Fatalf("expected ValidationError, got %v", err)
+	}
+}
+
+func TestUnsnoozeEmail_MovesToInboxAndClearsKeyword(t *testing.T) {
+	var updates []map[string]any
+	var created bool
+	client := newSnoozeTestClient(t, `[{"id": "inbox1", "name": "Inbox", "role": "inbox"}]`, &updates, &created)
+
+	if err := client.UnsnoozeEmail(context.Background(), "email1"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(updates) != 1 {
+		t.Fatalf("expected 1 Email/set update, got %d", len(updates))
+	}
+	if updates[0]["mailboxIds"].(map[string]any)["inbox1"] != true {
+		t.Errorf("expected email moved to inbox1, got %v", updates[0]["mailboxIds"])
+	}
+	if v, exists := updates[0]["keywords/$snoozed"]; !exists || v != nil {
+		t.Errorf("expected keywords/$snoozed=null, got %v (exists=%v)", v, exists)
+	}
+}
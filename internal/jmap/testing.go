@@ -14,23 +14,31 @@ type MockEmailService struct {
 	SearchEmailsFunc             func(ctx context.Context, filter *EmailSearchFilter, limit int) ([]Email, error)
 	GetDraftsFunc                func(ctx context.Context, limit int) ([]Email, error)
 	GetEmailByIDFunc             func(ctx context.Context, id string) (*Email, error)
+	GetEmailsByIDsFunc           func(ctx context.Context, ids []string) ([]Email, []string, error)
 	UpdateDraftFunc              func(ctx context.Context, draftID string, opts SendEmailOpts) error
 	SendDraftFunc                func(ctx context.Context, draftID string) (string, error)
 	SendEmailFunc                func(ctx context.Context, opts SendEmailOpts) (string, error)
+	SendEmailResultFunc          func(ctx context.Context, opts SendEmailOpts) (*SendResult, error)
 	DeleteEmailFunc              func(ctx context.Context, id string) error
 	MoveEmailFunc                func(ctx context.Context, id, targetMailboxID string) error
+	MoveEmailsFunc               func(ctx context.Context, ids []string, targetMailboxID string) (*BulkResult, error)
 	MarkEmailReadFunc            func(ctx context.Context, id string, read bool) error
+	UpdateEmailFunc              func(ctx context.Context, id string, patch map[string]any) error
 	GetThreadFunc                func(ctx context.Context, threadID string) ([]Email, error)
 	GetEmailAttachmentsFunc      func(ctx context.Context, id string) ([]Attachment, error)
 	GetMailboxesFunc             func(ctx context.Context) ([]Mailbox, error)
+	GetEmailBodiesFunc           func(ctx context.Context, ids []string, maxBodyValueBytes int) ([]Email, error)
 	DownloadBlobFunc             func(ctx context.Context, blobID string) (io.ReadCloser, error)
+	DownloadBlobRangeFunc        func(ctx context.Context, blobID string, offset int64) (io.ReadCloser, error)
 	UploadBlobFunc               func(ctx context.Context, reader io.Reader, contentType string) (*UploadBlobResult, error)
+	UploadBlobFromFileFunc       func(ctx context.Context, path, contentType string) (*UploadBlobResult, error)
 	GetIdentitiesFunc            func(ctx context.Context) ([]Identity, error)
 	GetMailboxByNameFunc         func(ctx context.Context, name string) (*Mailbox, error)
 	ResolveMailboxIDFunc         func(ctx context.Context, idOrName string) (string, error)
 	CreateMailboxFunc            func(ctx context.Context, opts CreateMailboxOpts) (*Mailbox, error)
 	DeleteMailboxFunc            func(ctx context.Context, id string) error
 	RenameMailboxFunc            func(ctx context.Context, id, newName string) error
+	MoveMailboxFunc              func(ctx context.Context, id, newParentID string) error
 	SearchEmailsWithSnippetsFunc func(ctx context.Context, filter *EmailSearchFilter, limit int) ([]Email, []SearchSnippet, error)
 	ImportEmailFunc              func(ctx context.Context, opts ImportEmailOpts) (string, error)
 	SaveDraftFunc                func(ctx context.Context, opts SendEmailOpts) (string, error)
@@ -65,6 +73,13 @@ func (m *MockEmailService) GetEmailByID(ctx context.Context, id string) (*Email,
 	return nil, nil
 }
 
+func (m *MockEmailService) GetEmailsByIDs(ctx context.Context, ids []string) ([]Email, []string, error) {
+	if m.GetEmailsByIDsFunc != nil {
+		return m.GetEmailsByIDsFunc(ctx, ids)
+	}
+	return nil, nil, nil
+}
+
 func (m *MockEmailService) UpdateDraft(ctx context.Context, draftID string, opts SendEmailOpts) error {
 	if m.UpdateDraftFunc != nil {
 		return m.UpdateDraftFunc(ctx, draftID, opts)
@@ -86,6 +101,13 @@ func (m *MockEmailService) SendEmail(ctx context.Context, opts SendEmailOpts) (s
 	return "", nil
 }
 
+func (m *MockEmailService) SendEmailResult(ctx context.Context, opts SendEmailOpts) (*SendResult, error) {
+	if m.SendEmailResultFunc != nil {
+		return m.SendEmailResultFunc(ctx, opts)
+	}
+	return &SendResult{}, nil
+}
+
 func (m *MockEmailService) DeleteEmail(ctx context.Context, id string) error {
 	if m.DeleteEmailFunc != nil {
 		return m.DeleteEmailFunc(ctx, id)
@@ -100,6 +122,13 @@ func (m *MockEmailService) MoveEmail(ctx context.Context, id, targetMailboxID st
 	return nil
 }
 
+func (m *MockEmailService) MoveEmails(ctx context.Context, ids []string, targetMailboxID string) (*BulkResult, error) {
+	if m.MoveEmailsFunc != nil {
+		return m.MoveEmailsFunc(ctx, ids, targetMailboxID)
+	}
+	return nil, nil
+}
+
 func (m *MockEmailService) MarkEmailRead(ctx context.Context, id string, read bool) error {
 	if m.MarkEmailReadFunc != nil {
 		return m.MarkEmailReadFunc(ctx, id, read)
@@ -107,6 +136,13 @@ func (m *MockEmailService) MarkEmailRead(ctx context.Context, id string, read bo
 	return nil
 }
 
+func (m *MockEmailService) UpdateEmail(ctx context.Context, id string, patch map[string]any) error {
+	if m.UpdateEmailFunc != nil {
+		return m.UpdateEmailFunc(ctx, id, patch)
+	}
+	return nil
+}
+
 func (m *MockEmailService) GetThread(ctx context.Context, threadID string) ([]Email, error) {
 	if m.GetThreadFunc != nil {
 		return m.GetThreadFunc(ctx, threadID)
@@ -128,6 +164,13 @@ func (m *MockEmailService) GetMailboxes(ctx context.Context) ([]Mailbox, error)
 	return nil, nil
 }
 
+func (m *MockEmailService) GetEmailBodies(ctx context.Context, ids []string, maxBodyValueBytes int) ([]Email, error) {
+	if m.GetEmailBodiesFunc != nil {
+		return m.GetEmailBodiesFunc(ctx, ids, maxBodyValueBytes)
+	}
+	return nil, nil
+}
+
 func (m *MockEmailService) DownloadBlob(ctx context.Context, blobID string) (io.ReadCloser, error) {
 	if m.DownloadBlobFunc != nil {
 		return m.DownloadBlobFunc(ctx, blobID)
@@ -135,6 +178,13 @@ func (m *MockEmailService) DownloadBlob(ctx context.Context, blobID string) (io.
 	return nil, nil
 }
 
+func (m *MockEmailService) DownloadBlobRange(ctx context.Context, blobID string, offset int64) (io.ReadCloser, error) {
+	if m.DownloadBlobRangeFunc != nil {
+		return m.DownloadBlobRangeFunc(ctx, blobID, offset)
+	}
+	return nil, nil
+}
+
 func (m *MockEmailService) UploadBlob(ctx context.Context, reader io.Reader, contentType string) (*UploadBlobResult, error) {
 	if m.UploadBlobFunc != nil {
 		return m.UploadBlobFunc(ctx, reader, contentType)
@@ -142,6 +192,13 @@ func (m *MockEmailService) UploadBlob(ctx context.Context, reader io.Reader, con
 	return nil, nil
 }
 
+func (m *MockEmailService) UploadBlobFromFile(ctx context.Context, path, contentType string) (*UploadBlobResult, error) {
+	if m.UploadBlobFromFileFunc != nil {
+		return m.UploadBlobFromFileFunc(ctx, path, contentType)
+	}
+	return nil, nil
+}
+
 func (m *MockEmailService) GetIdentities(ctx context.Context) ([]Identity, error) {
 	if m.GetIdentitiesFunc != nil {
 		return m.GetIdentitiesFunc(ctx)
@@ -184,6 +241,13 @@ func (m *MockEmailService) RenameMailbox(ctx context.Context, id, newName string
 	return nil
 }
 
+func (m *MockEmailService) MoveMailbox(ctx context.Context, id, newParentID string) error {
+	if m.MoveMailboxFunc != nil {
+		return m.MoveMailboxFunc(ctx, id, newParentID)
+	}
+	return nil
+}
+
 func (m *MockEmailService) SearchEmailsWithSnippets(ctx context.Context, filter *EmailSearchFilter, limit int) ([]Email, []SearchSnippet, error) {
 	if m.SearchEmailsWithSnippetsFunc != nil {
 		return m.SearchEmailsWithSnippetsFunc(ctx, filter, limit)
@@ -218,6 +282,7 @@ func (m *MockEmailService) CreateReplyDraft(ctx context.Context, replyToID strin
 type MockMaskedEmailService struct {
 	GetMaskedEmailsFunc              func(ctx context.Context) ([]MaskedEmail, error)
 	GetMaskedEmailByEmailFunc        func(ctx context.Context, email string) (*MaskedEmail, error)
+	GetMaskedEmailByIDFunc           func(ctx context.Context, id string) (*MaskedEmail, error)
 	GetMaskedEmailsForDomainFunc     func(ctx context.Context, domain string) ([]MaskedEmail, error)
 	CreateMaskedEmailFunc            func(ctx context.Context, domain, description string) (*MaskedEmail, error)
 	UpdateMaskedEmailStateFunc       func(ctx context.Context, id string, state MaskedEmailState) error
@@ -238,6 +303,13 @@ func (m *MockMaskedEmailService) GetMaskedEmailByEmail(ctx context.Context, emai
 	return nil, nil
 }
 
+func (m *MockMaskedEmailService) GetMaskedEmailByID(ctx context.Context, id string) (*MaskedEmail, error) {
+	if m.GetMaskedEmailByIDFunc != nil {
+		return m.GetMaskedEmailByIDFunc(ctx, id)
+	}
+	return nil, nil
+}
+
 func (m *MockMaskedEmailService) GetMaskedEmailsForDomain(ctx context.Context, domain string) ([]MaskedEmail, error) {
 	if m.GetMaskedEmailsForDomainFunc != nil {
 		return m.GetMaskedEmailsForDomainFunc(ctx, domain)
@@ -300,13 +372,15 @@ func (m *MockVacationService) DisableVacationResponse(ctx context.Context) error
 // Each method can be overridden by setting the corresponding Func field.
 // If a Func is not set, the method returns nil/empty values.
 type MockContactsService struct {
-	GetContactsFunc     func(ctx context.Context, addressBookID string, limit int) ([]Contact, error)
-	GetContactByIDFunc  func(ctx context.Context, id string) (*Contact, error)
-	CreateContactFunc   func(ctx context.Context, contact *Contact) (*Contact, error)
-	UpdateContactFunc   func(ctx context.Context, id string, updates map[string]interface{}) (*Contact, error)
-	DeleteContactFunc   func(ctx context.Context, id string) error
-	SearchContactsFunc  func(ctx context.Context, query string, limit int) ([]Contact, error)
-	GetAddressBooksFunc func(ctx context.Context) ([]AddressBook, error)
+	GetContactsFunc       func(ctx context.Context, addressBookID string, limit int) ([]Contact, error)
+	GetContactByIDFunc    func(ctx context.Context, id string) (*Contact, error)
+	GetContactByEmailFunc func(ctx context.Context, email string) (*Contact, error)
+	CreateContactFunc     func(ctx context.Context, contact *Contact) (*Contact, error)
+	UpdateContactFunc     func(ctx context.Context, id string, updates map[string]interface{}) (*Contact, error)
+	DeleteContactFunc     func(ctx context.Context, id string) error
+	SearchContactsFunc    func(ctx context.Context, query string, limit int) ([]Contact, error)
+	ResolveRecipientFunc  func(ctx context.Context, nameOrEmail string) ([]EmailAddress, error)
+	GetAddressBooksFunc   func(ctx context.Context) ([]AddressBook, error)
 }
 
 func (m *MockContactsService) GetContacts(ctx context.Context, addressBookID string, limit int) ([]Contact, error) {
@@ -323,6 +397,13 @@ func (m *MockContactsService) GetContactByID(ctx context.Context, id string) (*C
 	return nil, nil
 }
 
+func (m *MockContactsService) GetContactByEmail(ctx context.Context, email string) (*Contact, error) {
+	if m.GetContactByEmailFunc != nil {
+		return m.GetContactByEmailFunc(ctx, email)
+	}
+	return nil, nil
+}
+
 func (m *MockContactsService) CreateContact(ctx context.Context, contact *Contact) (*Contact, error) {
 	if m.CreateContactFunc != nil {
 		return m.CreateContactFunc(ctx, contact)
@@ -351,6 +432,13 @@ func (m *MockContactsService) SearchContacts(ctx context.Context, query string,
 	return nil, nil
 }
 
+func (m *MockContactsService) ResolveRecipient(ctx context.Context, nameOrEmail string) ([]EmailAddress, error) {
+	if m.ResolveRecipientFunc != nil {
+		return m.ResolveRecipientFunc(ctx, nameOrEmail)
+	}
+	return nil, nil
+}
+
 func (m *MockContactsService) GetAddressBooks(ctx context.Context) ([]AddressBook, error) {
 	if m.GetAddressBooksFunc != nil {
 		return m.GetAddressBooksFunc(ctx)
@@ -362,12 +450,13 @@ func (m *MockContactsService) GetAddressBooks(ctx context.Context) ([]AddressBoo
 // Each method can be overridden by setting the corresponding Func field.
 // If a Func is not set, the method returns nil/empty values.
 type MockCalendarService struct {
-	GetCalendarsFunc func(ctx context.Context) ([]Calendar, error)
-	GetEventsFunc    func(ctx context.Context, calendarID string, from, to time.Time, limit int) ([]CalendarEvent, error)
-	GetEventByIDFunc func(ctx context.Context, id string) (*CalendarEvent, error)
-	CreateEventFunc  func(ctx context.Context, event *CalendarEvent) (*CalendarEvent, error)
-	UpdateEventFunc  func(ctx context.Context, id string, updates map[string]interface{}) (*CalendarEvent, error)
-	DeleteEventFunc  func(ctx context.Context, id string) error
+	GetCalendarsFunc   func(ctx context.Context) ([]Calendar, error)
+	GetEventsFunc      func(ctx context.Context, calendarID string, from, to time.Time, limit int) ([]CalendarEvent, error)
+	GetBusyPeriodsFunc func(ctx context.Context, from, to time.Time) ([]BusyPeriod, error)
+	GetEventByIDFunc   func(ctx context.Context, id string) (*CalendarEvent, error)
+	CreateEventFunc    func(ctx context.Context, event *CalendarEvent) (*CalendarEvent, error)
+	UpdateEventFunc    func(ctx context.Context, id string, updates map[string]interface{}) (*CalendarEvent, error)
+	DeleteEventFunc    func(ctx context.Context, id string) error
 }
 
 func (m *MockCalendarService) GetCalendars(ctx context.Context) ([]Calendar, error) {
@@ -384,6 +473,13 @@ func (m *MockCalendarService) GetEvents(ctx context.Context, calendarID string,
 	return nil, nil
 }
 
+func (m *MockCalendarService) GetBusyPeriods(ctx context.Context, from, to time.Time) ([]BusyPeriod, error) {
+	if m.GetBusyPeriodsFunc != nil {
+		return m.GetBusyPeriodsFunc(ctx, from, to)
+	}
+	return nil, nil
+}
+
 func (m *MockCalendarService) GetEventByID(ctx context.Context, id string) (*CalendarEvent, error) {
 	if m.GetEventByIDFunc != nil {
 		return m.GetEventByIDFunc(ctx, id)
@@ -0,0 +1,105 @@
+package jmap
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// Request kinds reported in RequestEvent.Kind.
+const (
+	RequestKindSession  = "session"
+	RequestKindAPI      = "api"
+	RequestKindUpload   = "upload"
+	RequestKindDownload = "download"
+)
+
+// RequestEvent describes one HTTP attempt made by the client. Retries of a
+// call produce one event each. Events never carry the auth token or request
+// and response bodies.
+type RequestEvent struct {
+	Kind       string        // one of the RequestKind constants
+	Methods    []string      // JMAP method names (Kind "api" only)
+	CallIDs    []string      // JMAP call IDs (Kind "api" only)
+	Attempt    int           // 1 for the first try, 2 for the first retry, ...
+	StatusCode int           // HTTP status, 0 when no response was received
+	Duration   time.Duration // time until the response headers or the failure
+	Err        error         // set when the attempt failed without a response
+}
+
+// String formats the event as a single log line.
+func (e RequestEvent) String() string {
+	var b strings.Builder
+	b.WriteString(e.Kind)
+	if len(e.Methods) > 0 {
+		fmt.Fprintf(&b, " %s [%s]", strings.Join(e.Methods, ","), strings.Join(e.CallIDs, ","))
+	}
+	fmt.Fprintf(&b, " attempt=%d", e.Attempt)
+	if e.StatusCode != 0 {
+		fmt.Fprintf(&b, " status=%d", e.StatusCode)
+	}
+	fmt.Fprintf(&b, " duration=%s", e.Duration.Round(time.Millisecond))
+	if e.Err != nil {
+		fmt.Fprintf(&b, " error=%q", e.Err.Error())
+	}
+	return b.String()
+}
+
+// SetLogger installs fn to receive a RequestEvent for every HTTP attempt the
+// client makes, including retries and the final failed attempt. Pass nil to
+// disable tracing.
+func (c *Client) SetLogger(fn func(evt RequestEvent)) {
+	c.logger = fn
+}
+
+// tracedHTTP returns the HTTP client to use for one call. When a logger is
+// installed it wraps the transport so every attempt is reported.
+func (c *Client) tracedHTTP(kind string, req *Request) *http.Client {
+	if c.logger == nil {
+		return c.http
+	}
+
+	base := c.http.Transport
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	rt := &tracingTransport{base: base, logger: c.logger, event: RequestEvent{Kind: kind}}
+	if req != nil {
+		for _, call := range req.MethodCalls {
+			name, _ := call[0].(string)
+			callID, _ := call[2].(string)
+			rt.event.Methods = append(rt.event.Methods, name)
+			rt.event.CallIDs = append(rt.event.CallIDs, callID)
+		}
+	}
+
+	traced := *c.http
+	traced.Transport = rt
+	return &traced
+}
+
+// tracingTransport reports each round trip of one call to a logger.
+type tracingTransport struct {
+	base    http.RoundTripper
+	logger  func(RequestEvent)
+	event   RequestEvent
+	attempt int
+}
+
+func (t *tracingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	t.attempt++
+	start := time.Now()
+	resp, err := t.base.RoundTrip(req)
+
+	evt := t.event
+	evt.Attempt = t.attempt
+	evt.Duration = time.Since(start)
+	evt.Err = err
+	if resp != nil {
+		evt.StatusCode = resp.StatusCode
+	}
+	t.logger(evt)
+
+	return resp, err
+}
@@ -0,0 +1,102 @@
+package jmap
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestSetLogger_ReportsRetriesAndFinalFailure(t *testing.T) {
+	var failFirst atomic.Bool
+	failFirst.Store(true)
+	var alwaysFail atomic.Bool
+
+	apiServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if alwaysFail.Load() || failFirst.Swap(false) {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"methodResponses": [["Core/echo", {}, "c0"]]}`))
+	}))
+	defer apiServer.Close()
+
+	sessionServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"apiUrl": "` + apiServer.URL + `", "accounts": {"acc123": {}}}`))
+	}))
+	defer sessionServer.Close()
+
+	var events []RequestEvent
+	client := NewClientWithBaseURL("secret-token", sessionServer.URL)
+	client.SetRetryConfig(RetryConfig{MaxRetries: 1, InitialDelay: time.Millisecond, MaxDelay: time.Millisecond})
+	client.SetLogger(func(evt RequestEvent) { events = append(events, evt) })
+
+	req := &Request{
+		Using:       []string{"urn:ietf:params:jmap:core"},
+		MethodCalls: []MethodCall{{"Core/echo", map[string]any{"secret": "body"}, "c0"}},
+	}
+	if _, err := client.MakeRequest(context.Background(), req); err != nil {
+		t.Fatalf("MakeRequest() error = %v", err)
+	}
+
+	if len(events) != 3 {
+		t.Fatalf("got %d events, want session + 2 api attempts: %v", len(events), events)
+	}
+	if events[0].Kind != RequestKindSession || events[0].StatusCode != http.StatusOK {
+		t.Errorf("events[0] = %+v, want successful session fetch", events[0])
+	}
+	retry, success := events[1], events[2]
+	if retry.Kind != RequestKindAPI || retry.Attempt != 1 || retry.StatusCode != http.StatusServiceUnavailable {
+		t.Errorf("first api event = %+v, want attempt 1 with 503", retry)
+	}
+	if success.Attempt != 2 || success.StatusCode != http.StatusOK {
+		t.Errorf("second api event = %+v, want attempt 2 with 200", success)
+	}
+	if strings.Join(success.Methods, ",") != "Core/echo" || strings.Join(success.CallIDs, ",") != "c0" {
+		t.Errorf("methods = %v callIds = %v", success.Methods, success.CallIDs)
+	}
+	for _, evt := range events {
+		line := evt.String()
+		if strings.Contains(line, "secret") {
+			t.Errorf("event line leaks token or body: %s", line)
+		}
+	}
+
+	// Every attempt, including the last failing one, is reported.
+	events = nil
+	alwaysFail.Store(true)
+	if _, err := client.MakeRequest(context.Background(), req); err == nil {
+		t.Fatal("expected error when every attempt fails")
+	}
+	if len(events) != 2 {
+		t.Fatalf("got %d events, want 2 api attempts: %v", len(events), events)
+	}
+	if last := events[1]; last.Attempt != 2 || last.StatusCode != http.StatusServiceUnavailable {
+		t.Errorf("final event = %+v, want attempt 2 with 503", last)
+	}
+}
+
+func TestRequestEvent_String(t *testing.T) {
+	evt := RequestEvent{
+		Kind:       RequestKindAPI,
+		Methods:    []string{"Email/query", "Email/get"},
+		CallIDs:    []string{"query", "emails"},
+		Attempt:    1,
+		StatusCode: 200,
+		Duration:   142*time.Millisecond + 300*time.Microsecond,
+	}
+	want := "api Email/query,Email/get [query,emails] attempt=1 status=200 duration=142ms"
+	if got := evt.String(); got != want {
+		t.Errorf("String() = %q, want %q", got, want)
+	}
+
+	evt = RequestEvent{Kind: RequestKindDownload, Attempt: 3, Err: context.DeadlineExceeded}
+	want = `download attempt=3 duration=0s error="context deadline exceeded"`
+	if got := evt.String(); got != want {
+		t.Errorf("String() = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,509 @@
+package jmap
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"mime/quotedprintable"
+	"sort"
+	"strings"
+	"unicode/utf8"
+)
+
+// vCardMaxLineOctets is the maximum line length before folding (RFC 6350 section 3.2).
+const vCardMaxLineOctets = 75
+
+// ExportContactVCard fetches a contact and serializes it as vCard 4.0 text.
+func (c *Client) ExportContactVCard(ctx context.Context, id string) (string, error) {
+	contact, err := c.GetContactByID(ctx, id)
+	if err != nil {
+		return "", err
+	}
+	return ContactToVCard(contact), nil
+}
+
+// ContactToVCard serializes a contact as RFC 6350 vCard 4.0 text with CRLF
+// line endings and lines folded at 75 octets.
+func ContactToVCard(contact *Contact) string {
+	var b strings.Builder
+
+	writeLine := func(line string) {
+		b.WriteString(foldVCardLine(line))
+		b.WriteString("\r\n")
+	}
+
+	writeLine("BEGIN:VCARD")
+	writeLine("VERSION:4.0")
+	if contact.ID != "" {
+		writeLine("UID:" + escapeVCardText(contact.ID))
+	}
+	writeLine("FN:" + escapeVCardText(contact.Name))
+
+	for _, email := range contact.Emails {
+		writeLine("EMAIL" + vCardTypeParam(email.Type) + ":" + escapeVCardText(email.Value))
+	}
+	for _, phone := range contact.Phones {
+		writeLine("TEL" + vCardTypeParam(phone.Type) + ":" + escapeVCardText(phone.Value))
+	}
+	if contact.Company != "" {
+		writeLine("ORG:" + escapeVCardText(contact.Company))
+	}
+	if contact.JobTitle != "" {
+		writeLine("TITLE:" + escapeVCardText(contact.JobTitle))
+	}
+	for _, addr := range contact.Addresses {
+		// ADR components: PO box; extended; street; locality; region; postal code; country
+		components := []string{"", "", addr.Street, addr.City, addr.State, addr.PostalCode, addr.Country}
+		for i, component := range components {
+			components[i] = escapeVCardText(component)
+		}
+		writeLine("ADR" + vCardTypeParam(addr.Type) + ":" + strings.Join(components, ";"))
+	}
+	for _, online := range contact.Online {
+		writeLine("URL:" + escapeVCardText(online.Value))
+	}
+	if contact.Birthday != "" {
+		writeLine("BDAY:" + strings.ReplaceAll(contact.Birthday, "-", ""))
+	}
+	if contact.Anniversary != "" {
+		writeLine("ANNIVERSARY:" + strings.ReplaceAll(contact.Anniversary, "-", ""))
+	}
+	if contact.Notes != "" {
+		writeLine("NOTE:" + escapeVCardText(contact.Notes))
+	}
+
+	writeLine("END:VCARD")
+
+	return b.String()
+}
+
+// vCardTypeParam returns a ;TYPE= parameter for the given type, mapping
+// JSContact-style types onto vCard's vocabulary.
+func vCardTypeParam(t string) string {
+	switch strings.ToLower(t) {
+	case "":
+		return ""
+	case "mobile":
+		return ";TYPE=cell"
+	default:
+		return ";TYPE=" + strings.ToLower(t)
+	}
+}
+
+// escapeVCardText escapes a TEXT value per RFC 6350 section 3.4.
+func escapeVCardText(s string) string {
+	var b strings.Builder
+	for _, r := range s {
+		switch r {
+		case '\\':
+			b.WriteString(`\\`)
+		case ',':
+			b.WriteString(`\,`)
+		case ';':
+			b.WriteString(`\;`)
+		case '\n':
+			b.WriteString(`\n`)
+		case '\r':
+			// Dropped; CRLF in values is represented by \n alone.
+		default:
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+// foldVCardLine folds a content line so that no physical line exceeds 75
+// octets, never splitting a multi-byte UTF-8 sequence. Continuation lines
+// start with a single space.
+func foldVCardLine(line string) string {
+	if len(line) <= vCardMaxLineOctets {
+		return line
+	}
+
+	var b strings.Builder
+	lineLen := 0
+	for len(line) > 0 {
+		_, size := utf8.DecodeRuneInString(line)
+		if lineLen+size > vCardMaxLineOctets {
+			b.WriteString("\r\n ")
+			// The leading space counts toward the continuation line's length.
+			lineLen = 1
+		}
+		b.WriteString(line[:size])
+		lineLen += size
+		line = line[size:]
+	}
+	return b.String()
+}
+
+// ContactImportError reports vCards that could not be imported.
+// Contacts that were created successfully are still returned alongside it.
+type ContactImportError struct {
+	Failed map[string]string // card label -> error message
+}
+
+func (e *ContactImportError) Error() string {
+	labels := make([]string, 0, len(e.Failed))
+	for label := range e.Failed {
+		labels = append(labels, label)
+	}
+	sort.Strings(labels)
+
+	parts := make([]string, len(labels))
+	for i, label := range labels {
+		parts[i] = fmt.Sprintf("%s: %s", label, e.Failed[label])
+	}
+	return fmt.Sprintf("failed to import %d vCard(s): %s", len(e.Failed), strings.Join(parts, "; "))
+}
+
+// ImportContactVCard parses one or more vCards (4.0, and tolerantly 3.0/2.1)
+// and creates a contact for each. A card that fails to parse or create does not
+// abort the import; failures are reported in a *ContactImportError returned
+// together with the contacts that were created.
+func (c *Client) ImportContactVCard(ctx context.Context, vcardData string) ([]*Contact, error) {
+	cards := parseVCards(vcardData)
+	if len(cards) == 0 {
+		return nil, fmt.Errorf("no vCards found")
+	}
+
+	created := []*Contact{}
+	failed := map[string]string{}
+	for _, card := range cards {
+		if card.err != nil {
+			failed[card.label] = card.err.Error()
+			continue
+		}
+
+		contact, err := c.CreateContact(ctx, card.contact)
+		if err != nil {
+			failed[card.label] = err.Error()
+			continue
+		}
+		created = append(created, contact)
+	}
+
+	if len(failed) > 0 {
+		return created, &ContactImportError{Failed: failed}
+	}
+	return created, nil
+}
+
+// parsedVCard is the result of parsing a single BEGIN:VCARD ... END:VCARD block.
+type parsedVCard struct {
+	label   string // "card N" or "card N (Name)", for error reporting
+	contact *Contact
+	err     error
+}
+
+// vCardProperty is a single unfolded content line.
+type vCardProperty struct {
+	name   string
+	params map[string][]string
+	value  string
+}
+
+// parseVCards splits data into cards and parses each one.
+func parseVCards(data string) []parsedVCard {
+	var cards []parsedVCard
+	var current []vCardProperty
+	inCard := false
+
+	flush := func(err error) {
+		card := parsedVCard{label: fmt.Sprintf("card %d", len(cards)+1)}
+		if err == nil {
+			card.contact, err = vCardToContact(current)
+		}
+		if card.contact != nil && card.contact.Name != "" {
+			card.label = fmt.Sprintf("%s (%s)", card.label, card.contact.Name)
+		}
+		card.err = err
+		cards = append(cards, card)
+		current = nil
+	}
+
+	for _, line := range unfoldVCardLines(data) {
+		prop, ok := parseVCardLine(line)
+		if !ok {
+			continue
+		}
+
+		switch {
+		case prop.name == "BEGIN" && strings.EqualFold(prop.value, "VCARD"):
+			if inCard {
+				flush(fmt.Errorf("missing END:VCARD"))
+			}
+			inCard = true
+		case prop.name == "END" && strings.EqualFold(prop.value, "VCARD"):
+			if inCard {
+				flush(nil)
+			}
+			inCard = false
+		case inCard:
+			current = append(current, prop)
+		}
+	}
+
+	if inCard {
+		flush(fmt.Errorf("missing END:VCARD"))
+	}
+
+	return cards
+}
+
+// unfoldVCardLines normalizes line endings and joins folded lines
+// (RFC 6350 section 3.2), plus quoted-printable soft line breaks used by vCard 2.1.
+func unfoldVCardLines(data string) []string {
+	data = strings.ReplaceAll(data, "\r\n", "\n")
+	data = strings.ReplaceAll(data, "\r", "\n")
+
+	var lines []string
+	for _, raw := range strings.Split(data, "\n") {
+		if len(lines) > 0 && (strings.HasPrefix(raw, " ") || strings.HasPrefix(raw, "\t")) {
+			lines[len(lines)-1] += raw[1:]
+			continue
+		}
+		if len(lines) > 0 && isQuotedPrintableContinuation(lines[len(lines)-1]) {
+			last := lines[len(lines)-1]
+			lines[len(lines)-1] = last[:len(last)-1] + raw
+			continue
+		}
+		lines = append(lines, raw)
+	}
+
+	return lines
+}
+
+// isQuotedPrintableContinuation reports whether line is a quoted-printable
+// value ending in a soft line break ("=").
+func isQuotedPrintableContinuation(line string) bool {
+	if !strings.HasSuffix(line, "=") {
+		return false
+	}
+	colon := strings.Index(line, ":")
+	if colon < 0 {
+		return false
+	}
+	return strings.Contains(strings.ToUpper(line[:colon]), "QUOTED-PRINTABLE")
+}
+
+// parseVCardLine parses "[group.]NAME;PARAM=VALUE:value" into a property.
+func parseVCardLine(line string) (vCardProperty, bool) {
+	if strings.TrimSpace(line) == "" {
+		return vCardProperty{}, false
+	}
+
+	// The value starts after the first colon that is not inside a quoted parameter.
+	colon := -1
+	inQuotes := false
+	for i, r := range line {
+		if r == '"' {
+			inQuotes = !inQuotes
+		} else if r == ':' && !inQuotes {
+			colon = i
+			break
+		}
+	}
+	if colon < 0 {
+		return vCardProperty{}, false
+	}
+
+	head, value := line[:colon], line[colon+1:]
+	segments := strings.Split(head, ";")
+
+	name := strings.ToUpper(segments[0])
+	if dot := strings.LastIndex(name, "."); dot >= 0 {
+		name = name[dot+1:] // strip group prefix (e.g. item1.EMAIL)
+	}
+
+	params := map[string][]string{}
+	for _, seg := range segments[1:] {
+		key, val, hasValue := strings.Cut(seg, "=")
+		key = strings.ToUpper(strings.TrimSpace(key))
+		if !hasValue {
+			// vCard 2.1/3.0 bare parameters, e.g. TEL;WORK;VOICE or ;QUOTED-PRINTABLE
+			switch key {
+			case "QUOTED-PRINTABLE", "BASE64", "B":
+				params["ENCODING"] = append(params["ENCODING"], key)
+			default:
+				params["TYPE"] = append(params["TYPE"], strings.ToLower(key))
+			}
+			continue
+		}
+		for _, v := range strings.Split(strings.Trim(val, `"`), ",") {
+			if key == "TYPE" {
+				v = strings.ToLower(v)
+			}
+			params[key] = append(params[key], v)
+		}
+	}
+
+	return vCardProperty{name: name, params: params, value: value}, true
+}
+
+// decodedValue returns the property value with any ENCODING applied.
+func (p vCardProperty) decodedValue() (string, error) {
+	for _, enc := range p.params["ENCODING"] {
+		switch strings.ToUpper(enc) {
+		case "QUOTED-PRINTABLE":
+			decoded, err := io.ReadAll(quotedprintable.NewReader(strings.NewReader(p.value)))
+			if err != nil {
+				return "", fmt.Errorf("%s: invalid quoted-printable value: %w", p.name, err)
+			}
+			return string(decoded), nil
+		case "B", "BASE64":
+			decoded, err := base64.StdEncoding.DecodeString(strings.TrimSpace(p.value))
+			if err != nil {
+				return "", fmt.Errorf("%s: invalid base64 value: %w", p.name, err)
+			}
+			return string(decoded), nil
+		}
+	}
+	return p.value, nil
+}
+
+// contactType returns the first meaningful TYPE parameter, mapped to the
+// JSContact-style vocabulary used by Contact.
+func (p vCardProperty) contactType() string {
+	for _, t := range p.params["TYPE"] {
+		switch t {
+		case "pref", "internet", "voice", "x400":
+			continue
+		case "cell":
+			return "mobile"
+		default:
+			return t
+		}
+	}
+	return ""
+}
+
+// importedVCardProperties lists the properties mapped onto Contact fields.
+var importedVCardProperties = map[string]bool{
+	"FN": true, "N": true, "EMAIL": true, "TEL": true, "ORG": true, "TITLE": true,
+	"ADR": true, "URL": true, "NOTE": true, "BDAY": true, "ANNIVERSARY": true,
+}
+
+// vCardToContact converts the properties of one card into a Contact.
+func vCardToContact(props []vCardProperty) (*Contact, error) {
+	contact := &Contact{}
+	var structuredName []string
+
+	for _, prop := range props {
+		if !importedVCardProperties[prop.name] {
+			continue // e.g. PHOTO, PRODID, REV
+		}
+
+		value, err := prop.decodedValue()
+		if err != nil {
+			return nil, err
+		}
+
+		switch prop.name {
+		case "FN":
+			contact.Name = unescapeVCardText(value)
+		case "N":
+			structuredName = splitVCardComponents(value)
+		case "EMAIL":
+			contact.Emails = append(contact.Emails, ContactEmail{Type: prop.contactType(), Value: unescapeVCardText(value)})
+		case "TEL":
+			contact.Phones = append(contact.Phones, ContactPhone{Type: prop.contactType(), Value: strings.TrimPrefix(unescapeVCardText(value), "tel:")})
+		case "ORG":
+			if components := splitVCardComponents(value); len(components) > 0 {
+				contact.Company = components[0]
+			}
+		case "TITLE":
+			contact.JobTitle = unescapeVCardText(value)
+		case "ADR":
+			components := splitVCardComponents(value)
+			for len(components) < 7 {
+				components = append(components, "")
+			}
+			contact.Addresses = append(contact.Addresses, ContactAddress{
+				Type:       prop.contactType(),
+				Street:     components[2],
+				City:       components[3],
+				State:      components[4],
+				PostalCode: components[5],
+				Country:    components[6],
+			})
+		case "URL":
+			contact.Online = append(contact.Online, ContactOnline{Type: "uri", Value: unescapeVCardText(value)})
+		case "NOTE":
+			contact.Notes = unescapeVCardText(value)
+		case "BDAY":
+			contact.Birthday = normalizeVCardDate(value)
+		case "ANNIVERSARY":
+			contact.Anniversary = normalizeVCardDate(value)
+		}
+	}
+
+	if contact.Name == "" && len(structuredName) >= 2 {
+		// N components: family; given; additional; prefixes; suffixes
+		contact.Name = strings.TrimSpace(structuredName[1] + " " + structuredName[0])
+	}
+	if contact.Name == "" {
+		return nil, fmt.Errorf("missing FN (formatted name)")
+	}
+
+	return contact, nil
+}
+
+// splitVCardComponents splits a structured value on unescaped semicolons and
+// unescapes each component.
+func splitVCardComponents(value string) []string {
+	var components []string
+	var b strings.Builder
+	escaped := false
+	for _, r := range value {
+		switch {
+		case escaped:
+			b.WriteRune('\\')
+			b.WriteRune(r)
+			escaped = false
+		case r == '\\':
+			escaped = true
+		case r == ';':
+			components = append(components, unescapeVCardText(b.String()))
+			b.Reset()
+		default:
+			b.WriteRune(r)
+		}
+	}
+	components = append(components, unescapeVCardText(b.String()))
+	return components
+}
+
+// unescapeVCardText reverses escapeVCardText.
+func unescapeVCardText(s string) string {
+	var b strings.Builder
+	escaped := false
+	for _, r := range s {
+		if !escaped {
+			if r == '\\' {
+				escaped = true
+				continue
+			}
+			b.WriteRune(r)
+			continue
+		}
+		switch r {
+		case 'n', 'N':
+			b.WriteRune('\n')
+		default:
+			b.WriteRune(r)
+		}
+		escaped = false
+	}
+	return b.String()
+}
+
+// normalizeVCardDate converts a basic-format date (19900401) to YYYY-MM-DD.
+// Other forms (already extended, or partial like --0401) are returned unchanged.
+func normalizeVCardDate(s string) string {
+	s = strings.TrimSpace(s)
+	if len(s) == 8 && strings.Trim(s, "0123456789") == "" {
+		return s[:4] + "-" + s[4:6] + "-" + s[6:]
+	}
+	return s
+}
@@ -0,0 +1,195 @@
+package jmap
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+func TestContactToVCard(t *testing.T) {
+	contact := &Contact{
+		ID:        "c1",
+		Name:      "Smith, Jane",
+		Emails:    []ContactEmail{{Type: "work", Value: "jane@example.com"}},
+		Phones:    []ContactPhone{{Type: "mobile", Value: "+1-555-1234"}},
+		Company:   "Acme; Inc",
+		Addresses: []ContactAddress{{Type: "home", Street: "1 Main St, Apt 2", City: "Springfield", Country: "US"}},
+		Notes:     "line one\nline two",
+		Birthday:  "1990-04-01",
+	}
+
+	got := ContactToVCard(contact)
+
+	want := []string{
+		"BEGIN:VCARD",
+		"VERSION:4.0",
+		"UID:c1",
+		`FN:Smith\, Jane`,
+		"EMAIL;TYPE=work:jane@example.com",
+		"TEL;TYPE=cell:+1-555-1234",
+		`ORG:Acme\; Inc`,
+		`ADR;TYPE=home:;;1 Main St\, Apt 2;Springfield;;;US`,
+		"BDAY:19900401",
+		`NOTE:line one\nline two`,
+		"END:VCARD",
+	}
+	if wantText := strings.Join(want, "\r\n") + "\r\n"; got != wantText {
+		t.Errorf("ContactToVCard() =\n%q\nwant\n%q", got, wantText)
+	}
+}
+
+func TestContactToVCard_UsesCRLF(t *testing.T) {
+	got := ContactToVCard(&Contact{Name: "Jane"})
+
+	if strings.Contains(strings.ReplaceAll(got, "\r\n", ""), "\n") {
+		t.Errorf("expected only CRLF line endings, got %q", got)
+	}
+	if !strings.HasSuffix(got, "END:VCARD\r\n") {
+		t.Errorf("expected trailing CRLF after END:VCARD, got %q", got)
+	}
+}
+
+func TestFoldVCardLine(t *testing.T) {
+	tests := []struct {
+		name string
+		line string
+	}{
+		{name: "ascii", line: "NOTE:" + strings.Repeat("a", 200)},
+		{name: "multibyte", line: "NOTE:" + strings.Repeat("é", 100)},
+		{name: "emoji", line: "NOTE:" + strings.Repeat("😀", 50)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			folded := foldVCardLine(tt.line)
+
+			physical := strings.Split(folded, "\r\n")
+			if len(physical) < 2 {
+				t.Fatalf("expected line to be folded, got %q", folded)
+			}
+			for i, p := range physical {
+				if len(p) > vCardMaxLineOctets {
+					t.Errorf("physical line %d is %d octets, want <= %d", i, len(p), vCardMaxLineOctets)
+				}
+				if !utf8.ValidString(p) {
+					t.Errorf("physical line %d splits a UTF-8 sequence: %q", i, p)
+				}
+				if i > 0 && !strings.HasPrefix(p, " ") {
+					t.Errorf("continuation line %d does not start with a space: %q", i, p)
+				}
+			}
+
+			unfolded := strings.ReplaceAll(folded, "\r\n ", "")
+			if unfolded != tt.line {
+				t.Errorf("unfolded line does not match original")
+			}
+		})
+	}
+}
+
+func TestFoldVCardLine_ShortLineUnchanged(t *testing.T) {
+	line := "FN:Jane"
+	if got := foldVCardLine(line); got != line {
+		t.Errorf("foldVCardLine(%q) = %q, want unchanged", line, got)
+	}
+}
+
+func TestParseVCards_RoundTripsExport(t *testing.T) {
+	contact := &Contact{
+		Name:      "Smith, Jane",
+		Emails:    []ContactEmail{{Type: "work", Value: "jane@example.com"}},
+		Phones:    []ContactPhone{{Type: "mobile", Value: "+1-555-1234"}},
+		Company:   "Acme; Inc",
+		JobTitle:  "Engineer",
+		Addresses: []ContactAddress{{Type: "home", Street: "1 Main St, Apt 2", City: "Springfield", Country: "US"}},
+		Online:    []ContactOnline{{Type: "uri", Value: "https://example.com"}},
+		Notes:     strings.Repeat("A long note with ünïcödé, commas; and semicolons. ", 5) + "\nSecond line",
+		Birthday:  "1990-04-01",
+	}
+
+	cards := parseVCards(ContactToVCard(contact))
+	if len(cards) != 1 || cards[0].err != nil {
+		t.Fatalf("parseVCards() = %+v", cards)
+	}
+	if !reflect.DeepEqual(cards[0].contact, contact) {
+		t.Errorf("round-tripped contact =\n%+v\nwant\n%+v", cards[0].contact, contact)
+	}
+}
+
+func TestParseVCards_Version3QuotedPrintableAndBase64(t *testing.T) {
+	data := "BEGIN:VCARD\n" +
+		"VERSION:3.0\n" +
+		"N:Müller;Jürgen;;;\n" +
+		"item1.EMAIL;TYPE=INTERNET,WORK:jm@example.com\n" +
+		"TEL;CELL:+49 170 1234\n" +
+		"NOTE;ENCODING=QUOTED-PRINTABLE;CHARSET=UTF-8:Gr=C3=BC=C3=9Fe aus =\n" +
+		"M=C3=BCnchen\n" +
+		"TITLE;ENCODING=b:Q2hlZiDDqXF1aXBl\n" +
+		"PHOTO;ENCODING=b;TYPE=JPEG:not-really-base64!\n" +
+		"END:VCARD\n"
+
+	cards := parseVCards(data)
+	if len(cards) != 1 || cards[0].err != nil {
+		t.Fatalf("parseVCards() = %+v", cards)
+	}
+
+	got := cards[0].contact
+	if got.Name != "Jürgen Müller" {
+		t.Errorf("Name = %q, want name built from N", got.Name)
+	}
+	if len(got.Emails) != 1 || got.Emails[0] != (ContactEmail{Type: "work", Value: "jm@example.com"}) {
+		t.Errorf("Emails = %+v", got.Emails)
+	}
+	if len(got.Phones) != 1 || got.Phones[0].Type != "mobile" {
+		t.Errorf("Phones = %+v", got.Phones)
+	}
+	if got.Notes != "Grüße aus München" {
+		t.Errorf("Notes = %q, want decoded quoted-printable", got.Notes)
+	}
+	if got.JobTitle != "Chef équipe" {
+		t.Errorf("JobTitle = %q, want decoded base64", got.JobTitle)
+	}
+}
+
+func TestParseVCards_MultipleCardsWithFailures(t *testing.T) {
+	data := "BEGIN:VCARD\r\nVERSION:4.0\r\nFN:Alice\r\nEND:VCARD\r\n" +
+		"BEGIN:VCARD\r\nVERSION:4.0\r\nEMAIL:nobody@example.com\r\nEND:VCARD\r\n" +
+		"BEGIN:VCARD\r\nVERSION:4.0\r\nFN:Bob\r\n"
+
+	cards := parseVCards(data)
+	if len(cards) != 3 {
+		t.Fatalf("expected 3 cards, got %d", len(cards))
+	}
+	if cards[0].err != nil || cards[0].contact.Name != "Alice" {
+		t.Errorf("card 1 = %+v", cards[0])
+	}
+	if cards[1].err == nil || !strings.Contains(cards[1].err.Error(), "FN") {
+		t.Errorf("card 2 should fail for missing FN, got %+v", cards[1])
+	}
+	if cards[2].err == nil || !strings.Contains(cards[2].err.Error(), "END:VCARD") {
+		t.Errorf("card 3 should fail for missing END, got %+v", cards[2])
+	}
+}
+
+func TestImportContactVCard_ReportsPartialFailures(t *testing.T) {
+	client, _ := newContactStoreServer(t)
+
+	data := "BEGIN:VCARD\r\nVERSION:4.0\r\nFN:Alice\r\nEND:VCARD\r\n" +
+		"BEGIN:VCARD\r\nVERSION:4.0\r\nEMAIL:nobody@example.com\r\nEND:VCARD\r\n"
+
+	created, err := client.ImportContactVCard(context.Background(), data)
+
+	var importErr *ContactImportError
+	if !errors.As(err, &importErr) {
+		t.Fatalf("expected ContactImportError, got %v", err)
+	}
+	if len(created) != 1 || created[0].Name != "Alice" {
+		t.Errorf("created = %+v, want Alice", created)
+	}
+	if _, ok := importErr.Failed["card 2"]; !ok || len(importErr.Failed) != 1 {
+		t.Errorf("Failed = %v, want only card 2", importErr.Failed)
+	}
+}
@@ -0,0 +1,74 @@
+// Package maskmap persists which masked email address the CLI used for each
+// outbound correspondent, so later messages to them reuse the same alias.
+package maskmap
+
+import (
+	"strings"
+	"time"
+
+	"github.com/salmonumbrella/fastmail-cli/internal/config"
+)
+
+// Entry records the masked address used for a recipient.
+type Entry struct {
+	Account     string    `json:"account"`
+	Recipient   string    `json:"recipient"`
+	MaskedEmail string    `json:"masked_email"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+// State is the on-disk recipient mapping, keyed by account and recipient.
+type State struct {
+	Recipients map[string]Entry `json:"recipients"`
+}
+
+// stateFile is the name of the state file in the config directory.
+const stateFile = "masked-recipients.json"
+
+// Load reads the mapping from disk. A missing file yields an empty state.
+func Load() (*State, error) {
+	var state State
+	if err := config.LoadJSONState(stateFile, "masked recipient map", &state); err != nil {
+		return nil, err
+	}
+	if state.Recipients == nil {
+		state.Recipients = map[string]Entry{}
+	}
+	return &state, nil
+}
+
+// Save writes the mapping to disk
+func (s *State) Save() error {
+	return config.SaveJSONState(stateFile, "masked recipient map", s)
+}
+
+// Lookup returns the masked address previously used for recipient, if any.
+func (s *State) Lookup(account, recipient string) (string, bool) {
+	e, ok := s.Recipients[key(account, recipient)]
+	if !ok {
+		return "", false
+	}
+	return e.MaskedEmail, true
+}
+
+// Set records (or replaces) the masked address used for recipient.
+func (s *State) Set(account, recipient, maskedEmail string, now time.Time) {
+	if s.Recipients == nil {
+		s.Recipients = map[string]Entry{}
+	}
+	s.Recipients[key(account, recipient)] = Entry{
+		Account:     strings.ToLower(account),
+		Recipient:   strings.ToLower(recipient),
+		MaskedEmail: maskedEmail,
+		CreatedAt:   now,
+	}
+}
+
+// Remove forgets the mapping for recipient.
+func (s *State) Remove(account, recipient string) {
+	delete(s.Recipients, key(account, recipient))
+}
+
+func key(account, recipient string) string {
+	return strings.ToLower(account) + "|" + strings.ToLower(strings.TrimSpace(recipient))
+}
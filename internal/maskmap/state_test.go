@@ -0,0 +1,44 @@
+package maskmap
+
+import (
+	"testing"
+	"time"
+)
+
+func TestStateRoundTrip(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+
+	state, err := Load()
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if len(state.Recipients) != 0 {
+		t.Fatalf("expected empty state, got %v", state.Recipients)
+	}
+
+	now := time.Date(2025, 1, 10, 9, 0, 0, 0, time.UTC)
+	state.Set("User@Example.com", "Vendor@Shop.com", "shop.1234@fastmail.com", now)
+
+	if err := state.Save(); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+
+	loaded, err := Load()
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+
+	masked, ok := loaded.Lookup("user@example.com", "vendor@shop.com")
+	if !ok || masked != "shop.1234@fastmail.com" {
+		t.Fatalf("Lookup() = %q, %v; want shop.1234@fastmail.com", masked, ok)
+	}
+	if _, ok := loaded.Lookup("other@example.com", "vendor@shop.com"); ok {
+		t.Error("Lookup() matched a different account")
+	}
+
+	loaded.Remove("user@example.com", "vendor@shop.com")
+	if _, ok := loaded.Lookup("user@example.com", "vendor@shop.com"); ok {
+		t.Error("Lookup() after Remove() still found entry")
+	}
+}
@@ -0,0 +1,64 @@
+package outfmt
+
+import (
+	"os"
+
+	"golang.org/x/term"
+)
+
+// ANSI sequences for table cells. Print styled tables with a
+// StyledTabWriter, which doesn't count them as cell width.
+const (
+	ansiBold   = "\x1b[1m"
+	ansiDim    = "\x1b[2m"
+	ansiYellow = "\x1b[33m"
+	ansiReset  = "\x1b[0m"
+)
+
+// TableStyle colors table cells. The zero value leaves text unchanged.
+type TableStyle struct {
+	color bool
+}
+
+// NewTableStyle returns the style for tables printed to stdout. colorMode is
+// the --color value (auto|always|never). NO_COLOR disables color, and in auto
+// mode so does a stdout that isn't a terminal or a dumb terminal.
+func NewTableStyle(colorMode string) TableStyle {
+	if os.Getenv("NO_COLOR") != "" {
+		return TableStyle{}
+	}
+	switch colorMode {
+	case "always":
+		return TableStyle{color: true}
+	case "never":
+		return TableStyle{}
+	}
+	return TableStyle{color: term.IsTerminal(int(os.Stdout.Fd())) && os.Getenv("TERM") != "dumb"}
+}
+
+// Enabled reports whether cells are colored.
+func (s TableStyle) Enabled() bool {
+	return s.color
+}
+
+// Unread bolds text, e.g. the subject of an unread email.
+func (s TableStyle) Unread(text string) string {
+	return s.wrap(ansiBold, text)
+}
+
+// Read dims text, e.g. the subject of an email that has been read.
+func (s TableStyle) Read(text string) string {
+	return s.wrap(ansiDim, text)
+}
+
+// Flagged colors text yellow, e.g. the FLAGGED marker of a flagged email.
+func (s TableStyle) Flagged(text string) string {
+	return s.wrap(ansiYellow, text)
+}
+
+func (s TableStyle) wrap(code, text string) string {
+	if !s.color {
+		return text
+	}
+	return code + text + ansiReset
+}
@@ -0,0 +1,31 @@
+package outfmt
+
+import (
+	"encoding/csv"
+	"io"
+	"iter"
+	"os"
+)
+
+// WriteCSV writes RFC 4180 CSV to w: a header row of columns followed by
+// every row from rows. Fields containing commas, quotes or line breaks are
+// quoted.
+func WriteCSV(w io.Writer, columns []string, rows iter.Seq[[]string]) error {
+	cw := csv.NewWriter(w)
+	cw.UseCRLF = true
+	if err := cw.Write(columns); err != nil {
+		return err
+	}
+	for row := range rows {
+		if err := cw.Write(row); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// PrintCSV writes CSV to stdout.
+func PrintCSV(columns []string, rows iter.Seq[[]string]) error {
+	return WriteCSV(os.Stdout, columns, rows)
+}
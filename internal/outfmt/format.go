@@ -14,6 +14,8 @@ type Mode int
 const (
 	Text Mode = iota
 	JSON
+	NDJSON
+	CSV
 )
 
 // WriteJSON writes v as indented JSON to w.
@@ -0,0 +1,51 @@
+package outfmt
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/salmonumbrella/fastmail-cli/internal/filter"
+)
+
+// NDJSONWriter writes newline-delimited JSON: one compact value per line,
+// written as soon as it is produced so consumers can process items while a
+// command is still running.
+type NDJSONWriter struct {
+	enc   *json.Encoder
+	query string
+}
+
+// NewNDJSONWriter returns a writer to w. A non-empty JQ query is applied to
+// each value separately, and every value the query produces gets its own line.
+func NewNDJSONWriter(w io.Writer, query string) *NDJSONWriter {
+	return &NDJSONWriter{enc: json.NewEncoder(w), query: query}
+}
+
+// Write writes v as one line.
+func (n *NDJSONWriter) Write(v any) error {
+	if n.query == "" {
+		return n.enc.Encode(v)
+	}
+
+	// gojq expects JSON-compatible types, not Go structs
+	jsonBytes, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Errorf("failed to marshal data for filtering: %w", err)
+	}
+	var jsonData any
+	if err = json.Unmarshal(jsonBytes, &jsonData); err != nil {
+		return fmt.Errorf("failed to unmarshal data for filtering: %w", err)
+	}
+
+	results, err := filter.ApplyAll(jsonData, n.query)
+	if err != nil {
+		return err
+	}
+	for _, result := range results {
+		if err := n.enc.Encode(result); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -1,16 +1,85 @@
 package outfmt
 
 import (
+	"bytes"
+	"io"
 	"os"
+	"regexp"
 	"strings"
 	"text/tabwriter"
+	"unicode/utf8"
 )
 
+// tablePadding is the space between columns, as in NewTabWriter.
+const tablePadding = 2
+
 // NewTabWriter returns a tabwriter configured for stdout.
 func NewTabWriter() *tabwriter.Writer {
 	return tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
 }
 
+// StyledTabWriter lays out tab-separated lines like NewTabWriter, but pads
+// cells by their visible width: tabwriter counts ANSI escape sequences as
+// text, so cells styled with TableStyle would push their column out of line.
+// Lines are buffered until Flush.
+type StyledTabWriter struct {
+	out io.Writer
+	buf bytes.Buffer
+}
+
+// NewStyledTabWriter returns a StyledTabWriter for stdout.
+func NewStyledTabWriter() *StyledTabWriter {
+	return &StyledTabWriter{out: os.Stdout}
+}
+
+func (w *StyledTabWriter) Write(p []byte) (int, error) {
+	return w.buf.Write(p)
+}
+
+// Flush writes the buffered lines with every tab-terminated cell padded to
+// the widest cell of its column. The last cell of a line is not padded.
+func (w *StyledTabWriter) Flush() error {
+	text := strings.TrimSuffix(w.buf.String(), "\n")
+	w.buf.Reset()
+	if text == "" {
+		return nil
+	}
+
+	lines := strings.Split(text, "\n")
+	rows := make([][]string, len(lines))
+	var widths []int
+	for i, line := range lines {
+		rows[i] = strings.Split(line, "\t")
+		for col, cell := range rows[i][:len(rows[i])-1] {
+			if col == len(widths) {
+				widths = append(widths, 0)
+			}
+			widths[col] = max(widths[col], visibleWidth(cell))
+		}
+	}
+
+	var out strings.Builder
+	for _, row := range rows {
+		for col, cell := range row {
+			out.WriteString(cell)
+			if col < len(row)-1 {
+				out.WriteString(strings.Repeat(" ", widths[col]-visibleWidth(cell)+tablePadding))
+			}
+		}
+		out.WriteByte('\n')
+	}
+	_, err := io.WriteString(w.out, out.String())
+	return err
+}
+
+var ansiSequence = regexp.MustCompile("\x1b\\[[0-9;]*m")
+
+// visibleWidth returns the number of characters s takes up on a terminal,
+// ignoring ANSI color sequences.
+func visibleWidth(s string) int {
+	return utf8.RuneCountInString(ansiSequence.ReplaceAllString(s, ""))
+}
+
 // SanitizeTab replaces tab characters with spaces for clean tabwriter output.
 func SanitizeTab(s string) string {
 	return strings.ReplaceAll(s, "\t", " ")
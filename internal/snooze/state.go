@@ -0,0 +1,82 @@
+// Package snooze persists wake times for emails snoozed by the CLI.
+//
+// JMAP has no standard snooze, so the CLI moves snoozed emails to a Snoozed
+// mailbox and records when each one should return to the inbox here.
+package snooze
+
+import (
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/salmonumbrella/fastmail-cli/internal/config"
+)
+
+// Entry records when a snoozed email should be woken.
+type Entry struct {
+	EmailID string    `json:"email_id"`
+	Account string    `json:"account"`
+	Until   time.Time `json:"until"`
+}
+
+// State is the on-disk snooze state, keyed by email ID.
+type State struct {
+	Emails map[string]Entry `json:"emails"`
+}
+
+// stateFile is the name of the state file in the config directory.
+const stateFile = "snooze.json"
+
+// Load reads the snooze state from disk. A missing file yields an empty state.
+func Load() (*State, error) {
+	var state State
+	if err := config.LoadJSONState(stateFile, "snooze state", &state); err != nil {
+		return nil, err
+	}
+	if state.Emails == nil {
+		state.Emails = map[string]Entry{}
+	}
+	return &state, nil
+}
+
+// Save writes the snooze state to disk
+func (s *State) Save() error {
+	return config.SaveJSONState(stateFile, "snooze state", s)
+}
+
+// Add records (or replaces) the wake time for an email.
+func (s *State) Add(account, emailID string, until time.Time) {
+	if s.Emails == nil {
+		s.Emails = map[string]Entry{}
+	}
+	s.Emails[emailID] = Entry{
+		EmailID: emailID,
+		Account: strings.ToLower(account),
+		Until:   until,
+	}
+}
+
+// Remove forgets the wake time for an email.
+func (s *State) Remove(emailID string) {
+	delete(s.Emails, emailID)
+}
+
+// Due returns entries for account whose wake time is at or before now,
+// ordered by wake time.
+func (s *State) Due(account string, now time.Time) []Entry {
+	account = strings.ToLower(account)
+
+	var due []Entry
+	for _, e := range s.Emails {
+		if e.Account != account || e.Until.After(now) {
+			continue
+		}
+		due = append(due, e)
+	}
+
+	sort.Slice(due, func(i, j int) bool {
+		return due[i].Until.Before(due[j].Until)
+	})
+
+	return due
+}
@@ -0,0 +1,43 @@
+package snooze
+
+import (
+	"testing"
+	"time"
+)
+
+func TestStateRoundTripAndDue(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+
+	state, err := Load()
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if len(state.Emails) != 0 {
+		t.Fatalf("expected empty state, got %v", state.Emails)
+	}
+
+	now := time.Date(2025, 1, 10, 9, 0, 0, 0, time.UTC)
+	state.Add("User@Example.com", "past", now.Add(-time.Hour))
+	state.Add("user@example.com", "future", now.Add(time.Hour))
+	state.Add("other@example.com", "other", now.Add(-time.Hour))
+
+	if err := state.Save(); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+
+	loaded, err := Load()
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+
+	due := loaded.Due("user@example.com", now)
+	if len(due) != 1 || due[0].EmailID != "past" {
+		t.Fatalf("Due() = %v, want only 'past'", due)
+	}
+
+	loaded.Remove("past")
+	if len(loaded.Due("user@example.com", now)) != 0 {
+		t.Fatal("expected no due entries after Remove")
+	}
+}
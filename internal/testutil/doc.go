@@ -5,8 +5,20 @@
 //   - JSON response handlers
 //   - Error response handlers
 //   - Custom handlers for complex scenarios
+//   - Sequenced and one-shot responses (HandleSequence, HandleOnce) for
+//     flows that hit the same path several times, with AssertConsumed to
+//     check every queued response was requested
+//   - Request recording (Requests, LastRequestBody, AssertRequestBody) to
+//     check the payload a client sent; the most recent DefaultMaxRequests
+//     are kept unless SetMaxRequests changes it
+//   - Retry testing: SetLatency, FailNext (n failures before handlers run
+//     again) and SetResponseHeader (e.g. Retry-After)
 //   - Thread-safe handler registration
 //
+// JMAPMock wraps MockServer with a JMAP session endpoint pointing back at the
+// same server, so a JMAP client can use its URL directly; API responses are
+// queued with QueueResponse or QueueMethodResponses.
+//
 // Example usage:
 //
 //	ms := testutil.NewMockServer()
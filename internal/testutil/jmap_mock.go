@@ -0,0 +1,109 @@
+package testutil
+
+import (
+	"encoding/json"
+	"net/http"
+	"sync"
+)
+
+// Paths served by JMAPMock. JMAPSessionPath matches jmap.SessionPath.
+const (
+	JMAPSessionPath = "/jmap/session"
+	JMAPAPIPath     = "/jmap/api/"
+)
+
+// JMAPAccountID is the account the JMAPMock session advertises.
+const JMAPAccountID = "acc123"
+
+// JMAPMock is a MockServer that serves a JMAP session pointing at itself,
+// so a client only needs its URL:
+//
+//	jm := testutil.NewJMAPMock()
+//	defer jm.Close()
+//	jm.QueueMethodResponses(testutil.MethodResponse{Name: "Mailbox/get", Args: map[string]any{"list": []any{}}})
+//	client := jmap.NewClientWithBaseURL("test-token", jm.URL())
+//
+// API responses are queued in call order and served like HandleSequence:
+// each once, then the last one again.
+type JMAPMock struct {
+	*MockServer
+
+	mu           sync.Mutex
+	capabilities map[string]any
+}
+
+// MethodResponse is one entry of a JMAP API response's methodResponses.
+type MethodResponse struct {
+	Name   string
+	Args   any
+	CallID string
+}
+
+// NewJMAPMock starts a JMAP mock with the core, mail and submission
+// capabilities. Downloads are served from
+// /jmap/download/{accountId}/{blobId}/{name}; register them with Handle.
+func NewJMAPMock() *JMAPMock {
+	jm := &JMAPMock{
+		MockServer: NewMockServer(),
+		capabilities: map[string]any{
+			"urn:ietf:params:jmap:core":       map[string]any{},
+			"urn:ietf:params:jmap:mail":       map[string]any{},
+			"urn:ietf:params:jmap:submission": map[string]any{},
+		},
+	}
+	jm.Handle(http.MethodGet, JMAPSessionPath, jm.serveSession)
+	return jm
+}
+
+// AddCapability advertises an extra capability in the session, e.g.
+// "urn:ietf:params:jmap:contacts".
+func (j *JMAPMock) AddCapability(uri string) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	j.capabilities[uri] = map[string]any{}
+}
+
+// QueueResponse queues a raw JSON body for the next API request.
+func (j *JMAPMock) QueueResponse(body string) {
+	j.queue(http.MethodPost, JMAPAPIPath, Response{Body: body})
+}
+
+// QueueMethodResponses queues an API response made of the given method
+// responses, in order.
+func (j *JMAPMock) QueueMethodResponses(responses ...MethodResponse) {
+	list := make([]any, len(responses))
+	for i, r := range responses {
+		args := r.Args
+		if args == nil {
+			args = map[string]any{}
+		}
+		list[i] = []any{r.Name, args, r.CallID}
+	}
+	j.queue(http.MethodPost, JMAPAPIPath, Response{Body: map[string]any{"methodResponses": list}})
+}
+
+func (j *JMAPMock) serveSession(w http.ResponseWriter, r *http.Request) {
+	j.mu.Lock()
+	caps := make(map[string]any, len(j.capabilities))
+	for uri, v := range j.capabilities {
+		caps[uri] = v
+	}
+	j.mu.Unlock()
+
+	base := j.URL()
+	w.Header().Set("Content-Type", "application/json")
+	//nolint:errcheck // test utility: encoding errors not actionable
+	json.NewEncoder(w).Encode(map[string]any{
+		"apiUrl":      base + JMAPAPIPath,
+		"uploadUrl":   base + "/jmap/upload/{accountId}/",
+		"downloadUrl": base + "/jmap/download/{accountId}/{blobId}/{name}?type={type}",
+		"accounts": map[string]any{
+			JMAPAccountID: map[string]any{"name": "test@example.com", "isPersonal": true},
+		},
+		"primaryAccounts": map[string]any{
+			"urn:ietf:params:jmap:mail": JMAPAccountID,
+		},
+		"capabilities": caps,
+	})
+}
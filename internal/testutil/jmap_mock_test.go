@@ -0,0 +1,74 @@
+package testutil
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestJMAPMock_Session(t *testing.T) {
+	jm := NewJMAPMock()
+	defer jm.Close()
+	jm.AddCapability("urn:ietf:params:jmap:contacts")
+
+	status, body := getBody(t, jm.URL()+JMAPSessionPath)
+	if status != http.StatusOK {
+		t.Fatalf("got status %d, want %d", status, http.StatusOK)
+	}
+
+	var session struct {
+		APIUrl       string         `json:"apiUrl"`
+		UploadURL    string         `json:"uploadUrl"`
+		Accounts     map[string]any `json:"accounts"`
+		Capabilities map[string]any `json:"capabilities"`
+	}
+	if err := json.Unmarshal([]byte(body), &session); err != nil {
+		t.Fatalf("decode failed: %v", err)
+	}
+	if session.APIUrl != jm.URL()+JMAPAPIPath {
+		t.Errorf("apiUrl = %q, want %q", session.APIUrl, jm.URL()+JMAPAPIPath)
+	}
+	if !strings.HasPrefix(session.UploadURL, jm.URL()) {
+		t.Errorf("uploadUrl = %q, want it on the mock server", session.UploadURL)
+	}
+	if _, ok := session.Accounts[JMAPAccountID]; !ok {
+		t.Errorf("accounts = %v, want %s", session.Accounts, JMAPAccountID)
+	}
+	for _, uri := range []string{"urn:ietf:params:jmap:mail", "urn:ietf:params:jmap:contacts"} {
+		if _, ok := session.Capabilities[uri]; !ok {
+			t.Errorf("capabilities = %v, want %s", session.Capabilities, uri)
+		}
+	}
+}
+
+func TestJMAPMock_QueuedResponses(t *testing.T) {
+	jm := NewJMAPMock()
+	defer jm.Close()
+
+	jm.QueueMethodResponses(MethodResponse{Name: "Mailbox/get", Args: map[string]any{"list": []any{}}, CallID: "m"})
+	jm.QueueResponse(`{"methodResponses": [["Email/set", {}, "s"]]}`)
+
+	post := func() string {
+		t.Helper()
+		resp, err := http.Post(jm.URL()+JMAPAPIPath, "application/json", strings.NewReader(`{"methodCalls": []}`))
+		if err != nil {
+			t.Fatalf("request failed: %v", err)
+		}
+		defer resp.Body.Close()
+		var got struct {
+			MethodResponses [][]any `json:"methodResponses"`
+		}
+		if err := json.NewDecoder(resp.Body).Decode(&got); err != nil {
+			t.Fatalf("decode failed: %v", err)
+		}
+		return got.MethodResponses[0][0].(string)
+	}
+
+	for i, want := range []string{"Mailbox/get", "Email/set", "Email/set"} {
+		if got := post(); got != want {
+			t.Errorf("request %d: got %s, want %s", i+1, got, want)
+		}
+	}
+	jm.AssertConsumed(t)
+}
@@ -1,30 +1,103 @@
 package testutil
 
 import (
+	"bytes"
 	"encoding/json"
+	"io"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"sync"
+	"testing"
+	"time"
 )
 
+// DefaultMaxRequests is how many requests a MockServer records by default.
+const DefaultMaxRequests = 100
+
 // MockServer provides HTTP mocking for API tests.
 type MockServer struct {
-	Server *httptest.Server
-	mu     sync.Mutex
-	routes map[string]map[string]http.HandlerFunc // method -> path -> handler
+	Server      *httptest.Server
+	mu          sync.Mutex
+	routes      map[string]map[string]http.HandlerFunc // method -> path -> handler
+	sequences   map[string]*sequence                   // "method path" -> queued responses
+	requests    []RecordedRequest
+	maxRequests int
+
+	latency    time.Duration
+	failNext   int
+	failStatus int
+	headers    http.Header
+}
+
+// RecordedRequest is a request the MockServer received.
+type RecordedRequest struct {
+	Method string
+	Path   string
+	Header http.Header
+	Body   []byte
+	// JSON is the body decoded as JSON, or nil if it isn't JSON
+	JSON any
+}
+
+// Response is one queued response for HandleSequence and HandleOnce.
+// A zero Status means 200. A string or []byte Body is written as is,
+// anything else is encoded as JSON.
+type Response struct {
+	Status int
+	Body   any
+}
+
+// sequence holds the queued responses for one route and how many were served.
+type sequence struct {
+	responses  []Response
+	served     int
+	repeatLast bool
 }
 
 // NewMockServer creates a test server.
 func NewMockServer() *MockServer {
 	ms := &MockServer{
-		routes: make(map[string]map[string]http.HandlerFunc),
+		routes:      make(map[string]map[string]http.HandlerFunc),
+		sequences:   make(map[string]*sequence),
+		maxRequests: DefaultMaxRequests,
 	}
 
 	ms.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		rec := recordRequest(r)
+
 		ms.mu.Lock()
+		if ms.maxRequests > 0 {
+			if len(ms.requests) >= ms.maxRequests {
+				ms.requests = append(ms.requests[:0], ms.requests[len(ms.requests)-ms.maxRequests+1:]...)
+			}
+			ms.requests = append(ms.requests, rec)
+		}
 		methodRoutes, ok := ms.routes[r.Method]
+		latency := ms.latency
+		failStatus := 0
+		if ms.failNext > 0 {
+			ms.failNext--
+			failStatus = ms.failStatus
+		}
+		for key, values := range ms.headers {
+			w.Header()[key] = append([]string(nil), values...)
+		}
 		ms.mu.Unlock()
 
+		if latency > 0 {
+			select {
+			case <-time.After(latency):
+			case <-r.Context().Done():
+				return
+			}
+		}
+
+		if failStatus != 0 {
+			writeStatus(w, failStatus, "injected failure")
+			return
+		}
+
 		if ok {
 			if handler, found := methodRoutes[r.URL.Path]; found {
 				handler(w, r)
@@ -32,14 +105,7 @@ func NewMockServer() *MockServer {
 			}
 		}
 
-		// Default 404 response
-		w.Header().Set("Content-Type", "application/json")
-		w.WriteHeader(http.StatusNotFound)
-		//nolint:errcheck // test utility: encoding errors not actionable
-		json.NewEncoder(w).Encode(map[string]string{
-			"error": "not found",
-			"path":  r.URL.Path,
-		})
+		writeNotFound(w, r)
 	}))
 
 	return ms
@@ -55,11 +121,123 @@ func (m *MockServer) URL() string {
 	return m.Server.URL
 }
 
+// recordRequest captures r and puts its body back for the handler.
+func recordRequest(r *http.Request) RecordedRequest {
+	body, _ := io.ReadAll(r.Body)
+	r.Body = io.NopCloser(bytes.NewReader(body))
+
+	rec := RecordedRequest{
+		Method: r.Method,
+		Path:   r.URL.Path,
+		Header: r.Header.Clone(),
+		Body:   body,
+	}
+	var parsed any
+	if json.Unmarshal(body, &parsed) == nil {
+		rec.JSON = parsed
+	}
+	return rec
+}
+
+// SetMaxRequests sets how many requests are kept; older ones are dropped
+// first. Zero or less turns recording off.
+func (m *MockServer) SetMaxRequests(n int) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.maxRequests = n
+	if n <= 0 {
+		m.requests = nil
+	} else if len(m.requests) > n {
+		m.requests = append([]RecordedRequest(nil), m.requests[len(m.requests)-n:]...)
+	}
+}
+
+// Requests returns the recorded requests, oldest first.
+func (m *MockServer) Requests() []RecordedRequest {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return append([]RecordedRequest(nil), m.requests...)
+}
+
+// LastRequestBody returns the JSON body of the most recent request, or nil
+// if there was none or it wasn't JSON.
+func (m *MockServer) LastRequestBody() any {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if len(m.requests) == 0 {
+		return nil
+	}
+	return m.requests[len(m.requests)-1].JSON
+}
+
+// AssertRequestBody fails t unless the JSON body of at least one recorded
+// request satisfies matcher.
+func (m *MockServer) AssertRequestBody(t testing.TB, matcher func(body any) bool) {
+	t.Helper()
+
+	requests := m.Requests()
+	for _, req := range requests {
+		if req.JSON != nil && matcher(req.JSON) {
+			return
+		}
+	}
+
+	bodies := make([]string, len(requests))
+	for i, req := range requests {
+		bodies[i] = req.Method + " " + req.Path + " " + string(req.Body)
+	}
+	t.Errorf("no recorded request body matched; got %d request(s):\n%s", len(requests), strings.Join(bodies, "\n"))
+}
+
+// SetLatency delays every response by d; the delay ends early if the client
+// gives up on the request.
+func (m *MockServer) SetLatency(d time.Duration) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.latency = d
+}
+
+// FailNext makes the next n requests, on any route, fail with status before
+// the registered handlers run again.
+func (m *MockServer) FailNext(n, status int) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.failNext = n
+	m.failStatus = status
+}
+
+// SetResponseHeader adds a header to every response, including injected
+// failures (e.g. Retry-After). An empty value removes it.
+func (m *MockServer) SetResponseHeader(key, value string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.headers == nil {
+		m.headers = make(http.Header)
+	}
+	if value == "" {
+		m.headers.Del(key)
+		return
+	}
+	m.headers.Set(key, value)
+}
+
 // Handle registers a handler for a path and method.
 func (m *MockServer) Handle(method, path string, handler http.HandlerFunc) {
 	m.mu.Lock()
 	defer m.mu.Unlock()
 
+	m.setRoute(method, path, handler)
+	delete(m.sequences, method+" "+path)
+}
+
+// setRoute registers handler; m.mu must be held.
+func (m *MockServer) setRoute(method, path string, handler http.HandlerFunc) {
 	if m.routes[method] == nil {
 		m.routes[method] = make(map[string]http.HandlerFunc)
 	}
@@ -79,12 +257,121 @@ func (m *MockServer) HandleJSON(method, path string, statusCode int, response an
 // HandleError registers a handler that returns an error response.
 func (m *MockServer) HandleError(method, path string, statusCode int, message string) {
 	m.Handle(method, path, func(w http.ResponseWriter, r *http.Request) {
-		w.Header().Set("Content-Type", "application/json")
-		w.WriteHeader(statusCode)
-		//nolint:errcheck // test utility: encoding errors not actionable
-		json.NewEncoder(w).Encode(map[string]string{
-			"error":   http.StatusText(statusCode),
-			"message": message,
-		})
+		writeStatus(w, statusCode, message)
+	})
+}
+
+// writeStatus writes an error response as HandleError does.
+func writeStatus(w http.ResponseWriter, statusCode int, message string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	//nolint:errcheck // test utility: encoding errors not actionable
+	json.NewEncoder(w).Encode(map[string]string{
+		"error":   http.StatusText(statusCode),
+		"message": message,
+	})
+}
+
+// HandleSequence registers responses that are returned in order, one per
+// request; once they run out the last one is repeated. It replaces
+// hand-rolled request counters for flows that hit the same path several
+// times, such as a JMAP Mailbox/get followed by Email/set.
+func (m *MockServer) HandleSequence(method, path string, responses ...Response) {
+	if len(responses) == 0 {
+		panic("testutil: HandleSequence needs at least one response")
+	}
+	m.handleQueued(method, path, responses, true)
+}
+
+// HandleOnce registers a response that is returned to the first request
+// only; later requests get the default 404.
+func (m *MockServer) HandleOnce(method, path string, response Response) {
+	m.handleQueued(method, path, []Response{response}, false)
+}
+
+// queue appends response to the route's HandleSequence queue, starting one
+// if there is none.
+func (m *MockServer) queue(method, path string, response Response) {
+	m.mu.Lock()
+	if seq, ok := m.sequences[method+" "+path]; ok && seq.repeatLast {
+		seq.responses = append(seq.responses, response)
+		m.mu.Unlock()
+		return
+	}
+	m.mu.Unlock()
+
+	m.handleQueued(method, path, []Response{response}, true)
+}
+
+func (m *MockServer) handleQueued(method, path string, responses []Response, repeatLast bool) {
+	seq := &sequence{responses: append([]Response(nil), responses...), repeatLast: repeatLast}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.sequences[method+" "+path] = seq
+	m.setRoute(method, path, func(w http.ResponseWriter, r *http.Request) {
+		m.mu.Lock()
+		i := seq.served
+		if i >= len(seq.responses) && seq.repeatLast {
+			i = len(seq.responses) - 1
+		}
+		seq.served++
+		m.mu.Unlock()
+
+		if i >= len(seq.responses) {
+			writeNotFound(w, r)
+			return
+		}
+		writeResponse(w, seq.responses[i])
+	})
+}
+
+// AssertConsumed fails t for every HandleSequence or HandleOnce route whose
+// queued responses were not all requested.
+func (m *MockServer) AssertConsumed(t testing.TB) {
+	t.Helper()
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for route, seq := range m.sequences {
+		if left := len(seq.responses) - seq.served; left > 0 {
+			t.Errorf("%s: %d queued response(s) never requested", route, left)
+		}
+	}
+}
+
+// writeNotFound writes the default 404 response.
+func writeNotFound(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusNotFound)
+	//nolint:errcheck // test utility: encoding errors not actionable
+	json.NewEncoder(w).Encode(map[string]string{
+		"error": "not found",
+		"path":  r.URL.Path,
 	})
 }
+
+// writeResponse writes a queued Response.
+func writeResponse(w http.ResponseWriter, resp Response) {
+	status := resp.Status
+	if status == 0 {
+		status = http.StatusOK
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+
+	switch body := resp.Body.(type) {
+	case nil:
+	case string:
+		//nolint:errcheck // test utility: write errors not actionable
+		w.Write([]byte(body))
+	case []byte:
+		//nolint:errcheck // test utility: write errors not actionable
+		w.Write(body)
+	default:
+		//nolint:errcheck // test utility: encoding errors not actionable
+		json.NewEncoder(w).Encode(body)
+	}
+}
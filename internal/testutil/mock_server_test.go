@@ -2,9 +2,13 @@ package testutil
 
 import (
 	"encoding/json"
+	"fmt"
 	"io"
 	"net/http"
+	"strings"
+	"sync"
 	"testing"
+	"time"
 )
 
 func TestMockServer_HandleJSON(t *testing.T) {
@@ -116,3 +120,238 @@ func TestMockServer_CustomHandler(t *testing.T) {
 		t.Errorf("got status %d, want %d", resp.StatusCode, http.StatusCreated)
 	}
 }
+
+func getBody(t *testing.T, url string) (int, string) {
+	t.Helper()
+	resp, err := http.Get(url)
+	if err != nil {
+		t.Fatalf("request failed: %v", err)
+	}
+	defer resp.Body.Close()
+	body, _ := io.ReadAll(resp.Body)
+	return resp.StatusCode, string(body)
+}
+
+func TestMockServer_HandleSequence(t *testing.T) {
+	ms := NewMockServer()
+	defer ms.Close()
+
+	ms.HandleSequence("GET", "/seq",
+		Response{Body: `{"n":1}`},
+		Response{Status: http.StatusAccepted, Body: map[string]int{"n": 2}},
+	)
+
+	want := []struct {
+		status int
+		body   string
+	}{
+		{http.StatusOK, `{"n":1}`},
+		{http.StatusAccepted, "{\"n\":2}\n"},
+		{http.StatusAccepted, "{\"n\":2}\n"}, // last response repeats
+	}
+	for i, w := range want {
+		status, body := getBody(t, ms.URL()+"/seq")
+		if status != w.status || body != w.body {
+			t.Errorf("request %d: got %d %q, want %d %q", i+1, status, body, w.status, w.body)
+		}
+	}
+
+	ms.AssertConsumed(t)
+}
+
+func TestMockServer_HandleOnce(t *testing.T) {
+	ms := NewMockServer()
+	defer ms.Close()
+
+	ms.HandleOnce("GET", "/once", Response{Body: `{"ok":true}`})
+
+	if status, _ := getBody(t, ms.URL()+"/once"); status != http.StatusOK {
+		t.Errorf("first request: got status %d, want %d", status, http.StatusOK)
+	}
+	if status, _ := getBody(t, ms.URL()+"/once"); status != http.StatusNotFound {
+		t.Errorf("second request: got status %d, want %d", status, http.StatusNotFound)
+	}
+}
+
+func TestMockServer_AssertConsumed(t *testing.T) {
+	ms := NewMockServer()
+	defer ms.Close()
+
+	ms.HandleSequence("GET", "/seq", Response{Body: "1"}, Response{Body: "2"})
+	ms.HandleOnce("POST", "/once", Response{Body: "x"})
+	getBody(t, ms.URL()+"/seq")
+
+	rec := &recordingTB{TB: t}
+	ms.AssertConsumed(rec)
+	if len(rec.errors) != 2 {
+		t.Errorf("got %d failures, want 2: %v", len(rec.errors), rec.errors)
+	}
+
+	// Re-registering a route with Handle drops its queue
+	ms.Handle("POST", "/once", func(w http.ResponseWriter, r *http.Request) {})
+	getBody(t, ms.URL()+"/seq")
+	rec = &recordingTB{TB: t}
+	ms.AssertConsumed(rec)
+	if len(rec.errors) != 0 {
+		t.Errorf("got failures after consuming: %v", rec.errors)
+	}
+}
+
+func TestMockServer_HandleSequenceConcurrent(t *testing.T) {
+	ms := NewMockServer()
+	defer ms.Close()
+
+	responses := make([]Response, 20)
+	for i := range responses {
+		responses[i] = Response{Body: `{}`}
+	}
+	ms.HandleSequence("GET", "/seq", responses...)
+
+	var wg sync.WaitGroup
+	for range responses {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			resp, err := http.Get(ms.URL() + "/seq")
+			if err == nil {
+				resp.Body.Close()
+			}
+		}()
+	}
+	wg.Wait()
+
+	ms.AssertConsumed(t)
+}
+
+// recordingTB records Errorf calls instead of failing the test.
+type recordingTB struct {
+	testing.TB
+	errors []string
+}
+
+func (r *recordingTB) Errorf(format string, args ...any) {
+	r.errors = append(r.errors, fmt.Sprintf(format, args...))
+}
+
+func TestMockServer_Requests(t *testing.T) {
+	ms := NewMockServer()
+	defer ms.Close()
+
+	var handlerBody []byte
+	ms.Handle("POST", "/api", func(w http.ResponseWriter, r *http.Request) {
+		handlerBody, _ = io.ReadAll(r.Body)
+	})
+
+	req, _ := http.NewRequest("POST", ms.URL()+"/api", strings.NewReader(`{"inReplyTo":["<m1@example.com>"]}`))
+	req.Header.Set("Authorization", "Bearer token")
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("request failed: %v", err)
+	}
+	resp.Body.Close()
+
+	if string(handlerBody) != `{"inReplyTo":["<m1@example.com>"]}` {
+		t.Errorf("handler got body %q, want it unchanged", handlerBody)
+	}
+
+	requests := ms.Requests()
+	if len(requests) != 1 {
+		t.Fatalf("got %d requests, want 1", len(requests))
+	}
+	got := requests[0]
+	if got.Method != "POST" || got.Path != "/api" || got.Header.Get("Authorization") != "Bearer token" {
+		t.Errorf("got %s %s with Authorization %q", got.Method, got.Path, got.Header.Get("Authorization"))
+	}
+
+	body, ok := ms.LastRequestBody().(map[string]any)
+	if !ok || body["inReplyTo"].([]any)[0] != "<m1@example.com>" {
+		t.Errorf("LastRequestBody() = %v", ms.LastRequestBody())
+	}
+
+	ms.AssertRequestBody(t, func(body any) bool {
+		m, ok := body.(map[string]any)
+		return ok && m["inReplyTo"] != nil
+	})
+
+	rec := &recordingTB{TB: t}
+	ms.AssertRequestBody(rec, func(body any) bool { return false })
+	if len(rec.errors) != 1 {
+		t.Errorf("got %d failures for a non-matching body, want 1", len(rec.errors))
+	}
+}
+
+func TestMockServer_MaxRequests(t *testing.T) {
+	ms := NewMockServer()
+	defer ms.Close()
+
+	ms.SetMaxRequests(2)
+	for _, path := range []string{"/a", "/b", "/c"} {
+		getBody(t, ms.URL()+path)
+	}
+
+	requests := ms.Requests()
+	if len(requests) != 2 || requests[0].Path != "/b" || requests[1].Path != "/c" {
+		t.Errorf("got %v, want the last two requests", requests)
+	}
+	if ms.LastRequestBody() != nil {
+		t.Errorf("LastRequestBody() = %v, want nil for a non-JSON body", ms.LastRequestBody())
+	}
+
+	ms.SetMaxRequests(0)
+	getBody(t, ms.URL()+"/d")
+	if n := len(ms.Requests()); n != 0 {
+		t.Errorf("got %d requests with recording off, want 0", n)
+	}
+}
+
+func TestMockServer_FailNext(t *testing.T) {
+	ms := NewMockServer()
+	defer ms.Close()
+
+	ms.HandleJSON("GET", "/flaky", http.StatusOK, map[string]string{"status": "ok"})
+	ms.SetResponseHeader("Retry-After", "1")
+	ms.FailNext(2, http.StatusServiceUnavailable)
+
+	for i, want := range []int{http.StatusServiceUnavailable, http.StatusServiceUnavailable, http.StatusOK} {
+		resp, err := http.Get(ms.URL() + "/flaky")
+		if err != nil {
+			t.Fatalf("request failed: %v", err)
+		}
+		resp.Body.Close()
+		if resp.StatusCode != want {
+			t.Errorf("request %d: got status %d, want %d", i+1, resp.StatusCode, want)
+		}
+		if got := resp.Header.Get("Retry-After"); got != "1" {
+			t.Errorf("request %d: Retry-After = %q, want 1", i+1, got)
+		}
+	}
+
+	ms.SetResponseHeader("Retry-After", "")
+	resp, err := http.Get(ms.URL() + "/flaky")
+	if err != nil {
+		t.Fatalf("request failed: %v", err)
+	}
+	resp.Body.Close()
+	if got := resp.Header.Get("Retry-After"); got != "" {
+		t.Errorf("Retry-After = %q after removing it", got)
+	}
+}
+
+func TestMockServer_SetLatency(t *testing.T) {
+	ms := NewMockServer()
+	defer ms.Close()
+
+	ms.HandleJSON("GET", "/slow", http.StatusOK, map[string]string{})
+	ms.SetLatency(50 * time.Millisecond)
+
+	start := time.Now()
+	getBody(t, ms.URL()+"/slow")
+	if elapsed := time.Since(start); elapsed < 50*time.Millisecond {
+		t.Errorf("response took %v, want at least 50ms", elapsed)
+	}
+
+	client := &http.Client{Timeout: 10 * time.Millisecond}
+	if _, err := client.Get(ms.URL() + "/slow"); err == nil {
+		t.Error("expected timeout with a 10ms client")
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"strings"
 )
 
 // HTTPError represents an HTTP response failure with optional body context.
@@ -43,6 +44,18 @@ func NewHTTPError(op string, resp *http.Response, body []byte) *HTTPError {
 	}
 }
 
+// RedactSecrets replaces every occurrence of each non-empty secret in s with
+// "***". Clients run response bodies through it before putting them in an
+// error, in case a misconfigured proxy echoes the request headers back.
+func RedactSecrets(s string, secrets ...string) string {
+	for _, secret := range secrets {
+		if secret != "" {
+			s = strings.ReplaceAll(s, secret, "***")
+		}
+	}
+	return s
+}
+
 // IsHTTPStatus checks whether an error represents a specific HTTP status.
 func IsHTTPStatus(err error, status int) bool {
 	var he *HTTPError
@@ -267,3 +267,22 @@ func TestHTTPError_ErrorInterface(t *testing.T) {
 		t.Error("HTTPError.Error() returned empty string")
 	}
 }
+
+func TestRedactSecrets(t *testing.T) {
+	tests := []struct {
+		in      string
+		secrets []string
+		want    string
+	}{
+		{"Authorization: Bearer tok123", []string{"tok123"}, "Authorization: Bearer ***"},
+		{`{"token":"tok123"}`, []string{"tok123"}, `{"token":"***"}`},
+		{"Cookie: c=abc; Bearer tok123", []string{"tok123", "c=abc"}, "Cookie: ***; Bearer ***"},
+		{"nothing to hide", []string{"tok123"}, "nothing to hide"},
+		{"Bearer tok123", []string{""}, "Bearer tok123"},
+	}
+	for _, tt := range tests {
+		if got := RedactSecrets(tt.in, tt.secrets...); got != tt.want {
+			t.Errorf("RedactSecrets(%q, %q) = %q, want %q", tt.in, tt.secrets, got, tt.want)
+		}
+	}
+}
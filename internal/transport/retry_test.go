@@ -9,6 +9,8 @@ import (
 	"sync/atomic"
 	"testing"
 	"time"
+
+	"github.com/salmonumbrella/fastmail-cli/internal/testutil"
 )
 
 func TestIsRetriableStatus(t *testing.T) {
@@ -320,23 +322,16 @@ func TestDoWithRetry_Success(t *testing.T) {
 }
 
 func TestDoWithRetry_RetriableFailure(t *testing.T) {
-	var attempts int32
-
-	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		count := atomic.AddInt32(&attempts, 1)
-		if count < 3 {
-			w.WriteHeader(http.StatusServiceUnavailable)
-			return
-		}
-		w.WriteHeader(http.StatusOK)
-	}))
+	server := testutil.NewMockServer()
 	defer server.Close()
+	server.HandleJSON("GET", "/", http.StatusOK, map[string]string{})
+	server.FailNext(2, http.StatusServiceUnavailable)
 
 	cfg := RetryConfig{MaxRetries: 3, InitialDelay: 10 * time.Millisecond, MaxDelay: 50 * time.Millisecond}
-	client := server.Client()
+	client := server.Server.Client()
 
 	reqFn := func(ctx context.Context) (*http.Request, error) {
-		return http.NewRequestWithContext(ctx, "GET", server.URL, nil)
+		return http.NewRequestWithContext(ctx, "GET", server.URL()+"/", nil)
 	}
 
 	shouldRetry := func(attempt int, resp *http.Response) (bool, error) {
@@ -353,7 +348,7 @@ func TestDoWithRetry_RetriableFailure(t *testing.T) {
 		t.Errorf("StatusCode = %d, want %d", resp.StatusCode, http.StatusOK)
 	}
 
-	if atomic.LoadInt32(&attempts) != 3 {
+	if attempts := len(server.Requests()); attempts != 3 {
 		t.Errorf("attempts = %d, want 3", attempts)
 	}
 }
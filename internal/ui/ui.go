@@ -68,6 +68,14 @@ func (u *UI) Warning(msg string) {
 	}
 }
 
+// Highlight returns s styled for emphasis (bold, reversed) when color is enabled.
+func (u *UI) Highlight(s string) string {
+	if !u.color {
+		return s
+	}
+	return u.out.String(s).Bold().Reverse().String()
+}
+
 // Info prints an informational message to stderr.
 func (u *UI) Info(msg string) {
 	fmt.Fprintln(os.Stderr, msg)
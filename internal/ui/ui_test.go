@@ -106,3 +106,10 @@ func TestUI_Methods(t *testing.T) {
 	u.Warning("warning message")
 	u.Info("info message")
 }
+
+func TestHighlight_NoColorReturnsInput(t *testing.T) {
+	u := New("never")
+	if got := u.Highlight("match"); got != "match" {
+		t.Errorf("Highlight() = %q, want %q", got, "match")
+	}
+}
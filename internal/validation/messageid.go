@@ -0,0 +1,25 @@
+package validation
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// RFC 5322 msg-id: dot-atom-text "@" (dot-atom-text / no-fold-literal).
+var messageIDRegex = regexp.MustCompile(`^[A-Za-z0-9!#$%&'*+/=?^_` + "`" + `{|}~-]+(?:\.[A-Za-z0-9!#$%&'*+/=?^_` + "`" + `{|}~-]+)*@(?:[A-Za-z0-9!#$%&'*+/=?^_` + "`" + `{|}~-]+(?:\.[A-Za-z0-9!#$%&'*+/=?^_` + "`" + `{|}~-]+)*|\[[!-Z^-~]*\])$`)
+
+// MessageID validates a Message-ID such as <abc@example.com> and returns it
+// without the angle brackets, the form JMAP uses for inReplyTo and
+// references. The brackets are optional on input.
+func MessageID(id string) (string, error) {
+	trimmed := strings.TrimSpace(id)
+	if strings.HasPrefix(trimmed, "<") != strings.HasSuffix(trimmed, ">") {
+		return "", fmt.Errorf("invalid Message-ID (unbalanced angle brackets): %s", id)
+	}
+	trimmed = strings.TrimSuffix(strings.TrimPrefix(trimmed, "<"), ">")
+	if len(trimmed) > 998 || !messageIDRegex.MatchString(trimmed) {
+		return "", fmt.Errorf("invalid Message-ID (expected <id@domain>): %s", id)
+	}
+	return trimmed, nil
+}
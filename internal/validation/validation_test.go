@@ -265,3 +265,31 @@ func TestPositiveInt(t *testing.T) {
 		})
 	}
 }
+
+func TestMessageID(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    string
+		wantErr bool
+	}{
+		{in: "<abc.123@example.com>", want: "abc.123@example.com"},
+		{in: "abc.123@example.com", want: "abc.123@example.com"},
+		{in: "  <a+b@[10.0.0.1]>  ", want: "a+b@[10.0.0.1]"},
+		{in: "<abc@example.com", wantErr: true},
+		{in: "<>", wantErr: true},
+		{in: "no-at-sign", wantErr: true},
+		{in: "<a b@example.com>", wantErr: true},
+		{in: "<a@b@example.com>", wantErr: true},
+		{in: "<a@example.com>\r\nBcc: x@evil.com", wantErr: true},
+	}
+	for _, tt := range tests {
+		got, err := MessageID(tt.in)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("MessageID(%q) error = %v, wantErr %v", tt.in, err, tt.wantErr)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("MessageID(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
@@ -108,6 +108,12 @@ func (c *Client) SetRetryConfig(cfg transport.RetryConfig) {
 	c.retry = cfg
 }
 
+// httpError builds the error for a failed response, with the token
+// redacted from body.
+func (c *Client) httpError(op string, resp *http.Response, body []byte) *transport.HTTPError {
+	return transport.NewHTTPError(op, resp, []byte(transport.RedactSecrets(string(body), c.token)))
+}
+
 // validateRemotePath validates and cleans a remote path to prevent traversal attacks.
 // It ensures the path starts with / and doesn't contain parent directory references.
 func validateRemotePath(remotePath string) (string, error) {
@@ -187,7 +193,7 @@ func (c *Client) List(ctx context.Context, filePath string) ([]FileInfo, error)
 
 	if resp.StatusCode != http.StatusMultiStatus {
 		body, _ := io.ReadAll(resp.Body) //nolint:errcheck // best-effort read for error message
-		return nil, transport.NewHTTPError("PROPFIND", resp, body)
+		return nil, c.httpError("PROPFIND", resp, body)
 	}
 
 	// Parse XML response
@@ -284,7 +290,7 @@ func (c *Client) Upload(ctx context.Context, localPath, remotePath string) error
 
 	if resp.StatusCode != http.StatusCreated && resp.StatusCode != http.StatusNoContent && resp.StatusCode != http.StatusOK {
 		body, _ := io.ReadAll(resp.Body) //nolint:errcheck // best-effort read for error message
-		return transport.NewHTTPError("upload", resp, body)
+		return c.httpError("upload", resp, body)
 	}
 
 	return nil
@@ -326,7 +332,7 @@ func (c *Client) Download(ctx context.Context, remotePath, localPath string) err
 
 	if resp.StatusCode != http.StatusOK {
 		body, _ := io.ReadAll(resp.Body) //nolint:errcheck // best-effort read for error message
-		return transport.NewHTTPError("download", resp, body)
+		return c.httpError("download", resp, body)
 	}
 
 	// Create local file
@@ -390,7 +396,7 @@ func (c *Client) Mkdir(ctx context.Context, dirPath string) error {
 		if resp.StatusCode == http.StatusMethodNotAllowed {
 			return fmt.Errorf("directory may already exist or path is invalid")
 		}
-		return transport.NewHTTPError("mkdir", resp, body)
+		return c.httpError("mkdir", resp, body)
 	}
 
 	return nil
@@ -436,7 +442,7 @@ func (c *Client) Delete(ctx context.Context, filePath string) error {
 		if resp.StatusCode == http.StatusNotFound {
 			return fmt.Errorf("file or directory not found")
 		}
-		return transport.NewHTTPError("delete", resp, body)
+		return c.httpError("delete", resp, body)
 	}
 
 	return nil
@@ -495,7 +501,7 @@ func (c *Client) Move(ctx context.Context, source, destination string) error {
 		if resp.StatusCode == http.StatusPreconditionFailed {
 			return fmt.Errorf("destination already exists")
 		}
-		return transport.NewHTTPError("move", resp, body)
+		return c.httpError("move", resp, body)
 	}
 
 	return nil
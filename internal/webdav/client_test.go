@@ -197,6 +197,23 @@ func TestMkdir(t *testing.T) {
 	}
 }
 
+func TestMkdir_ErrorRedactsToken(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte("echo: " + r.Header.Get("Authorization")))
+	}))
+	defer server.Close()
+
+	client := NewClientWithBaseURL("test-token", server.URL)
+	err := client.Mkdir(context.Background(), "/newdir")
+	if err == nil {
+		t.Fatal("Mkdir() error = nil, want error")
+	}
+	if strings.Contains(err.Error(), "test-token") || !strings.Contains(err.Error(), "Bearer ***") {
+		t.Errorf("error = %v, want the token redacted", err)
+	}
+}
+
 func TestDelete(t *testing.T) {
 	// Create a test server that handles DELETE
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {